@@ -1,19 +1,33 @@
 package app
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"maps"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/rizface/doui/internal/config"
 	"github.com/rizface/doui/internal/docker"
 	"github.com/rizface/doui/internal/models"
 	"github.com/rizface/doui/internal/ui/components"
+	"github.com/rizface/doui/internal/ui/keys"
 	"github.com/rizface/doui/internal/ui/styles"
 	"github.com/rizface/doui/internal/ui/views"
+	"github.com/rizface/doui/pkg/utils"
 )
 
 // App is the main application model
@@ -35,24 +49,45 @@ type App struct {
 	modal   *components.Modal
 
 	// Views
-	containersView *views.ContainersView
-	imagesView     *views.ImagesView
-	groupsView     *views.GroupsView
-	volumesView    *views.VolumesView
-	composeView    *views.ComposeView
-	networksView   *views.NetworksView
-	logsView       *views.LogsView
-	statsView      *views.StatsView
-	envVarsView    *views.EnvVarsView
-	aboutView      *views.AboutView
+	containersView  *views.ContainersView
+	imagesView      *views.ImagesView
+	groupsView      *views.GroupsView
+	volumesView     *views.VolumesView
+	pluginsView     *views.PluginsView
+	composeView     *views.ComposeView
+	networksView    *views.NetworksView
+	logsView        *views.LogsView
+	statsView       *views.StatsView
+	envVarsView     *views.EnvVarsView
+	composeEnvView  *views.ComposeEnvView
+	imageLabelsView *views.ImageLabelsView
+	vulnsView       *views.VulnerabilitiesView
+	historyView     *views.ImageHistoryView
+	composeFileView *views.ComposeFileView
+	dotEnvView      *views.ComposeDotEnvView
+	groupLogsView   *views.GroupLogsView
+	aboutView       *views.AboutView
+	helpView        *views.HelpView
+
+	// Keybindings overlay shown on top of the current view
+	helpVisible bool
+
+	// Command palette and the key presses its entries stand for
+	palette        *components.Palette
+	paletteActions []paletteAction
+
+	// Actions pinned to the quick-action bar, by view
+	quickActions map[models.ViewType][]string
 
 	// Status
 	statusMessage string
 	errorMessage  string
 
 	// Pending operations
-	pendingDelete     string // ID of item pending deletion
-	pendingDeleteType string // "container", "image", "group"
+	pendingDelete      string // ID of item pending deletion
+	pendingDeleteType  string // "container", "image", "group"
+	pendingArchiveSize int64  // Size of the image or volume being archived, for its progress
+	pendingArchivePath string // Archive a volume is restored from
 
 	// Env var editing state
 	pendingEnvContainer *models.ContainerFullConfig
@@ -61,33 +96,281 @@ type App struct {
 	pullProgressChan <-chan docker.PullProgress
 	pullImageName    string
 	pullProgress     string // Current progress display
+	pullLayers       []docker.LayerProgress
+	pullCancel       context.CancelFunc
+	pullModal        *components.Modal // Per-layer progress, hidden with esc
+
+	// Group shown in the group logs dashboard
+	groupLogsID string
+
+	// Image shown in the vulnerabilities view, to rescan it
+	scannedImage models.Image
 
 	// Container rebuild state (track by name since ID changes)
 	rebuildingContainerName string
 
 	// Pending selection after container refresh (used after rebuild)
 	pendingSelectContainerID string
+
+	// Host CPU count used to validate cpuset edits
+	hostCPUCount int
+
+	// Auto-refresh interval, 0 means manual refresh only (ctrl+r)
+	refreshInterval time.Duration
+
+	// Auto-refresh slows down to config.IdleRefreshInterval after idleAfter
+	// without keyboard/mouse input (0 = never); ticks keep their normal rate
+	// so the first input restores it within one interval
+	idleAfter   time.Duration
+	lastInput   time.Time
+	lastRefresh time.Time
+	idle        bool
+
+	// Docker daemon unreachable, reconnect is being retried
+	disconnected bool
+
+	// Docker context the client is connected to, and the ones to pick from
+	dockerContext  models.DockerContext
+	dockerContexts []models.DockerContext
+
+	// Error from the initial Docker connection (shown until retry succeeds)
+	initErr      error
+	initRetrying bool
+
+	// Group being created or edited with the values of the group form,
+	// until its color is picked
+	groupDraft models.Group
+
+	// Minute the group schedules were last checked for
+	lastScheduleCheck time.Time
+
+	// Group hotkey prefix pressed, the next key picks the group
+	groupHotkeyPending bool
+
+	// Group bulk env change state (containers are recreated one at a time)
+	bulkEnvGroupName string
+	bulkEnvChange    models.EnvVarChange
+	bulkEnvQueue     []models.Container
+	bulkEnvResults   []models.BulkEnvResult
+	bulkEnvModal     *components.Modal
+
+	// Batch pull of the images listed in a file or used by a compose
+	// project, one image at a time
+	batchPullQueue   []string
+	batchPullSource  string // What the images are, e.g. "from list"
+	batchPullResults []models.BatchPullResult
+	batchPullStatus  string // Progress of the image being pulled
+	batchPullChan    <-chan docker.PullProgress
+	batchPullModal   *components.Modal
+
+	// Macvlan/ipvlan network wizard: host interfaces and the network to create
+	macvlanInterfaces []utils.HostInterface
+	macvlanNetwork    *models.MacvlanNetwork
+
+	// Compose project whose changed services are pending recreation
+	recreateProject  *models.ComposeProject
+	recreateServices []string
+
+	// Force-recreate of every container of a compose project, one at a time
+	projectRecreateName    string
+	projectRecreateQueue   []models.Container
+	projectRecreateResults []models.BulkEnvResult
+	projectRecreateModal   *components.Modal
+
+	// Compose project whose edited compose file is pending `up -d`
+	applyProject *models.ComposeProject
+
+	// Known projects offered to bring up from their compose files
+	composeUpChoices []models.ComposeProject
+
+	// Stops the exec `tail -F` of a file shown in the logs view
+	fileTailCancel context.CancelFunc
+
+	// Stops the logs/stats stream of the logs or stats view
+	streamCancel context.CancelFunc
+
+	// Terminal lost focus: streams are paused and auto-refresh skipped
+	blurred bool
+
+	// Container to select (or show the logs of) once containers are loaded,
+	// from the command line
+	startContainer string
+	startLogs      bool
+	startGroup     string // Group to open once groups are loaded
+
+	// Container selected when the last session ended, selected again if it
+	// still exists
+	restoreContainer string
+
+	// One-shot runs of this session, oldest first
+	runResults []models.RunResult
+
+	// Events stream for notifications about watched containers, restarted
+	// with each new client
+	notifyMethod string
+	watchCancel  context.CancelFunc
+	watchGen     int
+
+	// Long running commands in flight, shown with a spinner in the footer
+	operations      []operation
+	nextOperationID int
+	spinner         spinner.Model
+	spinning        bool
+}
+
+// operation is a tracked command that hasn't finished yet
+type operation struct {
+	id       int
+	label    string
+	started  time.Time
+	progress func() string // Optional, read on every spinner frame
 }
 
 // New creates a new application
 func New() *App {
-	return &App{
+	app := &App{
 		state:   models.NewAppState(),
 		sidebar: components.NewSidebar(),
 		header:  components.NewHeader(),
 		footer:  components.NewFooter(),
 
-		containersView: views.NewContainersView(),
-		imagesView:     views.NewImagesView(),
-		groupsView:     views.NewGroupsView(),
-		volumesView:    views.NewVolumesView(),
-		composeView:    views.NewComposeView(),
-		networksView:   views.NewNetworksView(),
-		logsView:       views.NewLogsView(),
-		statsView:      views.NewStatsView(),
-		envVarsView:    views.NewEnvVarsView(),
-		aboutView:      views.NewAboutView(),
+		containersView:  views.NewContainersView(),
+		imagesView:      views.NewImagesView(),
+		groupsView:      views.NewGroupsView(),
+		volumesView:     views.NewVolumesView(),
+		pluginsView:     views.NewPluginsView(),
+		composeView:     views.NewComposeView(),
+		networksView:    views.NewNetworksView(),
+		logsView:        views.NewLogsView(),
+		statsView:       views.NewStatsView(),
+		envVarsView:     views.NewEnvVarsView(),
+		composeEnvView:  views.NewComposeEnvView(),
+		imageLabelsView: views.NewImageLabelsView(),
+		vulnsView:       views.NewVulnerabilitiesView(),
+		historyView:     views.NewImageHistoryView(),
+		composeFileView: views.NewComposeFileView(),
+		dotEnvView:      views.NewComposeDotEnvView(),
+		groupLogsView:   views.NewGroupLogsView(),
+		aboutView:       views.NewAboutView(),
+		helpView:        views.NewHelpView(),
+		spinner:         spinner.New(spinner.WithSpinner(spinner.MiniDot)),
+
+		refreshInterval: config.GetRefreshInterval(),
+		idleAfter:       config.GetIdleAfter(),
+		lastInput:       time.Now(),
+		dockerContext: models.DockerContext{
+			Name: models.DefaultContextName,
+			Host: docker.ConfiguredHost(),
+		},
+	}
+
+	app.sidebar.SetContext(models.DefaultContextName)
+
+	app.envVarsView.SetFreePortRange(config.GetFreePortRange())
+
+	return app
+}
+
+// StartAt opens the app on a view instead of the containers list
+func (a *App) StartAt(view models.ViewType) {
+	a.state.CurrentView = view
+	a.state.PreviousView = view
+	a.sidebar.SetCurrentView(view)
+}
+
+// StartAtContainer selects a container (by name or ID prefix) once the
+// containers are loaded, and streams its logs when logs is set
+func (a *App) StartAtContainer(ref string, logs bool) {
+	a.StartAt(models.ViewContainers)
+	a.startContainer = ref
+	a.startLogs = logs
+}
+
+// StartAtGroup opens a group (by name) in the groups view once the groups
+// are loaded
+func (a *App) StartAtGroup(name string) {
+	a.StartAt(models.ViewGroups)
+	a.startGroup = name
+}
+
+// RestoreSession reopens the view, container selection and filters of the
+// last session
+func (a *App) RestoreSession(session models.Session) {
+	view, err := models.ParseView(session.View)
+	if err != nil {
+		return
+	}
+	a.StartAt(view)
+	a.containersView.SetQuickFilters(session.StateFilter, session.ProjectFilter, session.LabelFilter)
+	a.containersView.SetRuntimeColumn(session.RuntimeColumn)
+	if view == models.ViewContainers {
+		a.restoreContainer = session.Container
+	}
+}
+
+// Session returns what is shown now, to restore on the next launch. ok is
+// false if the app never connected, there is nothing worth restoring then.
+func (a *App) Session() (session models.Session, ok bool) {
+	if !a.ready {
+		return session, false
+	}
+
+	// Logs, stats and editors are opened from a main view, go back to it
+	view := a.state.CurrentView
+	switch view {
+	case models.ViewLogs, models.ViewStats, models.ViewEnvVars, models.ViewComposeEnv, models.ViewImageLabels, models.ViewVulnerabilities,
+		models.ViewImageHistory, models.ViewGroupLogs, models.ViewComposeFile, models.ViewComposeDotEnv:
+		view = a.state.PreviousView
+	}
+	if _, err := models.ParseView(view.String()); err != nil {
+		view = models.ViewContainers
+	}
+
+	session.View = strings.ToLower(view.String())
+	session.StateFilter, session.ProjectFilter, session.LabelFilter = a.containersView.QuickFilters()
+	session.RuntimeColumn = a.containersView.RuntimeColumn()
+	if container := a.containersView.GetSelectedContainer(); container != nil {
+		session.Container = container.Name
+	}
+	return session, true
+}
+
+// SetRefreshInterval sets how often the current view is refreshed (0 = manual)
+func (a *App) SetRefreshInterval(interval time.Duration) {
+	a.refreshInterval = interval
+}
+
+// MaxQuickActions is how many actions a view's quick-action bar holds
+const MaxQuickActions = 5
+
+// SetQuickActions pins actions (by keybinding action name) to the
+// quick-action bar of each main view (by view name)
+func (a *App) SetQuickActions(actions map[string][]string) error {
+	quick := make(map[models.ViewType][]string, len(actions))
+	for name, list := range actions {
+		view, err := models.ParseView(name)
+		if err != nil {
+			return err
+		}
+		if len(list) > MaxQuickActions {
+			return fmt.Errorf("%s has %d quick actions, at most %d fit the bar", name, len(list), MaxQuickActions)
+		}
+		for _, action := range list {
+			if _, ok := keys.Map.Binding(action); !ok || action == "quick_action" {
+				return fmt.Errorf("unknown quick action %q for %s", action, name)
+			}
+		}
+		if len(list) > 0 {
+			quick[view] = list
+		}
 	}
+	a.quickActions = quick
+	return nil
+}
+
+// SetNotifyMethod sets how watched containers are notified about
+func (a *App) SetNotifyMethod(method string) {
+	a.notifyMethod = method
 }
 
 // Init initializes the application
@@ -96,7 +379,9 @@ func (a *App) Init() tea.Cmd {
 		tea.EnterAltScreen,
 		initDockerClient(),
 		initGroupManager(),
-		tickRefresh(),
+		tickRefresh(a.refreshInterval),
+		tickHealth(),
+		tickSchedule(),
 	)
 }
 
@@ -107,12 +392,18 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.width = msg.Width
 		a.height = msg.Height
 
+		// The quick-action bar takes a line above the footer
+		height := msg.Height
+		if len(a.quickActions) > 0 {
+			height--
+		}
+
 		// Calculate layout dimensions
 		sidebarWidth := 22
 		mainWidth := msg.Width - sidebarWidth
 
 		// Update component sizes
-		a.sidebar.SetSize(sidebarWidth, msg.Height)
+		a.sidebar.SetSize(sidebarWidth, height)
 		a.header.SetSize(mainWidth)
 		a.footer.SetSize(msg.Width)
 
@@ -121,18 +412,70 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		// Update view sizes (main area)
-		a.containersView.SetSize(mainWidth, msg.Height-4) // Reserve for header+footer
-		a.imagesView.SetSize(mainWidth, msg.Height-4)
-		a.groupsView.SetSize(mainWidth, msg.Height-4)
-		a.volumesView.SetSize(mainWidth, msg.Height-4)
-		a.composeView.SetSize(mainWidth, msg.Height-4)
-		a.networksView.SetSize(mainWidth, msg.Height-4)
-		a.logsView.SetSize(mainWidth, msg.Height-4)
-		a.statsView.SetSize(mainWidth, msg.Height-4)
-		a.envVarsView.SetSize(mainWidth, msg.Height-4)
-		a.aboutView.SetSize(msg.Width, msg.Height-4) // Full width for about page
+		a.containersView.SetSize(mainWidth, height-4) // Reserve for header+footer
+		a.imagesView.SetSize(mainWidth, height-4)
+		a.groupsView.SetSize(mainWidth, height-4)
+		a.volumesView.SetSize(mainWidth, height-4)
+		a.composeView.SetSize(mainWidth, height-4)
+		a.networksView.SetSize(mainWidth, height-4)
+		a.pluginsView.SetSize(mainWidth, height-4)
+		a.logsView.SetSize(mainWidth, height-4)
+		a.statsView.SetSize(mainWidth, height-4)
+		a.envVarsView.SetSize(mainWidth, height-4)
+		a.composeEnvView.SetSize(mainWidth, height-4)
+		a.imageLabelsView.SetSize(mainWidth, height-4)
+		a.vulnsView.SetSize(mainWidth, height-4)
+		a.historyView.SetSize(mainWidth, height-4)
+		a.composeFileView.SetSize(mainWidth, height-4)
+		a.dotEnvView.SetSize(mainWidth, height-4)
+		a.groupLogsView.SetSize(msg.Width, height-2) // Full width, no sidebar
+		a.aboutView.SetSize(msg.Width, height-4)     // Full width for about page
+		a.helpView.SetSize(msg.Width, msg.Height-1)
+
+	case tea.MouseMsg:
+		a.noteInput()
+		// Clicks on the quick-action bar, the line above the footer
+		if a.ready && msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonLeft &&
+			msg.Y == a.height-2 && len(a.quickActions) > 0 && !a.helpVisible &&
+			(a.modal == nil || !a.modal.IsVisible()) && (a.palette == nil || !a.palette.IsVisible()) {
+			if action, ok := a.quickActionAt(msg.X); ok {
+				return a.runQuickAction(action)
+			}
+			return a, nil
+		}
+
+	case tea.BlurMsg:
+		a.blurred = true
+		a.pauseStreams()
+		return a, nil
+
+	case tea.FocusMsg:
+		// Catch up right away instead of waiting for the next tick
+		a.blurred = false
+		a.noteInput()
+		if !a.ready || a.disconnected {
+			return a, nil
+		}
+		a.lastRefresh = time.Now()
+		return a, tea.Batch(a.refreshCurrentView(), a.resumeStreams())
 
 	case tea.KeyMsg:
+		a.noteInput()
+
+		// Not connected yet: only retry and quit are available
+		if !a.ready {
+			switch {
+			case key.Matches(msg, keys.Map.Retry):
+				if a.initErr != nil && !a.initRetrying {
+					a.initRetrying = true
+					return a, initDockerClient()
+				}
+			case key.Matches(msg, keys.Map.Quit):
+				return a, tea.Quit
+			}
+			return a, nil
+		}
+
 		// Handle modal first if visible
 		if a.modal != nil && a.modal.IsVisible() {
 			var cmd tea.Cmd
@@ -144,6 +487,11 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					return a.handleModalConfirmed()
 				}
 				// Modal cancelled
+				if a.pendingDeleteType == "bulk_env" {
+					// The group env change wasn't started, drop its queue
+					a.bulkEnvQueue = nil
+					a.bulkEnvResults = nil
+				}
 				a.modal = nil
 				a.pendingDelete = ""
 				a.pendingDeleteType = ""
@@ -152,6 +500,31 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return a, cmd
 		}
 
+		// Command palette takes all input until closed
+		if a.palette != nil && a.palette.IsVisible() {
+			var cmd tea.Cmd
+			a.palette, cmd = a.palette.Update(msg)
+			if !a.palette.IsVisible() {
+				chosen := a.palette.Chosen()
+				a.palette = nil
+				if chosen >= 0 {
+					return a.runPaletteAction(a.paletteActions[chosen])
+				}
+			}
+			return a, cmd
+		}
+
+		// Help overlay takes all input until closed
+		if a.helpVisible {
+			if key.Matches(msg, keys.Map.Back, keys.Map.Help, keys.Map.Quit) {
+				a.helpVisible = false
+				return a, nil
+			}
+			var cmd tea.Cmd
+			a.helpView, cmd = a.helpView.Update(msg)
+			return a, cmd
+		}
+
 		// If any view is currently filtering or editing, skip command handling and let the view handle all input
 		if (a.state.CurrentView == models.ViewContainers && a.containersView.IsFiltering()) ||
 			(a.state.CurrentView == models.ViewImages && a.imagesView.IsFiltering()) ||
@@ -159,7 +532,10 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			(a.state.CurrentView == models.ViewVolumes && a.volumesView.IsFiltering()) ||
 			(a.state.CurrentView == models.ViewCompose && a.composeView.IsFiltering()) ||
 			(a.state.CurrentView == models.ViewNetworks && a.networksView.IsFiltering()) ||
-			(a.state.CurrentView == models.ViewEnvVars && a.envVarsView.IsEditing()) {
+			(a.state.CurrentView == models.ViewPlugins && a.pluginsView.IsFiltering()) ||
+			(a.state.CurrentView == models.ViewEnvVars && a.envVarsView.IsEditing()) ||
+			(a.state.CurrentView == models.ViewComposeDotEnv && a.dotEnvView.IsEditing()) ||
+			(a.state.CurrentView == models.ViewLogs && a.logsView.IsSearching()) {
 			// Delegate directly to the view to handle input
 			var cmd tea.Cmd
 			switch a.state.CurrentView {
@@ -175,23 +551,56 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				a.composeView, cmd = a.composeView.Update(msg)
 			case models.ViewNetworks:
 				a.networksView, cmd = a.networksView.Update(msg)
+			case models.ViewPlugins:
+				a.pluginsView, cmd = a.pluginsView.Update(msg)
 			case models.ViewEnvVars:
 				a.envVarsView, cmd = a.envVarsView.Update(msg)
+			case models.ViewComposeDotEnv:
+				a.dotEnvView, cmd = a.dotEnvView.Update(msg)
+			case models.ViewLogs:
+				a.logsView, cmd = a.logsView.Update(msg)
 			}
 			return a, cmd
 		}
 
+		// The key after the group hotkey prefix picks the group to start or stop
+		if a.groupHotkeyPending {
+			a.groupHotkeyPending = false
+			a.statusMessage = ""
+			if key.Matches(msg, keys.Map.Back) {
+				return a, nil
+			}
+			group := a.groupManager.GetGroupByHotkey(msg.String())
+			if group == nil {
+				a.errorMessage = fmt.Sprintf("No group on hotkey %s %s", keys.Label(keys.Map.GroupHotkey), msg.String())
+				return a, clearStatus(2 * time.Second)
+			}
+			return a, a.track(fmt.Sprintf("Starting or stopping %s", group.Name), toggleGroup(a.docker, a.groupManager, group.ID))
+		}
+
 		// Global keybindings
-		switch msg.String() {
-		case "ctrl+c", "q":
+		switch {
+		case key.Matches(msg, keys.Map.GroupHotkey) && a.groupManager != nil && a.docker != nil:
+			// Wait for the group's hotkey, from any view
+			a.groupHotkeyPending = true
+			a.statusMessage = fmt.Sprintf("%s: press a group's hotkey (esc cancels)", keys.Label(keys.Map.GroupHotkey))
+			return a, nil
+
+		case key.Matches(msg, keys.Map.Quit):
 			// Don't quit if in logs/stats/shell/about views, return to previous view instead
-			if a.state.CurrentView == models.ViewLogs || a.state.CurrentView == models.ViewStats || a.state.CurrentView == models.ViewAbout {
+			if a.state.CurrentView == models.ViewLogs || a.state.CurrentView == models.ViewStats ||
+				a.state.CurrentView == models.ViewComposeEnv || a.state.CurrentView == models.ViewAbout ||
+				a.state.CurrentView == models.ViewImageLabels || a.state.CurrentView == models.ViewVulnerabilities ||
+				a.state.CurrentView == models.ViewImageHistory || a.state.CurrentView == models.ViewGroupLogs ||
+				a.state.CurrentView == models.ViewComposeFile {
 				// Re-enable mouse if leaving logs view with mouse disabled
 				var cmd tea.Cmd
 				if a.state.CurrentView == models.ViewLogs && !a.logsView.IsMouseEnabled() {
 					a.logsView.ResetMouseState()
 					cmd = tea.EnableMouseCellMotion
 				}
+				a.stopFileTail()
+				a.stopStream()
 				a.state.CurrentView = a.state.PreviousView
 				a.sidebar.SetCurrentView(a.state.PreviousView)
 				return a, cmd
@@ -202,14 +611,24 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return a, tea.Quit
 
-		case "?":
-			// Open About page
-			a.state.PreviousView = a.state.CurrentView
-			a.state.CurrentView = models.ViewAbout
-			a.sidebar.SetCurrentView(models.ViewAbout)
+		case key.Matches(msg, keys.Map.Refresh):
+			// Manual refresh (the only way to refresh when auto-refresh is off)
+			if cmd := a.refreshCurrentView(); cmd != nil {
+				a.statusMessage = "Refreshed"
+				return a, tea.Batch(cmd, clearStatus(1*time.Second))
+			}
+
+		case key.Matches(msg, keys.Map.Palette):
+			a.openPalette()
+			return a, nil
+
+		case key.Matches(msg, keys.Map.Help):
+			// Open the keybindings overlay on top of the current view
+			a.helpVisible = true
+			a.helpView.Reset()
 			return a, nil
 
-		case "esc":
+		case key.Matches(msg, keys.Map.Back):
 			// Handle About view - go back
 			if a.state.CurrentView == models.ViewAbout {
 				a.state.CurrentView = a.state.PreviousView
@@ -232,7 +651,23 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return a, nil
 			}
 
-			// Handle logs view - go back to previous view
+			// Handle compose .env view - back without saving
+			if a.state.CurrentView == models.ViewComposeDotEnv {
+				if a.dotEnvView.IsEditing() {
+					var cmd tea.Cmd
+					a.dotEnvView, cmd = a.dotEnvView.Update(msg)
+					return a, cmd
+				}
+				a.state.CurrentView = a.state.PreviousView
+				a.sidebar.SetCurrentView(a.state.PreviousView)
+				return a, nil
+			}
+
+			// Handle logs view - clear the search first, then go back to previous view
+			if a.state.CurrentView == models.ViewLogs && a.logsView.HasSearch() {
+				a.logsView.ClearSearch()
+				return a, nil
+			}
 			if a.state.CurrentView == models.ViewLogs {
 				// Re-enable mouse if it was disabled for text selection
 				var cmd tea.Cmd
@@ -240,13 +675,19 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					a.logsView.ResetMouseState()
 					cmd = tea.EnableMouseCellMotion
 				}
+				a.stopFileTail()
+				a.stopStream()
 				a.state.CurrentView = a.state.PreviousView
 				a.sidebar.SetCurrentView(a.state.PreviousView)
 				return a, cmd
 			}
 
-			// Handle stats view - go back to previous view
-			if a.state.CurrentView == models.ViewStats {
+			// Handle stats, compose env/file, image labels/history, vulnerabilities and group logs views - go back to previous view
+			if a.state.CurrentView == models.ViewStats || a.state.CurrentView == models.ViewComposeEnv ||
+				a.state.CurrentView == models.ViewImageLabels || a.state.CurrentView == models.ViewVulnerabilities ||
+				a.state.CurrentView == models.ViewImageHistory || a.state.CurrentView == models.ViewGroupLogs ||
+				a.state.CurrentView == models.ViewComposeFile {
+				a.stopStream()
 				a.state.CurrentView = a.state.PreviousView
 				a.sidebar.SetCurrentView(a.state.PreviousView)
 				return a, nil
@@ -260,6 +701,21 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return a, cmd
 			}
 
+			// Back from the containers of a volume to the volumes
+			if a.state.CurrentView == models.ViewVolumes && a.volumesView.GetCurrentTab() == models.VolumesUsedByTab {
+				a.volumesView.ShowVolumes()
+				return a, nil
+			}
+
+			// Back from the containers of an image to the images
+			if a.state.CurrentView == models.ViewContainers && a.containersView.ImageFilter() != "" {
+				a.containersView.SetImageFilter("", "")
+				a.state.PreviousView = a.state.CurrentView
+				a.state.CurrentView = models.ViewImages
+				a.sidebar.SetCurrentView(models.ViewImages)
+				return a, fetchImages(a.docker)
+			}
+
 			// Return to previous view or containers
 			if a.state.CurrentView != models.ViewContainers {
 				a.state.PreviousView = a.state.CurrentView
@@ -268,49 +724,55 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return a, nil
 			}
 
-		case "1":
+		case key.Matches(msg, keys.Map.Containers):
 			a.state.PreviousView = a.state.CurrentView
 			a.state.CurrentView = models.ViewContainers
 			a.sidebar.SetCurrentView(models.ViewContainers)
 			return a, nil
 
-		case "2":
+		case key.Matches(msg, keys.Map.Images):
 			a.state.PreviousView = a.state.CurrentView
 			a.state.CurrentView = models.ViewImages
 			a.sidebar.SetCurrentView(models.ViewImages)
 			return a, tea.Batch(fetchImages(a.docker))
 
-		case "3":
+		case key.Matches(msg, keys.Map.Groups):
 			a.state.PreviousView = a.state.CurrentView
 			a.state.CurrentView = models.ViewGroups
 			a.sidebar.SetCurrentView(models.ViewGroups)
 			return a, loadGroups(a.groupManager)
 
-		case "4":
+		case key.Matches(msg, keys.Map.Volumes):
 			a.state.PreviousView = a.state.CurrentView
 			a.state.CurrentView = models.ViewVolumes
 			a.sidebar.SetCurrentView(models.ViewVolumes)
 			return a, tea.Batch(fetchVolumes(a.docker), fetchContainers(a.docker))
 
-		case "5":
+		case key.Matches(msg, keys.Map.Compose):
 			a.state.PreviousView = a.state.CurrentView
 			a.state.CurrentView = models.ViewCompose
 			a.sidebar.SetCurrentView(models.ViewCompose)
 			return a, fetchComposeProjects(a.docker)
 
-		case "6":
+		case key.Matches(msg, keys.Map.Networks):
 			a.state.PreviousView = a.state.CurrentView
 			a.state.CurrentView = models.ViewNetworks
 			a.sidebar.SetCurrentView(models.ViewNetworks)
 			return a, tea.Batch(fetchNetworks(a.docker), fetchContainers(a.docker))
 
-		case "7":
+		case key.Matches(msg, keys.Map.Plugins):
+			a.state.PreviousView = a.state.CurrentView
+			a.state.CurrentView = models.ViewPlugins
+			a.sidebar.SetCurrentView(models.ViewPlugins)
+			return a, fetchPlugins(a.docker)
+
+		case key.Matches(msg, keys.Map.AboutView):
 			a.state.PreviousView = a.state.CurrentView
 			a.state.CurrentView = models.ViewAbout
 			a.sidebar.SetCurrentView(models.ViewAbout)
 			return a, nil
 
-		case "tab", "right":
+		case key.Matches(msg, keys.Map.NextView):
 			// Cycle forward through tabs (only in main views, not logs/stats)
 			if a.state.CurrentView == models.ViewContainers ||
 				a.state.CurrentView == models.ViewImages ||
@@ -318,11 +780,12 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				a.state.CurrentView == models.ViewVolumes ||
 				a.state.CurrentView == models.ViewCompose ||
 				a.state.CurrentView == models.ViewNetworks ||
+				a.state.CurrentView == models.ViewPlugins ||
 				a.state.CurrentView == models.ViewAbout {
 				return a.cycleTabForward()
 			}
 
-		case "shift+tab", "left":
+		case key.Matches(msg, keys.Map.PrevView):
 			// Cycle backward through tabs (only in main views, not logs/stats)
 			if a.state.CurrentView == models.ViewContainers ||
 				a.state.CurrentView == models.ViewImages ||
@@ -330,14 +793,16 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				a.state.CurrentView == models.ViewVolumes ||
 				a.state.CurrentView == models.ViewCompose ||
 				a.state.CurrentView == models.ViewNetworks ||
+				a.state.CurrentView == models.ViewPlugins ||
 				a.state.CurrentView == models.ViewAbout {
 				return a.cycleTabBackward()
 			}
 
-		case "n":
+		case key.Matches(msg, keys.Map.New):
 			// Create new group (only in groups view, list tab)
 			if a.state.CurrentView == models.ViewGroups && a.groupsView.GetCurrentTab() == models.GroupsListTab {
 				a.modal = components.NewFormModal("Create New Group", []string{"Name", "Description"})
+				a.modal.SetConfirmText("Next")
 				a.modal.SetSize(a.width, a.height)
 				a.pendingDeleteType = "create_group"
 				return a, nil
@@ -349,8 +814,57 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				a.pendingDeleteType = "create_network"
 				return a, nil
 			}
+			// Bring up a compose project from its files (compose projects list)
+			if a.state.CurrentView == models.ViewCompose && !a.composeView.IsViewingServices() && !a.composeView.IsViewingContainers() {
+				a.composeUpChoices = nil
+				for _, project := range a.composeView.GetProjects() {
+					if len(project.ConfigFiles) > 0 {
+						a.composeUpChoices = append(a.composeUpChoices, project)
+					}
+				}
+				if len(a.composeUpChoices) == 0 {
+					a.openComposeUpForm(nil)
+					return a, nil
+				}
+				options := make([]string, 0, len(a.composeUpChoices)+1)
+				for _, project := range a.composeUpChoices {
+					options = append(options, fmt.Sprintf("%-20s %s", project.Name, strings.Join(project.ConfigFiles, ", ")))
+				}
+				options = append(options, "Other compose file...")
+				a.modal = components.NewMenuModal("Compose Up", options)
+				a.modal.SetSize(a.width, a.height)
+				a.pendingDeleteType = "compose_up_pick"
+				return a, nil
+			}
+			// Create new volume
+			if a.state.CurrentView == models.ViewVolumes && a.volumesView.GetCurrentTab() == models.VolumesListTab {
+				a.modal = components.NewFormModalWithOptional("Create Volume", []string{
+					"Name (empty for a generated one)",
+					"Driver (default: local)",
+					"Labels (key=value, space separated)",
+					"Driver options (e.g. type=nfs o=addr=10.0.0.2,rw device=:/export)",
+				}, []int{0, 1, 2, 3})
+				a.modal.SetSize(a.width, a.height)
+				a.pendingDeleteType = "create_volume"
+				return a, nil
+			}
 
-		case "enter":
+		case key.Matches(msg, keys.Map.Select):
+			// In Images view: drill into the containers created from the image
+			if a.state.CurrentView == models.ViewImages {
+				if img := a.imagesView.GetSelectedImage(); img != nil {
+					if img.Containers == 0 {
+						a.errorMessage = fmt.Sprintf("No containers use %s", img.GetPrimaryTag())
+						return a, clearStatus(2 * time.Second)
+					}
+					a.containersView.SetImageFilter(img.ID, img.GetPrimaryTag())
+					a.state.PreviousView = a.state.CurrentView
+					a.state.CurrentView = models.ViewContainers
+					a.sidebar.SetCurrentView(models.ViewContainers)
+					return a, fetchContainers(a.docker)
+				}
+				return a, nil
+			}
 			// In Groups view, Available tab: Add container to group
 			if a.state.CurrentView == models.ViewGroups && a.groupsView.GetCurrentTab() == models.GroupsAvailableTab {
 				if container := a.groupsView.GetSelectedAvailableContainer(); container != nil {
@@ -360,19 +874,170 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 				return a, nil
 			}
+			// In Networks view, Networks tab: open the network and load its
+			// endpoints for the containers' addresses
+			if a.state.CurrentView == models.ViewNetworks && a.networksView.GetCurrentTab() == models.NetworksListTab && !a.networksView.IsFiltering() {
+				var cmd tea.Cmd
+				a.networksView, cmd = a.networksView.Update(msg)
+				if selectedNetwork := a.networksView.GetSelectedNetworkForApp(); selectedNetwork != nil {
+					return a, tea.Batch(cmd, fetchNetworkEndpoints(a.docker, selectedNetwork.ID))
+				}
+				return a, cmd
+			}
+			// In Compose view, projects list: open the project and hash its
+			// compose files to flag the services whose config changed
+			if a.state.CurrentView == models.ViewCompose && !a.composeView.IsViewingServices() && !a.composeView.IsViewingContainers() {
+				var cmd tea.Cmd
+				a.composeView, cmd = a.composeView.Update(msg)
+				if project := a.composeView.GetSelectedProject(); project != nil && a.composeView.IsViewingServices() {
+					return a, tea.Batch(cmd, fetchComposeConfigHashes(a.docker, *project))
+				}
+				return a, cmd
+			}
 			// In Networks view, Available tab: Connect container to network
 			if a.state.CurrentView == models.ViewNetworks && a.networksView.GetCurrentTab() == models.NetworksAvailableTab {
 				if container := a.networksView.GetSelectedAvailableContainer(); container != nil {
 					if selectedNetwork := a.networksView.GetSelectedNetworkForApp(); selectedNetwork != nil {
 						if !selectedNetwork.IsSystemNetwork() {
-							return a, connectContainerToNetwork(a.docker, selectedNetwork.ID, container.ID)
+							// Submitting the form empty connects with the defaults
+							a.modal = components.NewFormModalWithOptional(
+								fmt.Sprintf("Connect %s to %s", container.Name, selectedNetwork.Name),
+								[]string{"Aliases (comma separated)", "Static IPv4 address"},
+								[]int{0, 1},
+							)
+							a.modal.SetSize(a.width, a.height)
+							a.pendingDelete = container.ID
+							a.pendingDeleteType = "network_connect"
+							return a, nil
+						}
+					}
+				}
+				return a, nil
+			}
+
+		case key.Matches(msg, keys.Map.StartOrder):
+			// In Groups view, In Group tab: Set start order dependencies
+			if a.state.CurrentView == models.ViewGroups && a.groupsView.GetCurrentTab() == models.GroupsContainersTab {
+				if container := a.groupsView.GetSelectedInGroupContainer(); container != nil {
+					if selectedGroup := a.groupsView.GetSelectedGroupForApp(); selectedGroup != nil {
+						waitHealthy := "n"
+						if selectedGroup.WaitHealthy[container.ID] {
+							waitHealthy = "y"
 						}
+						a.modal = components.NewFormModalWithOptional(
+							fmt.Sprintf("Start Order: %s", container.Name),
+							[]string{
+								"Starts after (container names, comma-separated)",
+								"Dependents wait until healthy (y/n)",
+								"Seconds dependents wait after it started",
+								"Stop grace period in seconds",
+							},
+							[]int{0, 1, 2, 3},
+						)
+						a.modal.SetInputValues([]string{
+							strings.Join(a.groupsView.GetDependencyNames(container.ID), ", "),
+							waitHealthy,
+							strconv.Itoa(selectedGroup.GetStartDelay(container.ID)),
+							strconv.Itoa(selectedGroup.GetStopGracePeriod(container.ID)),
+						})
+						a.modal.SetConfirmText("Save")
+						a.modal.SetSize(a.width, a.height)
+						a.pendingDelete = container.ID
+						a.pendingDeleteType = "set_dependencies"
+						return a, nil
 					}
 				}
+			}
+
+		case key.Matches(msg, keys.Map.OrderedStop, keys.Map.LoadImage, keys.Map.RestoreVolume):
+			// Restore a volume from a tar archive (Volumes view), into the
+			// selected volume unless another name is given
+			if key.Matches(msg, keys.Map.RestoreVolume) && a.state.CurrentView == models.ViewVolumes && a.volumesView.GetCurrentTab() == models.VolumesListTab {
+				a.modal = components.NewFormModal("Restore Volume From Archive", []string{
+					"Archive path (.tar or .tar.gz, ~/ allowed)",
+					"Volume (new or existing)",
+				})
+				if vol := a.volumesView.GetSelectedVolume(); vol != nil {
+					a.modal.SetInputValues([]string{"", vol.Name})
+				}
+				a.modal.SetConfirmText("Restore")
+				a.modal.SetSize(a.width, a.height)
+				a.pendingDeleteType = "restore_volume"
+				return a, nil
+			}
+			// Load images from a tar archive (Images view)
+			if key.Matches(msg, keys.Map.LoadImage) && a.state.CurrentView == models.ViewImages {
+				a.modal = components.NewFormModal("Load Images From Archive", []string{"Archive path (.tar or .tar.gz, ~/ allowed)"})
+				a.modal.SetConfirmText("Load")
+				a.modal.SetSize(a.width, a.height)
+				a.pendingDeleteType = "load_image"
 				return a, nil
+			} else if !key.Matches(msg, keys.Map.OrderedStop) {
+				break
+			}
+			// In Groups view, list tab: toggle stopping in reverse start order
+			if a.state.CurrentView == models.ViewGroups && a.groupsView.GetCurrentTab() == models.GroupsListTab {
+				if group := a.groupsView.GetSelectedGroup(); group != nil {
+					return a, setGroupOrderedStop(a.groupManager, group.ID, !group.OrderedStop)
+				}
+			}
+
+		case key.Matches(msg, keys.Map.ScanImage, keys.Map.GroupEnv):
+			// Scan an image for vulnerabilities, or rescan it (Images and
+			// Vulnerabilities views). Scans are cached per image digest.
+			if key.Matches(msg, keys.Map.ScanImage) && a.state.CurrentView == models.ViewVulnerabilities {
+				a.statusMessage = fmt.Sprintf("Rescanning %s...", a.scannedImage.GetPrimaryTag())
+				return a, a.track(fmt.Sprintf("Scanning %s", a.scannedImage.GetPrimaryTag()), scanImage(a.docker, a.scannedImage))
+			} else if key.Matches(msg, keys.Map.ScanImage) && a.state.CurrentView == models.ViewImages {
+				if img := a.imagesView.GetSelectedImage(); img != nil && a.docker != nil {
+					a.scannedImage = *img
+					if scan := a.docker.CachedScan(img.ID); scan != nil {
+						a.openVulnerabilities(scan)
+						return a, nil
+					}
+					a.statusMessage = fmt.Sprintf("Scanning %s for vulnerabilities...", img.GetPrimaryTag())
+					return a, a.track(fmt.Sprintf("Scanning %s", img.GetPrimaryTag()), scanImage(a.docker, *img))
+				}
+				break
+			} else if !key.Matches(msg, keys.Map.GroupEnv) {
+				break
+			}
+			// In Groups view: set/remove an env var on every container in the group
+			if a.state.CurrentView == models.ViewGroups {
+				selectedGroup := a.groupsView.GetSelectedGroupForApp()
+				if a.groupsView.GetCurrentTab() == models.GroupsListTab {
+					selectedGroup = a.groupsView.GetSelectedGroup()
+				}
+				if selectedGroup != nil {
+					if len(a.bulkEnvQueue) > 0 {
+						a.errorMessage = "An env change is already being applied"
+						return a, clearStatus(2 * time.Second)
+					}
+					a.modal = components.NewFormModalWithOptional(
+						fmt.Sprintf("Set Env Var in Group '%s'", selectedGroup.Name),
+						[]string{"Key", "Value (leave empty to remove the key)"},
+						[]int{1},
+					)
+					a.modal.SetConfirmText("Next")
+					a.modal.SetSize(a.width, a.height)
+					a.pendingDelete = selectedGroup.ID
+					a.pendingDeleteType = "bulk_env_form"
+					return a, nil
+				}
 			}
 
-		case "u":
+		case key.Matches(msg, keys.Map.FilterUsage, keys.Map.Unlink, keys.Map.VolumeSizes):
+			// Quick filter: only dangling / unused / in-use images (cycles)
+			if key.Matches(msg, keys.Map.FilterUsage) && a.state.CurrentView == models.ViewImages {
+				a.imagesView.CycleUsageFilter()
+				return a, nil
+			} else if key.Matches(msg, keys.Map.VolumeSizes) && a.state.CurrentView == models.ViewVolumes && a.volumesView.GetCurrentTab() == models.VolumesListTab {
+				// Measuring walks every volume, so it only runs on demand
+				a.statusMessage = "Measuring volume sizes..."
+				return a, a.track("Measuring volume sizes", fetchVolumeSizes(a.docker))
+			} else if !key.Matches(msg, keys.Map.Unlink) {
+				break
+			}
 			// In Groups view, In Group tab: Unlink/remove container from group
 			if a.state.CurrentView == models.ViewGroups && a.groupsView.GetCurrentTab() == models.GroupsContainersTab {
 				if container := a.groupsView.GetSelectedInGroupContainer(); container != nil {
@@ -406,8 +1071,9 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return a, nil
 			}
 
-		case "r":
-			// Restart container (in containers view, group tab, compose services/containers, or networks containers tab)
+		case key.Matches(msg, keys.Map.Restart):
+			// Restart container (in containers view, group tab, compose services/containers, or networks containers tab),
+			// or all containers of a group or compose project
 			if a.state.CurrentView == models.ViewContainers {
 				if container := a.containersView.GetSelectedContainer(); container != nil {
 					// Block if container is being rebuilt
@@ -417,6 +1083,11 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					}
 					return a, restartContainer(a.docker, container.ID)
 				}
+			} else if a.state.CurrentView == models.ViewGroups && a.groupsView.GetCurrentTab() == models.GroupsListTab {
+				// Restart all containers in group
+				if group := a.groupsView.GetSelectedGroup(); group != nil {
+					return a, a.track(fmt.Sprintf("Restarting group %s", group.Name), restartGroup(a.docker, a.groupManager, group.ID))
+				}
 			} else if a.state.CurrentView == models.ViewGroups && a.groupsView.GetCurrentTab() == models.GroupsContainersTab {
 				if container := a.groupsView.GetSelectedInGroupContainer(); container != nil {
 					return a, restartContainer(a.docker, container.ID)
@@ -430,17 +1101,21 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				} else {
 					// Restart all containers in compose project
 					if project := a.composeView.GetSelectedProject(); project != nil {
-						return a, restartComposeProject(a.docker, project.Name)
+						return a, a.track(fmt.Sprintf("Restarting %s", project.Name), restartComposeProject(a.docker, project.Name))
 					}
 				}
 			} else if a.state.CurrentView == models.ViewNetworks && a.networksView.GetCurrentTab() == models.NetworksContainersTab {
 				if container := a.networksView.GetSelectedInNetworkContainer(); container != nil {
 					return a, restartContainer(a.docker, container.ID)
 				}
+			} else if a.state.CurrentView == models.ViewVolumes && a.volumesView.GetCurrentTab() == models.VolumesUsedByTab {
+				if container := a.volumesView.GetSelectedUsedByContainer(); container != nil {
+					return a, restartContainer(a.docker, container.ID)
+				}
 			}
 
 		// Container operations (containers view, group tab, and compose services/containers)
-		case "s":
+		case key.Matches(msg, keys.Map.Start):
 			if a.state.CurrentView == models.ViewContainers {
 				if container := a.containersView.GetSelectedContainer(); container != nil {
 					// Block if container is being rebuilt
@@ -453,7 +1128,7 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			} else if a.state.CurrentView == models.ViewGroups && a.groupsView.GetCurrentTab() == models.GroupsListTab {
 				// Start all containers in group
 				if group := a.groupsView.GetSelectedGroup(); group != nil {
-					return a, startGroup(a.docker, a.groupManager, group.ID)
+					return a, a.track(fmt.Sprintf("Starting group %s", group.Name), startGroup(a.docker, a.groupManager, group.ID))
 				}
 			} else if a.state.CurrentView == models.ViewGroups && a.groupsView.GetCurrentTab() == models.GroupsContainersTab {
 				// Start individual container in group
@@ -469,16 +1144,28 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				} else {
 					// Start all containers in compose project
 					if project := a.composeView.GetSelectedProject(); project != nil {
-						return a, startComposeProject(a.docker, project.Name)
+						return a, a.track(fmt.Sprintf("Starting %s", project.Name), startComposeProject(a.docker, project.Name))
 					}
 				}
 			} else if a.state.CurrentView == models.ViewNetworks && a.networksView.GetCurrentTab() == models.NetworksContainersTab {
 				if container := a.networksView.GetSelectedInNetworkContainer(); container != nil {
 					return a, startContainer(a.docker, container.ID)
 				}
+			} else if a.state.CurrentView == models.ViewVolumes && a.volumesView.GetCurrentTab() == models.VolumesUsedByTab {
+				if container := a.volumesView.GetSelectedUsedByContainer(); container != nil {
+					return a, startContainer(a.docker, container.ID)
+				}
+			} else if a.state.CurrentView == models.ViewPlugins {
+				if plugin := a.pluginsView.GetSelectedPlugin(); plugin != nil {
+					if plugin.Enabled {
+						a.statusMessage = fmt.Sprintf("Plugin '%s' is already enabled", plugin.Name)
+						return a, clearStatus(2 * time.Second)
+					}
+					return a, enablePlugin(a.docker, plugin.Name)
+				}
 			}
 
-		case "x":
+		case key.Matches(msg, keys.Map.Stop):
 			if a.state.CurrentView == models.ViewContainers {
 				if container := a.containersView.GetSelectedContainer(); container != nil {
 					// Block if container is being rebuilt
@@ -491,7 +1178,7 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			} else if a.state.CurrentView == models.ViewGroups && a.groupsView.GetCurrentTab() == models.GroupsListTab {
 				// Stop all containers in group
 				if group := a.groupsView.GetSelectedGroup(); group != nil {
-					return a, stopGroup(a.docker, a.groupManager, group.ID)
+					return a, a.track(fmt.Sprintf("Stopping group %s", group.Name), stopGroup(a.docker, a.groupManager, group.ID))
 				}
 			} else if a.state.CurrentView == models.ViewGroups && a.groupsView.GetCurrentTab() == models.GroupsContainersTab {
 				// Stop individual container in group
@@ -507,16 +1194,28 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				} else {
 					// Stop all containers in compose project
 					if project := a.composeView.GetSelectedProject(); project != nil {
-						return a, stopComposeProject(a.docker, project.Name)
+						return a, a.track(fmt.Sprintf("Stopping %s", project.Name), stopComposeProject(a.docker, project.Name))
 					}
 				}
 			} else if a.state.CurrentView == models.ViewNetworks && a.networksView.GetCurrentTab() == models.NetworksContainersTab {
 				if container := a.networksView.GetSelectedInNetworkContainer(); container != nil {
 					return a, stopContainer(a.docker, container.ID)
 				}
+			} else if a.state.CurrentView == models.ViewVolumes && a.volumesView.GetCurrentTab() == models.VolumesUsedByTab {
+				if container := a.volumesView.GetSelectedUsedByContainer(); container != nil {
+					return a, stopContainer(a.docker, container.ID)
+				}
+			} else if a.state.CurrentView == models.ViewPlugins {
+				if plugin := a.pluginsView.GetSelectedPlugin(); plugin != nil {
+					if !plugin.Enabled {
+						a.statusMessage = fmt.Sprintf("Plugin '%s' is already disabled", plugin.Name)
+						return a, clearStatus(2 * time.Second)
+					}
+					return a, disablePlugin(a.docker, plugin.Name)
+				}
 			}
 
-		case "l":
+		case key.Matches(msg, keys.Map.Logs):
 			// View logs (containers view, group tab, or compose services/containers)
 			if a.state.CurrentView == models.ViewContainers {
 				if container := a.containersView.GetSelectedContainer(); container != nil {
@@ -528,34 +1227,81 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					a.state.PreviousView = a.state.CurrentView
 					a.state.CurrentView = models.ViewLogs
 					a.state.SelectedContainer = container
-					return a, startLogStreaming(a.docker, a.logsView, container)
+					return a, startLogStreaming(a.streamContext(), a.docker, a.logsView, container)
+				}
+			} else if a.state.CurrentView == models.ViewGroups && a.groupsView.GetCurrentTab() == models.GroupsListTab {
+				// Dashboard of the last lines of every container in the group
+				if group := a.groupsView.GetSelectedGroup(); group != nil {
+					a.groupLogsID = group.ID
+					a.groupLogsView.SetGroup(group.Name)
+					a.state.PreviousView = a.state.CurrentView
+					a.state.CurrentView = models.ViewGroupLogs
+					return a, fetchGroupLogs(a.docker, *group)
 				}
 			} else if a.state.CurrentView == models.ViewGroups && a.groupsView.GetCurrentTab() == models.GroupsContainersTab {
 				if container := a.groupsView.GetSelectedInGroupContainer(); container != nil {
 					a.state.PreviousView = a.state.CurrentView
 					a.state.CurrentView = models.ViewLogs
 					a.state.SelectedContainer = container
-					return a, startLogStreaming(a.docker, a.logsView, container)
+					return a, startLogStreaming(a.streamContext(), a.docker, a.logsView, container)
 				}
 			} else if a.state.CurrentView == models.ViewCompose && (a.composeView.IsViewingServices() || a.composeView.IsViewingContainers()) {
 				if container := a.composeView.GetSelectedContainer(); container != nil {
 					a.state.PreviousView = a.state.CurrentView
 					a.state.CurrentView = models.ViewLogs
 					a.state.SelectedContainer = container
-					return a, startLogStreaming(a.docker, a.logsView, container)
+					return a, startLogStreaming(a.streamContext(), a.docker, a.logsView, container)
+				}
+			} else if a.state.CurrentView == models.ViewCompose {
+				// Logs of every container of the project, merged
+				if project := a.composeView.GetSelectedProject(); project != nil {
+					a.state.PreviousView = a.state.CurrentView
+					a.state.CurrentView = models.ViewLogs
+					a.state.SelectedContainer = nil
+					return a, startProjectLogStreaming(a.streamContext(), a.docker, a.logsView, *project)
 				}
 			} else if a.state.CurrentView == models.ViewNetworks && a.networksView.GetCurrentTab() == models.NetworksContainersTab {
 				if container := a.networksView.GetSelectedInNetworkContainer(); container != nil {
 					a.state.PreviousView = a.state.CurrentView
 					a.state.CurrentView = models.ViewLogs
 					a.state.SelectedContainer = container
-					return a, startLogStreaming(a.docker, a.logsView, container)
+					return a, startLogStreaming(a.streamContext(), a.docker, a.logsView, container)
+				}
+			} else if a.state.CurrentView == models.ViewVolumes && a.volumesView.GetCurrentTab() == models.VolumesUsedByTab {
+				if container := a.volumesView.GetSelectedUsedByContainer(); container != nil {
+					a.state.PreviousView = a.state.CurrentView
+					a.state.CurrentView = models.ViewLogs
+					a.state.SelectedContainer = container
+					return a, startLogStreaming(a.streamContext(), a.docker, a.logsView, container)
 				}
 			}
 
-		case "t":
-			// View stats (containers view, group tab, or compose services/containers)
-			if a.state.CurrentView == models.ViewContainers {
+		case key.Matches(msg, keys.Map.Stats, keys.Map.TagImage):
+			// Tag image (Images view) or view stats (containers view, group
+			// tab, or compose services/containers)
+			if key.Matches(msg, keys.Map.TagImage) && a.state.CurrentView == models.ViewImages {
+				if img := a.imagesView.GetSelectedImage(); img != nil {
+					source := img.GetPrimaryTag()
+					if img.IsDangling() {
+						source = img.ID
+					}
+					a.modal = components.NewFormModalWithOptional(
+						fmt.Sprintf("Tag %s", source),
+						[]string{"New tag (repo:tag)", "Rename: remove the old tag (y/N)"},
+						[]int{1},
+					)
+					if !img.IsDangling() {
+						a.modal.SetInputValues([]string{source, ""})
+					}
+					a.modal.SetConfirmText("Tag")
+					a.modal.SetSize(a.width, a.height)
+					a.pendingDelete = source
+					a.pendingDeleteType = "tag_image"
+					return a, nil
+				}
+			} else if !key.Matches(msg, keys.Map.Stats) {
+				break
+			} else if a.state.CurrentView == models.ViewContainers {
 				if container := a.containersView.GetSelectedContainer(); container != nil {
 					// Block if container is being rebuilt
 					if a.containersView.IsRebuilding(container.Name) {
@@ -565,32 +1311,78 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					a.state.PreviousView = a.state.CurrentView
 					a.state.CurrentView = models.ViewStats
 					a.state.SelectedContainer = container
-					return a, startStatsStreaming(a.docker, a.statsView, container)
+					ctx := a.streamContext()
+					return a, tea.Batch(
+						startStatsStreaming(ctx, a.docker, a.statsView, container),
+						startStatsEvents(ctx, a.docker, a.statsView),
+					)
 				}
 			} else if a.state.CurrentView == models.ViewGroups && a.groupsView.GetCurrentTab() == models.GroupsContainersTab {
 				if container := a.groupsView.GetSelectedInGroupContainer(); container != nil {
 					a.state.PreviousView = a.state.CurrentView
 					a.state.CurrentView = models.ViewStats
 					a.state.SelectedContainer = container
-					return a, startStatsStreaming(a.docker, a.statsView, container)
+					ctx := a.streamContext()
+					return a, tea.Batch(
+						startStatsStreaming(ctx, a.docker, a.statsView, container),
+						startStatsEvents(ctx, a.docker, a.statsView),
+					)
 				}
 			} else if a.state.CurrentView == models.ViewCompose && (a.composeView.IsViewingServices() || a.composeView.IsViewingContainers()) {
 				if container := a.composeView.GetSelectedContainer(); container != nil {
 					a.state.PreviousView = a.state.CurrentView
 					a.state.CurrentView = models.ViewStats
 					a.state.SelectedContainer = container
-					return a, startStatsStreaming(a.docker, a.statsView, container)
+					ctx := a.streamContext()
+					return a, tea.Batch(
+						startStatsStreaming(ctx, a.docker, a.statsView, container),
+						startStatsEvents(ctx, a.docker, a.statsView),
+					)
 				}
 			} else if a.state.CurrentView == models.ViewNetworks && a.networksView.GetCurrentTab() == models.NetworksContainersTab {
 				if container := a.networksView.GetSelectedInNetworkContainer(); container != nil {
 					a.state.PreviousView = a.state.CurrentView
 					a.state.CurrentView = models.ViewStats
 					a.state.SelectedContainer = container
-					return a, startStatsStreaming(a.docker, a.statsView, container)
+					ctx := a.streamContext()
+					return a, tea.Batch(
+						startStatsStreaming(ctx, a.docker, a.statsView, container),
+						startStatsEvents(ctx, a.docker, a.statsView),
+					)
+				}
+			} else if a.state.CurrentView == models.ViewVolumes && a.volumesView.GetCurrentTab() == models.VolumesUsedByTab {
+				if container := a.volumesView.GetSelectedUsedByContainer(); container != nil {
+					a.state.PreviousView = a.state.CurrentView
+					a.state.CurrentView = models.ViewStats
+					a.state.SelectedContainer = container
+					ctx := a.streamContext()
+					return a, tea.Batch(
+						startStatsStreaming(ctx, a.docker, a.statsView, container),
+						startStatsEvents(ctx, a.docker, a.statsView),
+					)
 				}
 			}
 
-		case "e":
+		case key.Matches(msg, keys.Map.Shell, keys.Map.EditComposeFile):
+			// Edit a compose file in $EDITOR (compose projects list or compose file view)
+			if key.Matches(msg, keys.Map.EditComposeFile) && a.state.CurrentView == models.ViewCompose && !a.composeView.IsViewingServices() && !a.composeView.IsViewingContainers() {
+				if project := a.composeView.GetSelectedProject(); project != nil {
+					path, err := docker.MainComposeFile(*project)
+					if err != nil {
+						a.errorMessage = err.Error()
+						return a, clearStatus(4 * time.Second)
+					}
+					return a, editComposeFile(*project, path)
+				}
+				break
+			} else if key.Matches(msg, keys.Map.EditComposeFile) && a.state.CurrentView == models.ViewComposeFile {
+				if file := a.composeFileView.GetCurrentFile(); file != nil {
+					return a, editComposeFile(a.composeFileView.GetProject(), file.Path)
+				}
+				break
+			} else if !key.Matches(msg, keys.Map.Shell) {
+				break
+			}
 			// Enter shell (containers view, group tab, or compose services/containers)
 			if a.state.CurrentView == models.ViewContainers {
 				if container := a.containersView.GetSelectedContainer(); container != nil {
@@ -613,11 +1405,46 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if container := a.networksView.GetSelectedInNetworkContainer(); container != nil {
 					return a, execShell(container.ID, container.Name)
 				}
+			} else if a.state.CurrentView == models.ViewVolumes && a.volumesView.GetCurrentTab() == models.VolumesUsedByTab {
+				if container := a.volumesView.GetSelectedUsedByContainer(); container != nil {
+					return a, execShell(container.ID, container.Name)
+				}
 			}
 
-		case "v":
-			// View/Edit environment variables (containers view, group tab, compose, or networks)
-			if a.state.CurrentView == models.ViewContainers {
+		case key.Matches(msg, keys.Map.EditConfig):
+			// View/Edit environment variables and labels (containers view, group tab, compose, or networks),
+			// or the .env file of a compose project (projects list)
+			if a.state.CurrentView == models.ViewCompose && !a.composeView.IsViewingServices() && !a.composeView.IsViewingContainers() {
+				if project := a.composeView.GetSelectedProject(); project != nil {
+					return a, loadComposeDotEnv(*project)
+				}
+			} else if a.state.CurrentView == models.ViewGroups && a.groupsView.GetCurrentTab() == models.GroupsListTab {
+				// Edit the name, description, hotkey, included groups,
+				// schedules and color of a group
+				if group := a.groupsView.GetSelectedGroup(); group != nil {
+					a.modal = components.NewFormModalWithOptional("Edit Group", []string{
+						"Name",
+						"Description",
+						fmt.Sprintf("Hotkey (letter or digit, pressed after %s)", keys.Label(keys.Map.GroupHotkey)),
+						"Includes groups (names, comma-separated)",
+						"Start schedule (cron, e.g. 0 8 * * 1-5)",
+						"Stop schedule (cron, e.g. 0 19 * * *)",
+					}, []int{1, 2, 3, 4, 5})
+					a.modal.SetInputValues([]string{
+						group.Name,
+						group.Description,
+						group.Hotkey,
+						strings.Join(a.groupsView.GetIncludedNames(group), ", "),
+						group.StartSchedule,
+						group.StopSchedule,
+					})
+					a.modal.SetConfirmText("Next")
+					a.modal.SetSize(a.width, a.height)
+					a.pendingDelete = group.ID
+					a.pendingDeleteType = "edit_group"
+					return a, nil
+				}
+			} else if a.state.CurrentView == models.ViewContainers {
 				if container := a.containersView.GetSelectedContainer(); container != nil {
 					// Block if container is being rebuilt
 					if a.containersView.IsRebuilding(container.Name) {
@@ -638,35 +1465,605 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if container := a.networksView.GetSelectedInNetworkContainer(); container != nil {
 					return a, loadContainerConfig(a.docker, container.ID)
 				}
+			} else if a.state.CurrentView == models.ViewVolumes && a.volumesView.GetCurrentTab() == models.VolumesUsedByTab {
+				if container := a.volumesView.GetSelectedUsedByContainer(); container != nil {
+					return a, loadContainerConfig(a.docker, container.ID)
+				}
 			}
 
-		case "ctrl+s":
-			// Save env vars and rebuild container
-			if a.state.CurrentView == models.ViewEnvVars && a.envVarsView.IsModified() {
-				if a.pendingEnvContainer != nil {
-					// Update env vars in pending config
-					a.pendingEnvContainer.Env = a.envVarsView.GetEnvVars()
-					// Track rebuilding state to block operations and show status
-					a.rebuildingContainerName = a.pendingEnvContainer.Name
-					a.containersView.SetRebuilding(a.pendingEnvContainer.Name)
-					// Switch to containers view immediately so user can see the rebuilding status
-					a.state.CurrentView = models.ViewContainers
-					a.sidebar.SetCurrentView(models.ViewContainers)
-					a.statusMessage = fmt.Sprintf("Rebuilding container '%s'...", a.pendingEnvContainer.Name)
-					return a, recreateContainer(a.docker, a.state.SelectedContainer.ID, a.pendingEnvContainer)
+		case key.Matches(msg, keys.Map.FilterRunning, keys.Map.FilterExited, keys.Map.QuickRun, keys.Map.ProtectImage, keys.Map.RecreateProject):
+			// Force-recreate every container of a project (compose projects list)
+			if key.Matches(msg, keys.Map.RecreateProject) && a.state.CurrentView == models.ViewCompose && !a.composeView.IsViewingServices() && !a.composeView.IsViewingContainers() {
+				project := a.composeView.GetSelectedProject()
+				if project == nil {
+					break
+				}
+				if a.projectRecreateModal != nil {
+					a.errorMessage = "A project is already being recreated"
+					return a, clearStatus(2 * time.Second)
 				}
-			}
 
-		case " ":
-			// Toggle selection in images view
-			if a.state.CurrentView == models.ViewImages {
-				a.imagesView.ToggleSelection()
+				var containers []models.Container
+				names := make([]string, 0, len(project.ContainerIDs))
+				for _, service := range project.Services {
+					for _, ctr := range service.Containers {
+						containers = append(containers, ctr)
+						names = append(names, "  • "+ctr.Name)
+					}
+				}
+				if len(containers) == 0 {
+					break
+				}
+				if maxLines := a.height - 14; maxLines > 0 && len(names) > maxLines {
+					hidden := len(names) - maxLines + 1
+					names = append(names[:maxLines-1], styles.DescStyle.Render(fmt.Sprintf("  ... %d more", hidden)))
+				}
+				a.projectRecreateName = project.Name
+				a.projectRecreateQueue = containers
+				a.projectRecreateResults = nil
+				a.modal = components.NewConfirmModal(
+					"Recreate Project",
+					fmt.Sprintf("Stop, remove and recreate the %d container(s) of %s from their current config, one at a time?\nAnonymous volumes are not kept.\n\n%s",
+						len(containers), project.Name, strings.Join(names, "\n")),
+				)
+				a.modal.SetConfirmText("Recreate")
+				a.modal.SetSize(a.width, a.height)
+				a.pendingDeleteType = "recreate_project"
 				return a, nil
+			} else if !key.Matches(msg, keys.Map.FilterRunning, keys.Map.FilterExited, keys.Map.QuickRun, keys.Map.ProtectImage) {
+				break
 			}
-
-		case "d":
-			// Delete with confirmation
-			if a.state.CurrentView == models.ViewContainers {
+			// Protect the image from remove and prune, or lift it (Images view)
+			if key.Matches(msg, keys.Map.ProtectImage) && a.state.CurrentView == models.ViewImages && a.groupManager != nil {
+				if img := a.imagesView.GetSelectedImage(); img != nil {
+					protected, err := a.groupManager.ToggleProtected(*img)
+					if err != nil {
+						a.errorMessage = fmt.Sprintf("Failed to save protected images: %v", err)
+						return a, clearStatus(3 * time.Second)
+					}
+					a.imagesView.SetProtected(a.groupManager.ProtectedImages())
+					if protected {
+						a.statusMessage = fmt.Sprintf("Protected %s: doui won't remove or prune it", img.ProtectionRef())
+					} else {
+						a.statusMessage = fmt.Sprintf("%s is no longer protected", img.GetPrimaryTag())
+					}
+					return a, clearStatus(3 * time.Second)
+				}
+				break
+			} else if !key.Matches(msg, keys.Map.FilterRunning, keys.Map.FilterExited, keys.Map.QuickRun) {
+				break
+			}
+			// Run a container from the image in the background (Images view)
+			if key.Matches(msg, keys.Map.QuickRun) && a.state.CurrentView == models.ViewImages {
+				if img := a.imagesView.GetSelectedImage(); img != nil {
+					ref := img.GetPrimaryTag()
+					if img.IsDangling() {
+						ref = img.ID
+					}
+					a.modal = components.NewFormModalWithOptional(
+						"Run "+ref,
+						[]string{
+							"Container name (empty for a random one)",
+							"Ports, host:container (e.g. 8080:80, 127.0.0.1:5432:5432)",
+							"Detach (Y/n, n opens its logs)",
+							"Remove when it stops (y/N)",
+						},
+						[]int{0, 1, 2, 3},
+					)
+					a.modal.SetConfirmText("Run")
+					a.modal.SetSize(a.width, a.height)
+					a.pendingDeleteType = "quick_run"
+					a.pendingDelete = ref
+					return a, nil
+				}
+				break
+			} else if !key.Matches(msg, keys.Map.FilterRunning, keys.Map.FilterExited) {
+				break
+			}
+			// Quick filter: only running / only exited containers
+			if a.state.CurrentView == models.ViewContainers {
+				state := "running"
+				if key.Matches(msg, keys.Map.FilterExited) {
+					state = "exited"
+				}
+				a.containersView.ToggleStateFilter(state)
+				return a, nil
+			}
+
+		case key.Matches(msg, keys.Map.FilterProject):
+			// Quick filter: cycle through compose projects
+			if a.state.CurrentView == models.ViewContainers {
+				if project := a.containersView.CycleProjectFilter(); project != "" {
+					a.statusMessage = fmt.Sprintf("Showing compose project '%s'", project)
+				} else {
+					a.statusMessage = "Showing all compose projects"
+				}
+				return a, clearStatus(2 * time.Second)
+			}
+
+		case key.Matches(msg, keys.Map.FilterLabel, keys.Map.RegistryLogin):
+			// Quick filter by label key or key=value (Containers view) or
+			// registry login (Images view)
+			if key.Matches(msg, keys.Map.FilterLabel) && a.state.CurrentView == models.ViewContainers {
+				a.modal = components.NewFormModalWithOptional(
+					"Filter by Label",
+					[]string{"Label (key or key=value, empty to clear)"},
+					[]int{0},
+				)
+				a.modal.SetInputValues([]string{a.containersView.GetLabelFilter()})
+				a.modal.SetConfirmText("Apply")
+				a.modal.SetSize(a.width, a.height)
+				a.pendingDeleteType = "filter_label"
+				return a, nil
+			} else if key.Matches(msg, keys.Map.RegistryLogin) && a.state.CurrentView == models.ViewImages {
+				a.modal = components.NewFormModalWithOptional(
+					"Registry Login",
+					[]string{"Registry (empty for Docker Hub)", "Username", "Password or access token", "Save like docker login (y/N)"},
+					[]int{0, 3},
+				)
+				a.modal.SetPasswordField(2)
+				a.modal.SetConfirmText("Log In")
+				a.modal.SetSize(a.width, a.height)
+				a.pendingDeleteType = "registry_login"
+				return a, nil
+			}
+
+		case key.Matches(msg, keys.Map.Snapshot, keys.Map.SaveImage, keys.Map.BackupVolume, keys.Map.ExportCompose):
+			// Save the image to a tar archive (Images view), back up a volume
+			// (Volumes view), export a group as a compose file (Groups view)
+			// or snapshot the container list for later comparison
+			if key.Matches(msg, keys.Map.ExportCompose) && a.state.CurrentView == models.ViewGroups && a.groupsView.GetCurrentTab() == models.GroupsListTab {
+				if group := a.groupsView.GetSelectedGroup(); group != nil {
+					a.modal = components.NewFormModal(fmt.Sprintf("Export %s As Compose File", group.Name), []string{"Compose file path (~/ allowed)"})
+					a.modal.SetInputValues([]string{filepath.Join(group.Name, "docker-compose.yml")})
+					a.modal.SetConfirmText("Export")
+					a.modal.SetSize(a.width, a.height)
+					a.pendingDeleteType = "export_compose"
+					a.pendingDelete = group.ID
+					return a, nil
+				}
+				break
+			} else if key.Matches(msg, keys.Map.BackupVolume) && a.state.CurrentView == models.ViewVolumes && a.volumesView.GetCurrentTab() == models.VolumesListTab {
+				if vol := a.volumesView.GetSelectedVolume(); vol != nil {
+					a.modal = components.NewFormModal(fmt.Sprintf("Back Up Volume %s", vol.Name), []string{"Archive path (.tar or .tar.gz, ~/ allowed)"})
+					a.modal.SetInputValues([]string{vol.ArchiveName(time.Now())})
+					a.modal.SetConfirmText("Back up")
+					a.modal.SetSize(a.width, a.height)
+					a.pendingDeleteType = "backup_volume"
+					a.pendingDelete = vol.Name
+					a.pendingArchiveSize = -1
+					if vol.UsageData != nil {
+						a.pendingArchiveSize = vol.UsageData.Size
+					}
+					return a, nil
+				}
+				break
+			} else if key.Matches(msg, keys.Map.SaveImage) && a.state.CurrentView == models.ViewImages {
+				if img := a.imagesView.GetSelectedImage(); img != nil {
+					ref := img.GetPrimaryTag()
+					if img.IsDangling() {
+						ref = img.ID
+					}
+					a.modal = components.NewFormModal(fmt.Sprintf("Save %s", ref), []string{"Archive path (.tar, ~/ allowed)"})
+					a.modal.SetInputValues([]string{img.ArchiveName()})
+					a.modal.SetConfirmText("Save")
+					a.modal.SetSize(a.width, a.height)
+					a.pendingDeleteType = "save_image"
+					a.pendingDelete = ref
+					a.pendingArchiveSize = img.Size
+					return a, nil
+				}
+				break
+			} else if !key.Matches(msg, keys.Map.Snapshot) {
+				break
+			}
+			if a.state.CurrentView == models.ViewContainers {
+				count := a.containersView.TakeSnapshot()
+				a.statusMessage = fmt.Sprintf("Snapshot taken (%d containers), press %s to compare", count, keys.Label(keys.Map.SnapshotDiff))
+				return a, clearStatus(3 * time.Second)
+			}
+
+		case key.Matches(msg, keys.Map.CompareImages, keys.Map.SnapshotDiff):
+			// Compare the two selected images, older first (Images view)
+			if key.Matches(msg, keys.Map.CompareImages) && a.state.CurrentView == models.ViewImages {
+				selected := a.imagesView.GetSelectedImages()
+				if len(selected) != 2 {
+					a.errorMessage = fmt.Sprintf("Select exactly two images to compare with %s (%d selected)", keys.Label(keys.Map.ToggleSelect), len(selected))
+					return a, clearStatus(3 * time.Second)
+				}
+				older, newer := selected[0], selected[1]
+				if newer.Created.Before(older.Created) {
+					older, newer = newer, older
+				}
+				a.statusMessage = fmt.Sprintf("Comparing %s and %s...", older.GetPrimaryTag(), newer.GetPrimaryTag())
+				return a, compareImages(a.docker, older, newer)
+			} else if !key.Matches(msg, keys.Map.SnapshotDiff) {
+				break
+			}
+			// Show what changed since the snapshot
+			if a.state.CurrentView == models.ViewContainers {
+				snapshot := a.containersView.GetSnapshot()
+				if snapshot == nil {
+					a.errorMessage = fmt.Sprintf("No snapshot taken yet, press %s first", keys.Label(keys.Map.Snapshot))
+					return a, clearStatus(2 * time.Second)
+				}
+				a.modal = components.NewInfoModal(
+					fmt.Sprintf("Changes since %s", snapshot.Taken.Format("15:04:05")),
+					a.containersView.RenderSnapshotDiff(),
+				)
+				a.modal.SetSize(a.width, a.height)
+				return a, nil
+			}
+
+		case key.Matches(msg, keys.Map.EnvMatrix):
+			// Env var matrix of a compose project (projects list)
+			if a.state.CurrentView == models.ViewCompose && !a.composeView.IsViewingServices() && !a.composeView.IsViewingContainers() {
+				if project := a.composeView.GetSelectedProject(); project != nil {
+					a.statusMessage = fmt.Sprintf("Loading environment of %s...", project.Name)
+					return a, loadComposeEnvMatrix(a.docker, *project)
+				}
+			}
+
+		case key.Matches(msg, keys.Map.RecreateChanged):
+			// Recreate the services whose config changed (projects list)
+			if a.state.CurrentView == models.ViewCompose && !a.composeView.IsViewingServices() && !a.composeView.IsViewingContainers() {
+				if project := a.composeView.GetSelectedProject(); project != nil {
+					a.statusMessage = fmt.Sprintf("Comparing %s with its compose files...", project.Name)
+					return a, checkComposeChanges(a.docker, *project)
+				}
+			}
+
+		case key.Matches(msg, keys.Map.ComposeFile):
+			// Show the compose files of the project (projects list)
+			if a.state.CurrentView == models.ViewCompose && !a.composeView.IsViewingServices() && !a.composeView.IsViewingContainers() {
+				if project := a.composeView.GetSelectedProject(); project != nil {
+					return a, loadComposeFiles(*project)
+				}
+			}
+
+		case key.Matches(msg, keys.Map.NewMacvlan):
+			// Macvlan/ipvlan network wizard (networks list tab)
+			if a.state.CurrentView == models.ViewNetworks && a.networksView.GetCurrentTab() == models.NetworksListTab {
+				return a, loadHostInterfaces(a.docker)
+			}
+
+		case key.Matches(msg, keys.Map.EditAliases):
+			// Edit a container's DNS aliases on the network (networks containers tab)
+			if a.state.CurrentView == models.ViewNetworks && a.networksView.GetCurrentTab() == models.NetworksContainersTab {
+				container := a.networksView.GetSelectedInNetworkContainer()
+				selectedNetwork := a.networksView.GetSelectedNetworkForApp()
+				if container != nil && selectedNetwork != nil {
+					if selectedNetwork.IsSystemNetwork() {
+						a.errorMessage = fmt.Sprintf("Aliases are only supported on user-defined networks, not '%s'", selectedNetwork.Name)
+						return a, clearStatus(3 * time.Second)
+					}
+					a.modal = components.NewFormModalWithOptional(
+						fmt.Sprintf("Aliases of %s on %s", container.Name, selectedNetwork.Name),
+						[]string{"Aliases (comma separated, empty for none)"},
+						[]int{0},
+					)
+					a.modal.SetInputValues([]string{strings.Join(container.Aliases[selectedNetwork.Name], ", ")})
+					a.modal.SetSize(a.width, a.height)
+					a.pendingDelete = container.ID
+					a.pendingDeleteType = "network_aliases"
+					return a, nil
+				}
+			}
+
+		case key.Matches(msg, keys.Map.ImageHistory, keys.Map.PortCheck):
+			// Layer history of the image (images view)
+			if key.Matches(msg, keys.Map.ImageHistory) && a.state.CurrentView == models.ViewImages {
+				if img := a.imagesView.GetSelectedImage(); img != nil {
+					a.statusMessage = fmt.Sprintf("Loading history of %s...", img.GetPrimaryTag())
+					return a, loadImageHistory(a.docker, *img)
+				}
+				break
+			} else if !key.Matches(msg, keys.Map.PortCheck) {
+				break
+			}
+			// Host port diagnostics (containers view)
+			if a.state.CurrentView == models.ViewContainers {
+				a.statusMessage = "Checking host ports..."
+				return a, checkPortConflicts(a.docker)
+			}
+
+		case key.Matches(msg, keys.Map.ClockCheck):
+			// Container clock drift diagnostics (containers view)
+			if a.state.CurrentView == models.ViewContainers {
+				if container := a.containersView.GetSelectedContainer(); container != nil {
+					if !container.IsRunning() {
+						a.errorMessage = "Cannot check clock: container is not running"
+						return a, clearStatus(2 * time.Second)
+					}
+					a.statusMessage = fmt.Sprintf("Checking the clock of %s...", container.Name)
+					return a, checkClock(a.docker, container.ID, container.Name)
+				}
+			}
+
+		case key.Matches(msg, keys.Map.TailFile):
+			// Tail a file inside the selected container (for services that log to files)
+			if a.state.CurrentView != models.ViewLogs && a.state.CurrentView != models.ViewStats {
+				if container := a.selectedContainer(); container != nil {
+					if !container.IsRunning() {
+						a.errorMessage = "Cannot tail file: container is not running"
+						return a, clearStatus(2 * time.Second)
+					}
+					a.state.SelectedContainer = container
+					a.pendingDeleteType = "tail_file"
+					a.modal = components.NewFormModal(
+						fmt.Sprintf("Tail file in %s", container.Name),
+						[]string{"File path (e.g. /var/log/nginx/error.log)"},
+					)
+					a.modal.SetConfirmText("Tail")
+					a.modal.SetSize(a.width, a.height)
+					return a, nil
+				}
+			}
+
+		case key.Matches(msg, keys.Map.ClearFilters):
+			// Clear all quick filters
+			if a.state.CurrentView == models.ViewContainers {
+				a.containersView.ClearQuickFilters()
+				return a, nil
+			}
+			if a.state.CurrentView == models.ViewImages {
+				a.imagesView.ClearUsageFilter()
+				return a, nil
+			}
+
+		case key.Matches(msg, keys.Map.SwitchContext):
+			// Switch docker context (main views)
+			if a.state.CurrentView == models.ViewContainers ||
+				a.state.CurrentView == models.ViewImages ||
+				a.state.CurrentView == models.ViewGroups ||
+				a.state.CurrentView == models.ViewVolumes ||
+				a.state.CurrentView == models.ViewCompose ||
+				a.state.CurrentView == models.ViewNetworks ||
+				a.state.CurrentView == models.ViewPlugins {
+				return a, loadDockerContexts()
+			}
+
+		case key.Matches(msg, keys.Map.Watch):
+			// Notify when the selected container exits or becomes unhealthy
+			if a.state.CurrentView == models.ViewContainers && a.groupManager != nil {
+				if container := a.containersView.GetSelectedContainer(); container != nil {
+					watched, err := a.groupManager.ToggleWatched(container.Name)
+					if err != nil {
+						a.errorMessage = fmt.Sprintf("Failed to save watched containers: %v", err)
+						return a, clearStatus(3 * time.Second)
+					}
+					a.containersView.SetWatched(a.groupManager.WatchedNames())
+					if watched {
+						a.statusMessage = fmt.Sprintf("Watching %s: notifying (%s) when it exits or becomes unhealthy", container.Name, a.notifyMethod)
+					} else {
+						a.statusMessage = fmt.Sprintf("Stopped watching %s", container.Name)
+					}
+					return a, clearStatus(3 * time.Second)
+				}
+			}
+
+		case key.Matches(msg, keys.Map.RuntimeColumn):
+			// Show runtime, platform and privileged badges in the list
+			if a.state.CurrentView == models.ViewContainers {
+				if a.containersView.ToggleRuntimeColumn() {
+					a.statusMessage = "Showing runtime, platform and privileged column"
+				} else {
+					a.statusMessage = "Hiding runtime column"
+				}
+				return a, clearStatus(2 * time.Second)
+			}
+
+		case key.Matches(msg, keys.Map.RunOnce):
+			// Run the selected image once with an optional command
+			if a.state.CurrentView == models.ViewImages {
+				if img := a.imagesView.GetSelectedImage(); img != nil {
+					ref := img.GetPrimaryTag()
+					if img.IsDangling() {
+						ref = img.ID
+					}
+					a.modal = components.NewFormModalWithOptional(
+						"Run Once: "+ref,
+						[]string{"Command (empty for the image's default)"},
+						[]int{0},
+					)
+					a.modal.SetConfirmText("Run")
+					a.modal.SetSize(a.width, a.height)
+					a.pendingDelete = ref
+					a.pendingDeleteType = "run_once"
+					return a, nil
+				}
+			}
+
+		case key.Matches(msg, keys.Map.QuickAction):
+			// Numbered slot of the current view's quick-action bar
+			slot := slices.Index(keys.Map.QuickAction.Keys(), msg.String())
+			if actions := a.quickActions[a.state.CurrentView]; slot >= 0 && slot < len(actions) {
+				return a.runQuickAction(actions[slot])
+			}
+
+		case key.Matches(msg, keys.Map.RunResults):
+			// Results of the one-shot runs of this session
+			if _, err := models.ParseView(a.state.CurrentView.String()); err == nil {
+				a.modal = components.NewInfoModal("Run Results", a.renderRunResults())
+				a.modal.SetSize(a.width, a.height)
+				return a, nil
+			}
+
+		case key.Matches(msg, keys.Map.AuditLog):
+			// Actions recorded in the audit log, only the selected container's
+			// in the containers view
+			if _, err := models.ParseView(a.state.CurrentView.String()); err == nil {
+				if a.state.CurrentView == models.ViewContainers {
+					if container := a.containersView.GetSelectedContainer(); container != nil {
+						return a, loadAuditLog(container)
+					}
+				}
+				return a, loadAuditLog(nil)
+			}
+
+		case key.Matches(msg, keys.Map.CopyCommand):
+			if a.state.CurrentView == models.ViewImageLabels {
+				if label := a.imageLabelsView.GetSelectedLabel(); label != nil {
+					if label.Link == "" {
+						a.errorMessage = fmt.Sprintf("%s has no link", label.Key)
+						return a, clearStatus(2 * time.Second)
+					}
+					return a, copyToClipboard("link", label.Link)
+				}
+				return a, nil
+			}
+			// Open copy menu with ready-to-paste commands for the selected container
+			if container := a.selectedContainer(); container != nil {
+				a.modal = components.NewMenuModal(
+					fmt.Sprintf("Copy Command: %s", container.Name),
+					containerCommands(a.docker, container.ID),
+				)
+				a.modal.SetConfirmText("Copy")
+				a.modal.SetSize(a.width, a.height)
+				a.pendingDelete = container.ID
+				a.pendingDeleteType = "copy_command"
+				return a, nil
+			}
+
+		case key.Matches(msg, keys.Map.CopyID):
+			// Yank the selected resource identifier to the clipboard
+			switch a.state.CurrentView {
+			case models.ViewContainers:
+				if container := a.containersView.GetSelectedContainer(); container != nil {
+					return a, copyToClipboard("container ID", container.ID)
+				}
+			case models.ViewImages:
+				if image := a.imagesView.GetSelectedImage(); image != nil {
+					if image.IsDangling() {
+						return a, copyToClipboard("image ID", image.ID)
+					}
+					return a, copyToClipboard("image tag", image.GetPrimaryTag())
+				}
+			case models.ViewGroups:
+				if a.groupsView.GetCurrentTab() == models.GroupsContainersTab {
+					if container := a.groupsView.GetSelectedInGroupContainer(); container != nil {
+						return a, copyToClipboard("container ID", container.ID)
+					}
+				} else if a.groupsView.GetCurrentTab() == models.GroupsAvailableTab {
+					if container := a.groupsView.GetSelectedAvailableContainer(); container != nil {
+						return a, copyToClipboard("container ID", container.ID)
+					}
+				}
+			case models.ViewVolumes:
+				if a.volumesView.GetCurrentTab() == models.VolumesUsedByTab {
+					if container := a.volumesView.GetSelectedUsedByContainer(); container != nil {
+						return a, copyToClipboard("container ID", container.ID)
+					}
+				} else if volume := a.volumesView.GetSelectedVolume(); volume != nil {
+					return a, copyToClipboard("volume name", volume.Name)
+				}
+			case models.ViewPlugins:
+				if plugin := a.pluginsView.GetSelectedPlugin(); plugin != nil {
+					return a, copyToClipboard("plugin name", plugin.Name)
+				}
+			case models.ViewCompose:
+				if a.composeView.IsViewingServices() || a.composeView.IsViewingContainers() {
+					if container := a.composeView.GetSelectedContainer(); container != nil {
+						return a, copyToClipboard("container ID", container.ID)
+					}
+				} else if project := a.composeView.GetSelectedProject(); project != nil {
+					return a, copyToClipboard("project name", project.Name)
+				}
+			case models.ViewNetworks:
+				switch a.networksView.GetCurrentTab() {
+				case models.NetworksListTab:
+					if network := a.networksView.GetSelectedNetwork(); network != nil {
+						return a, copyToClipboard("network ID", network.ID)
+					}
+				case models.NetworksContainersTab:
+					// Prefer the container's IP on this network, it's what you need for debugging
+					if container := a.networksView.GetSelectedInNetworkContainer(); container != nil {
+						if ip := a.networksView.GetContainerIP(*container); ip != "" {
+							return a, copyToClipboard("container IP", ip)
+						}
+						return a, copyToClipboard("container ID", container.ID)
+					}
+				case models.NetworksAvailableTab:
+					if container := a.networksView.GetSelectedAvailableContainer(); container != nil {
+						return a, copyToClipboard("container ID", container.ID)
+					}
+				}
+			case models.ViewLogs, models.ViewStats:
+				if a.state.SelectedContainer != nil {
+					return a, copyToClipboard("container ID", a.state.SelectedContainer.ID)
+				}
+			case models.ViewImageLabels:
+				if label := a.imageLabelsView.GetSelectedLabel(); label != nil {
+					return a, copyToClipboard(label.Key, label.Value)
+				}
+			case models.ViewVulnerabilities:
+				if finding := a.vulnsView.GetSelectedFinding(); finding != nil {
+					return a, copyToClipboard("vulnerability ID", finding.ID)
+				}
+			case models.ViewImageHistory:
+				if layer := a.historyView.GetSelectedLayer(); layer != nil {
+					return a, copyToClipboard("command", layer.CreatedBy)
+				}
+			case models.ViewComposeFile:
+				if file := a.composeFileView.GetCurrentFile(); file != nil {
+					return a, copyToClipboard("path", file.Path)
+				}
+			}
+
+		case key.Matches(msg, keys.Map.CheckConfig, keys.Map.EditCpuset):
+			// Validate compose files (compose projects list)
+			if key.Matches(msg, keys.Map.CheckConfig) && a.state.CurrentView == models.ViewCompose && !a.composeView.IsViewingServices() && !a.composeView.IsViewingContainers() {
+				if project := a.composeView.GetSelectedProject(); project != nil {
+					a.statusMessage = fmt.Sprintf("Validating compose config of %s...", project.Name)
+					return a, validateComposeConfig(a.docker, *project)
+				}
+			}
+
+			// Edit CPU pinning (containers view)
+			if key.Matches(msg, keys.Map.EditCpuset) && a.state.CurrentView == models.ViewContainers {
+				if container := a.containersView.GetSelectedContainer(); container != nil {
+					// Block if container is being rebuilt
+					if a.containersView.IsRebuilding(container.Name) {
+						a.errorMessage = "Cannot edit cpuset: container is being rebuilt"
+						return a, clearStatus(2 * time.Second)
+					}
+					return a, loadCpusetConfig(a.docker, container.ID)
+				}
+			}
+
+		case key.Matches(msg, keys.Map.Save):
+			// Save a compose project's .env file
+			if a.state.CurrentView == models.ViewComposeDotEnv && a.dotEnvView.IsModified() {
+				return a, saveComposeDotEnv(a.dotEnvView.GetProject(), a.dotEnvView.GetFile())
+			}
+			// Save env vars/labels/ports and rebuild container
+			if a.state.CurrentView == models.ViewEnvVars && a.envVarsView.IsModified() {
+				if a.pendingEnvContainer != nil {
+					// Update env vars, labels and ports in pending config
+					a.pendingEnvContainer.Env = a.envVarsView.GetEnvVars()
+					a.pendingEnvContainer.Labels = a.envVarsView.GetLabels()
+					a.pendingEnvContainer.PortBindings = a.envVarsView.GetPortBindings()
+					// Track rebuilding state to block operations and show status
+					a.rebuildingContainerName = a.pendingEnvContainer.Name
+					a.containersView.SetRebuilding(a.pendingEnvContainer.Name)
+					// Switch to containers view immediately so user can see the rebuilding status
+					a.state.CurrentView = models.ViewContainers
+					a.sidebar.SetCurrentView(models.ViewContainers)
+					return a, a.track(fmt.Sprintf("Rebuilding %s", a.pendingEnvContainer.Name),
+						recreateContainer(a.docker, a.state.SelectedContainer.ID, a.pendingEnvContainer))
+				}
+			}
+
+		case key.Matches(msg, keys.Map.ToggleSelect):
+			// Toggle selection in images view
+			if a.state.CurrentView == models.ViewImages {
+				a.imagesView.ToggleSelection()
+				return a, nil
+			}
+
+		case key.Matches(msg, keys.Map.Delete):
+			// Delete with confirmation
+			if a.state.CurrentView == models.ViewContainers {
 				if container := a.containersView.GetSelectedContainer(); container != nil {
 					// Block if container is being rebuilt
 					if a.containersView.IsRebuilding(container.Name) {
@@ -687,17 +2084,32 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if a.imagesView.HasSelection() {
 					selectedImages := a.imagesView.GetSelectedImages()
 
-					// Check if any selected image is in use
+					// Check if any selected image is protected or in use
 					for _, img := range selectedImages {
+						if a.imagesView.IsProtected(img) {
+							a.errorMessage = fmt.Sprintf("Cannot delete: image '%s' is protected, press %s on it to unprotect", img.GetPrimaryTag(), keys.Label(keys.Map.ProtectImage))
+							return a, clearStatus(3 * time.Second)
+						}
 						if !img.IsUnused() {
 							a.errorMessage = fmt.Sprintf("Cannot delete: image '%s' is in use by %d container(s)", img.GetPrimaryTag(), img.Containers)
 							return a, clearStatus(3 * time.Second)
 						}
 					}
 
+					var size int64
+					names := make([]string, 0, len(selectedImages))
+					for _, img := range selectedImages {
+						size += img.Size
+						names = append(names, img.GetPrimaryTag())
+					}
+					const maxNames = 5
+					if len(names) > maxNames {
+						names = append(names[:maxNames], fmt.Sprintf("+%d more", len(selectedImages)-maxNames))
+					}
 					a.modal = components.NewConfirmModal(
 						"Delete Selected Images",
-						fmt.Sprintf("Are you sure you want to remove %d selected image(s)?", len(selectedImages)),
+						fmt.Sprintf("Are you sure you want to remove %d selected image(s) (up to %s)?\n\n%s",
+							len(selectedImages), formatBytesShort(size), strings.Join(names, ", ")),
 					)
 					a.modal.SetSize(a.width, a.height)
 					a.pendingDeleteType = "images_bulk"
@@ -706,6 +2118,10 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 				// Single image delete
 				if image := a.imagesView.GetSelectedImage(); image != nil {
+					if a.imagesView.IsProtected(*image) {
+						a.errorMessage = fmt.Sprintf("Cannot delete: image '%s' is protected, press %s to unprotect", image.GetPrimaryTag(), keys.Label(keys.Map.ProtectImage))
+						return a, clearStatus(3 * time.Second)
+					}
 					a.modal = components.NewConfirmModal(
 						"Delete Image",
 						fmt.Sprintf("Are you sure you want to remove image '%s'?", image.GetPrimaryTag()),
@@ -737,7 +2153,7 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					a.pendingDeleteType = "container"
 					return a, nil
 				}
-			} else if a.state.CurrentView == models.ViewVolumes {
+			} else if a.state.CurrentView == models.ViewVolumes && a.volumesView.GetCurrentTab() == models.VolumesListTab {
 				if volume := a.volumesView.GetSelectedVolume(); volume != nil {
 					a.modal = components.NewConfirmModal(
 						"Delete Volume",
@@ -748,6 +2164,21 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					a.pendingDeleteType = "volume"
 					return a, nil
 				}
+			} else if a.state.CurrentView == models.ViewCompose && !a.composeView.IsViewingServices() && !a.composeView.IsViewingContainers() {
+				// Take the project down, with what to remove besides its containers
+				if project := a.composeView.GetSelectedProject(); project != nil {
+					a.modal = components.NewMenuModal(fmt.Sprintf("Compose Down: %s", project.Name), []string{
+						fmt.Sprintf("Containers and networks (%d containers)", project.GetContainerCount()),
+						"Containers, networks and named volumes (their data is lost)",
+						"Containers, networks and the images of the services",
+						"Everything: containers, networks, named volumes and images",
+					})
+					a.modal.SetConfirmText("Down")
+					a.modal.SetSize(a.width, a.height)
+					a.pendingDelete = project.Name
+					a.pendingDeleteType = "compose_down"
+					return a, nil
+				}
 			} else if a.state.CurrentView == models.ViewCompose && a.composeView.IsViewingContainers() {
 				// Only allow delete when viewing containers in a scaled service
 				if container := a.composeView.GetSelectedContainer(); container != nil {
@@ -771,6 +2202,17 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					a.pendingDeleteType = "container"
 					return a, nil
 				}
+			} else if a.state.CurrentView == models.ViewVolumes && a.volumesView.GetCurrentTab() == models.VolumesUsedByTab {
+				if container := a.volumesView.GetSelectedUsedByContainer(); container != nil {
+					a.modal = components.NewConfirmModal(
+						"Delete Container",
+						fmt.Sprintf("Are you sure you want to remove container '%s'?", container.Name),
+					)
+					a.modal.SetSize(a.width, a.height)
+					a.pendingDelete = container.ID
+					a.pendingDeleteType = "container"
+					return a, nil
+				}
 			} else if a.state.CurrentView == models.ViewNetworks && a.networksView.GetCurrentTab() == models.NetworksListTab {
 				if network := a.networksView.GetSelectedNetwork(); network != nil {
 					if network.IsSystemNetwork() {
@@ -786,16 +2228,76 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					a.pendingDeleteType = "network"
 					return a, nil
 				}
+			} else if a.state.CurrentView == models.ViewPlugins {
+				if plugin := a.pluginsView.GetSelectedPlugin(); plugin != nil {
+					message := fmt.Sprintf("Are you sure you want to remove plugin '%s'?", plugin.Name)
+					if plugin.Enabled {
+						message += "\nThe plugin is enabled and will be force removed."
+					}
+					a.modal = components.NewConfirmModal("Remove Plugin", message)
+					a.modal.SetSize(a.width, a.height)
+					a.pendingDelete = plugin.Name
+					a.pendingDeleteType = "plugin"
+					return a, nil
+				}
 			}
 
-		case "p":
-			// Pull image (Images view) or Prune volumes (Volumes view)
-			if a.state.CurrentView == models.ViewImages {
-				a.modal = components.NewFormModal("Pull Image", []string{"Image Name (e.g. nginx:latest)"})
+		case key.Matches(msg, keys.Map.PullImage, keys.Map.PruneVolumes, keys.Map.PruneNetworks):
+			// Pull image (Images view) or the images of a project (compose
+			// projects list), prune volumes (Volumes view) or prune networks
+			// (Networks view)
+			if key.Matches(msg, keys.Map.PullImage) && a.state.CurrentView == models.ViewCompose && !a.composeView.IsViewingServices() && !a.composeView.IsViewingContainers() {
+				project := a.composeView.GetSelectedProject()
+				if project == nil {
+					break
+				}
+				if a.batchPullChan != nil {
+					a.errorMessage = "A batch pull is already running"
+					return a, clearStatus(2 * time.Second)
+				}
+				images := project.GetPullableImages()
+				if len(images) == 0 {
+					a.errorMessage = fmt.Sprintf("%s has no images to pull (only locally built ones)", project.Name)
+					return a, clearStatus(3 * time.Second)
+				}
+
+				a.batchPullQueue = images
+				a.batchPullSource = "of " + project.Name
+				a.batchPullResults = nil
+				names := make([]string, 0, len(images))
+				for _, image := range images {
+					names = append(names, "  • "+image)
+				}
+				if maxLines := a.height - 14; maxLines > 0 && len(names) > maxLines {
+					hidden := len(names) - maxLines + 1
+					names = append(names[:maxLines-1], styles.DescStyle.Render(fmt.Sprintf("  ... %d more", hidden)))
+				}
+				a.modal = components.NewConfirmModal(
+					"Pull Project Images",
+					fmt.Sprintf("Pull the %d image(s) of %s's services?\nRunning containers keep their image until they are recreated.\n\n%s",
+						len(images), project.Name, strings.Join(names, "\n")),
+				)
+				a.modal.SetConfirmText("Pull")
+				a.modal.SetSize(a.width, a.height)
+				a.pendingDeleteType = "batch_pull"
+				return a, nil
+			} else if key.Matches(msg, keys.Map.PullImage) && a.state.CurrentView == models.ViewImages {
+				if a.pullModal != nil {
+					// Back to the progress of the running pull
+					a.pullModal.SetMessage(a.renderPullProgress())
+					a.pullModal.Show()
+					a.modal = a.pullModal
+					a.pendingDeleteType = "cancel_pull"
+					return a, nil
+				}
+				a.modal = components.NewFormModalWithOptional("Pull Image", []string{
+					"Image Name (e.g. nginx:latest)",
+					"Platform (e.g. linux/arm64, empty for the host's)",
+				}, []int{1})
 				a.modal.SetSize(a.width, a.height)
 				a.pendingDeleteType = "pull_image"
 				return a, nil
-			} else if a.state.CurrentView == models.ViewVolumes {
+			} else if key.Matches(msg, keys.Map.PruneVolumes) && a.state.CurrentView == models.ViewVolumes && a.volumesView.GetCurrentTab() == models.VolumesListTab {
 				a.modal = components.NewConfirmModal(
 					"Prune Unused Volumes",
 					"Remove all volumes not used by at least one container?",
@@ -803,32 +2305,330 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				a.modal.SetSize(a.width, a.height)
 				a.pendingDeleteType = "prune_volumes"
 				return a, nil
-			}
-
-		case "P":
-			// Prune dangling images
-			if a.state.CurrentView == models.ViewImages {
-				a.modal = components.NewConfirmModal(
-					"Prune Dangling Images",
-					"Remove all dangling (untagged) images?",
+			} else if key.Matches(msg, keys.Map.PruneNetworks) && a.state.CurrentView == models.ViewNetworks && a.networksView.GetCurrentTab() == models.NetworksListTab {
+				unused := a.networksView.Unused()
+				if len(unused) == 0 {
+					a.statusMessage = "No unused networks"
+					return a, clearStatus(2 * time.Second)
+				}
+				names := make([]string, 0, len(unused))
+				for _, n := range unused {
+					names = append(names, n.Name)
+				}
+				const maxNames = 5
+				if len(names) > maxNames {
+					names = append(names[:maxNames], fmt.Sprintf("+%d more", len(unused)-maxNames))
+				}
+				a.modal = components.NewConfirmModal(
+					"Prune Unused Networks",
+					fmt.Sprintf("Remove %d network(s) no container is connected to?\n\n%s", len(unused), strings.Join(names, ", ")),
 				)
+				a.modal.SetConfirmText("Prune")
+				a.modal.SetSize(a.width, a.height)
+				a.pendingDeleteType = "prune_networks"
+				return a, nil
+			}
+
+		case key.Matches(msg, keys.Map.PullList):
+			// Pull every image listed in a text/lock or compose file
+			if a.state.CurrentView == models.ViewImages {
+				if a.batchPullChan != nil {
+					a.errorMessage = "A batch pull is already running"
+					return a, clearStatus(2 * time.Second)
+				}
+				a.modal = components.NewFormModal("Pull Images From File", []string{"Path to an image list or compose file"})
+				a.modal.SetConfirmText("Load")
+				a.modal.SetSize(a.width, a.height)
+				a.pendingDeleteType = "pull_list"
+				return a, nil
+			}
+
+		case key.Matches(msg, keys.Map.Inspect):
+			// Container runtime, platform and privileges
+			if a.state.CurrentView == models.ViewContainers {
+				if ctr := a.containersView.GetSelectedContainer(); ctr != nil {
+					a.modal = components.NewInfoModal("Container "+ctr.Name, renderContainerRuntime(*ctr))
+					a.modal.SetSize(a.width, a.height)
+					return a, nil
+				}
+			}
+			// Image details with signing/provenance info
+			if a.state.CurrentView == models.ViewImages {
+				if img := a.imagesView.GetSelectedImage(); img != nil {
+					a.statusMessage = fmt.Sprintf("Inspecting %s...", img.GetPrimaryTag())
+					return a, loadImageProvenance(a.docker, *img)
+				}
+			}
+			// Full IPAM config, options and endpoints of the network (the
+			// one under the cursor, or the one opened in the other tabs)
+			if a.state.CurrentView == models.ViewNetworks {
+				selected := a.networksView.GetSelectedNetworkForApp()
+				if a.networksView.GetCurrentTab() == models.NetworksListTab {
+					selected = a.networksView.GetSelectedNetwork()
+				}
+				if selected != nil {
+					a.statusMessage = fmt.Sprintf("Inspecting %s...", selected.Name)
+					return a, inspectNetwork(a.docker, selected.ID)
+				}
+			}
+
+		case key.Matches(msg, keys.Map.ImageLabels):
+			// Labels and OCI annotations of the image, with copyable values
+			if a.state.CurrentView == models.ViewImages {
+				if img := a.imagesView.GetSelectedImage(); img != nil {
+					a.imageLabelsView.SetImage(*img)
+					a.state.PreviousView = a.state.CurrentView
+					a.state.CurrentView = models.ViewImageLabels
+					return a, nil
+				}
+			}
+
+		case key.Matches(msg, keys.Map.PruneImages, keys.Map.PruneAnonymous):
+			// Remove the anonymous volumes no container uses, leaving named
+			// ones alone unlike the general prune (Volumes view)
+			if key.Matches(msg, keys.Map.PruneAnonymous) && a.state.CurrentView == models.ViewVolumes && a.volumesView.GetCurrentTab() == models.VolumesListTab {
+				volumes := a.volumesView.AnonymousUnused()
+				if len(volumes) == 0 {
+					a.statusMessage = "No unused anonymous volumes"
+					return a, clearStatus(2 * time.Second)
+				}
+				names := make([]string, len(volumes))
+				var size int64
+				sized := false
+				for i, vol := range volumes {
+					names[i] = vol.Name
+					if vol.UsageData != nil && vol.UsageData.Size >= 0 {
+						size += vol.UsageData.Size
+						sized = true
+					}
+				}
+				body := fmt.Sprintf("Remove %d anonymous volume(s) not used by any container?", len(volumes))
+				if sized {
+					body = fmt.Sprintf("Remove %d anonymous volume(s) not used by any container (%s)?", len(volumes), formatBytesShort(size))
+				}
+				a.modal = components.NewConfirmModal("Remove Anonymous Volumes", body)
+				a.modal.SetSize(a.width, a.height)
+				a.pendingDeleteType = "prune_anonymous"
+				a.pendingDelete = strings.Join(names, ",")
+				return a, nil
+			} else if !key.Matches(msg, keys.Map.PruneImages) {
+				break
+			}
+			// Prune dangling or all unused images
+			if a.state.CurrentView == models.ViewImages {
+				dangling, danglingSize := a.imagesView.Unused(true)
+				unused, unusedSize := a.imagesView.Unused(false)
+				title := "Prune Images"
+				if protected := len(a.imagesView.ProtectedIDs()); protected > 0 {
+					title = fmt.Sprintf("Prune Images (%d protected kept)", protected)
+				}
+				a.modal = components.NewMenuModal(title, []string{
+					fmt.Sprintf("Dangling only: untagged images (%d, up to %s)", dangling, formatBytesShort(danglingSize)),
+					fmt.Sprintf("All unused: every image without a container (%d, up to %s)", unused, formatBytesShort(unusedSize)),
+				})
+				a.modal.SetConfirmText("Prune")
 				a.modal.SetSize(a.width, a.height)
 				a.pendingDeleteType = "prune_images"
 				return a, nil
 			}
 		}
 
+	case RunFinishedMsg:
+		a.runResults = append(a.runResults, *msg.result)
+		a.statusMessage = ""
+
+		// Don't replace a dialog the user opened while the run went on
+		if a.modal != nil && a.modal.IsVisible() {
+			if msg.result.Succeeded() {
+				a.statusMessage = fmt.Sprintf("Run of %s finished, press %s for the results", msg.result.Image, keys.Label(keys.Map.RunResults))
+			} else {
+				a.errorMessage = fmt.Sprintf("Run of %s failed, press %s for the results", msg.result.Image, keys.Label(keys.Map.RunResults))
+			}
+			return a, clearStatus(5 * time.Second)
+		}
+		a.modal = components.NewInfoModal("Run Results", a.renderRunResults())
+		a.modal.SetSize(a.width, a.height)
+		return a, nil
+
+	case AuditLogLoadedMsg:
+		if msg.err != nil {
+			a.errorMessage = fmt.Sprintf("Failed to read audit log: %v", msg.err)
+			return a, clearStatus(3 * time.Second)
+		}
+		a.modal = components.NewInfoModal(msg.title, renderAuditLog(msg.entries, max(5, a.height-12)))
+		a.modal.SetSize(a.width, a.height)
+		return a, nil
+
+	case DockerContextsLoadedMsg:
+		if msg.err != nil {
+			a.errorMessage = fmt.Sprintf("Failed to read docker contexts: %v", msg.err)
+			return a, clearStatus(3 * time.Second)
+		}
+
+		a.dockerContexts = msg.contexts
+		options := make([]string, len(msg.contexts))
+		for i, c := range msg.contexts {
+			marker := "  "
+			if c.Name == a.dockerContext.Name {
+				marker = "● "
+			}
+			options[i] = fmt.Sprintf("%s%s  %s", marker, c.Name, c.Host)
+			if c.Current {
+				options[i] += "  (docker CLI default)"
+			}
+		}
+		a.modal = components.NewMenuModal("Docker Context", options)
+		a.modal.SetConfirmText("Connect")
+		a.modal.SetSize(a.width, a.height)
+		a.pendingDeleteType = "switch_context"
+		return a, nil
+
+	case DockerContextSwitchedMsg:
+		if msg.err != nil {
+			a.statusMessage = ""
+			a.errorMessage = fmt.Sprintf("Failed to connect to context '%s': %v", msg.context.Name, msg.err)
+			return a, clearStatus(4 * time.Second)
+		}
+
+		if a.docker != nil {
+			a.docker.Close()
+		}
+		a.docker = msg.client
+		a.dockerContext = msg.context
+		a.disconnected = false
+		a.sidebar.SetDisconnected(false)
+		a.sidebar.SetContext(msg.context.Name)
+		a.sidebar.SetLatency(0)
+
+		// Daemon specific state must not leak into the other context
+		a.containersView.SwitchContext(msg.context.Name)
+		a.imagesView.Reset()
+		a.volumesView.Reset()
+		a.pendingSelectContainerID = ""
+		a.rebuildingContainerName = ""
+
+		a.statusMessage = fmt.Sprintf("Connected to context '%s' (%s, API %s)", msg.context.Name, msg.client.DaemonHost(), msg.client.APIVersion())
+		return a, tea.Batch(
+			fetchContainers(a.docker),
+			a.refreshCurrentView(),
+			a.startWatching(),
+			clearStatus(3*time.Second),
+		)
+
+	case DockerInitFailedMsg:
+		a.initErr = msg.err
+		a.initRetrying = false
+		return a, nil
+
 	case DockerClientReadyMsg:
+		a.initErr = nil
+		a.initRetrying = false
+		if a.docker != nil && a.docker != msg.client {
+			a.docker.Close()
+		}
 		a.docker = msg.client
 		a.ready = true
-		return a, fetchContainers(a.docker)
+		watch := a.startWatching()
+
+		if a.disconnected {
+			// Back after a daemon restart, reload whatever is on screen
+			a.disconnected = false
+			a.sidebar.SetDisconnected(false)
+			a.statusMessage = "Reconnected to Docker daemon"
+			return a, tea.Batch(fetchContainers(a.docker), a.refreshCurrentView(), watch, clearStatus(2*time.Second))
+		}
+		if a.state.CurrentView != models.ViewContainers {
+			// Started on another view from the command line
+			return a, tea.Batch(fetchContainers(a.docker), a.refreshCurrentView(), watch)
+		}
+		return a, tea.Batch(fetchContainers(a.docker), watch)
+
+	case WatchEventMsg:
+		// A replaced or ended stream is restarted with the next client
+		if msg.gen != a.watchGen || msg.event == nil {
+			return a, nil
+		}
+		next := waitForWatchEvent(msg.gen, msg.events, msg.errs)
+		name := strings.TrimPrefix(msg.event.Name, "/")
+		if a.groupManager == nil || !a.groupManager.IsWatched(name) {
+			return a, next
+		}
+
+		title := fmt.Sprintf("%s exited", name)
+		if msg.event.Action == "unhealthy" {
+			title = fmt.Sprintf("%s is unhealthy", name)
+		} else if msg.event.ExitCode != "" {
+			title = fmt.Sprintf("%s exited with code %s", name, msg.event.ExitCode)
+		}
+		a.errorMessage = "Watched container " + title
+		return a, tea.Batch(next, notify(a.notifyMethod, "doui: "+title, msg.event.Description()), clearStatus(5*time.Second))
+
+	case ReconnectTickMsg:
+		return a, reconnectDocker(a.dockerContext)
+
+	case DockerReconnectFailedMsg:
+		return a, tickReconnect()
+
+	case ScheduleTickMsg:
+		// Start and stop the groups whose schedule fires this minute, once.
+		// A group due to start and stop in the same minute is left alone.
+		minute := msg.time.Truncate(time.Minute)
+		cmds := []tea.Cmd{tickSchedule()}
+		if a.groupManager != nil && a.docker != nil && !a.disconnected && minute.After(a.lastScheduleCheck) {
+			a.lastScheduleCheck = minute
+			for _, group := range a.groupManager.GetAllGroups() {
+				switch start, stop := group.ScheduledAt(minute); {
+				case start && !stop:
+					cmds = append(cmds, a.track(fmt.Sprintf("Scheduled start of %s", group.Name), startGroup(a.docker, a.groupManager, group.ID)))
+				case stop && !start:
+					cmds = append(cmds, a.track(fmt.Sprintf("Scheduled stop of %s", group.Name), stopGroup(a.docker, a.groupManager, group.ID)))
+				}
+			}
+		}
+		return a, tea.Batch(cmds...)
+
+	case HealthTickMsg:
+		// Only remote daemons are pinged: a local socket fails loudly on its own
+		if !a.ready || a.disconnected || a.docker == nil || !a.docker.IsRemote() {
+			return a, tickHealth()
+		}
+		return a, checkHealth(a.docker)
+
+	case DaemonHealthMsg:
+		if msg.client != a.docker {
+			// Answer from a client replaced in the meantime
+			return a, tickHealth()
+		}
+		if msg.err != nil {
+			// The ssh tunnel (or link) dropped: the reconnect loop dials anew
+			a.sidebar.SetLatency(0)
+			if !a.disconnected {
+				a.disconnected = true
+				a.sidebar.SetDisconnected(true)
+				return a, tea.Batch(tickHealth(), tickReconnect())
+			}
+			return a, tickHealth()
+		}
+		a.sidebar.SetLatency(msg.latency)
+		return a, tickHealth()
 
 	case GroupManagerReadyMsg:
 		a.groupManager = msg.manager
+		a.containersView.SetWatched(a.groupManager.WatchedNames())
+		a.imagesView.SetProtected(a.groupManager.ProtectedImages())
 		// Load groups into the view
 		groups := a.groupManager.GetAllGroups()
 		a.groupsView.SetGroups(groups)
 
+		if a.startGroup != "" {
+			name := a.startGroup
+			a.startGroup = ""
+			if group := a.groupManager.GetGroupByName(name); group == nil || !a.groupsView.OpenGroup(group.ID) {
+				a.errorMessage = fmt.Sprintf("Group '%s' not found", name)
+				return a, clearStatus(3 * time.Second)
+			}
+		}
+
 	case ContainersLoadedMsg:
 		a.containersView.SetContainers(msg.containers)
 		// Also pass to groups view, networks view, and volumes view for usage counting
@@ -844,25 +2644,72 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			a.pendingSelectContainerID = ""
 		}
 
+		if a.restoreContainer != "" {
+			if container := models.FindContainer(msg.containers, a.restoreContainer); container != nil {
+				a.containersView.SelectByID(container.ID)
+			}
+			a.restoreContainer = ""
+		}
+
+		if a.startContainer != "" {
+			ref, logs := a.startContainer, a.startLogs
+			a.startContainer, a.startLogs = "", false
+			container := models.FindContainer(msg.containers, ref)
+			if container == nil {
+				a.errorMessage = fmt.Sprintf("Container '%s' not found", ref)
+				return a, clearStatus(4 * time.Second)
+			}
+			a.containersView.SelectByID(container.ID)
+			if logs {
+				a.state.PreviousView = models.ViewContainers
+				a.state.CurrentView = models.ViewLogs
+				a.state.SelectedContainer = container
+				return a, startLogStreaming(a.streamContext(), a.docker, a.logsView, container)
+			}
+		}
+
+		// Follow group members recreated outside doui to their new ID
+		return a, resolveGroupMembers(a.groupManager, msg.containers)
+
 	case ImagesLoadedMsg:
 		a.imagesView.SetImages(msg.images)
 
 	case GroupsLoadedMsg:
 		a.groupsView.SetGroups(msg.groups)
+		a.containersView.SetGroups(msg.groups)
 
 	case VolumesLoadedMsg:
 		a.volumesView.SetVolumes(msg.volumes)
 
+	case VolumeSizesLoadedMsg:
+		if msg.err != nil {
+			a.errorMessage = fmt.Sprintf("Failed to measure volumes: %v", msg.err)
+			return a, clearStatus(5 * time.Second)
+		}
+		a.statusMessage = ""
+		a.volumesView.SetVolumeSizes(msg.sizes, time.Now())
+
 	case ComposeProjectsLoadedMsg:
 		a.composeView.SetProjects(msg.projects)
 
 	case NetworksLoadedMsg:
 		a.networksView.SetNetworks(msg.networks)
 
+	case GroupLogsLoadedMsg:
+		if msg.groupID != a.groupLogsID || a.state.CurrentView != models.ViewGroupLogs {
+			return a, nil
+		}
+		if msg.err != nil {
+			a.errorMessage = fmt.Sprintf("Failed to read group logs: %v", msg.err)
+			return a, clearStatus(3 * time.Second)
+		}
+		a.groupLogsView.SetPanes(msg.panes)
+
 	case RefreshTickMsg:
-		// Auto-refresh current view
-		if !a.ready {
-			return a, tickRefresh()
+		// Auto-refresh current view (the reconnect loop takes over while
+		// disconnected, and nothing is refreshed while the terminal is unfocused)
+		if !a.ready || a.disconnected || a.blurred {
+			return a, tickRefresh(a.refreshInterval)
 		}
 
 		// Skip refresh if currently filtering to avoid clearing filter input
@@ -871,39 +2718,76 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			(a.state.CurrentView == models.ViewGroups && a.groupsView.IsFiltering()) ||
 			(a.state.CurrentView == models.ViewVolumes && a.volumesView.IsFiltering()) ||
 			(a.state.CurrentView == models.ViewCompose && a.composeView.IsFiltering()) ||
-			(a.state.CurrentView == models.ViewNetworks && a.networksView.IsFiltering()) {
-			return a, tickRefresh()
+			(a.state.CurrentView == models.ViewNetworks && a.networksView.IsFiltering()) ||
+			(a.state.CurrentView == models.ViewPlugins && a.pluginsView.IsFiltering()) {
+			return a, tickRefresh(a.refreshInterval)
 		}
 
-		var cmd tea.Cmd
-		switch a.state.CurrentView {
-		case models.ViewContainers:
-			cmd = fetchContainers(a.docker)
-		case models.ViewImages:
-			cmd = fetchImages(a.docker)
-		case models.ViewGroups:
-			cmd = loadGroups(a.groupManager)
-		case models.ViewVolumes:
-			cmd = tea.Batch(fetchVolumes(a.docker), fetchContainers(a.docker))
-		case models.ViewCompose:
-			cmd = fetchComposeProjects(a.docker)
-		case models.ViewNetworks:
-			cmd = tea.Batch(fetchNetworks(a.docker), fetchContainers(a.docker))
+		// Idle: only refresh every IdleRefreshInterval
+		a.updateIdle()
+		if a.idle && time.Since(a.lastRefresh) < config.IdleRefreshInterval {
+			return a, tickRefresh(a.refreshInterval)
 		}
 
-		return a, tea.Batch(cmd, tickRefresh())
+		a.lastRefresh = time.Now()
+		return a, tea.Batch(a.refreshCurrentView(), tickRefresh(a.refreshInterval))
 
 	case ContainerStartedMsg:
+		var startupCmd tea.Cmd
 		if msg.err != nil {
 			a.errorMessage = fmt.Sprintf("Failed to start container: %v", msg.err)
+			startupCmd = fetchStartupLogs(a.docker, msg.containerID, msg.err.Error())
 		} else {
 			a.statusMessage = fmt.Sprintf("Container %s started", msg.containerID[:12])
+			startupCmd = scheduleStartupCheck(msg.containerID)
 		}
 		return a, tea.Batch(
 			fetchContainers(a.docker),
 			clearStatus(2*time.Second),
+			startupCmd,
 		)
 
+	case StartupCheckMsg:
+		return a, checkStartup(a.docker, msg.containerID)
+
+	case ContainerStartFailedMsg:
+		// Don't replace a dialog the user is busy with
+		if a.modal != nil && a.modal.IsVisible() {
+			a.errorMessage = fmt.Sprintf("Container %s: %s", msg.containerID[:12], msg.reason)
+			return a, clearStatus(3 * time.Second)
+		}
+
+		// Show why the container didn't come up, with its last log lines
+		var b strings.Builder
+		b.WriteString(styles.ErrorStyle.Render(msg.reason))
+		b.WriteString("\n\n")
+		if models.IsExecFormatError(msg.reason + "\n" + strings.Join(msg.logs, "\n")) {
+			b.WriteString(styles.WarningStyle.Width(70).Render("⚠ " + models.ExecFormatHint))
+			b.WriteString("\n\n")
+		}
+		if len(msg.logs) == 0 {
+			b.WriteString(styles.DescStyle.Render("No log output."))
+		} else {
+			// Keep the modal within the screen
+			logs := msg.logs
+			if maxLines := a.height - 14; maxLines > 0 && len(logs) > maxLines {
+				logs = logs[len(logs)-maxLines:]
+			}
+			maxWidth := a.width - 12
+			for i, line := range logs {
+				if maxWidth > 0 && len([]rune(line)) > maxWidth {
+					line = string([]rune(line)[:maxWidth-1]) + "…"
+				}
+				b.WriteString(line)
+				if i < len(logs)-1 {
+					b.WriteString("\n")
+				}
+			}
+		}
+		a.modal = components.NewInfoModal(fmt.Sprintf("Container %s failed to start", msg.containerID[:12]), b.String())
+		a.modal.SetSize(a.width, a.height)
+		return a, fetchContainers(a.docker)
+
 	case ContainerStoppedMsg:
 		if msg.err != nil {
 			a.errorMessage = fmt.Sprintf("Failed to stop container: %v", msg.err)
@@ -952,6 +2836,27 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.statusMessage = ""
 		a.errorMessage = ""
 
+	case OperationDoneMsg:
+		for i, op := range a.operations {
+			if op.id == msg.id {
+				a.operations = append(a.operations[:i], a.operations[i+1:]...)
+				break
+			}
+		}
+		if msg.msg == nil {
+			return a, nil
+		}
+		return a.Update(msg.msg)
+
+	case spinner.TickMsg:
+		if !a.busy() {
+			a.spinning = false
+			return a, nil
+		}
+		var cmd tea.Cmd
+		a.spinner, cmd = a.spinner.Update(msg)
+		return a, cmd
+
 	case ImageRemovedMsg:
 		if msg.err != nil {
 			a.errorMessage = fmt.Sprintf("Failed to remove image: %v", msg.err)
@@ -963,6 +2868,173 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			clearStatus(2*time.Second),
 		)
 
+	case ImageTaggedMsg:
+		if msg.err != nil {
+			a.errorMessage = fmt.Sprintf("Failed to tag image: %v", msg.err)
+		} else if msg.untag {
+			a.statusMessage = fmt.Sprintf("Renamed %s to %s", msg.source, msg.target)
+		} else {
+			a.statusMessage = fmt.Sprintf("Tagged %s as %s", msg.source, msg.target)
+		}
+		return a, tea.Batch(
+			fetchImages(a.docker),
+			clearStatus(2*time.Second),
+		)
+
+	case QuickRunStartedMsg:
+		a.statusMessage = ""
+		if msg.err != nil {
+			a.errorMessage = fmt.Sprintf("Failed to run %s: %v", msg.image, msg.err)
+			return a, clearStatus(5 * time.Second)
+		}
+		if !msg.detach {
+			// Follow its output like an attached `docker run`
+			container := &models.Container{ID: msg.id, ShortID: msg.id[:12], Name: msg.name, Image: msg.image, State: "running"}
+			a.state.PreviousView = a.state.CurrentView
+			a.state.CurrentView = models.ViewLogs
+			a.state.SelectedContainer = container
+			return a, tea.Batch(startLogStreaming(a.streamContext(), a.docker, a.logsView, container), fetchContainers(a.docker))
+		}
+		a.statusMessage = fmt.Sprintf("Started %s from %s", msg.name, msg.image)
+		return a, tea.Batch(fetchContainers(a.docker), clearStatus(3*time.Second))
+
+	case ImageSavedMsg:
+		if msg.err != nil {
+			a.errorMessage = fmt.Sprintf("Failed to save %s: %v", msg.ref, msg.err)
+			return a, clearStatus(5 * time.Second)
+		}
+		a.statusMessage = fmt.Sprintf("Saved %s to %s (%s)", msg.ref, msg.path, utils.FormatBytes(msg.size))
+		return a, clearStatus(5 * time.Second)
+
+	case ImagesComparedMsg:
+		if msg.err != nil {
+			a.errorMessage = fmt.Sprintf("Failed to compare images: %v", msg.err)
+			return a, clearStatus(5 * time.Second)
+		}
+		a.statusMessage = ""
+		a.modal = components.NewInfoModal(
+			fmt.Sprintf("Compare %s and %s", msg.comparison.A.Ref, msg.comparison.B.Ref),
+			a.renderImageComparison(msg.comparison),
+		)
+		a.modal.SetSize(a.width, a.height)
+		return a, nil
+
+	case ImageHistoryLoadedMsg:
+		if msg.err != nil {
+			a.errorMessage = fmt.Sprintf("Failed to load history of %s: %v", msg.image.GetPrimaryTag(), msg.err)
+			return a, clearStatus(5 * time.Second)
+		}
+		a.statusMessage = ""
+		if a.state.CurrentView == models.ViewImages {
+			a.historyView.SetHistory(msg.image, msg.layers)
+			a.state.PreviousView = a.state.CurrentView
+			a.state.CurrentView = models.ViewImageHistory
+		}
+		return a, nil
+
+	case ComposeFilesLoadedMsg:
+		if msg.err != nil {
+			a.errorMessage = msg.err.Error()
+			return a, clearStatus(4 * time.Second)
+		}
+		if a.state.CurrentView == models.ViewCompose {
+			a.composeFileView.SetFiles(msg.project, msg.files)
+			a.state.PreviousView = a.state.CurrentView
+			a.state.CurrentView = models.ViewComposeFile
+		} else if a.state.CurrentView == models.ViewComposeFile {
+			// Reloaded after editing
+			a.composeFileView.SetFiles(msg.project, msg.files)
+		}
+		return a, nil
+
+	case ComposeDotEnvLoadedMsg:
+		if msg.err != nil {
+			a.errorMessage = msg.err.Error()
+			return a, clearStatus(4 * time.Second)
+		}
+		if a.state.CurrentView == models.ViewCompose {
+			a.dotEnvView.SetFile(msg.project, msg.file)
+			a.state.PreviousView = a.state.CurrentView
+			a.state.CurrentView = models.ViewComposeDotEnv
+		}
+		return a, nil
+
+	case ComposeDotEnvSavedMsg:
+		if msg.err != nil {
+			a.errorMessage = msg.err.Error()
+			return a, clearStatus(5 * time.Second)
+		}
+		if a.state.CurrentView == models.ViewComposeDotEnv {
+			a.state.CurrentView = a.state.PreviousView
+			a.sidebar.SetCurrentView(a.state.PreviousView)
+		}
+		// Compose only reads .env when the project is brought up
+		project := msg.project
+		a.applyProject = &project
+		a.modal = components.NewConfirmModal(
+			"Apply Compose Changes",
+			fmt.Sprintf("Saved %s.\nRun `docker compose up -d` to recreate the services of %s whose config changed?", msg.path, project.Name),
+		)
+		a.modal.SetConfirmText("Apply")
+		a.modal.SetSize(a.width, a.height)
+		a.pendingDeleteType = "compose_apply"
+		return a, nil
+
+	case ComposeFileEditedMsg:
+		var cmds []tea.Cmd
+		if a.state.CurrentView == models.ViewComposeFile {
+			cmds = append(cmds, loadComposeFiles(msg.project))
+		}
+		if msg.err != nil {
+			a.errorMessage = msg.err.Error()
+			return a, tea.Batch(append(cmds, clearStatus(5*time.Second))...)
+		}
+		if !msg.changed {
+			a.statusMessage = fmt.Sprintf("No changes to %s", filepath.Base(msg.path))
+			return a, tea.Batch(append(cmds, clearStatus(3*time.Second))...)
+		}
+		project := msg.project
+		a.applyProject = &project
+		a.modal = components.NewConfirmModal(
+			"Apply Compose Changes",
+			fmt.Sprintf("%s was changed.\nRun `docker compose up -d` to apply the changes to %s?", msg.path, project.Name),
+		)
+		a.modal.SetConfirmText("Apply")
+		a.modal.SetSize(a.width, a.height)
+		a.pendingDeleteType = "compose_apply"
+		return a, tea.Batch(cmds...)
+
+	case VulnScanMsg:
+		if msg.err != nil {
+			a.errorMessage = fmt.Sprintf("Failed to scan %s: %v", msg.image.GetPrimaryTag(), msg.err)
+			return a, clearStatus(5 * time.Second)
+		}
+		// Open the findings unless the user moved on while it was scanning
+		if a.state.CurrentView == models.ViewImages || a.state.CurrentView == models.ViewVulnerabilities {
+			a.scannedImage = msg.image
+			a.openVulnerabilities(msg.scan)
+			a.statusMessage = ""
+			return a, nil
+		}
+		a.statusMessage = fmt.Sprintf("Scanned %s: %s", msg.image.GetPrimaryTag(), msg.scan.Summary())
+		return a, clearStatus(5 * time.Second)
+
+	case ArchiveLoadedMsg:
+		if msg.err != nil && len(msg.loaded) == 0 {
+			a.errorMessage = fmt.Sprintf("Failed to load images: %v", msg.err)
+			return a, clearStatus(5 * time.Second)
+		}
+		lines := make([]string, 0, len(msg.loaded)+2)
+		for _, ref := range msg.loaded {
+			lines = append(lines, styles.SuccessStyle.Render("✓ "+ref))
+		}
+		if msg.err != nil {
+			lines = append(lines, "", styles.ErrorStyle.Render(fmt.Sprintf("✗ %v", msg.err)))
+		}
+		a.modal = components.NewInfoModal(fmt.Sprintf("Loaded %d from %s", len(msg.loaded), msg.path), strings.Join(lines, "\n"))
+		a.modal.SetSize(a.width, a.height)
+		return a, fetchImages(a.docker)
+
 	case ImagesBulkRemovedMsg:
 		if msg.failed > 0 {
 			a.errorMessage = fmt.Sprintf("Removed %d images, %d failed", msg.count-msg.failed, msg.failed)
@@ -977,6 +3049,8 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case ImagesPrunedMsg:
 		if msg.err != nil {
 			a.errorMessage = fmt.Sprintf("Failed to prune images: %v", msg.err)
+		} else if msg.count == 0 && msg.all {
+			a.statusMessage = "No unused images to prune"
 		} else if msg.count == 0 {
 			a.statusMessage = "No dangling images to prune"
 		} else {
@@ -995,6 +3069,7 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return a, tea.Batch(
 			fetchContainers(a.docker),
+			loadGroups(a.groupManager),
 			clearStatus(2*time.Second),
 		)
 
@@ -1006,6 +3081,19 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return a, tea.Batch(
 			fetchContainers(a.docker),
+			loadGroups(a.groupManager),
+			clearStatus(2*time.Second),
+		)
+
+	case GroupRestartedMsg:
+		if msg.err != nil {
+			a.errorMessage = fmt.Sprintf("Failed to restart group: %v", msg.err)
+		} else {
+			a.statusMessage = "Group restarted successfully"
+		}
+		return a, tea.Batch(
+			fetchContainers(a.docker),
+			loadGroups(a.groupManager),
 			clearStatus(2*time.Second),
 		)
 
@@ -1016,6 +3104,17 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			clearStatus(2*time.Second),
 		)
 
+	case GroupEditedMsg:
+		if msg.err != nil {
+			a.errorMessage = fmt.Sprintf("Failed to edit group: %v", msg.err)
+		} else {
+			a.statusMessage = fmt.Sprintf("Group '%s' updated", msg.name)
+		}
+		return a, tea.Batch(
+			loadGroups(a.groupManager),
+			clearStatus(2*time.Second),
+		)
+
 	case ContainerAddedToGroupMsg:
 		if msg.err != nil {
 			a.errorMessage = fmt.Sprintf("Failed to add container: %v", msg.err)
@@ -1028,6 +3127,30 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			clearStatus(2*time.Second),
 		)
 
+	case GroupOrderedStopSetMsg:
+		if msg.err != nil {
+			a.errorMessage = fmt.Sprintf("Failed to update group: %v", msg.err)
+		} else if msg.ordered {
+			a.statusMessage = "Group stops in reverse start order"
+		} else {
+			a.statusMessage = "Group stops all containers at once"
+		}
+		return a, tea.Batch(
+			loadGroups(a.groupManager),
+			clearStatus(2*time.Second),
+		)
+
+	case GroupDependenciesSetMsg:
+		if msg.err != nil {
+			a.errorMessage = fmt.Sprintf("Failed to set start order: %v", msg.err)
+		} else {
+			a.statusMessage = "Start order updated"
+		}
+		return a, tea.Batch(
+			loadGroups(a.groupManager),
+			clearStatus(2*time.Second),
+		)
+
 	case ContainerRemovedFromGroupMsg:
 		if msg.err != nil {
 			a.errorMessage = fmt.Sprintf("Failed to remove container: %v", msg.err)
@@ -1048,21 +3171,126 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return a, loadGroups(a.groupManager)
 
-	case VolumeRemovedMsg:
+	case VolumeCreatedMsg:
 		if msg.err != nil {
-			a.errorMessage = fmt.Sprintf("Failed to remove volume: %v", msg.err)
+			a.errorMessage = fmt.Sprintf("Failed to create volume: %v", msg.err)
 		} else {
-			a.statusMessage = fmt.Sprintf("Volume '%s' removed", msg.volumeName)
+			a.statusMessage = fmt.Sprintf("Volume '%s' created", msg.name)
 		}
 		return a, tea.Batch(
 			fetchVolumes(a.docker),
 			clearStatus(2*time.Second),
 		)
 
-	case ComposeProjectStartedMsg:
+	case GroupComposeExportedMsg:
 		if msg.err != nil {
-			a.errorMessage = fmt.Sprintf("Failed to start project: %v", msg.err)
-		} else {
+			a.errorMessage = fmt.Sprintf("Failed to export %s: %v", msg.name, msg.err)
+			return a, clearStatus(5 * time.Second)
+		}
+		a.statusMessage = fmt.Sprintf("Exported %s to %s (%d services)", msg.name, msg.path, msg.services)
+		return a, clearStatus(5 * time.Second)
+
+	case VolumeBackedUpMsg:
+		if msg.err != nil {
+			a.errorMessage = fmt.Sprintf("Failed to back up %s: %v", msg.name, msg.err)
+			return a, clearStatus(5 * time.Second)
+		}
+		a.statusMessage = fmt.Sprintf("Backed up %s to %s (%s)", msg.name, msg.path, utils.FormatBytes(msg.size))
+		return a, clearStatus(5 * time.Second)
+
+	case VolumeRestoredMsg:
+		if msg.err != nil {
+			a.errorMessage = fmt.Sprintf("Failed to restore %s: %v", msg.name, msg.err)
+		} else if msg.created {
+			a.statusMessage = fmt.Sprintf("Restored %s into new volume '%s'", msg.path, msg.name)
+		} else {
+			a.statusMessage = fmt.Sprintf("Restored %s into volume '%s'", msg.path, msg.name)
+		}
+		return a, tea.Batch(
+			fetchVolumes(a.docker),
+			clearStatus(5*time.Second),
+		)
+
+	case VolumesRemovedMsg:
+		if msg.err != nil {
+			a.errorMessage = fmt.Sprintf("Removed %d volume(s), failed: %v", len(msg.removed), msg.err)
+		} else {
+			a.statusMessage = fmt.Sprintf("Removed %d volume(s)", len(msg.removed))
+		}
+		return a, tea.Batch(
+			fetchVolumes(a.docker),
+			clearStatus(3*time.Second),
+		)
+
+	case VolumeRemovedMsg:
+		if msg.err != nil {
+			a.errorMessage = fmt.Sprintf("Failed to remove volume: %v", msg.err)
+		} else {
+			a.statusMessage = fmt.Sprintf("Volume '%s' removed", msg.volumeName)
+		}
+		return a, tea.Batch(
+			fetchVolumes(a.docker),
+			clearStatus(2*time.Second),
+		)
+
+	case ImageProvenanceLoadedMsg:
+		a.statusMessage = ""
+		if a.modal != nil && a.modal.IsVisible() {
+			return a, nil
+		}
+		a.modal = components.NewInfoModal(
+			fmt.Sprintf("Image: %s", msg.image.GetPrimaryTag()),
+			a.renderImageProvenance(msg.image, msg.provenance),
+		)
+		a.modal.SetSize(a.width, a.height)
+		return a, nil
+
+	case NetworkInspectedMsg:
+		if msg.err != nil {
+			a.errorMessage = msg.err.Error()
+			return a, clearStatus(3 * time.Second)
+		}
+		a.statusMessage = ""
+		if a.modal != nil && a.modal.IsVisible() {
+			return a, nil
+		}
+		a.modal = components.NewInfoModal(fmt.Sprintf("Network: %s", msg.detail.Name), a.renderNetworkDetail(msg.detail))
+		a.modal.SetSize(a.width, a.height)
+		return a, nil
+
+	case PluginsLoadedMsg:
+		a.pluginsView.SetUnsupported(msg.unsupported)
+		a.pluginsView.SetPlugins(msg.plugins)
+		return a, nil
+
+	case PluginToggledMsg:
+		if msg.err != nil {
+			a.errorMessage = fmt.Sprintf("Failed to update plugin: %v", msg.err)
+		} else if msg.enabled {
+			a.statusMessage = fmt.Sprintf("Plugin '%s' enabled", msg.name)
+		} else {
+			a.statusMessage = fmt.Sprintf("Plugin '%s' disabled", msg.name)
+		}
+		return a, tea.Batch(
+			fetchPlugins(a.docker),
+			clearStatus(2*time.Second),
+		)
+
+	case PluginRemovedMsg:
+		if msg.err != nil {
+			a.errorMessage = fmt.Sprintf("Failed to remove plugin: %v", msg.err)
+		} else {
+			a.statusMessage = fmt.Sprintf("Plugin '%s' removed", msg.name)
+		}
+		return a, tea.Batch(
+			fetchPlugins(a.docker),
+			clearStatus(2*time.Second),
+		)
+
+	case ComposeProjectStartedMsg:
+		if msg.err != nil {
+			a.errorMessage = fmt.Sprintf("Failed to start project: %v", msg.err)
+		} else {
 			a.statusMessage = fmt.Sprintf("Compose project '%s' started", msg.projectName)
 		}
 		return a, tea.Batch(
@@ -1096,12 +3324,14 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		)
 
 	case ImagePullCompletedMsg:
-		// Clean up pull state
-		a.pullProgressChan = nil
-		a.pullImageName = ""
-		a.pullProgress = ""
-		if msg.err != nil {
+		a.endPull()
+		if errors.Is(msg.err, context.Canceled) {
+			a.statusMessage = fmt.Sprintf("Pull of '%s' cancelled", msg.imageName)
+		} else if msg.err != nil {
 			a.errorMessage = fmt.Sprintf("Failed to pull image: %v", msg.err)
+			if hubRateLimited(msg.imageName, msg.err) {
+				return a, tea.Batch(fetchImages(a.docker), checkHubRateLimit(a.docker))
+			}
 		} else {
 			a.statusMessage = fmt.Sprintf("Image '%s' pulled successfully", msg.imageName)
 		}
@@ -1112,12 +3342,16 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case ImagePullProgressMsg:
 		if msg.done {
-			// Pull completed or errored - clean up
-			a.pullProgressChan = nil
-			a.pullImageName = ""
-			a.pullProgress = ""
+			a.endPull()
+			if errors.Is(msg.err, context.Canceled) {
+				a.statusMessage = fmt.Sprintf("Pull of '%s' cancelled", msg.imageName)
+				return a, tea.Batch(fetchImages(a.docker), clearStatus(2*time.Second))
+			}
 			if msg.err != nil {
 				a.errorMessage = fmt.Sprintf("Failed to pull image: %v", msg.err)
+				if hubRateLimited(msg.imageName, msg.err) {
+					return a, tea.Batch(fetchImages(a.docker), checkHubRateLimit(a.docker))
+				}
 				return a, fetchImages(a.docker)
 			}
 			a.statusMessage = fmt.Sprintf("Image '%s' pulled successfully", msg.imageName)
@@ -1131,6 +3365,10 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if msg.progress != "" {
 			a.pullProgress = msg.progress
 		}
+		a.pullLayers = msg.layers
+		if a.modal != nil && a.modal == a.pullModal {
+			a.modal.SetMessage(a.renderPullProgress())
+		}
 		if msg.total > 0 {
 			percent := float64(msg.current) / float64(msg.total) * 100
 			a.statusMessage = fmt.Sprintf("Pulling '%s': %s (%.1f%%)", msg.imageName, msg.status, percent)
@@ -1144,6 +3382,118 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return a, nil
 
+	case PullListLoadedMsg:
+		a.statusMessage = ""
+		if msg.err != nil {
+			a.errorMessage = msg.err.Error()
+			return a, clearStatus(4 * time.Second)
+		}
+		if a.batchPullChan != nil {
+			a.errorMessage = "A batch pull is already running"
+			return a, clearStatus(2 * time.Second)
+		}
+
+		a.batchPullQueue = msg.images
+		a.batchPullSource = "from list"
+		a.batchPullResults = nil
+		names := make([]string, 0, len(msg.images))
+		for _, image := range msg.images {
+			names = append(names, "  • "+image)
+		}
+		if maxLines := a.height - 14; maxLines > 0 && len(names) > maxLines {
+			hidden := len(names) - maxLines + 1
+			names = append(names[:maxLines-1], styles.DescStyle.Render(fmt.Sprintf("  ... %d more", hidden)))
+		}
+		a.modal = components.NewConfirmModal(
+			"Pull Images From File",
+			fmt.Sprintf("Pull %d image(s) listed in %s?\n\n%s", len(msg.images), msg.path, strings.Join(names, "\n")),
+		)
+		a.modal.SetSize(a.width, a.height)
+		a.pendingDeleteType = "batch_pull"
+		return a, nil
+
+	case BatchPullProgressMsg:
+		// Ignore updates of a pull that is no longer current
+		if a.batchPullChan == nil || msg.index != len(a.batchPullResults) {
+			return a, nil
+		}
+		image := a.batchPullQueue[msg.index]
+
+		if !msg.closed && !msg.progress.Done {
+			a.batchPullStatus = msg.progress.Status
+			if msg.progress.Total > 0 {
+				percent := float64(msg.progress.Current) / float64(msg.progress.Total) * 100
+				a.batchPullStatus = fmt.Sprintf("%s (%.1f%%)", msg.progress.Status, percent)
+			}
+			a.statusMessage = fmt.Sprintf("Pulling %d/%d '%s': %s", msg.index+1, len(a.batchPullQueue), image, a.batchPullStatus)
+			if a.modal != nil && a.modal == a.batchPullModal {
+				a.modal.SetMessage(a.renderBatchPullProgress())
+			}
+			return a, waitForBatchPull(msg.index, a.batchPullChan)
+		}
+
+		a.batchPullResults = append(a.batchPullResults, models.BatchPullResult{Image: image, Err: msg.progress.Error})
+		a.batchPullStatus = ""
+		if a.modal != nil && a.modal == a.batchPullModal {
+			a.modal.SetMessage(a.renderBatchPullProgress())
+		}
+
+		if next := len(a.batchPullResults); next < len(a.batchPullQueue) {
+			a.statusMessage = fmt.Sprintf("Pulling %d/%d '%s'", next+1, len(a.batchPullQueue), a.batchPullQueue[next])
+			progressChan, cmd := startBatchPull(a.docker, next, a.batchPullQueue[next])
+			a.batchPullChan = progressChan
+			return a, tea.Batch(cmd, fetchImages(a.docker))
+		}
+
+		// All images processed
+		failed, rateLimited := 0, false
+		for _, r := range a.batchPullResults {
+			if r.Err != nil {
+				failed++
+				rateLimited = rateLimited || hubRateLimited(r.Image, r.Err)
+			}
+		}
+		summary := fmt.Sprintf("Pulled %d of %d image(s) %s", len(a.batchPullResults)-failed, len(a.batchPullResults), a.batchPullSource)
+		a.statusMessage = ""
+		if failed > 0 {
+			a.errorMessage = fmt.Sprintf("%s, %d failed", summary, failed)
+		} else {
+			a.statusMessage = summary
+		}
+		a.batchPullChan = nil
+		a.batchPullModal = nil
+		if rateLimited {
+			return a, tea.Batch(fetchImages(a.docker), checkHubRateLimit(a.docker))
+		}
+		return a, tea.Batch(fetchImages(a.docker), clearStatus(5*time.Second))
+
+	case RegistryLoggedInMsg:
+		a.statusMessage = ""
+		if msg.err != nil {
+			a.errorMessage = fmt.Sprintf("Login failed: %v", msg.err)
+			return a, clearStatus(5 * time.Second)
+		}
+		if msg.saveErr != nil {
+			a.errorMessage = fmt.Sprintf("Logged in to %s for this session only: %v", msg.registry, msg.saveErr)
+			return a, clearStatus(5 * time.Second)
+		}
+		if msg.savedTo != "" {
+			a.statusMessage = fmt.Sprintf("Logged in to %s as %s, saved to %s", msg.registry, msg.username, msg.savedTo)
+			return a, clearStatus(3 * time.Second)
+		}
+		a.statusMessage = fmt.Sprintf("Logged in to %s as %s, pulls use this login until doui exits", msg.registry, msg.username)
+		return a, clearStatus(3 * time.Second)
+
+	case RateLimitCheckedMsg:
+		// Without the limit the explanation still helps, minus the reset time
+		limit := msg.limit
+		if msg.err != nil {
+			limit = nil
+		}
+		a.statusMessage = ""
+		a.errorMessage = models.RateLimitMessage(limit, msg.user, keys.Label(keys.Map.RegistryLogin))
+		return a, clearStatus(15 * time.Second)
+
 	case ContainerConnectedToNetworkMsg:
 		if msg.err != nil {
 			a.errorMessage = fmt.Sprintf("Failed to connect container: %v", msg.err)
@@ -1153,6 +3503,7 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return a, tea.Batch(
 			fetchNetworks(a.docker),
 			fetchContainers(a.docker),
+			fetchNetworkEndpoints(a.docker, msg.networkID),
 			clearStatus(2*time.Second),
 		)
 
@@ -1165,9 +3516,53 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return a, tea.Batch(
 			fetchNetworks(a.docker),
 			fetchContainers(a.docker),
+			fetchNetworkEndpoints(a.docker, msg.networkID),
 			clearStatus(2*time.Second),
 		)
 
+	case HostInterfacesLoadedMsg:
+		if msg.err != nil {
+			a.errorMessage = fmt.Sprintf("Failed to list host interfaces: %v", msg.err)
+			return a, clearStatus(3 * time.Second)
+		}
+		a.macvlanInterfaces = msg.interfaces
+		if len(msg.interfaces) == 0 {
+			// Remote daemon: its interfaces can't be listed from here
+			a.openMacvlanForm(nil)
+			return a, nil
+		}
+
+		options := make([]string, 0, len(msg.interfaces)+1)
+		for _, iface := range msg.interfaces {
+			option := fmt.Sprintf("%-10s %s", iface.Name, strings.Join(iface.Addrs, ", "))
+			if iface.Gateway != "" {
+				option += "  gw " + iface.Gateway
+			}
+			if iface.Wireless {
+				option += "  (wireless)"
+			}
+			options = append(options, option)
+		}
+		options = append(options, "Other (VLAN sub-interface, bond...)")
+		a.modal = components.NewMenuModal("Macvlan/ipvlan: parent interface", options)
+		a.modal.SetSize(a.width, a.height)
+		a.pendingDeleteType = "macvlan_parent"
+		return a, nil
+
+	case NetworkAliasesSetMsg:
+		if msg.err != nil {
+			a.errorMessage = fmt.Sprintf("Failed to set aliases: %v", msg.err)
+		} else if len(msg.aliases) == 0 {
+			a.statusMessage = fmt.Sprintf("✓ Removed the aliases of %s on %s", msg.containerName, msg.networkName)
+		} else {
+			a.statusMessage = fmt.Sprintf("✓ %s is reachable on %s as %s", msg.containerName, msg.networkName, strings.Join(msg.aliases, ", "))
+		}
+		return a, tea.Batch(
+			fetchNetworks(a.docker),
+			fetchContainers(a.docker),
+			clearStatus(3*time.Second),
+		)
+
 	case NetworkCreatedMsg:
 		if msg.err != nil {
 			a.errorMessage = fmt.Sprintf("Failed to create network: %v", msg.err)
@@ -1179,6 +3574,33 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			clearStatus(2*time.Second),
 		)
 
+	case NetworkEndpointsLoadedMsg:
+		// Without them the container list's addresses are shown
+		if msg.err == nil {
+			a.networksView.SetEndpoints(msg.networkID, msg.endpoints)
+		}
+		return a, nil
+
+	case ComposeConfigHashesLoadedMsg:
+		// Needs the compose files on this machine, without them nothing is flagged
+		if msg.err == nil {
+			a.composeView.SetConfigHashes(msg.projectName, msg.hashes)
+		}
+		return a, nil
+
+	case NetworksPrunedMsg:
+		if msg.err != nil {
+			a.errorMessage = fmt.Sprintf("Failed to prune networks: %v", msg.err)
+		} else if len(msg.removed) == 0 {
+			a.statusMessage = "No networks pruned"
+		} else {
+			a.statusMessage = fmt.Sprintf("Pruned %d network(s): %s", len(msg.removed), strings.Join(msg.removed, ", "))
+		}
+		return a, tea.Batch(
+			fetchNetworks(a.docker),
+			clearStatus(3*time.Second),
+		)
+
 	case NetworkRemovedMsg:
 		if msg.err != nil {
 			a.errorMessage = fmt.Sprintf("Failed to remove network: %v", msg.err)
@@ -1207,9 +3629,236 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		a.state.PreviousView = a.state.CurrentView
 		a.state.CurrentView = models.ViewEnvVars
-		a.envVarsView.SetContainer(msg.containerID, msg.config.Name, msg.config.Env)
+		// Free ports are probed on this machine, meaningless for a remote daemon
+		a.envVarsView.SetPortSuggestions(!a.docker.IsRemote(), msg.published)
+		a.envVarsView.SetContainer(msg.containerID, msg.config.Name, msg.config.Env, msg.config.Labels, msg.config.PortBindings)
+		return a, nil
+
+	case BulkEnvStepMsg:
+		a.bulkEnvResults = append(a.bulkEnvResults, msg.result)
+
+		var cmds []tea.Cmd
+		if msg.result.NewID != "" {
+			// Recreated containers get a new ID, keep group membership
+			cmds = append(cmds, replaceContainerIDInGroups(a.groupManager, msg.result.ContainerID, msg.result.NewID))
+		}
+
+		done := len(a.bulkEnvResults) >= len(a.bulkEnvQueue)
+		if a.modal != nil && a.modal == a.bulkEnvModal {
+			a.modal.SetMessage(a.renderBulkEnvProgress())
+		}
+
+		if !done {
+			next := a.bulkEnvQueue[len(a.bulkEnvResults)]
+			a.statusMessage = fmt.Sprintf("Applying env change: %d/%d", len(a.bulkEnvResults), len(a.bulkEnvQueue))
+			cmds = append(cmds, applyEnvChange(a.docker, next, a.bulkEnvChange))
+			return a, tea.Batch(cmds...)
+		}
+
+		// All containers processed
+		updated, skipped, failed := 0, 0, 0
+		for _, r := range a.bulkEnvResults {
+			switch {
+			case r.Err != nil:
+				failed++
+			case r.Skipped:
+				skipped++
+			default:
+				updated++
+			}
+		}
+		summary := fmt.Sprintf("'%s' applied to group '%s': %d recreated, %d unchanged, %d failed",
+			a.bulkEnvChange.Description(), a.bulkEnvGroupName, updated, skipped, failed)
+		if failed > 0 {
+			a.errorMessage = summary
+		} else {
+			a.statusMessage = summary
+		}
+		a.bulkEnvQueue = nil
+		a.bulkEnvModal = nil
+
+		cmds = append(cmds, fetchContainers(a.docker), loadGroups(a.groupManager), clearStatus(5*time.Second))
+		return a, tea.Batch(cmds...)
+
+	case ProjectRecreateStepMsg:
+		if len(a.projectRecreateQueue) == 0 {
+			return a, nil
+		}
+		a.projectRecreateResults = append(a.projectRecreateResults, msg.result)
+
+		var cmds []tea.Cmd
+		if msg.result.NewID != "" {
+			// Recreated containers get a new ID, keep group membership
+			cmds = append(cmds, replaceContainerIDInGroups(a.groupManager, msg.result.ContainerID, msg.result.NewID))
+		}
+
+		if a.modal != nil && a.modal == a.projectRecreateModal {
+			a.modal.SetMessage(a.renderProjectRecreateProgress())
+		}
+
+		if done := len(a.projectRecreateResults); done < len(a.projectRecreateQueue) {
+			a.statusMessage = fmt.Sprintf("Recreating %s: %d/%d", a.projectRecreateName, done, len(a.projectRecreateQueue))
+			cmds = append(cmds, recreateProjectContainer(a.docker, a.projectRecreateQueue[done]))
+			return a, tea.Batch(cmds...)
+		}
+
+		// All containers processed
+		failed := 0
+		for _, r := range a.projectRecreateResults {
+			if r.Err != nil {
+				failed++
+			}
+		}
+		summary := fmt.Sprintf("Recreated %d of %d container(s) of %s",
+			len(a.projectRecreateResults)-failed, len(a.projectRecreateResults), a.projectRecreateName)
+		a.statusMessage = ""
+		if failed > 0 {
+			a.errorMessage = fmt.Sprintf("%s, %d failed", summary, failed)
+		} else {
+			a.statusMessage = "✓ " + summary
+		}
+		a.projectRecreateQueue = nil
+		a.projectRecreateModal = nil
+
+		cmds = append(cmds, fetchComposeProjects(a.docker), fetchContainers(a.docker), loadGroups(a.groupManager), clearStatus(5*time.Second))
+		return a, tea.Batch(cmds...)
+
+	case ComposeEnvLoadedMsg:
+		a.statusMessage = ""
+		if msg.err != nil {
+			a.errorMessage = fmt.Sprintf("Failed to load compose environment: %v", msg.err)
+			return a, clearStatus(3 * time.Second)
+		}
+
+		a.composeEnvView.SetMatrix(msg.projectName, msg.matrix)
+		a.state.PreviousView = a.state.CurrentView
+		a.state.CurrentView = models.ViewComposeEnv
+		return a, nil
+
+	case ComposeConfigCheckedMsg:
+		a.statusMessage = ""
+		if msg.err != nil {
+			a.errorMessage = fmt.Sprintf("Failed to validate compose config: %v", msg.err)
+			return a, clearStatus(3 * time.Second)
+		}
+		if msg.check.Valid() && len(msg.check.Warnings) == 0 {
+			a.statusMessage = fmt.Sprintf("✓ Compose config of %s is valid", msg.projectName)
+			return a, clearStatus(2 * time.Second)
+		}
+
+		title := fmt.Sprintf("Compose config of %s is valid", msg.projectName)
+		if !msg.check.Valid() {
+			title = fmt.Sprintf("Compose config of %s has errors", msg.projectName)
+		}
+		a.modal = components.NewInfoModal(title, a.renderComposeConfigCheck(msg.check))
+		a.modal.SetSize(a.width, a.height)
+		return a, nil
+
+	case ComposeChangesLoadedMsg:
+		a.statusMessage = ""
+		if msg.err != nil {
+			a.errorMessage = fmt.Sprintf("Failed to compare compose config: %v", msg.err)
+			return a, clearStatus(4 * time.Second)
+		}
+		if len(msg.changes) == 0 {
+			a.statusMessage = fmt.Sprintf("✓ All services of %s match the compose files", msg.project.Name)
+			return a, clearStatus(2 * time.Second)
+		}
+
+		project := msg.project
+		a.recreateProject = &project
+		a.recreateServices = make([]string, len(msg.changes))
+		names := make([]string, len(msg.changes))
+		for i, change := range msg.changes {
+			a.recreateServices[i] = change.Service
+			names[i] = "  • " + change.Service
+			if change.New {
+				names[i] += styles.DescStyle.Render(" (new)")
+			}
+		}
+		if maxLines := a.height - 14; maxLines > 0 && len(names) > maxLines {
+			hidden := len(names) - maxLines + 1
+			names = append(names[:maxLines-1], styles.DescStyle.Render(fmt.Sprintf("  ... %d more", hidden)))
+		}
+		a.modal = components.NewConfirmModal(
+			"Recreate Changed Services",
+			fmt.Sprintf("%d service(s) of %s differ from the compose files.\nRecreate them? Other services are left running.\n\n%s",
+				len(msg.changes), project.Name, strings.Join(names, "\n")),
+		)
+		a.modal.SetSize(a.width, a.height)
+		a.pendingDeleteType = "recreate_changed"
+		return a, nil
+
+	case ComposeServicesRecreatedMsg:
+		a.statusMessage = ""
+		if msg.err != nil {
+			a.errorMessage = msg.err.Error()
+			return a, tea.Batch(fetchComposeProjects(a.docker), clearStatus(5*time.Second))
+		}
+		a.statusMessage = fmt.Sprintf("✓ Recreated %d service(s) of %s: %s", len(msg.services), msg.projectName, strings.Join(msg.services, ", "))
+		return a, tea.Batch(fetchComposeProjects(a.docker), clearStatus(4*time.Second))
+
+	case ComposeDownMsg:
+		a.statusMessage = ""
+		if msg.err != nil {
+			a.errorMessage = msg.err.Error()
+			return a, tea.Batch(fetchComposeProjects(a.docker), clearStatus(5*time.Second))
+		}
+		removed := "containers and networks"
+		switch {
+		case msg.volumes && msg.images:
+			removed = "containers, networks, volumes and images"
+		case msg.volumes:
+			removed = "containers, networks and volumes"
+		case msg.images:
+			removed = "containers, networks and images"
+		}
+		a.statusMessage = fmt.Sprintf("✓ Took down %s: removed its %s", msg.projectName, removed)
+		return a, tea.Batch(fetchComposeProjects(a.docker), clearStatus(4*time.Second))
+
+	case ComposeUpMsg:
+		a.statusMessage = ""
+		if msg.err != nil {
+			a.errorMessage = msg.err.Error()
+			return a, tea.Batch(fetchComposeProjects(a.docker), clearStatus(5*time.Second))
+		}
+		if msg.name != "" {
+			a.statusMessage = fmt.Sprintf("✓ Compose project %s is up", msg.name)
+		} else {
+			a.statusMessage = fmt.Sprintf("✓ Brought up %s", strings.Join(msg.files, ", "))
+		}
+		return a, tea.Batch(fetchComposeProjects(a.docker), clearStatus(4*time.Second))
+
+	case CpusetConfigLoadedMsg:
+		if msg.err != nil {
+			a.errorMessage = fmt.Sprintf("Failed to load cpuset: %v", msg.err)
+			return a, clearStatus(3 * time.Second)
+		}
+
+		a.hostCPUCount = msg.hostCPUs
+		a.modal = components.NewFormModalWithOptional(
+			fmt.Sprintf("CPU Pinning: %s (host has %d CPUs)", msg.containerName, msg.hostCPUs),
+			[]string{"CPUs (e.g. 0-3,5; empty = all)", "Memory nodes (e.g. 0)"},
+			[]int{0, 1},
+		)
+		a.modal.SetInputValues([]string{msg.cpus, msg.mems})
+		a.modal.SetConfirmText("Apply")
+		a.modal.SetSize(a.width, a.height)
+		a.pendingDelete = msg.containerID
+		a.pendingDeleteType = "edit_cpuset"
 		return a, nil
 
+	case ContainerCpusetUpdatedMsg:
+		if msg.err != nil {
+			a.errorMessage = fmt.Sprintf("Failed to update cpuset: %v", msg.err)
+		} else {
+			a.statusMessage = fmt.Sprintf("Container %s pinned to CPUs %s", msg.containerID[:12], msg.cpus)
+		}
+		return a, tea.Batch(
+			fetchContainers(a.docker),
+			clearStatus(2*time.Second),
+		)
+
 	case ContainerRecreatedMsg:
 		// Clear rebuilding state
 		a.rebuildingContainerName = ""
@@ -1225,7 +3874,7 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				clearStatus(3*time.Second),
 			)
 		}
-		a.statusMessage = fmt.Sprintf("Container '%s' rebuilt with new environment", msg.containerName)
+		a.statusMessage = fmt.Sprintf("Container '%s' rebuilt with new configuration", msg.containerName)
 		// Queue selection of the rebuilt container - will be applied after containers are fetched
 		// (can't select now because the list still has old data)
 		a.pendingSelectContainerID = msg.newID
@@ -1236,20 +3885,84 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			clearStatus(3*time.Second),
 		)
 
-	case StatusMsg:
-		a.statusMessage = msg.message
-		return a, clearStatus(2 * time.Second)
+	case PortConflictsCheckedMsg:
+		a.statusMessage = ""
+		if msg.err != nil {
+			a.errorMessage = fmt.Sprintf("Failed to check ports: %v", msg.err)
+			return a, clearStatus(3 * time.Second)
+		}
 
-	case ErrorMsg:
-		a.errorMessage = msg.err.Error()
-		return a, clearStatus(3 * time.Second)
-	}
+		var lines []string
+		for _, c := range msg.conflicts {
+			line := fmt.Sprintf("%d/%s  %s", c.Port, c.Proto, c.Message)
+			if c.Severity == "error" {
+				lines = append(lines, styles.ErrorStyle.Render("✗ "+line))
+			} else {
+				lines = append(lines, styles.WarningStyle.Render("! "+line))
+			}
+		}
+		if len(lines) == 0 {
+			lines = append(lines, styles.SuccessStyle.Render("No port conflicts found."))
+		}
+		if !msg.hostChecked {
+			lines = append(lines, "", styles.SubtitleStyle.Render("Host listeners not checked (remote daemon or unsupported OS)."))
+		}
 
-	// Delegate to current view
-	var cmd tea.Cmd
-	switch a.state.CurrentView {
-	case models.ViewContainers:
-		a.containersView, cmd = a.containersView.Update(msg)
+		a.modal = components.NewInfoModal("Port Diagnostics", strings.Join(lines, "\n"))
+		a.modal.SetSize(a.width, a.height)
+		return a, nil
+
+	case ClockCheckedMsg:
+		a.statusMessage = ""
+		if msg.err != nil {
+			a.errorMessage = fmt.Sprintf("Failed to check clock: %v", msg.err)
+			return a, clearStatus(3 * time.Second)
+		}
+		a.modal = components.NewInfoModal("Clock Check", renderClockCheck(msg.check))
+		a.modal.SetSize(a.width, a.height)
+		return a, nil
+
+	case ClipboardCopiedMsg:
+		if msg.err != nil {
+			a.errorMessage = fmt.Sprintf("Failed to copy %s: %v", msg.label, msg.err)
+		} else {
+			a.statusMessage = fmt.Sprintf("Copied %s to clipboard: %s", msg.label, msg.value)
+		}
+		return a, clearStatus(2 * time.Second)
+
+	case StatusMsg:
+		a.statusMessage = msg.message
+		return a, clearStatus(2 * time.Second)
+
+	case ErrorMsg:
+		if docker.IsConnectionError(msg.err) {
+			// Daemon went away (e.g. restart): show the disconnected state and
+			// retry in the background instead of toasting every failed fetch
+			if a.disconnected {
+				return a, nil
+			}
+			a.disconnected = true
+			a.sidebar.SetDisconnected(true)
+			a.sidebar.SetLatency(0)
+			return a, tickReconnect()
+		}
+		a.errorMessage = msg.err.Error()
+		return a, clearStatus(3 * time.Second)
+
+	case *models.ContainerEvent:
+		// Events of a previously watched container end that stream
+		if msg.ContainerID != a.statsView.ContainerID() {
+			return a, nil
+		}
+		a.statsView, _ = a.statsView.Update(msg)
+		return a, waitForContainerEvent(a.statsView.EventStream())
+	}
+
+	// Delegate to current view
+	var cmd tea.Cmd
+	switch a.state.CurrentView {
+	case models.ViewContainers:
+		a.containersView, cmd = a.containersView.Update(msg)
 	case models.ViewImages:
 		a.imagesView, cmd = a.imagesView.Update(msg)
 	case models.ViewGroups:
@@ -1260,12 +3973,26 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.composeView, cmd = a.composeView.Update(msg)
 	case models.ViewNetworks:
 		a.networksView, cmd = a.networksView.Update(msg)
+	case models.ViewPlugins:
+		a.pluginsView, cmd = a.pluginsView.Update(msg)
 	case models.ViewLogs:
 		a.logsView, cmd = a.logsView.Update(msg)
 	case models.ViewStats:
 		a.statsView, cmd = a.statsView.Update(msg)
 	case models.ViewEnvVars:
 		a.envVarsView, cmd = a.envVarsView.Update(msg)
+	case models.ViewComposeEnv:
+		a.composeEnvView, cmd = a.composeEnvView.Update(msg)
+	case models.ViewImageLabels:
+		a.imageLabelsView, cmd = a.imageLabelsView.Update(msg)
+	case models.ViewVulnerabilities:
+		a.vulnsView, cmd = a.vulnsView.Update(msg)
+	case models.ViewImageHistory:
+		a.historyView, cmd = a.historyView.Update(msg)
+	case models.ViewComposeFile:
+		a.composeFileView, cmd = a.composeFileView.Update(msg)
+	case models.ViewComposeDotEnv:
+		a.dotEnvView, cmd = a.dotEnvView.Update(msg)
 	}
 
 	return a, cmd
@@ -1274,6 +4001,9 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 // View renders the application
 func (a *App) View() string {
 	if !a.ready {
+		if a.initErr != nil {
+			return a.renderConnectionError()
+		}
 		return "Initializing Docker UI...\n\nConnecting to Docker daemon..."
 	}
 
@@ -1282,6 +4012,18 @@ func (a *App) View() string {
 		return a.modal.View()
 	}
 
+	if a.palette != nil && a.palette.IsVisible() {
+		return a.palette.View()
+	}
+
+	if a.helpVisible {
+		return lipgloss.JoinVertical(
+			lipgloss.Left,
+			a.helpView.View(),
+			a.helpView.GetHelpText(),
+		)
+	}
+
 	var mainContent string
 
 	// Render current view based on state
@@ -1298,6 +4040,8 @@ func (a *App) View() string {
 		mainContent = a.composeView.View()
 	case models.ViewNetworks:
 		mainContent = a.networksView.View()
+	case models.ViewPlugins:
+		mainContent = a.pluginsView.View()
 	case models.ViewLogs:
 		// Logs and stats take full screen (no sidebar)
 		return lipgloss.JoinVertical(
@@ -1317,6 +4061,49 @@ func (a *App) View() string {
 			a.envVarsView.View(),
 			a.renderFooter(),
 		)
+	case models.ViewComposeEnv:
+		return lipgloss.JoinVertical(
+			lipgloss.Left,
+			a.composeEnvView.View(),
+			a.renderFooter(),
+		)
+	case models.ViewImageLabels:
+		return lipgloss.JoinVertical(
+			lipgloss.Left,
+			a.imageLabelsView.View(),
+			a.renderFooter(),
+		)
+	case models.ViewVulnerabilities:
+		return lipgloss.JoinVertical(
+			lipgloss.Left,
+			a.vulnsView.View(),
+			a.renderFooter(),
+		)
+	case models.ViewImageHistory:
+		return lipgloss.JoinVertical(
+			lipgloss.Left,
+			a.historyView.View(),
+			a.renderFooter(),
+		)
+	case models.ViewComposeFile:
+		return lipgloss.JoinVertical(
+			lipgloss.Left,
+			a.composeFileView.View(),
+			a.renderFooter(),
+		)
+	case models.ViewComposeDotEnv:
+		return lipgloss.JoinVertical(
+			lipgloss.Left,
+			a.dotEnvView.View(),
+			a.renderFooter(),
+		)
+	case models.ViewGroupLogs:
+		// The grid takes full screen (no sidebar)
+		return lipgloss.JoinVertical(
+			lipgloss.Left,
+			a.groupLogsView.View(),
+			a.renderFooter(),
+		)
 	case models.ViewAbout:
 		// About page takes full screen (no sidebar)
 		return lipgloss.JoinVertical(
@@ -1349,15 +4136,60 @@ func (a *App) View() string {
 	)
 }
 
+// renderConnectionError renders the startup screen shown when the Docker
+// daemon could not be reached, with hints and a retry key
+func (a *App) renderConnectionError() string {
+	var b strings.Builder
+
+	b.WriteString(styles.TitleStyle.Render("Cannot connect to Docker"))
+	b.WriteString("\n\n")
+	b.WriteString(styles.ErrorStyle.Render(a.initErr.Error()))
+	b.WriteString("\n\n")
+
+	host := docker.ConfiguredHost()
+	b.WriteString(fmt.Sprintf("Daemon address: %s\n", styles.KeyStyle.Render(host)))
+	if os.Getenv("DOCKER_HOST") == "" {
+		b.WriteString(styles.DescStyle.Render("Set DOCKER_HOST to use a different daemon (e.g. unix://$HOME/.docker/run/docker.sock)"))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	var hints []string
+	if docker.IsPermissionError(a.initErr) {
+		hints = []string{
+			"Your user may not access the Docker socket. Add it to the docker group:",
+			"  sudo usermod -aG docker $USER",
+			"then log out and back in (or run: newgrp docker).",
+		}
+	} else {
+		hints = []string{
+			"Make sure the Docker daemon is running, e.g.:",
+			"  sudo systemctl start docker",
+			"or start Docker Desktop.",
+		}
+	}
+	b.WriteString(strings.Join(hints, "\n"))
+	b.WriteString("\n\n")
+
+	if a.initRetrying {
+		b.WriteString(styles.WarningStyle.Render("⟳ Connecting..."))
+	} else {
+		b.WriteString(styles.KeyStyle.Render(keys.Label(keys.Map.Retry)) + " retry" + styles.SeparatorStyle.String() + styles.KeyStyle.Render(keys.Label(keys.Map.Quit)) + " quit")
+	}
+
+	return lipgloss.NewStyle().Padding(1, 2).Render(b.String())
+}
+
 func (a *App) renderFooter() string {
 	var footer string
 
 	// Status message
-	if a.errorMessage != "" {
+	if a.disconnected {
+		footer += styles.WarningStyle.Render("⟳ Docker daemon unreachable, reconnecting...")
+	} else if a.errorMessage != "" {
 		footer += styles.ErrorStyle.Render("✗ " + a.errorMessage)
-	} else if a.pullProgressChan != nil && a.statusMessage != "" {
-		// Show progress indicator for ongoing pull
-		footer += styles.WarningStyle.Render("⟳ " + a.statusMessage)
+	} else if a.busy() {
+		footer += a.renderOperations()
 	} else if a.statusMessage != "" {
 		footer += styles.SuccessStyle.Render("✓ " + a.statusMessage)
 	} else {
@@ -1375,20 +4207,418 @@ func (a *App) renderFooter() string {
 			footer += a.composeView.GetHelpText()
 		case models.ViewNetworks:
 			footer += a.networksView.GetHelpText()
+		case models.ViewPlugins:
+			footer += a.pluginsView.GetHelpText()
 		case models.ViewLogs:
 			footer += a.logsView.GetHelpText()
 		case models.ViewStats:
 			footer += a.statsView.GetHelpText()
 		case models.ViewEnvVars:
 			footer += a.envVarsView.GetHelpText()
+		case models.ViewComposeEnv:
+			footer += a.composeEnvView.GetHelpText()
+		case models.ViewImageLabels:
+			footer += a.imageLabelsView.GetHelpText()
+		case models.ViewVulnerabilities:
+			footer += a.vulnsView.GetHelpText()
+		case models.ViewImageHistory:
+			footer += a.historyView.GetHelpText()
+		case models.ViewComposeFile:
+			footer += a.composeFileView.GetHelpText()
+		case models.ViewComposeDotEnv:
+			footer += a.dotEnvView.GetHelpText()
+		case models.ViewGroupLogs:
+			footer += a.groupLogsView.GetHelpText()
 		case models.ViewAbout:
 			footer += a.aboutView.GetHelpText()
 		}
 	}
 
+	if len(a.quickActions) > 0 {
+		footer = a.renderQuickActions() + "\n" + footer
+	}
 	return footer
 }
 
+// quickActionItems returns the labels of the current view's quick-action
+// bar entries, e.g. "alt+1 logs"
+func (a *App) quickActionItems() []string {
+	slots := keys.Map.QuickAction.Keys()
+	var items []string
+	for i, action := range a.quickActions[a.state.CurrentView] {
+		if i >= len(slots) {
+			break
+		}
+		items = append(items, fmt.Sprintf("%s %s", slots[i], strings.ReplaceAll(action, "_", " ")))
+	}
+	return items
+}
+
+// quickActionSeparator is drawn between quick-action bar entries
+const quickActionSeparator = "  "
+
+// renderQuickActions renders the current view's quick-action bar. The line is
+// kept on every view once some view has quick actions, so the layout is stable.
+func (a *App) renderQuickActions() string {
+	items := a.quickActionItems()
+	for i, item := range items {
+		items[i] = styles.KeyStyle.Render("[" + item + "]")
+	}
+	return lipgloss.NewStyle().MaxWidth(a.width).Render(strings.Join(items, quickActionSeparator))
+}
+
+// quickActionAt returns the quick action drawn at column x of the bar
+func (a *App) quickActionAt(x int) (string, bool) {
+	start := 0
+	actions := a.quickActions[a.state.CurrentView]
+	for i, item := range a.quickActionItems() {
+		end := start + lipgloss.Width("["+item+"]")
+		if x >= start && x < end {
+			return actions[i], true
+		}
+		start = end + len(quickActionSeparator)
+	}
+	return "", false
+}
+
+// runQuickAction presses the key of a quick action, like the palette does
+func (a *App) runQuickAction(action string) (tea.Model, tea.Cmd) {
+	b, ok := keys.Map.Binding(action)
+	if !ok {
+		return a, nil
+	}
+	return a.runPaletteAction(paletteAction{press: b})
+}
+
+// renderOperations renders the spinner line of the commands in flight, with
+// the progress of a running pull and the last status message after them
+func (a *App) renderOperations() string {
+	const maxShown = 3
+
+	var parts []string
+	for i, op := range a.operations {
+		if i == maxShown {
+			parts = append(parts, fmt.Sprintf("+%d more", len(a.operations)-maxShown))
+			break
+		}
+		elapsed := time.Since(op.started).Round(time.Second).String()
+		if op.progress != nil {
+			elapsed = op.progress() + ", " + elapsed
+		}
+		parts = append(parts, fmt.Sprintf("%s (%s)", op.label, elapsed))
+	}
+	pulling := a.pullProgressChan != nil || a.batchPullChan != nil
+	if pulling && a.statusMessage != "" {
+		parts = append(parts, a.statusMessage)
+	}
+
+	line := styles.WarningStyle.Render(a.spinner.View() + " " + strings.Join(parts, " · "))
+	if !pulling && a.statusMessage != "" {
+		line += styles.SeparatorStyle.String() + styles.SuccessStyle.Render("✓ "+a.statusMessage)
+	}
+	return line
+}
+
+// renderBulkEnvProgress renders the per-container progress of a group env change
+func (a *App) renderBulkEnvProgress() string {
+	lines := make([]string, 0, len(a.bulkEnvQueue))
+	for i, c := range a.bulkEnvQueue {
+		switch {
+		case i < len(a.bulkEnvResults):
+			r := a.bulkEnvResults[i]
+			if r.Err != nil {
+				lines = append(lines, styles.ErrorStyle.Render(fmt.Sprintf("✗ %s: %v", c.Name, r.Err)))
+			} else if r.Skipped {
+				lines = append(lines, styles.DescStyle.Render(fmt.Sprintf("- %s: unchanged", c.Name)))
+			} else {
+				lines = append(lines, styles.SuccessStyle.Render(fmt.Sprintf("✓ %s: recreated", c.Name)))
+			}
+		case i == len(a.bulkEnvResults):
+			lines = append(lines, styles.WarningStyle.Render(fmt.Sprintf("⟳ %s: applying...", c.Name)))
+		default:
+			lines = append(lines, fmt.Sprintf("  %s: pending", c.Name))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderProjectRecreateProgress renders the per-container progress of a
+// project force-recreate
+func (a *App) renderProjectRecreateProgress() string {
+	lines := make([]string, 0, len(a.projectRecreateQueue))
+	for i, c := range a.projectRecreateQueue {
+		switch {
+		case i < len(a.projectRecreateResults):
+			if err := a.projectRecreateResults[i].Err; err != nil {
+				lines = append(lines, styles.ErrorStyle.Render(fmt.Sprintf("✗ %s: %v", c.Name, err)))
+			} else {
+				lines = append(lines, styles.SuccessStyle.Render(fmt.Sprintf("✓ %s: recreated", c.Name)))
+			}
+		case i == len(a.projectRecreateResults):
+			lines = append(lines, styles.WarningStyle.Render(fmt.Sprintf("⟳ %s: recreating...", c.Name)))
+		default:
+			lines = append(lines, fmt.Sprintf("  %s: pending", c.Name))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// paletteAction is what a command palette entry does: switch to the view the
+// action belongs to (if needed) and press the action's key there
+type paletteAction struct {
+	switchTo *key.Binding
+	press    key.Binding
+}
+
+// paletteSections maps help sections to the view their actions run in and
+// the key switching to it. Sections without a switch key only apply while
+// their view is open.
+var paletteSections = map[string]struct {
+	views    []models.ViewType
+	switchTo *key.Binding
+}{
+	"Containers":              {[]models.ViewType{models.ViewContainers}, &keys.Map.Containers},
+	"Images":                  {[]models.ViewType{models.ViewImages}, &keys.Map.Images},
+	"Groups":                  {[]models.ViewType{models.ViewGroups}, &keys.Map.Groups},
+	"Volumes":                 {[]models.ViewType{models.ViewVolumes}, &keys.Map.Volumes},
+	"Compose":                 {[]models.ViewType{models.ViewCompose}, &keys.Map.Compose},
+	"Networks":                {[]models.ViewType{models.ViewNetworks}, &keys.Map.Networks},
+	"Plugins":                 {[]models.ViewType{models.ViewPlugins}, &keys.Map.Plugins},
+	"Logs and stats":          {[]models.ViewType{models.ViewLogs, models.ViewStats}, nil},
+	"Env matrix":              {[]models.ViewType{models.ViewComposeEnv}, nil},
+	"Image labels":            {[]models.ViewType{models.ViewImageLabels}, nil},
+	"Image history":           {[]models.ViewType{models.ViewImageHistory}, nil},
+	"Compose file":            {[]models.ViewType{models.ViewComposeFile}, nil},
+	"Vulnerabilities":         {[]models.ViewType{models.ViewVulnerabilities}, nil},
+	"Env/labels/ports editor": {[]models.ViewType{models.ViewEnvVars, models.ViewComposeDotEnv}, nil},
+}
+
+// openPalette lists the actions of the current view first, then the global
+// ones, then those of the other main views
+func (a *App) openPalette() {
+	var current, global, others []components.PaletteItem
+	var currentActions, globalActions, otherActions []paletteAction
+
+	for _, section := range keys.Map.HelpSections() {
+		target, isView := paletteSections[section.Title]
+		inView := isView && slices.Contains(target.views, a.state.CurrentView)
+		if isView && !inView && target.switchTo == nil {
+			continue
+		}
+
+		for _, e := range section.Entries {
+			if e.Action == "command_palette" || e.Action == "retry" || e.Action == "quick_action" {
+				continue
+			}
+			b, ok := keys.Map.Binding(e.Action)
+			if !ok {
+				continue
+			}
+
+			item := components.PaletteItem{Title: e.Desc, Hint: e.Keys}
+			action := paletteAction{press: b}
+			switch {
+			case !isView:
+				global = append(global, item)
+				globalActions = append(globalActions, action)
+			case inView:
+				item.Title = section.Title + ": " + e.Desc
+				current = append(current, item)
+				currentActions = append(currentActions, action)
+			default:
+				item.Title = section.Title + ": " + e.Desc
+				item.Hint = keys.Label(*target.switchTo) + " then " + e.Keys
+				action.switchTo = target.switchTo
+				others = append(others, item)
+				otherActions = append(otherActions, action)
+			}
+		}
+	}
+
+	a.palette = components.NewPalette(slices.Concat(current, global, others))
+	a.palette.SetSize(a.width, a.height)
+	a.paletteActions = slices.Concat(currentActions, globalActions, otherActions)
+}
+
+// runPaletteAction runs a palette entry as if its keys were pressed, so it
+// acts on the current selection exactly like the key would
+func (a *App) runPaletteAction(action paletteAction) (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
+	if action.switchTo != nil {
+		_, cmd := a.Update(keys.Press(*action.switchTo))
+		cmds = append(cmds, cmd)
+	}
+	_, cmd := a.Update(keys.Press(action.press))
+	cmds = append(cmds, cmd)
+	return a, tea.Batch(cmds...)
+}
+
+// renderBatchPullProgress renders the per-image progress of a batch pull,
+// keeping the image being pulled in sight when the list is long
+func (a *App) renderBatchPullProgress() string {
+	lines := make([]string, 0, len(a.batchPullQueue))
+	for i, image := range a.batchPullQueue {
+		switch {
+		case i < len(a.batchPullResults):
+			if err := a.batchPullResults[i].Err; err != nil {
+				lines = append(lines, styles.ErrorStyle.Render(fmt.Sprintf("✗ %s: %v", image, err)))
+			} else {
+				lines = append(lines, styles.SuccessStyle.Render(fmt.Sprintf("✓ %s", image)))
+			}
+		case i == len(a.batchPullResults):
+			status := a.batchPullStatus
+			if status == "" {
+				status = "starting..."
+			}
+			lines = append(lines, styles.WarningStyle.Render(fmt.Sprintf("⟳ %s: %s", image, status)))
+		default:
+			lines = append(lines, fmt.Sprintf("  %s: pending", image))
+		}
+	}
+
+	maxWidth := a.width - 14
+	for i, line := range lines {
+		if maxWidth > 0 && lipgloss.Width(line) > maxWidth {
+			lines[i] = lipgloss.NewStyle().MaxWidth(maxWidth).Render(line)
+		}
+	}
+
+	if maxLines := a.height - 12; maxLines > 0 && len(lines) > maxLines {
+		start := len(a.batchPullResults) - maxLines/2
+		start = max(0, min(start, len(lines)-maxLines))
+		lines = lines[start : start+maxLines]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// openComposeUpForm opens the compose up form, prefilled with the files and
+// name of a known project, or with the current directory
+func (a *App) openComposeUpForm(project *models.ComposeProject) {
+	values := []string{"", ""}
+	if project != nil {
+		values = []string{strings.Join(project.ConfigFiles, ", "), project.Name}
+	} else if dir, err := os.Getwd(); err == nil {
+		values[0] = dir
+	}
+	a.modal = components.NewFormModalWithOptional(
+		"Compose Up",
+		[]string{
+			"Compose files or directory (comma separated)",
+			"Project name (default: directory name)",
+		},
+		[]int{1},
+	)
+	a.modal.SetInputValues(values)
+	a.modal.SetSize(a.width, a.height)
+	a.pendingDeleteType = "compose_up"
+}
+
+// openMacvlanForm opens the macvlan/ipvlan network form, prefilled from the
+// parent interface when one was picked
+func (a *App) openMacvlanForm(parent *utils.HostInterface) {
+	values := []string{"", "macvlan", "", "", "", "", ""}
+	if parent != nil {
+		// Wi-Fi drops the extra MAC addresses of macvlan
+		if parent.Wireless {
+			values[1] = "ipvlan"
+		}
+		values[3] = parent.Name
+		values[4] = parent.Subnet()
+		values[5] = parent.Gateway
+	}
+	a.modal = components.NewFormModalWithOptional(
+		"Create Macvlan/Ipvlan Network",
+		[]string{
+			"Name",
+			"Driver (macvlan or ipvlan)",
+			"Mode (default: bridge / l2)",
+			"Parent interface (eth0, eth0.10 = VLAN 10)",
+			"Subnet (CIDR)",
+			"Gateway",
+			"IP range (CIDR outside the DHCP pool)",
+		},
+		[]int{2, 5, 6},
+	)
+	a.modal.SetInputValues(values)
+	a.modal.SetSize(a.width, a.height)
+	a.pendingDeleteType = "macvlan_form"
+}
+
+// macvlanInterface returns the listed host interface with the given name
+func (a *App) macvlanInterface(name string) *utils.HostInterface {
+	for i := range a.macvlanInterfaces {
+		if a.macvlanInterfaces[i].Name == name {
+			return &a.macvlanInterfaces[i]
+		}
+	}
+	return nil
+}
+
+// renderMacvlanSummary shows the network to create with validation warnings
+// and the reachability caveats of the driver
+func (a *App) renderMacvlanSummary(n models.MacvlanNetwork, parent *utils.HostInterface, warnings []string) string {
+	maxWidth := a.width - 14
+	wrap := lipgloss.NewStyle().Width(max(20, maxWidth))
+	row := func(label, value string) string {
+		if value == "" {
+			value = styles.DescStyle.Render("none")
+		}
+		return styles.KeyStyle.Render(fmt.Sprintf("%-10s", label)) + value
+	}
+
+	lines := []string{
+		row("Driver:", n.Driver+" ("+n.GetMode()+")"),
+		row("Parent:", n.Parent),
+		row("Subnet:", n.Subnet),
+		row("Gateway:", n.Gateway),
+		row("IP range:", n.IPRange),
+	}
+	if parent == nil && len(a.macvlanInterfaces) > 0 && !strings.Contains(n.Parent, ".") {
+		warnings = append(warnings, fmt.Sprintf("%s is not an interface of this host", n.Parent))
+	}
+	if len(warnings) > 0 {
+		lines = append(lines, "")
+		for _, w := range warnings {
+			lines = append(lines, wrap.Render(styles.WarningStyle.Render("⚠ "+w)))
+		}
+	}
+	lines = append(lines, "")
+	for _, c := range n.Caveats(parent) {
+		lines = append(lines, wrap.Render(styles.DescStyle.Render("• "+c)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// refreshCurrentView reloads the data shown in the current view
+func (a *App) refreshCurrentView() tea.Cmd {
+	switch a.state.CurrentView {
+	case models.ViewContainers:
+		return fetchContainers(a.docker)
+	case models.ViewImages:
+		return fetchImages(a.docker)
+	case models.ViewGroups:
+		return loadGroups(a.groupManager)
+	case models.ViewVolumes:
+		return tea.Batch(fetchVolumes(a.docker), fetchContainers(a.docker))
+	case models.ViewCompose:
+		return fetchComposeProjects(a.docker)
+	case models.ViewNetworks:
+		if selectedNetwork := a.networksView.GetSelectedNetworkForApp(); selectedNetwork != nil {
+			return tea.Batch(fetchNetworks(a.docker), fetchContainers(a.docker), fetchNetworkEndpoints(a.docker, selectedNetwork.ID))
+		}
+		return tea.Batch(fetchNetworks(a.docker), fetchContainers(a.docker))
+	case models.ViewPlugins:
+		return fetchPlugins(a.docker)
+	case models.ViewGroupLogs:
+		if a.groupManager != nil {
+			if group := a.groupManager.GetGroup(a.groupLogsID); group != nil {
+				return fetchGroupLogs(a.docker, *group)
+			}
+		}
+	}
+	return nil
+}
+
 // cycleTabForward cycles to the next tab
 func (a *App) cycleTabForward() (tea.Model, tea.Cmd) {
 	a.state.PreviousView = a.state.CurrentView
@@ -1415,6 +4645,10 @@ func (a *App) cycleTabForward() (tea.Model, tea.Cmd) {
 		a.sidebar.SetCurrentView(models.ViewNetworks)
 		return a, tea.Batch(fetchNetworks(a.docker), fetchContainers(a.docker))
 	case models.ViewNetworks:
+		a.state.CurrentView = models.ViewPlugins
+		a.sidebar.SetCurrentView(models.ViewPlugins)
+		return a, fetchPlugins(a.docker)
+	case models.ViewPlugins:
 		a.state.CurrentView = models.ViewAbout
 		a.sidebar.SetCurrentView(models.ViewAbout)
 		return a, nil
@@ -1437,6 +4671,10 @@ func (a *App) cycleTabBackward() (tea.Model, tea.Cmd) {
 		a.sidebar.SetCurrentView(models.ViewAbout)
 		return a, nil
 	case models.ViewAbout:
+		a.state.CurrentView = models.ViewPlugins
+		a.sidebar.SetCurrentView(models.ViewPlugins)
+		return a, fetchPlugins(a.docker)
+	case models.ViewPlugins:
 		a.state.CurrentView = models.ViewNetworks
 		a.sidebar.SetCurrentView(models.ViewNetworks)
 		return a, tea.Batch(fetchNetworks(a.docker), fetchContainers(a.docker))
@@ -1465,10 +4703,29 @@ func (a *App) cycleTabBackward() (tea.Model, tea.Cmd) {
 	return a, nil
 }
 
+// openGroupColorMenu asks for the color of a group, current preselected
+func (a *App) openGroupColorMenu(pendingType, current string) {
+	options := make([]string, len(models.GroupColors))
+	selected := 0
+	for i, color := range models.GroupColors {
+		options[i] = styles.GroupMarker(color) + " " + color
+		if color == current {
+			selected = i
+		}
+	}
+	a.modal = components.NewMenuModal("Group Color", options)
+	a.modal.SetSelectedIndex(selected)
+	a.modal.SetSize(a.width, a.height)
+	a.pendingDeleteType = pendingType
+}
+
 // handleModalConfirmed handles the confirmed modal action
 func (a *App) handleModalConfirmed() (tea.Model, tea.Cmd) {
 	defer func() {
-		a.modal = nil
+		// Keep a follow-up modal opened by the handler
+		if a.modal != nil && !a.modal.IsVisible() {
+			a.modal = nil
+		}
 	}()
 
 	switch a.pendingDeleteType {
@@ -1482,10 +4739,15 @@ func (a *App) handleModalConfirmed() (tea.Model, tea.Cmd) {
 		// Get selected images and remove them
 		selectedImages := a.imagesView.GetSelectedImages()
 		a.imagesView.ClearSelection()
-		return a, removeImagesBulk(a.docker, selectedImages)
+		return a, a.track(fmt.Sprintf("Removing %d images", len(selectedImages)), removeImagesBulk(a.docker, selectedImages))
 
 	case "prune_images":
-		return a, pruneImages(a.docker)
+		all := a.modal.GetSelectedIndex() == 1
+		label := "Pruning dangling images"
+		if all {
+			label = "Pruning unused images"
+		}
+		return a, a.track(label, pruneImages(a.docker, all, a.imagesView.ProtectedIDs()))
 
 	case "group":
 		return a, deleteGroup(a.groupManager, a.pendingDelete)
@@ -1495,87 +4757,1845 @@ func (a *App) handleModalConfirmed() (tea.Model, tea.Cmd) {
 			return a, removeContainerFromGroup(a.groupManager, selectedGroup.ID, a.pendingDelete)
 		}
 
-	case "create_group":
-		// Get form values
+	case "filter_label":
 		values := a.modal.GetInputValues()
-		if len(values) >= 2 {
-			name := values[0]
-			description := values[1]
+		if len(values) >= 1 {
+			a.containersView.SetLabelFilter(values[0])
+		}
 
-			// Create group with selected containers
-			// For now, create empty group - user can add containers later
-			return a, createGroup(a.groupManager, name, description, []string{})
+	case "tail_file":
+		values := a.modal.GetInputValues()
+		if container := a.state.SelectedContainer; container != nil && len(values) >= 1 {
+			path := strings.TrimSpace(values[0])
+			if path == "" {
+				return a, nil
+			}
+			a.stopFileTail()
+			ctx, cancel := context.WithCancel(context.Background())
+			a.fileTailCancel = cancel
+			a.state.PreviousView = a.state.CurrentView
+			a.state.CurrentView = models.ViewLogs
+			return a, startFileTail(ctx, a.docker, a.logsView, container, path)
 		}
 
-	case "volume":
-		return a, removeVolume(a.docker, a.pendingDelete)
+	case "copy_command":
+		return a, copyToClipboard("command", a.modal.GetSelectedOption())
 
-	case "prune_volumes":
-		return a, pruneVolumes(a.docker)
+	case "switch_context":
+		idx := a.modal.GetSelectedIndex()
+		if idx < len(a.dockerContexts) {
+			dockerCtx := a.dockerContexts[idx]
+			if dockerCtx.Name == a.dockerContext.Name {
+				return a, nil
+			}
+			a.statusMessage = fmt.Sprintf("Connecting to context '%s'...", dockerCtx.Name)
+			return a, switchDockerContext(dockerCtx)
+		}
 
-	case "pull_image":
-		// Get form values
+	case "set_dependencies":
+		selectedGroup := a.groupsView.GetSelectedGroupForApp()
 		values := a.modal.GetInputValues()
-		if len(values) >= 1 && values[0] != "" {
-			imageName := values[0]
-			a.pullImageName = imageName
-			a.pullProgress = "Starting pull..."
-			a.statusMessage = fmt.Sprintf("Pulling '%s': Starting...", imageName)
-			progressChan, cmd := startImagePull(a.docker, imageName)
-			a.pullProgressChan = progressChan
-			return a, cmd
+		if selectedGroup != nil && len(values) >= 2 {
+			// Resolve container names to IDs within the group
+			nameToID := make(map[string]string)
+			for _, c := range a.groupsView.GetContainersInGroup() {
+				nameToID[c.Name] = c.ID
+			}
+
+			var dependsOn []string
+			for _, name := range strings.Split(values[0], ",") {
+				name = strings.TrimSpace(name)
+				if name == "" {
+					continue
+				}
+				id, ok := nameToID[name]
+				if !ok {
+					a.errorMessage = fmt.Sprintf("Container '%s' is not in group '%s'", name, selectedGroup.Name)
+					return a, clearStatus(3 * time.Second)
+				}
+				dependsOn = append(dependsOn, id)
+			}
+
+			waitHealthy := strings.HasPrefix(strings.ToLower(strings.TrimSpace(values[1])), "y")
+
+			startDelay := 0
+			if len(values) >= 3 && strings.TrimSpace(values[2]) != "" {
+				seconds, err := strconv.Atoi(strings.TrimSpace(values[2]))
+				if err != nil || seconds < 0 {
+					a.errorMessage = fmt.Sprintf("Invalid start delay '%s'", values[2])
+					return a, clearStatus(3 * time.Second)
+				}
+				startDelay = seconds
+			}
+
+			stopGrace := models.DefaultStopGracePeriod
+			if len(values) >= 4 && strings.TrimSpace(values[3]) != "" {
+				seconds, err := strconv.Atoi(strings.TrimSpace(values[3]))
+				if err != nil || seconds < 0 {
+					a.errorMessage = fmt.Sprintf("Invalid stop grace period '%s'", values[3])
+					return a, clearStatus(3 * time.Second)
+				}
+				stopGrace = seconds
+			}
+			return a, setContainerDependencies(a.groupManager, selectedGroup.ID, a.pendingDelete, dependsOn, waitHealthy, startDelay, stopGrace)
 		}
 
-	case "create_network":
-		// Get form values
+	case "bulk_env_form":
 		values := a.modal.GetInputValues()
-		if len(values) >= 1 && values[0] != "" {
-			networkName := values[0]
-			driver := "bridge" // Default driver
-			if len(values) >= 2 && values[1] != "" {
-				driver = values[1]
+		if len(values) >= 2 {
+			key := strings.TrimSpace(values[0])
+			if key == "" || strings.ContainsAny(key, "= \t") {
+				a.errorMessage = fmt.Sprintf("Invalid env var name '%s'", key)
+				return a, clearStatus(3 * time.Second)
 			}
-			return a, createNetwork(a.docker, networkName, driver)
+
+			var group *models.Group
+			for _, g := range a.groupManager.GetAllGroups() {
+				if g.ID == a.pendingDelete {
+					group = &g
+					break
+				}
+			}
+			if group == nil {
+				return a, nil
+			}
+			containers := a.groupsView.ContainersForGroup(group)
+			if len(containers) == 0 {
+				a.errorMessage = fmt.Sprintf("Group '%s' has no containers", group.Name)
+				return a, clearStatus(3 * time.Second)
+			}
+
+			a.bulkEnvGroupName = group.Name
+			a.bulkEnvChange = models.EnvVarChange{Key: key, Value: values[1], Remove: values[1] == ""}
+			a.bulkEnvQueue = containers
+			a.bulkEnvResults = nil
+
+			names := make([]string, len(containers))
+			for i, c := range containers {
+				names[i] = "  • " + c.Name
+			}
+			a.modal = components.NewConfirmModal(
+				"Apply to Group",
+				fmt.Sprintf("Apply '%s' to %d container(s) in '%s'?\nContainers whose env changes are recreated:\n\n%s",
+					a.bulkEnvChange.Description(), len(containers), group.Name, strings.Join(names, "\n")),
+			)
+			a.modal.SetSize(a.width, a.height)
+			a.pendingDeleteType = "bulk_env"
+			return a, nil
 		}
 
-	case "network":
-		return a, removeNetwork(a.docker, a.pendingDelete)
+	case "bulk_env":
+		if len(a.bulkEnvQueue) > 0 {
+			a.bulkEnvModal = components.NewInfoModal(
+				fmt.Sprintf("Group '%s': %s", a.bulkEnvGroupName, a.bulkEnvChange.Description()),
+				a.renderBulkEnvProgress(),
+			)
+			a.bulkEnvModal.SetSize(a.width, a.height)
+			a.modal = a.bulkEnvModal
+			a.pendingDeleteType = ""
+			return a, applyEnvChange(a.docker, a.bulkEnvQueue[0], a.bulkEnvChange)
+		}
 
-	case "disconnect_from_network":
-		if selectedNetwork := a.networksView.GetSelectedNetworkForApp(); selectedNetwork != nil {
-			return a, disconnectContainerFromNetwork(a.docker, selectedNetwork.ID, a.pendingDelete)
+	case "recreate_project":
+		if len(a.projectRecreateQueue) > 0 {
+			a.projectRecreateModal = components.NewInfoModal(
+				fmt.Sprintf("Recreating %s", a.projectRecreateName),
+				a.renderProjectRecreateProgress(),
+			)
+			a.projectRecreateModal.SetSize(a.width, a.height)
+			a.modal = a.projectRecreateModal
+			a.pendingDeleteType = ""
+			return a, recreateProjectContainer(a.docker, a.projectRecreateQueue[0])
 		}
-	}
+
+	case "create_group":
+		// Pick the color next, the form values are kept meanwhile
+		values := a.modal.GetInputValues()
+		if len(values) >= 2 && a.groupManager != nil {
+			a.groupDraft = models.Group{Name: values[0], Description: values[1]}
+			a.openGroupColorMenu("create_group_color", a.groupManager.NextColor())
+		}
+
+	case "create_group_color":
+		if a.groupDraft.Name != "" {
+			color := models.GroupColors[a.modal.GetSelectedIndex()]
+
+			// Create group with selected containers
+			// For now, create empty group - user can add containers later
+			return a, createGroup(a.groupManager, a.groupDraft.Name, a.groupDraft.Description, color, []string{})
+		}
+
+	case "edit_group":
+		values := a.modal.GetInputValues()
+		if group := a.groupsView.GetSelectedGroup(); len(values) >= 6 && group != nil && group.ID == a.pendingDelete && a.groupManager != nil {
+			hotkey := strings.TrimSpace(values[2])
+			if hotkey != "" && !models.ValidHotkey(hotkey) {
+				a.errorMessage = fmt.Sprintf("Invalid hotkey '%s': use a letter or digit", hotkey)
+				return a, clearStatus(3 * time.Second)
+			}
+			if other := a.groupManager.GetGroupByHotkey(hotkey); other != nil && other.ID != group.ID {
+				a.errorMessage = fmt.Sprintf("Hotkey %s is already used by group '%s'", hotkey, other.Name)
+				return a, clearStatus(3 * time.Second)
+			}
+
+			var includes []string
+			for _, name := range strings.Split(values[3], ",") {
+				name = strings.TrimSpace(name)
+				if name == "" {
+					continue
+				}
+				included := a.groupManager.GetGroupByName(name)
+				if included == nil {
+					a.errorMessage = fmt.Sprintf("Group '%s' not found", name)
+					return a, clearStatus(3 * time.Second)
+				}
+				if included.ID == group.ID {
+					a.errorMessage = "A group can't include itself"
+					return a, clearStatus(3 * time.Second)
+				}
+				includes = append(includes, included.ID)
+			}
+
+			draft := models.Group{
+				ID:            group.ID,
+				Name:          values[0],
+				Description:   values[1],
+				Hotkey:        hotkey,
+				GroupIDs:      includes,
+				StartSchedule: strings.Join(strings.Fields(values[4]), " "),
+				StopSchedule:  strings.Join(strings.Fields(values[5]), " "),
+			}
+			if err := draft.ValidateSchedules(); err != nil {
+				a.errorMessage = err.Error()
+				return a, clearStatus(5 * time.Second)
+			}
+			a.groupDraft = draft
+			a.openGroupColorMenu("edit_group_color", group.Color)
+		}
+
+	case "edit_group_color":
+		if a.groupDraft.ID == a.pendingDelete {
+			a.groupDraft.Color = models.GroupColors[a.modal.GetSelectedIndex()]
+			return a, editGroup(a.groupManager, a.groupDraft)
+		}
+
+	case "volume":
+		return a, removeVolume(a.docker, a.pendingDelete)
+
+	case "prune_volumes":
+		return a, a.track("Pruning volumes", pruneVolumes(a.docker))
+
+	case "prune_anonymous":
+		names := strings.Split(a.pendingDelete, ",")
+		return a, a.track(fmt.Sprintf("Removing %d anonymous volumes", len(names)), removeVolumes(a.docker, names))
+
+	case "plugin":
+		return a, removePlugin(a.docker, a.pendingDelete)
+
+	case "compose_down":
+		idx := a.modal.GetSelectedIndex()
+		volumes, images := idx == 1 || idx == 3, idx == 2 || idx == 3
+		return a, a.track("Taking down "+a.pendingDelete, composeDown(a.docker, a.pendingDelete, volumes, images))
+
+	case "pull_image":
+		// Get form values
+		values := a.modal.GetInputValues()
+		if len(values) >= 1 && values[0] != "" {
+			imageName := values[0]
+			platform := ""
+			if len(values) > 1 {
+				platform = strings.ToLower(strings.TrimSpace(values[1]))
+			}
+			if platform != "" && !models.ValidPlatform(platform) {
+				a.errorMessage = fmt.Sprintf("Invalid platform %q, expected os/arch like linux/arm64", platform)
+				return a, clearStatus(3 * time.Second)
+			}
+			a.pullImageName = imageName
+			a.pullProgress = "Starting pull..."
+			a.statusMessage = fmt.Sprintf("Pulling '%s': Starting...", imageName)
+			progressChan, cancel, cmd := startImagePull(a.docker, imageName, platform)
+			a.pullProgressChan = progressChan
+			a.pullCancel = cancel
+			a.pullLayers = nil
+
+			// Follow-up modal: esc hides it and the pull goes on in the footer
+			title := fmt.Sprintf("Pulling %s", imageName)
+			if platform != "" {
+				title += " (" + platform + ")"
+			}
+			a.pullModal = components.NewConfirmModal(title, "")
+			a.pullModal.SetConfirmText("Cancel pull")
+			a.pullModal.SetCancelText("Hide (esc)")
+			a.pullModal.SetSize(a.width, a.height)
+			a.pullModal.SetMessage(a.renderPullProgress())
+			a.modal = a.pullModal
+			a.pendingDeleteType = "cancel_pull"
+			return a, tea.Batch(cmd, a.spin())
+		}
+
+	case "tag_image":
+		values := a.modal.GetInputValues()
+		if len(values) >= 1 {
+			target := strings.TrimSpace(values[0])
+			if target == "" || strings.ContainsAny(target, " \t") {
+				a.errorMessage = "Invalid tag: expected repo:tag"
+				return a, clearStatus(2 * time.Second)
+			}
+			untag := len(values) >= 2 && strings.EqualFold(strings.TrimSpace(values[1]), "y")
+			if target == a.pendingDelete {
+				a.statusMessage = fmt.Sprintf("Image is already tagged %s", target)
+				return a, clearStatus(2 * time.Second)
+			}
+			// Untagging an image known only by its ID would delete it
+			untag = untag && a.pendingDelete != "" && !strings.HasPrefix(a.pendingDelete, "sha256:")
+			return a, tagImage(a.docker, a.pendingDelete, target, untag)
+		}
+
+	case "quick_run":
+		values := a.modal.GetInputValues()
+		if len(values) >= 4 {
+			ports := strings.FieldsFunc(values[1], func(r rune) bool { return r == ',' || r == ' ' })
+			detach := !strings.EqualFold(strings.TrimSpace(values[2]), "n")
+			autoRemove := strings.EqualFold(strings.TrimSpace(values[3]), "y")
+			a.statusMessage = fmt.Sprintf("Starting a container from %s...", a.pendingDelete)
+			return a, quickRun(a.docker, a.pendingDelete, strings.TrimSpace(values[0]), ports, detach, autoRemove)
+		}
+
+	case "save_image":
+		values := a.modal.GetInputValues()
+		if len(values) >= 1 && strings.TrimSpace(values[0]) != "" {
+			ref, path, size := a.pendingDelete, strings.TrimSpace(values[0]), a.pendingArchiveSize
+			written := new(atomic.Int64)
+			progress := func() string {
+				return fmt.Sprintf("%s of ~%s", utils.FormatBytes(written.Load()), utils.FormatBytes(size))
+			}
+			return a, a.trackProgress(fmt.Sprintf("Saving %s", ref), progress, saveImage(a.docker, ref, path, written))
+		}
+
+	case "export_compose":
+		values := a.modal.GetInputValues()
+		if group := a.groupsView.GetSelectedGroup(); len(values) >= 1 && strings.TrimSpace(values[0]) != "" && group != nil && group.ID == a.pendingDelete {
+			return a, a.track(fmt.Sprintf("Exporting %s", group.Name), exportGroupCompose(a.docker, a.groupManager, *group, strings.TrimSpace(values[0])))
+		}
+
+	case "backup_volume":
+		values := a.modal.GetInputValues()
+		if len(values) >= 1 && strings.TrimSpace(values[0]) != "" {
+			name, path, size := a.pendingDelete, strings.TrimSpace(values[0]), a.pendingArchiveSize
+			written := new(atomic.Int64)
+			progress := func() string {
+				if size < 0 {
+					return utils.FormatBytes(written.Load())
+				}
+				return fmt.Sprintf("%s of ~%s", utils.FormatBytes(written.Load()), utils.FormatBytes(size))
+			}
+			return a, a.trackProgress(fmt.Sprintf("Backing up %s", name), progress, backupVolume(a.docker, name, path, written))
+		}
+
+	case "restore_volume":
+		values := a.modal.GetInputValues()
+		if len(values) >= 2 && strings.TrimSpace(values[0]) != "" && strings.TrimSpace(values[1]) != "" {
+			path, name := strings.TrimSpace(values[0]), strings.TrimSpace(values[1])
+			if vol := a.volumesView.GetVolume(name); vol != nil {
+				// Extracting over existing data can't be undone
+				body := fmt.Sprintf("Volume '%s' already exists. Files of %s overwrite the ones in it, other files are kept.", name, path)
+				if vol.IsInUse() {
+					body += fmt.Sprintf("\n\nIt is used by %d container(s), stop them first to avoid corrupting their data.", vol.UsageData.RefCount)
+				}
+				a.modal = components.NewConfirmModal("Restore Into Existing Volume", body)
+				a.modal.SetConfirmText("Overwrite")
+				a.modal.SetSize(a.width, a.height)
+				a.pendingDeleteType = "restore_volume_overwrite"
+				a.pendingDelete = name
+				a.pendingArchivePath = path
+				return a, nil
+			}
+			return a, a.restoreVolume(name, path)
+		}
+
+	case "restore_volume_overwrite":
+		return a, a.restoreVolume(a.pendingDelete, a.pendingArchivePath)
+
+	case "load_image":
+		values := a.modal.GetInputValues()
+		if len(values) >= 1 && strings.TrimSpace(values[0]) != "" {
+			path := strings.TrimSpace(values[0])
+			read, total := new(atomic.Int64), new(atomic.Int64)
+			progress := func() string {
+				return fmt.Sprintf("%s of %s", utils.FormatBytes(read.Load()), utils.FormatBytes(total.Load()))
+			}
+			return a, a.trackProgress(fmt.Sprintf("Loading %s", path), progress, loadImage(a.docker, path, read, total))
+		}
+
+	case "cancel_pull":
+		if a.pullCancel != nil {
+			a.pullCancel()
+			a.statusMessage = fmt.Sprintf("Cancelling pull of '%s'...", a.pullImageName)
+		}
+
+	case "registry_login":
+		values := a.modal.GetInputValues()
+		if len(values) >= 3 && strings.TrimSpace(values[1]) != "" && values[2] != "" {
+			registry := models.NormalizeRegistry(values[0])
+			save := len(values) >= 4 && strings.EqualFold(strings.TrimSpace(values[3]), "y")
+			a.statusMessage = fmt.Sprintf("Logging in to %s...", registry)
+			return a, registryLogin(a.docker, registry, strings.TrimSpace(values[1]), values[2], save)
+		}
+
+	case "run_once":
+		values := a.modal.GetInputValues()
+		var cmd []string
+		if len(values) >= 1 {
+			var err error
+			if cmd, err = models.SplitCommand(values[0]); err != nil {
+				a.errorMessage = err.Error()
+				return a, clearStatus(3 * time.Second)
+			}
+		}
+		return a, a.track(fmt.Sprintf("Running %s", a.pendingDelete), runOnce(a.docker, a.pendingDelete, cmd))
+
+	case "pull_list":
+		values := a.modal.GetInputValues()
+		if len(values) >= 1 && strings.TrimSpace(values[0]) != "" {
+			a.statusMessage = "Reading image list..."
+			return a, loadPullList(strings.TrimSpace(values[0]))
+		}
+
+	case "batch_pull":
+		if len(a.batchPullQueue) > 0 && a.batchPullChan == nil {
+			a.batchPullResults = nil
+			a.batchPullModal = components.NewInfoModal(
+				fmt.Sprintf("Pulling %d image(s)", len(a.batchPullQueue)),
+				"",
+			)
+			a.batchPullModal.SetSize(a.width, a.height)
+			a.modal = a.batchPullModal
+			a.pendingDeleteType = ""
+
+			a.statusMessage = fmt.Sprintf("Pulling 1/%d '%s'", len(a.batchPullQueue), a.batchPullQueue[0])
+			progressChan, cmd := startBatchPull(a.docker, 0, a.batchPullQueue[0])
+			a.batchPullChan = progressChan
+			a.modal.SetMessage(a.renderBatchPullProgress())
+			return a, tea.Batch(cmd, a.spin())
+		}
+
+	case "recreate_changed":
+		if a.recreateProject != nil && len(a.recreateServices) > 0 {
+			project, services := *a.recreateProject, a.recreateServices
+			a.recreateProject, a.recreateServices = nil, nil
+			return a, a.track(fmt.Sprintf("Recreating %s of %s", strings.Join(services, ", "), project.Name),
+				recreateComposeServices(a.docker, project, services))
+		}
+
+	case "compose_apply":
+		if a.applyProject != nil {
+			project := *a.applyProject
+			a.applyProject = nil
+			return a, a.track("Applying changes to "+project.Name, applyComposeProject(a.docker, project))
+		}
+
+	case "compose_up_pick":
+		var project *models.ComposeProject
+		if idx := a.modal.GetSelectedIndex(); idx >= 0 && idx < len(a.composeUpChoices) {
+			project = &a.composeUpChoices[idx]
+		}
+		a.composeUpChoices = nil
+		a.openComposeUpForm(project)
+		return a, nil
+
+	case "compose_up":
+		values := a.modal.GetInputValues()
+		if len(values) >= 2 {
+			var files []string
+			for _, file := range strings.Split(values[0], ",") {
+				if file = strings.TrimSpace(file); file != "" {
+					files = append(files, file)
+				}
+			}
+			if len(files) == 0 {
+				a.errorMessage = "A compose file is required"
+				return a, clearStatus(3 * time.Second)
+			}
+			name := strings.TrimSpace(values[1])
+			label := name
+			if label == "" {
+				label = files[0]
+			}
+			return a, a.track("Bringing up "+label, composeUp(a.docker, name, files))
+		}
+
+	case "create_network":
+		// Get form values
+		values := a.modal.GetInputValues()
+		if len(values) >= 1 && values[0] != "" {
+			networkName := values[0]
+			driver := "bridge" // Default driver
+			if len(values) >= 2 && values[1] != "" {
+				driver = values[1]
+			}
+			return a, createNetwork(a.docker, networkName, driver)
+		}
+
+	case "network":
+		return a, removeNetwork(a.docker, a.pendingDelete)
+
+	case "prune_networks":
+		return a, a.track("Pruning networks", pruneNetworks(a.docker))
+
+	case "create_volume":
+		values := a.modal.GetInputValues()
+		if len(values) >= 4 {
+			labels, err := models.ParseKeyValues(values[2])
+			if err != nil {
+				a.errorMessage = fmt.Sprintf("Invalid labels: %v", err)
+				return a, clearStatus(3 * time.Second)
+			}
+			opts, err := models.ParseKeyValues(values[3])
+			if err != nil {
+				a.errorMessage = fmt.Sprintf("Invalid driver options: %v", err)
+				return a, clearStatus(3 * time.Second)
+			}
+			return a, createVolume(a.docker, strings.TrimSpace(values[0]), strings.TrimSpace(values[1]), labels, opts)
+		}
+
+	case "disconnect_from_network":
+		if selectedNetwork := a.networksView.GetSelectedNetworkForApp(); selectedNetwork != nil {
+			return a, disconnectContainerFromNetwork(a.docker, selectedNetwork.ID, a.pendingDelete)
+		}
+
+	case "macvlan_parent":
+		var parent *utils.HostInterface
+		if idx := a.modal.GetSelectedIndex(); idx >= 0 && idx < len(a.macvlanInterfaces) {
+			parent = &a.macvlanInterfaces[idx]
+		}
+		a.openMacvlanForm(parent)
+		return a, nil
+
+	case "macvlan_form":
+		values := a.modal.GetInputValues()
+		if len(values) >= 7 {
+			n := models.MacvlanNetwork{
+				Name:    strings.TrimSpace(values[0]),
+				Driver:  strings.ToLower(strings.TrimSpace(values[1])),
+				Mode:    strings.ToLower(strings.TrimSpace(values[2])),
+				Parent:  strings.TrimSpace(values[3]),
+				Subnet:  strings.TrimSpace(values[4]),
+				Gateway: strings.TrimSpace(values[5]),
+				IPRange: strings.TrimSpace(values[6]),
+			}
+			parent := a.macvlanInterface(n.Parent)
+			warnings, err := n.Validate(parent)
+			if err != nil {
+				a.errorMessage = err.Error()
+				return a, clearStatus(4 * time.Second)
+			}
+
+			a.macvlanNetwork = &n
+			a.modal = components.NewConfirmModal(
+				"Create Network",
+				fmt.Sprintf("Create %s network '%s'?\n\n%s", n.Driver, n.Name, a.renderMacvlanSummary(n, parent, warnings)),
+			)
+			a.modal.SetConfirmText("Create")
+			a.modal.SetSize(a.width, a.height)
+			a.pendingDeleteType = "macvlan_create"
+			return a, nil
+		}
+
+	case "macvlan_create":
+		if a.macvlanNetwork != nil {
+			n := *a.macvlanNetwork
+			a.macvlanNetwork = nil
+			return a, createMacvlanNetwork(a.docker, n)
+		}
+
+	case "network_connect":
+		values := a.modal.GetInputValues()
+		selectedNetwork := a.networksView.GetSelectedNetworkForApp()
+		container := a.networksView.GetSelectedAvailableContainer()
+		if len(values) >= 2 && selectedNetwork != nil && container != nil && container.ID == a.pendingDelete {
+			ip := strings.TrimSpace(values[1])
+			if ip != "" {
+				if err := selectedNetwork.ValidateStaticIP(ip); err != nil {
+					a.errorMessage = err.Error()
+					return a, clearStatus(3 * time.Second)
+				}
+			}
+			return a, connectContainerToNetwork(a.docker, selectedNetwork.ID, container.ID, models.ParseAliases(values[0]), ip)
+		}
+
+	case "network_aliases":
+		values := a.modal.GetInputValues()
+		selectedNetwork := a.networksView.GetSelectedNetworkForApp()
+		container := a.networksView.GetSelectedInNetworkContainer()
+		if len(values) >= 1 && selectedNetwork != nil && container != nil && container.ID == a.pendingDelete {
+			a.statusMessage = fmt.Sprintf("Reconnecting %s to %s...", container.Name, selectedNetwork.Name)
+			return a, setNetworkAliases(a.docker, *selectedNetwork, *container, models.ParseAliases(values[0]))
+		}
+
+	case "edit_cpuset":
+		values := a.modal.GetInputValues()
+		if len(values) >= 2 {
+			cpus := strings.TrimSpace(values[0])
+			mems := strings.TrimSpace(values[1])
+
+			// An empty CPU list removes pinning (allow all host CPUs)
+			if cpus == "" && a.hostCPUCount > 0 {
+				cpus = fmt.Sprintf("0-%d", a.hostCPUCount-1)
+			}
+			if err := models.ValidateCPUSet(cpus, a.hostCPUCount); err != nil {
+				a.errorMessage = err.Error()
+				return a, clearStatus(3 * time.Second)
+			}
+			if mems != "" {
+				if _, err := models.ParseCPUSet(mems); err != nil {
+					a.errorMessage = err.Error()
+					return a, clearStatus(3 * time.Second)
+				}
+			}
+			return a, updateContainerCpuset(a.docker, a.pendingDelete, cpus, mems)
+		}
+	}
 
 	a.pendingDelete = ""
 	a.pendingDeleteType = ""
 	return a, nil
 }
 
-// Commands
-
-func initDockerClient() tea.Cmd {
+// Commands
+
+func initDockerClient() tea.Cmd {
+	return func() tea.Msg {
+		client, err := docker.NewClient()
+		if err != nil {
+			return DockerInitFailedMsg{err: err}
+		}
+		return DockerClientReadyMsg{client: client}
+	}
+}
+
+// reconnectInterval is how often a lost Docker daemon is pinged again
+const reconnectInterval = 3 * time.Second
+
+func tickReconnect() tea.Cmd {
+	return tea.Tick(reconnectInterval, func(t time.Time) tea.Msg {
+		return ReconnectTickMsg{}
+	})
+}
+
+// healthInterval is how often a remote daemon is pinged for its latency and
+// to notice a dropped ssh tunnel before the next refresh fails
+const healthInterval = 10 * time.Second
+
+func tickHealth() tea.Cmd {
+	return tea.Tick(healthInterval, func(t time.Time) tea.Msg {
+		return HealthTickMsg{}
+	})
+}
+
+// tickSchedule ticks at the start of the next minute, when group schedules
+// fire
+func tickSchedule() tea.Cmd {
+	now := time.Now()
+	return tea.Tick(now.Truncate(time.Minute).Add(time.Minute).Sub(now), func(t time.Time) tea.Msg {
+		return ScheduleTickMsg{time: t}
+	})
+}
+
+func checkHealth(client *docker.Client) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		latency, err := client.Ping(ctx)
+		return DaemonHealthMsg{client: client, latency: latency, err: err}
+	}
+}
+
+// reconnectDocker creates a fresh client, which pings the daemon; the old
+// client's connections may be stale after a daemon restart
+func reconnectDocker(dockerCtx models.DockerContext) tea.Cmd {
+	return func() tea.Msg {
+		client, err := docker.NewClientForContext(dockerCtx)
+		if err != nil {
+			return DockerReconnectFailedMsg{err: err}
+		}
+		return DockerClientReadyMsg{client: client}
+	}
+}
+
+// startWatching (re)starts the events stream of the current client for
+// notifications about watched containers
+func (a *App) startWatching() tea.Cmd {
+	if a.watchCancel != nil {
+		a.watchCancel()
+		a.watchCancel = nil
+	}
+	if a.docker == nil {
+		return nil
+	}
+
+	a.watchGen++
+	ctx, cancel := context.WithCancel(context.Background())
+	a.watchCancel = cancel
+	events, errs := a.docker.StreamWatchEvents(ctx)
+	return waitForWatchEvent(a.watchGen, events, errs)
+}
+
+// waitForWatchEvent waits for the next event of the watch stream
+func waitForWatchEvent(gen int, events <-chan *models.ContainerEvent, errs <-chan error) tea.Cmd {
+	return func() tea.Msg {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return WatchEventMsg{gen: gen}
+			}
+			return WatchEventMsg{gen: gen, event: event, events: events, errs: errs}
+		case <-errs:
+			return WatchEventMsg{gen: gen}
+		}
+	}
+}
+
+// notify sends a notification, failures only cost the notification
+func notify(method, title, body string) tea.Cmd {
+	return func() tea.Msg {
+		_ = utils.Notify(method, title, body)
+		return nil
+	}
+}
+
+// runOnce runs an image until it exits and saves its full output
+func runOnce(client *docker.Client, imageName string, cmd []string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+		defer cancel()
+
+		result := client.RunOnce(ctx, imageName, cmd)
+		if result.Output != "" {
+			if path, err := config.SaveRunOutput(result); err == nil {
+				result.OutputFile = path
+			}
+		}
+		return RunFinishedMsg{result: result}
+	}
+}
+
+// renderRunResults shows the latest run with the end of its output, then the
+// earlier runs of the session
+func (a *App) renderRunResults() string {
+	if len(a.runResults) == 0 {
+		return styles.DescStyle.Render(fmt.Sprintf("No runs yet: select an image and press %s", keys.Label(keys.Map.RunOnce)))
+	}
+
+	latest := a.runResults[len(a.runResults)-1]
+	row := func(label, value string) string {
+		return styles.KeyStyle.Render(fmt.Sprintf("%-9s", label)) + " " + value
+	}
+
+	command := latest.Command
+	if command == "" {
+		command = styles.DescStyle.Render("image default")
+	}
+	exit := styles.SuccessStyle.Render("0")
+	if latest.ExitCode != 0 {
+		exit = styles.ErrorStyle.Render(fmt.Sprintf("%d", latest.ExitCode))
+	}
+	lines := []string{
+		row("Image:", latest.Image),
+		row("Command:", command),
+		row("Exit:", exit),
+		row("Duration:", latest.Duration().Round(10*time.Millisecond).String()),
+	}
+	if latest.Err != "" {
+		lines = append(lines, row("Error:", styles.ErrorStyle.Render(latest.Err)))
+	}
+	if latest.OutputFile != "" {
+		lines = append(lines, row("Output:", latest.OutputFile))
+	}
+
+	earlier := min(len(a.runResults)-1, 5)
+	tail := latest.OutputTail(max(3, a.height-20-len(lines)-earlier))
+	lines = append(lines, "")
+	if len(tail) == 0 {
+		lines = append(lines, styles.DescStyle.Render("No output"))
+	}
+	lineStyle := lipgloss.NewStyle().MaxWidth(max(20, a.width-14))
+	for _, line := range tail {
+		lines = append(lines, lineStyle.Render(line))
+	}
+
+	if earlier > 0 {
+		lines = append(lines, "", styles.KeyStyle.Render("Earlier runs:"))
+		for i := len(a.runResults) - 2; i >= len(a.runResults)-1-earlier; i-- {
+			lines = append(lines, a.runResults[i].Summary())
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// loadAuditLog reads the audit log, keeping the entries about container if
+// one is given
+func loadAuditLog(container *models.Container) tea.Cmd {
+	return func() tea.Msg {
+		entries, err := config.LoadAuditLog()
+		if err != nil || container == nil {
+			return AuditLogLoadedMsg{title: "Audit Log", entries: entries, err: err}
+		}
+
+		var matching []models.AuditEntry
+		for _, e := range entries {
+			if e.Concerns(container.ID, container.Name) {
+				matching = append(matching, e)
+			}
+		}
+		return AuditLogLoadedMsg{title: "Audit Log: " + container.Name, entries: matching}
+	}
+}
+
+// renderAuditLog lists the latest entries, newest first
+func renderAuditLog(entries []models.AuditEntry, limit int) string {
+	if len(entries) == 0 {
+		return styles.DescStyle.Render("No actions recorded yet")
+	}
+
+	var lines []string
+	for i := len(entries) - 1; i >= 0 && len(lines) < limit; i-- {
+		line := entries[i].String()
+		if !entries[i].Succeeded() {
+			line = styles.ErrorStyle.Render(line)
+		}
+		lines = append(lines, line)
+	}
+	if hidden := len(entries) - len(lines); hidden > 0 {
+		lines = append(lines, styles.DescStyle.Render(fmt.Sprintf("... %d older entries in the audit log", hidden)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func loadDockerContexts() tea.Cmd {
+	return func() tea.Msg {
+		contexts, err := docker.ListContexts()
+		return DockerContextsLoadedMsg{contexts: contexts, err: err}
+	}
+}
+
+func switchDockerContext(dockerCtx models.DockerContext) tea.Cmd {
+	return func() tea.Msg {
+		client, err := docker.NewClientForContext(dockerCtx)
+		return DockerContextSwitchedMsg{context: dockerCtx, client: client, err: err}
+	}
+}
+
+func initGroupManager() tea.Cmd {
+	return func() tea.Msg {
+		gm, err := config.NewGroupManager()
+		if err != nil {
+			// Non-fatal, just log
+			return ErrorMsg{err: fmt.Errorf("failed to load groups: %w", err)}
+		}
+		return GroupManagerReadyMsg{manager: gm}
+	}
+}
+
+func fetchContainers(client *docker.Client) tea.Cmd {
+	return func() tea.Msg {
+		if client == nil {
+			return nil
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		containers, err := client.ListContainers(ctx, true)
+		if err != nil {
+			return ErrorMsg{err: err}
+		}
+
+		return ContainersLoadedMsg{containers: containers}
+	}
+}
+
+func startContainer(client *docker.Client, containerID string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		err := client.StartContainer(ctx, containerID)
+		return ContainerStartedMsg{containerID: containerID, err: err}
+	}
+}
+
+// startupLogLines is how many log lines are shown when a container fails to start
+const startupLogLines = 50
+
+// startupCheckDelay is how long a container must stay up to count as started
+const startupCheckDelay = 2 * time.Second
+
+func scheduleStartupCheck(containerID string) tea.Cmd {
+	return tea.Tick(startupCheckDelay, func(t time.Time) tea.Msg {
+		return StartupCheckMsg{containerID: containerID}
+	})
+}
+
+// checkStartup reports a container that exited right after being started
+func checkStartup(client *docker.Client, containerID string) tea.Cmd {
+	return func() tea.Msg {
+		if client == nil {
+			return nil
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		exited, exitCode, stateErr, err := client.GetExitState(ctx, containerID)
+		if err != nil || !exited {
+			return nil
+		}
+		// One-shot containers legitimately exit right away
+		if exitCode == 0 && stateErr == "" {
+			return nil
+		}
+
+		reason := fmt.Sprintf("Container exited immediately with code %d", exitCode)
+		if stateErr != "" {
+			reason += ": " + stateErr
+		}
+		logs, _ := client.TailLogs(ctx, containerID, startupLogLines)
+		return ContainerStartFailedMsg{containerID: containerID, reason: reason, logs: logs}
+	}
+}
+
+// fetchStartupLogs collects the last log lines of a container that failed to start
+func fetchStartupLogs(client *docker.Client, containerID, reason string) tea.Cmd {
+	return func() tea.Msg {
+		if client == nil {
+			return nil
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		logs, _ := client.TailLogs(ctx, containerID, startupLogLines)
+		return ContainerStartFailedMsg{containerID: containerID, reason: reason, logs: logs}
+	}
+}
+
+func stopContainer(client *docker.Client, containerID string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		err := client.StopContainer(ctx, containerID, 10)
+		return ContainerStoppedMsg{containerID: containerID, err: err}
+	}
+}
+
+func restartContainer(client *docker.Client, containerID string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		err := client.RestartContainer(ctx, containerID, 10)
+		return ContainerRestartedMsg{containerID: containerID, err: err}
+	}
+}
+
+// noteInput records keyboard/mouse activity, leaving idle mode
+func (a *App) noteInput() {
+	a.lastInput = time.Now()
+	if a.idle {
+		a.idle = false
+		a.sidebar.SetIdle(false)
+	}
+}
+
+// updateIdle enters idle mode once there's been no input for idleAfter
+func (a *App) updateIdle() {
+	if a.idle || a.idleAfter <= 0 || a.refreshInterval >= config.IdleRefreshInterval {
+		return
+	}
+	if time.Since(a.lastInput) >= a.idleAfter {
+		a.idle = true
+		a.sidebar.SetIdle(true)
+	}
+}
+
+// tickRefresh schedules the next auto-refresh, or nothing in manual mode
+func tickRefresh(interval time.Duration) tea.Cmd {
+	if interval <= 0 {
+		return nil
+	}
+	return tea.Tick(interval, func(t time.Time) tea.Msg {
+		return RefreshTickMsg{}
+	})
+}
+
+// track runs cmd as a long operation: it's listed in the footer under label
+// until its message arrives
+func (a *App) track(label string, cmd tea.Cmd) tea.Cmd {
+	a.nextOperationID++
+	id := a.nextOperationID
+	a.operations = append(a.operations, operation{id: id, label: label, started: time.Now()})
+	done := func() tea.Msg {
+		return OperationDoneMsg{id: id, msg: cmd()}
+	}
+	return tea.Batch(done, a.spin())
+}
+
+// trackProgress is track for long operations that report how far along
+// they are, e.g. the bytes written so far
+func (a *App) trackProgress(label string, progress func() string, cmd tea.Cmd) tea.Cmd {
+	tracked := a.track(label, cmd)
+	a.operations[len(a.operations)-1].progress = progress
+	return tracked
+}
+
+// busy returns true while a tracked operation or a pull is in flight
+func (a *App) busy() bool {
+	return len(a.operations) > 0 || a.pullProgressChan != nil || a.batchPullChan != nil
+}
+
+// spin starts the footer spinner, which stops by itself once nothing is busy
+func (a *App) spin() tea.Cmd {
+	if a.spinning {
+		return nil
+	}
+	a.spinning = true
+	return a.spinner.Tick
+}
+
+func clearStatus(duration time.Duration) tea.Cmd {
+	return tea.Tick(duration, func(t time.Time) tea.Msg {
+		return ClearStatusMsg{}
+	})
+}
+
+func fetchImages(client *docker.Client) tea.Cmd {
+	return func() tea.Msg {
+		if client == nil {
+			return nil
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		images, err := client.ListImages(ctx)
+		if err != nil {
+			return ErrorMsg{err: err}
+		}
+
+		return ImagesLoadedMsg{images: images}
+	}
+}
+
+func removeImage(client *docker.Client, imageID string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		err := client.RemoveImage(ctx, imageID, true)
+		return ImageRemovedMsg{imageID: imageID, err: err}
+	}
+}
+
+func removeImagesBulk(client *docker.Client, images []models.Image) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+		defer cancel()
+
+		var failed int
+		for _, img := range images {
+			// Use force=true since we've already validated none are in use by containers
+			// This handles parent/child image dependencies
+			err := client.RemoveImage(ctx, img.ID, true)
+			if err != nil {
+				failed++
+			}
+		}
+
+		return ImagesBulkRemovedMsg{
+			count:  len(images),
+			failed: failed,
+		}
+	}
+}
+
+func tagImage(client *docker.Client, source, target string, untag bool) tea.Cmd {
+	return func() tea.Msg {
+		if client == nil {
+			return ErrorMsg{err: fmt.Errorf("docker client not initialized")}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		err := client.TagImage(ctx, source, target, untag)
+		return ImageTaggedMsg{source: source, target: target, untag: untag, err: err}
+	}
+}
+
+// quickRun starts a container from an image in the background
+func quickRun(client *docker.Client, image, name string, ports []string, detach, autoRemove bool) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		id, actualName, err := client.QuickRun(ctx, image, name, ports, autoRemove)
+		return QuickRunStartedMsg{image: image, id: id, name: actualName, detach: detach, err: err}
+	}
+}
+
+// loadImage loads the images of a tar archive
+func loadImage(client *docker.Client, path string, read, total *atomic.Int64) tea.Cmd {
+	return func() tea.Msg {
+		if client == nil {
+			return ErrorMsg{err: fmt.Errorf("docker client not initialized")}
+		}
+
+		loaded, err := client.LoadImage(context.Background(), path, read, total)
+		return ArchiveLoadedMsg{path: path, loaded: loaded, err: err}
+	}
+}
+
+// saveImage writes an image to a tar archive, counting the bytes in written
+func saveImage(client *docker.Client, ref, path string, written *atomic.Int64) tea.Cmd {
+	return func() tea.Msg {
+		if client == nil {
+			return ErrorMsg{err: fmt.Errorf("docker client not initialized")}
+		}
+
+		err := client.SaveImage(context.Background(), ref, path, written)
+		return ImageSavedMsg{ref: ref, path: path, size: written.Load(), err: err}
+	}
+}
+
+// openVulnerabilities shows the findings of an image scan
+func (a *App) openVulnerabilities(scan *models.VulnScan) {
+	a.vulnsView.SetScan(scan)
+	if a.state.CurrentView != models.ViewVulnerabilities {
+		a.state.PreviousView = a.state.CurrentView
+		a.state.CurrentView = models.ViewVulnerabilities
+	}
+}
+
+func scanImage(client *docker.Client, img models.Image) tea.Cmd {
+	return func() tea.Msg {
+		if client == nil {
+			return ErrorMsg{err: fmt.Errorf("docker client not initialized")}
+		}
+
+		// The first trivy run downloads its vulnerability database
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+		defer cancel()
+
+		scan, err := client.ScanImage(ctx, img)
+		return VulnScanMsg{image: img, scan: scan, err: err}
+	}
+}
+
+func pruneImages(client *docker.Client, all bool, keep map[string]bool) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+		defer cancel()
+
+		count, spaceFreed, err := client.PruneImages(ctx, all, keep)
+		return ImagesPrunedMsg{
+			count:      count,
+			spaceFreed: spaceFreed,
+			all:        all,
+			err:        err,
+		}
+	}
+}
+
+func loadGroups(groupManager *config.GroupManager) tea.Cmd {
+	return func() tea.Msg {
+		if groupManager == nil {
+			return nil
+		}
+
+		groups := groupManager.GetAllGroups()
+		return GroupsLoadedMsg{groups: groups}
+	}
+}
+
+// resolveGroupMembers follows group members recreated outside doui to their
+// new ID, reloading the groups if one changed
+func resolveGroupMembers(groupManager *config.GroupManager, containers []models.Container) tea.Cmd {
+	return func() tea.Msg {
+		if groupManager == nil {
+			return nil
+		}
+
+		changed, err := groupManager.ResolveContainers(containers)
+		if err != nil || !changed {
+			return nil
+		}
+		return GroupsLoadedMsg{groups: groupManager.GetAllGroups()}
+	}
+}
+
+// resolveGroupMembersNow lists the containers and follows group members
+// recreated outside doui, so a group operation reaches the current containers
+func resolveGroupMembersNow(ctx context.Context, client *docker.Client, groupManager *config.GroupManager) {
+	if containers, err := client.ListContainers(ctx, true); err == nil {
+		_, _ = groupManager.ResolveContainers(containers)
+	}
+}
+
+func startGroup(client *docker.Client, groupManager *config.GroupManager, groupID string) tea.Cmd {
+	return func() tea.Msg {
+		if client == nil || groupManager == nil {
+			return nil
+		}
+
+		// Longer timeout to allow waiting for dependencies to become healthy
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+
+		start := func(ctx context.Context, containerID string) error {
+			return client.StartContainer(ctx, containerID)
+		}
+		waitHealthy := func(ctx context.Context, containerID string) error {
+			return client.WaitForHealthy(ctx, containerID)
+		}
+
+		resolveGroupMembersNow(ctx, client, groupManager)
+		err := groupManager.ExecuteGroupStartOrdered(ctx, groupID, start, waitHealthy)
+		return GroupStartedMsg{groupID: groupID, err: err}
+	}
+}
+
+// toggleGroup stops a group if any of its containers runs, and starts it
+// otherwise
+func toggleGroup(client *docker.Client, groupManager *config.GroupManager, groupID string) tea.Cmd {
+	return func() tea.Msg {
+		if client == nil || groupManager == nil {
+			return ErrorMsg{err: fmt.Errorf("docker client not initialized")}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		containers, err := client.ListContainers(ctx, true)
+		if err != nil {
+			return GroupStartedMsg{groupID: groupID, err: err}
+		}
+		_, _ = groupManager.ResolveContainers(containers)
+		ids, err := groupManager.GroupContainerIDs(groupID)
+		if err != nil {
+			return GroupStartedMsg{groupID: groupID, err: err}
+		}
+		for _, c := range containers {
+			if c.State == "running" && slices.Contains(ids, c.ID) {
+				return stopGroup(client, groupManager, groupID)()
+			}
+		}
+		return startGroup(client, groupManager, groupID)()
+	}
+}
+
+func stopGroup(client *docker.Client, groupManager *config.GroupManager, groupID string) tea.Cmd {
+	return func() tea.Msg {
+		if client == nil || groupManager == nil {
+			return nil
+		}
+
+		group := groupManager.GetGroup(groupID)
+		if group == nil {
+			return GroupStoppedMsg{groupID: groupID, err: fmt.Errorf("group not found: %s", groupID)}
+		}
+
+		// Ordered stops and long grace periods need more than the usual timeout
+		ctx, cancel := context.WithTimeout(context.Background(), group.StopTimeout())
+		defer cancel()
+
+		stop := func(ctx context.Context, containerID string, gracePeriod int) error {
+			return client.StopContainer(ctx, containerID, gracePeriod)
+		}
+
+		resolveGroupMembersNow(ctx, client, groupManager)
+		err := groupManager.ExecuteGroupStop(ctx, groupID, stop)
+		return GroupStoppedMsg{groupID: groupID, err: err}
+	}
+}
+
+// restartGroup restarts all containers of a group in parallel
+func restartGroup(client *docker.Client, groupManager *config.GroupManager, groupID string) tea.Cmd {
+	return func() tea.Msg {
+		if client == nil || groupManager == nil {
+			return nil
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		restart := func(ctx context.Context, containerID string) error {
+			return client.RestartContainer(ctx, containerID, 10)
+		}
+
+		resolveGroupMembersNow(ctx, client, groupManager)
+		err := groupManager.ExecuteGroupOperation(ctx, groupID, restart)
+		return GroupRestartedMsg{groupID: groupID, err: err}
+	}
+}
+
+func addContainerToGroup(gm *config.GroupManager, groupID, containerID string) tea.Cmd {
+	return func() tea.Msg {
+		err := gm.AddContainerToGroup(groupID, containerID)
+		return ContainerAddedToGroupMsg{
+			groupID:     groupID,
+			containerID: containerID,
+			err:         err,
+		}
+	}
+}
+
+func removeContainerFromGroup(gm *config.GroupManager, groupID, containerID string) tea.Cmd {
+	return func() tea.Msg {
+		err := gm.RemoveContainerFromGroup(groupID, containerID)
+		return ContainerRemovedFromGroupMsg{
+			groupID:     groupID,
+			containerID: containerID,
+			err:         err,
+		}
+	}
+}
+
+func setContainerDependencies(gm *config.GroupManager, groupID, containerID string, dependsOn []string, waitHealthy bool, startDelay, stopGrace int) tea.Cmd {
+	return func() tea.Msg {
+		err := gm.SetContainerDependencies(groupID, containerID, dependsOn, waitHealthy, startDelay, stopGrace)
+		return GroupDependenciesSetMsg{
+			groupID:     groupID,
+			containerID: containerID,
+			err:         err,
+		}
+	}
+}
+
+func setGroupOrderedStop(gm *config.GroupManager, groupID string, ordered bool) tea.Cmd {
+	return func() tea.Msg {
+		err := gm.SetOrderedStop(groupID, ordered)
+		return GroupOrderedStopSetMsg{
+			groupID: groupID,
+			ordered: ordered,
+			err:     err,
+		}
+	}
+}
+
+func replaceContainerIDInGroups(gm *config.GroupManager, oldID, newID string) tea.Cmd {
+	return func() tea.Msg {
+		err := gm.ReplaceContainerID(oldID, newID)
+		return ContainerIDReplacedMsg{
+			oldID: oldID,
+			newID: newID,
+			err:   err,
+		}
+	}
+}
+
+func removeContainerFromAllGroups(gm *config.GroupManager, containerID string) tea.Cmd {
+	return func() tea.Msg {
+		err := gm.RemoveContainerFromAllGroups(containerID)
+		return ContainerRemovedFromAllGroupsMsg{
+			containerID: containerID,
+			err:         err,
+		}
+	}
+}
+
+func startLogStreaming(ctx context.Context, client *docker.Client, logsView *views.LogsView, container *models.Container) tea.Cmd {
+	// Set container synchronously to reset the view state before the async Cmd runs
+	// This prevents race conditions where View() is called with stale data
+	logsView.SetContainer(container.ID, container.Name)
+
+	streamCmd := func() tea.Msg {
+		if client == nil {
+			return nil
+		}
+
+		logsChan, errorChan := client.StreamLogs(ctx, container.ID, true, time.Time{}, "100")
+		logsView.StartStreaming(logsChan, errorChan)
+
+		// Return the first log wait command
+		return waitForLogEntry(logsChan, errorChan)()
+	}
+
+	// Disable mouse on enter for text selection, batch with stream command
+	return tea.Batch(tea.DisableMouse, streamCmd)
+}
+
+// startProjectLogStreaming streams the merged logs of a compose project
+// into the logs view
+func startProjectLogStreaming(ctx context.Context, client *docker.Client, logsView *views.LogsView, project models.ComposeProject) tea.Cmd {
+	logsView.SetProject(project.Name)
+
+	streamCmd := func() tea.Msg {
+		if client == nil {
+			return nil
+		}
+
+		logsChan, errorChan := client.StreamProjectLogs(ctx, project, "100")
+		logsView.StartStreaming(logsChan, errorChan)
+		return waitForLogEntry(logsChan, errorChan)()
+	}
+
+	return tea.Batch(tea.DisableMouse, streamCmd)
+}
+
+// resumeLogStreaming continues a paused logs view from where it stopped
+func resumeLogStreaming(ctx context.Context, client *docker.Client, logsView *views.LogsView) tea.Cmd {
+	containerID, since := logsView.ResumePoint()
+	return func() tea.Msg {
+		if client == nil {
+			return nil
+		}
+
+		logsChan, errorChan := client.StreamLogs(ctx, containerID, true, since, "all")
+		logsView.StartStreaming(logsChan, errorChan)
+		return waitForLogEntry(logsChan, errorChan)()
+	}
+}
+
+// renderComposeConfigCheck lists compose config errors and warnings, clipped
+// to fit the screen
+func (a *App) renderComposeConfigCheck(check *models.ComposeConfigCheck) string {
+	maxWidth := a.width - 14
+	clip := func(line string) string {
+		if maxWidth > 0 && len([]rune(line)) > maxWidth {
+			return string([]rune(line)[:maxWidth-1]) + "…"
+		}
+		return line
+	}
+
+	var lines []string
+	for _, file := range check.Files {
+		if file == check.Override {
+			lines = append(lines, styles.WarningStyle.Render(clip(file+" (override, not in the last up)")))
+			continue
+		}
+		lines = append(lines, styles.DescStyle.Render(clip(file)))
+	}
+	if len(check.AllProfiles) > 0 {
+		active := "none"
+		if len(check.Profiles) > 0 {
+			active = strings.Join(check.Profiles, ", ")
+		}
+		lines = append(lines, styles.DescStyle.Render(clip(fmt.Sprintf("Profiles: %s (of %s)", active, strings.Join(check.AllProfiles, ", ")))))
+	}
+	if len(lines) > 0 {
+		lines = append(lines, "")
+	}
+	for _, e := range check.Errors {
+		lines = append(lines, styles.ErrorStyle.Render(clip("✗ "+e)))
+	}
+	for _, w := range check.Warnings {
+		lines = append(lines, styles.WarningStyle.Render(clip("⚠ "+w)))
+	}
+
+	if maxLines := a.height - 12; maxLines > 0 && len(lines) > maxLines {
+		hidden := len(lines) - maxLines + 1
+		lines = append(lines[:maxLines-1], styles.DescStyle.Render(fmt.Sprintf("... %d more", hidden)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderImageProvenance shows an image's details and what can be verified
+// about its origin (OCI labels, attestations, cosign signatures)
+func (a *App) renderImageProvenance(img models.Image, p *models.ImageProvenance) string {
+	maxWidth := a.width - 14
+	clip := func(line string) string {
+		if maxWidth > 0 && len([]rune(line)) > maxWidth {
+			return string([]rune(line)[:maxWidth-1]) + "…"
+		}
+		return line
+	}
+	row := func(label, value string) string {
+		return clip(styles.KeyStyle.Render(fmt.Sprintf("%-13s", label)) + value)
+	}
+
+	var lines []string
+	lines = append(lines, row("ID:", img.GetShortID()))
+	if p.Digest != "" {
+		lines = append(lines, row("Digest:", p.Digest))
+	} else {
+		lines = append(lines, row("Digest:", styles.DescStyle.Render("none (built locally or never pushed)")))
+	}
+	lines = append(lines, row("Created:", img.Created.Format("2006-01-02 15:04")))
+	if p.Platform != "" {
+		lines = append(lines, row("Platform:", p.Platform))
+	}
+	switch {
+	case p.Digest == "":
+		// Built locally, there is no manifest list to look at
+	case p.PlatformsErr != "":
+		lines = append(lines, row("Available:", styles.DescStyle.Render("couldn't check registry: "+p.PlatformsErr)))
+	case len(p.Platforms) > 1:
+		available := make([]string, 0, len(p.Platforms))
+		for _, platform := range p.Platforms {
+			if platform == p.Platform || strings.HasPrefix(platform, p.Platform+"/") {
+				platform = styles.SuccessStyle.Render(platform)
+			}
+			available = append(available, platform)
+		}
+		lines = append(lines, row("Available:", strings.Join(available, ", ")))
+		lines = append(lines, styles.DescStyle.Render(clip(fmt.Sprintf("Multi-arch image, pull another variant with %s and a platform", keys.Label(keys.Map.PullImage)))))
+	case len(p.Platforms) == 1:
+		lines = append(lines, row("Available:", p.Platforms[0]+styles.DescStyle.Render(" only (single platform image)")))
+	}
+	if img.ArchWarning != "" {
+		lines = append(lines, styles.WarningStyle.Render(clip("⚠ "+img.ArchWarning)))
+	}
+
+	lines = append(lines, "", styles.SubtitleStyle.Render("Provenance"))
+	if len(p.OCILabels) == 0 {
+		lines = append(lines, styles.DescStyle.Render("No OCI labels (source, revision...) on this image"))
+	}
+	for _, name := range p.SortedOCILabels() {
+		lines = append(lines, row(name+":", p.OCILabels[name]))
+	}
+
+	switch {
+	case !p.AttestationsKnown:
+		lines = append(lines, row("Attestations:", styles.DescStyle.Render("unknown (needs the containerd image store)")))
+	case p.Attestations == 0:
+		lines = append(lines, row("Attestations:", styles.WarningStyle.Render("none")))
+	default:
+		lines = append(lines, row("Attestations:", styles.SuccessStyle.Render(fmt.Sprintf("%d (SBOM/provenance)", p.Attestations))))
+	}
+
+	lines = append(lines, "", styles.SubtitleStyle.Render("Signatures"))
+	switch {
+	case !p.CosignChecked:
+		lines = append(lines, row("Cosign:", styles.DescStyle.Render("not checked, image has no registry digest")))
+	case p.CosignErr != "":
+		lines = append(lines, row("Cosign:", styles.ErrorStyle.Render("couldn't check registry: "+p.CosignErr)))
+	case p.CosignSignature != "":
+		lines = append(lines, row("Cosign:", styles.SuccessStyle.Render("signature found")+" "+p.CosignSignature))
+	default:
+		lines = append(lines, row("Cosign:", styles.WarningStyle.Render("no signature found")))
+	}
+	if p.CosignAttestation != "" {
+		lines = append(lines, row("Cosign att:", p.CosignAttestation))
+	}
+	lines = append(lines, styles.DescStyle.Render(clip("Found signatures aren't verified here, use `cosign verify` to check the signer.")))
+
+	return strings.Join(lines, "\n")
+}
+
+// renderImageComparison shows how the newer of two images differs from the older
+func (a *App) renderImageComparison(cmp models.ImageComparison) string {
+	const maxItems = 10
+	maxWidth := a.width - 14
+	clip := func(line string) string {
+		if maxWidth > 0 && len([]rune(line)) > maxWidth {
+			return string([]rune(line)[:maxWidth-1]) + "…"
+		}
+		return line
+	}
+	row := func(label, value string) string {
+		return clip(styles.KeyStyle.Render(fmt.Sprintf("%-13s", label)) + value)
+	}
+	shortLayer := func(layer string) string {
+		layer = strings.TrimPrefix(layer, "sha256:")
+		return layer[:min(12, len(layer))]
+	}
+
+	var lines []string
+	lines = append(lines,
+		row("A (older):", fmt.Sprintf("%s  %s", cmp.A.Ref, utils.FormatBytes(cmp.A.Size))),
+		row("B (newer):", fmt.Sprintf("%s  %s", cmp.B.Ref, utils.FormatBytes(cmp.B.Size))),
+		"",
+	)
+	if cmp.SameContent() {
+		lines = append(lines, styles.SuccessStyle.Render("✓ Same layers and config, the rebuild changed nothing"), "")
+	}
+
+	delta := utils.FormatBytes(cmp.SizeDelta)
+	switch {
+	case cmp.SizeDelta > 0:
+		delta = styles.WarningStyle.Render("+" + delta)
+	case cmp.SizeDelta < 0:
+		delta = styles.SuccessStyle.Render("-" + utils.FormatBytes(-cmp.SizeDelta))
+	}
+	lines = append(lines, row("Size:", delta))
+
+	lines = append(lines, "", styles.SubtitleStyle.Render("Layers"))
+	lines = append(lines,
+		row("Shared:", fmt.Sprintf("%d of %d / %d, the first %d in the same order", cmp.Shared, len(cmp.A.Layers), len(cmp.B.Layers), cmp.CommonBase)),
+	)
+	for _, side := range []struct {
+		label  string
+		layers []string
+	}{{"Only in A:", cmp.OnlyA}, {"Only in B:", cmp.OnlyB}} {
+		if len(side.layers) == 0 {
+			continue
+		}
+		short := make([]string, 0, maxItems)
+		for _, layer := range side.layers[:min(maxItems, len(side.layers))] {
+			short = append(short, shortLayer(layer))
+		}
+		value := fmt.Sprintf("%d: %s", len(side.layers), strings.Join(short, " "))
+		if len(side.layers) > maxItems {
+			value += " …"
+		}
+		lines = append(lines, row(side.label, value))
+	}
+
+	lines = append(lines, "", styles.SubtitleStyle.Render("Config"))
+	if len(cmp.ConfigDiffs) == 0 {
+		lines = append(lines, styles.DescStyle.Render("Env, labels, ports, cmd and entrypoint are the same"))
+	}
+	unset := styles.DescStyle.Render("(unset)")
+	for _, diff := range cmp.ConfigDiffs[:min(maxItems, len(cmp.ConfigDiffs))] {
+		before, after := diff.A, diff.B
+		if before == "" {
+			before = unset
+		}
+		if after == "" {
+			after = unset
+		}
+		lines = append(lines, clip(styles.KeyStyle.Render(diff.Key+":")+" "+before+" → "+after))
+	}
+	if len(cmp.ConfigDiffs) > maxItems {
+		lines = append(lines, styles.DescStyle.Render(fmt.Sprintf("…and %d more", len(cmp.ConfigDiffs)-maxItems)))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// startFileTail streams a file inside the container into the logs view
+func startFileTail(ctx context.Context, client *docker.Client, logsView *views.LogsView, container *models.Container, path string) tea.Cmd {
+	logsView.SetFile(container.ID, container.Name, path)
+
+	streamCmd := func() tea.Msg {
+		if client == nil {
+			return nil
+		}
+
+		logsChan, errorChan := client.TailFile(ctx, container.ID, path, 100)
+		logsView.StartStreaming(logsChan, errorChan)
+
+		return waitForLogEntry(logsChan, errorChan)()
+	}
+
+	return tea.Batch(tea.DisableMouse, streamCmd)
+}
+
+// stopFileTail stops tailing a file in the container, if one is being tailed
+func (a *App) stopFileTail() {
+	if a.fileTailCancel != nil {
+		a.fileTailCancel()
+		a.fileTailCancel = nil
+	}
+}
+
+// streamContext stops the current logs/stats stream and returns the context
+// for the next one
+func (a *App) streamContext() context.Context {
+	a.stopStream()
+	ctx, cancel := context.WithCancel(context.Background())
+	a.streamCancel = cancel
+	return ctx
+}
+
+// stopStream stops the logs/stats stream, if one is running
+func (a *App) stopStream() {
+	if a.streamCancel != nil {
+		a.streamCancel()
+		a.streamCancel = nil
+	}
+}
+
+// pauseStreams stops sampling logs/stats while the terminal is unfocused
+// (file tails keep running, tail -F can't resume where it stopped, and so do
+// project logs, merged from several containers)
+func (a *App) pauseStreams() {
+	switch {
+	case a.state.CurrentView == models.ViewLogs && !a.logsView.IsTailingFile() && !a.logsView.IsProjectLogs() && a.streamCancel != nil:
+		a.stopStream()
+		a.logsView.SetPaused(true)
+	case a.state.CurrentView == models.ViewStats && a.streamCancel != nil:
+		a.stopStream()
+		a.statsView.SetPaused(true)
+	}
+}
+
+// resumeStreams restarts the streams paused by pauseStreams
+func (a *App) resumeStreams() tea.Cmd {
+	switch {
+	case a.state.CurrentView == models.ViewLogs && a.logsView.IsPaused():
+		a.logsView.SetPaused(false)
+		return resumeLogStreaming(a.streamContext(), a.docker, a.logsView)
+	case a.state.CurrentView == models.ViewStats && a.statsView.IsPaused():
+		a.statsView.SetPaused(false)
+		ctx := a.streamContext()
+		return tea.Batch(resumeStatsStreaming(ctx, a.docker, a.statsView), startStatsEvents(ctx, a.docker, a.statsView))
+	}
+	return nil
+}
+
+func waitForLogEntry(logsChan <-chan docker.LogEntry, errorChan <-chan error) tea.Cmd {
+	return func() tea.Msg {
+		select {
+		case entry, ok := <-logsChan:
+			if !ok {
+				return nil
+			}
+			return entry
+		case err, ok := <-errorChan:
+			if !ok {
+				return nil
+			}
+			return ErrorMsg{err: err}
+		}
+	}
+}
+
+func startStatsStreaming(ctx context.Context, client *docker.Client, statsView *views.StatsView, container *models.Container) tea.Cmd {
+	// Set container synchronously to reset the view state before the async Cmd runs
+	// This prevents race conditions where View() is called with stale data
+	statsView.SetContainer(container.ID, container.Name)
+
+	return resumeStatsStreaming(ctx, client, statsView)
+}
+
+// resumeStatsStreaming (re)starts the stats stream of the container shown in
+// the stats view, keeping its history
+func resumeStatsStreaming(ctx context.Context, client *docker.Client, statsView *views.StatsView) tea.Cmd {
+	containerID := statsView.ContainerID()
+	return func() tea.Msg {
+		if client == nil {
+			return nil
+		}
+
+		statsChan, errorChan := client.StreamStats(ctx, containerID)
+		statsView.StartStreaming(statsChan, errorChan)
+
+		// Return the first stats wait command
+		return waitForStats(statsChan, errorChan)()
+	}
+}
+
+// startStatsEvents watches the OOM and die events of the container shown in
+// the stats view, until ctx (the stats stream's) is cancelled
+func startStatsEvents(ctx context.Context, client *docker.Client, statsView *views.StatsView) tea.Cmd {
+	containerID := statsView.ContainerID()
+	return func() tea.Msg {
+		if client == nil {
+			return nil
+		}
+
+		eventChan, errChan := client.StreamContainerEvents(ctx, containerID)
+		statsView.StartEventStreaming(eventChan, errChan)
+
+		// Return the first event wait command
+		return waitForContainerEvent(eventChan, errChan)()
+	}
+}
+
+// waitForContainerEvent waits for the next event of the stats view's
+// container, errors of the stream are reported as ErrorMsg
+func waitForContainerEvent(eventChan <-chan *models.ContainerEvent, errChan <-chan error) tea.Cmd {
+	return func() tea.Msg {
+		select {
+		case event, ok := <-eventChan:
+			if !ok {
+				return nil
+			}
+			return event
+		case err, ok := <-errChan:
+			if !ok {
+				return nil
+			}
+			return ErrorMsg{err: err}
+		}
+	}
+}
+
+func waitForStats(statsChan <-chan *models.ContainerStats, errorChan <-chan error) tea.Cmd {
+	return func() tea.Msg {
+		select {
+		case stats, ok := <-statsChan:
+			if !ok {
+				return nil
+			}
+			return stats
+		case err, ok := <-errorChan:
+			if !ok {
+				return nil
+			}
+			return ErrorMsg{err: err}
+		}
+	}
+}
+
+func execShell(containerID, containerName string) tea.Cmd {
+	// Try sh first (most compatible)
+	cmd := exec.Command("docker", "exec", "-it", containerID, "sh")
+
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		if err != nil {
+			return ErrorMsg{err: fmt.Errorf("failed to exec shell in %s: %w", containerName, err)}
+		}
+		return StatusMsg{message: fmt.Sprintf("Exited shell for %s", containerName)}
+	})
+}
+
+func removeContainer(client *docker.Client, containerID string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		err := client.RemoveContainer(ctx, containerID, true) // force=true
+		return ContainerRemovedMsg{containerID: containerID, err: err}
+	}
+}
+
+func deleteGroup(groupManager *config.GroupManager, groupID string) tea.Cmd {
+	return func() tea.Msg {
+		if groupManager == nil {
+			return ErrorMsg{err: fmt.Errorf("group manager not initialized")}
+		}
+
+		err := groupManager.DeleteGroup(groupID)
+		if err != nil {
+			return ErrorMsg{err: fmt.Errorf("failed to delete group: %w", err)}
+		}
+
+		return StatusMsg{message: "Group deleted successfully"}
+	}
+}
+
+func createGroup(groupManager *config.GroupManager, name, description, color string, containerIDs []string) tea.Cmd {
 	return func() tea.Msg {
-		client, err := docker.NewClient()
+		if groupManager == nil {
+			return ErrorMsg{err: fmt.Errorf("group manager not initialized")}
+		}
+
+		_, err := groupManager.CreateGroup(name, description, color, containerIDs)
 		if err != nil {
-			return ErrorMsg{err: fmt.Errorf("failed to initialize Docker client: %w", err)}
+			return ErrorMsg{err: fmt.Errorf("failed to create group: %w", err)}
 		}
-		return DockerClientReadyMsg{client: client}
+
+		// Return a message that will trigger group reload
+		return GroupCreatedMsg{name: name}
 	}
 }
 
-func initGroupManager() tea.Cmd {
+func editGroup(groupManager *config.GroupManager, details models.Group) tea.Cmd {
 	return func() tea.Msg {
-		gm, err := config.NewGroupManager()
-		if err != nil {
-			// Non-fatal, just log
-			return ErrorMsg{err: fmt.Errorf("failed to load groups: %w", err)}
+		if groupManager == nil {
+			return ErrorMsg{err: fmt.Errorf("group manager not initialized")}
 		}
-		return GroupManagerReadyMsg{manager: gm}
+
+		err := groupManager.EditGroup(details)
+		return GroupEditedMsg{name: details.Name, err: err}
 	}
 }
 
-func fetchContainers(client *docker.Client) tea.Cmd {
+// Volume commands
+func fetchVolumes(client *docker.Client) tea.Cmd {
 	return func() tea.Msg {
 		if client == nil {
 			return nil
@@ -1584,553 +6604,1230 @@ func fetchContainers(client *docker.Client) tea.Cmd {
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
 
-		containers, err := client.ListContainers(ctx, true)
+		volumes, err := client.ListVolumes(ctx)
 		if err != nil {
 			return ErrorMsg{err: err}
 		}
 
-		return ContainersLoadedMsg{containers: containers}
+		return VolumesLoadedMsg{volumes: volumes}
 	}
 }
 
-func startContainer(client *docker.Client, containerID string) tea.Cmd {
+// compareImages loads the layers and config of two images and compares them,
+// older first
+func compareImages(client *docker.Client, older, newer models.Image) tea.Cmd {
 	return func() tea.Msg {
+		if client == nil {
+			return ErrorMsg{err: fmt.Errorf("docker client not initialized")}
+		}
+
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 
-		err := client.StartContainer(ctx, containerID)
-		return ContainerStartedMsg{containerID: containerID, err: err}
+		a, err := client.ImageSpec(ctx, older)
+		if err != nil {
+			return ImagesComparedMsg{err: err}
+		}
+		b, err := client.ImageSpec(ctx, newer)
+		if err != nil {
+			return ImagesComparedMsg{err: err}
+		}
+		return ImagesComparedMsg{comparison: models.CompareImages(a, b)}
 	}
 }
 
-func stopContainer(client *docker.Client, containerID string) tea.Cmd {
+func loadImageHistory(client *docker.Client, img models.Image) tea.Cmd {
 	return func() tea.Msg {
+		if client == nil {
+			return ErrorMsg{err: fmt.Errorf("docker client not initialized")}
+		}
+
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 
-		err := client.StopContainer(ctx, containerID, 10)
-		return ContainerStoppedMsg{containerID: containerID, err: err}
+		layers, err := client.ImageHistory(ctx, img.ID)
+		return ImageHistoryLoadedMsg{image: img, layers: layers, err: err}
 	}
 }
 
-func restartContainer(client *docker.Client, containerID string) tea.Cmd {
+// loadImageProvenance looks up signing/provenance info of an image, which
+// may involve asking the registry for cosign signatures
+func loadImageProvenance(client *docker.Client, img models.Image) tea.Cmd {
 	return func() tea.Msg {
+		if client == nil {
+			return ErrorMsg{err: fmt.Errorf("docker client not initialized")}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+		defer cancel()
+
+		return ImageProvenanceLoadedMsg{image: img, provenance: client.GetImageProvenance(ctx, img)}
+	}
+}
+
+// inspectNetwork loads the full configuration of a network
+func inspectNetwork(client *docker.Client, networkID string) tea.Cmd {
+	return func() tea.Msg {
+		if client == nil {
+			return ErrorMsg{err: fmt.Errorf("docker client not initialized")}
+		}
+
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 
-		err := client.RestartContainer(ctx, containerID, 10)
-		return ContainerRestartedMsg{containerID: containerID, err: err}
+		detail, err := client.InspectNetwork(ctx, networkID)
+		return NetworkInspectedMsg{detail: detail, err: err}
 	}
 }
 
-func tickRefresh() tea.Cmd {
-	return tea.Tick(2*time.Second, func(t time.Time) tea.Msg {
-		return RefreshTickMsg{}
+// fetchNetworkEndpoints loads the containers' attachments to a network
+func fetchNetworkEndpoints(client *docker.Client, networkID string) tea.Cmd {
+	return func() tea.Msg {
+		if client == nil {
+			return ErrorMsg{err: fmt.Errorf("docker client not initialized")}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		detail, err := client.InspectNetwork(ctx, networkID)
+		if err != nil {
+			return NetworkEndpointsLoadedMsg{networkID: networkID, err: err}
+		}
+		return NetworkEndpointsLoadedMsg{networkID: networkID, endpoints: detail.Endpoints}
+	}
+}
+
+// Plugin commands
+func fetchPlugins(client *docker.Client) tea.Cmd {
+	return func() tea.Msg {
+		if client == nil {
+			return nil
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		plugins, err := client.ListPlugins(ctx)
+		var unsupported *models.UnsupportedFeatureError
+		if errors.As(err, &unsupported) {
+			return PluginsLoadedMsg{unsupported: err.Error()}
+		}
+		if err != nil {
+			return ErrorMsg{err: err}
+		}
+
+		return PluginsLoadedMsg{plugins: plugins}
+	}
+}
+
+func enablePlugin(client *docker.Client, name string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		err := client.EnablePlugin(ctx, name)
+		return PluginToggledMsg{name: name, enabled: true, err: err}
+	}
+}
+
+func disablePlugin(client *docker.Client, name string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		// Not forced: the daemon refuses to disable a plugin that is in use
+		err := client.DisablePlugin(ctx, name, false)
+		return PluginToggledMsg{name: name, enabled: false, err: err}
+	}
+}
+
+func removePlugin(client *docker.Client, name string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		// Forced so enabled plugins can be removed too, the confirmation warns about it
+		err := client.RemovePlugin(ctx, name, true)
+		return PluginRemovedMsg{name: name, err: err}
+	}
+}
+
+func createVolume(client *docker.Client, name, driver string, labels, opts map[string]string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		created, err := client.CreateVolume(ctx, name, driver, labels, opts)
+		return VolumeCreatedMsg{name: created, err: err}
+	}
+}
+
+// fetchVolumeSizes measures the disk space used by each volume
+func fetchVolumeSizes(client *docker.Client) tea.Cmd {
+	return func() tea.Msg {
+		if client == nil {
+			return ErrorMsg{err: fmt.Errorf("docker client not initialized")}
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer cancel()
+
+		sizes, err := client.VolumeSizes(ctx)
+		return VolumeSizesLoadedMsg{sizes: sizes, err: err}
+	}
+}
+
+// exportGroupCompose writes the containers of a group, and of the groups it
+// includes, as a compose file
+func exportGroupCompose(client *docker.Client, groupManager *config.GroupManager, group models.Group, path string) tea.Cmd {
+	return func() tea.Msg {
+		if client == nil || groupManager == nil {
+			return ErrorMsg{err: fmt.Errorf("docker client not initialized")}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		resolveGroupMembersNow(ctx, client, groupManager)
+		containerIDs, err := groupManager.GroupContainerIDs(group.ID)
+		if err != nil {
+			return GroupComposeExportedMsg{name: group.Name, path: path, err: err}
+		}
+		header := fmt.Sprintf("Generated by doui from group %s on %s", group.Name, time.Now().Format("2006-01-02"))
+		services, err := client.ExportCompose(ctx, containerIDs, path, header)
+		return GroupComposeExportedMsg{name: group.Name, path: path, services: services, err: err}
+	}
+}
+
+// backupVolume writes a volume to a tar archive, counting the bytes in written
+func backupVolume(client *docker.Client, name, path string, written *atomic.Int64) tea.Cmd {
+	return func() tea.Msg {
+		if client == nil {
+			return ErrorMsg{err: fmt.Errorf("docker client not initialized")}
+		}
+
+		size, err := client.BackupVolume(context.Background(), name, path, written)
+		return VolumeBackedUpMsg{name: name, path: path, size: size, err: err}
+	}
+}
+
+// restoreVolume extracts a tar archive into a volume, showing the bytes sent
+// in the footer
+func (a *App) restoreVolume(name, path string) tea.Cmd {
+	read, total := new(atomic.Int64), new(atomic.Int64)
+	progress := func() string {
+		return fmt.Sprintf("%s of %s", utils.FormatBytes(read.Load()), utils.FormatBytes(total.Load()))
+	}
+	client := a.docker
+	return a.trackProgress(fmt.Sprintf("Restoring %s", name), progress, func() tea.Msg {
+		if client == nil {
+			return ErrorMsg{err: fmt.Errorf("docker client not initialized")}
+		}
+
+		created, err := client.RestoreVolume(context.Background(), name, path, read, total)
+		return VolumeRestoredMsg{name: name, path: path, created: created, err: err}
 	})
 }
 
-func clearStatus(duration time.Duration) tea.Cmd {
-	return tea.Tick(duration, func(t time.Time) tea.Msg {
-		return ClearStatusMsg{}
-	})
+func removeVolume(client *docker.Client, volumeName string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		err := client.RemoveVolume(ctx, volumeName, false)
+		return VolumeRemovedMsg{volumeName: volumeName, err: err}
+	}
+}
+
+// removeVolumes removes the given volumes, e.g. unused anonymous ones
+func removeVolumes(client *docker.Client, names []string) tea.Cmd {
+	return func() tea.Msg {
+		if client == nil {
+			return ErrorMsg{err: fmt.Errorf("docker client not initialized")}
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+
+		removed, err := client.RemoveVolumes(ctx, names)
+		return VolumesRemovedMsg{removed: removed, err: err}
+	}
+}
+
+func pruneVolumes(client *docker.Client) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		_, err := client.PruneUnusedVolumes(ctx)
+		if err != nil {
+			return ErrorMsg{err: err}
+		}
+
+		return StatusMsg{message: "Unused volumes pruned successfully"}
+	}
+}
+
+// Compose commands
+func fetchComposeProjects(client *docker.Client) tea.Cmd {
+	return func() tea.Msg {
+		if client == nil {
+			return nil
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		projects, err := client.ListComposeProjects(ctx)
+		if err != nil {
+			return ErrorMsg{err: err}
+		}
+
+		return ComposeProjectsLoadedMsg{projects: projects}
+	}
+}
+
+func startComposeProject(client *docker.Client, projectName string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		err := client.StartComposeProject(ctx, projectName)
+		return ComposeProjectStartedMsg{projectName: projectName, err: err}
+	}
+}
+
+func stopComposeProject(client *docker.Client, projectName string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		err := client.StopComposeProject(ctx, projectName, 10)
+		return ComposeProjectStoppedMsg{projectName: projectName, err: err}
+	}
+}
+
+func restartComposeProject(client *docker.Client, projectName string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		err := client.RestartComposeProject(ctx, projectName, 10)
+		return ComposeProjectRestartedMsg{projectName: projectName, err: err}
+	}
+}
+
+// startImagePull starts an image pull with progress channel
+func startImagePull(client *docker.Client, imageName, platform string) (<-chan docker.PullProgress, context.CancelFunc, tea.Cmd) {
+	ctx, cancel := context.WithCancel(context.Background()) // No timeout - let Docker handle it
+	progressChan := client.PullImageWithProgress(ctx, imageName, platform)
+
+	return progressChan, cancel, waitForPullProgress(imageName, progressChan)
+}
+
+// endPull clears the state of a finished single image pull
+func (a *App) endPull() {
+	if a.pullCancel != nil {
+		a.pullCancel()
+	}
+	if a.modal != nil && a.modal == a.pullModal {
+		a.modal = nil
+		a.pendingDeleteType = ""
+	}
+	a.pullProgressChan = nil
+	a.pullCancel = nil
+	a.pullModal = nil
+	a.pullImageName = ""
+	a.pullProgress = ""
+	a.pullLayers = nil
+}
+
+// renderPullProgress renders a bar per layer of the running pull
+func (a *App) renderPullProgress() string {
+	if len(a.pullLayers) == 0 {
+		return styles.DescStyle.Render("Resolving " + a.pullImageName + "...")
+	}
+
+	const barWidth = 20
+	lines := make([]string, 0, len(a.pullLayers))
+	for _, layer := range a.pullLayers {
+		id := layer.ID
+		if len(id) > 12 {
+			id = id[:12]
+		}
+		line := fmt.Sprintf("%-12s  %-18s", id, layer.Status)
+		switch {
+		case layer.Status == "Pull complete" || layer.Status == "Already exists":
+			line = styles.SuccessStyle.Render(line)
+		case layer.Total > 0:
+			filled := int(float64(layer.Current) / float64(layer.Total) * barWidth)
+			filled = min(max(filled, 0), barWidth)
+			line += fmt.Sprintf("  %s %3.0f%%  %s / %s",
+				strings.Repeat("█", filled)+strings.Repeat("░", barWidth-filled),
+				float64(layer.Current)/float64(layer.Total)*100,
+				formatBytesShort(layer.Current), formatBytesShort(layer.Total))
+		default:
+			line = styles.DescStyle.Render(line)
+		}
+		lines = append(lines, line)
+	}
+	if maxLines := a.height - 12; maxLines > 0 && len(lines) > maxLines {
+		lines = append(lines[:maxLines-1], styles.DescStyle.Render(fmt.Sprintf("... %d more layers", len(lines)-maxLines+1)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// hubRateLimited returns true if pulling imageName failed on Docker Hub's
+// pull rate limit
+func hubRateLimited(imageName string, err error) bool {
+	return docker.IsRateLimitError(err) && models.ImageRegistry(imageName) == models.DockerHub
 }
 
-func fetchImages(client *docker.Client) tea.Cmd {
+// checkHubRateLimit looks up the Docker Hub limit to tell when pulls work again
+func checkHubRateLimit(client *docker.Client) tea.Cmd {
 	return func() tea.Msg {
-		if client == nil {
-			return nil
-		}
-
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 
-		images, err := client.ListImages(ctx)
-		if err != nil {
-			return ErrorMsg{err: err}
-		}
-
-		return ImagesLoadedMsg{images: images}
+		limit, err := client.DockerHubRateLimit(ctx)
+		return RateLimitCheckedMsg{limit: limit, user: client.RegistryUser(models.DockerHub), err: err}
 	}
 }
 
-func removeImage(client *docker.Client, imageID string) tea.Cmd {
+// registryLogin logs in to a registry for the pulls of this session, and
+// stores the login for later ones with save
+func registryLogin(client *docker.Client, registry, username, password string, save bool) tea.Cmd {
 	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
 
-		err := client.RemoveImage(ctx, imageID, true)
-		return ImageRemovedMsg{imageID: imageID, err: err}
+		msg := RegistryLoggedInMsg{registry: registry, username: username}
+		if msg.err = client.RegistryLogin(ctx, registry, username, password); msg.err == nil && save {
+			msg.savedTo, msg.saveErr = client.SaveRegistryLogin(registry)
+		}
+		return msg
 	}
 }
 
-func removeImagesBulk(client *docker.Client, images []models.Image) tea.Cmd {
+// loadPullList reads the images to pull from a file
+func loadPullList(path string) tea.Cmd {
 	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
 
-		var failed int
-		for _, img := range images {
-			// Use force=true since we've already validated none are in use by containers
-			// This handles parent/child image dependencies
-			err := client.RemoveImage(ctx, img.ID, true)
-			if err != nil {
-				failed++
-			}
-		}
-
-		return ImagesBulkRemovedMsg{
-			count:  len(images),
-			failed: failed,
-		}
+		images, err := docker.ReadImageList(ctx, path)
+		return PullListLoadedMsg{path: path, images: images, err: err}
 	}
 }
 
-func pruneImages(client *docker.Client) tea.Cmd {
-	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
-		defer cancel()
+// startBatchPull starts pulling the index-th image of a batch pull
+func startBatchPull(client *docker.Client, index int, imageName string) (<-chan docker.PullProgress, tea.Cmd) {
+	progressChan := client.PullImageWithProgress(context.Background(), imageName, "")
+	return progressChan, waitForBatchPull(index, progressChan)
+}
 
-		count, spaceFreed, err := client.PruneImages(ctx)
-		return ImagesPrunedMsg{
-			count:      count,
-			spaceFreed: spaceFreed,
-			err:        err,
+// waitForBatchPull waits for the next progress update of a batch pull
+func waitForBatchPull(index int, progressChan <-chan docker.PullProgress) tea.Cmd {
+	return func() tea.Msg {
+		progress, ok := <-progressChan
+		if !ok {
+			return BatchPullProgressMsg{index: index, closed: true}
 		}
+		return BatchPullProgressMsg{index: index, progress: progress}
 	}
 }
 
-func loadGroups(groupManager *config.GroupManager) tea.Cmd {
+// waitForPullProgress waits for the next progress update
+func waitForPullProgress(imageName string, progressChan <-chan docker.PullProgress) tea.Cmd {
 	return func() tea.Msg {
-		if groupManager == nil {
-			return nil
+		progress, ok := <-progressChan
+		if !ok {
+			return ImagePullCompletedMsg{imageName: imageName, err: nil}
 		}
 
-		groups := groupManager.GetAllGroups()
-		return GroupsLoadedMsg{groups: groups}
+		return ImagePullProgressMsg{
+			imageName: imageName,
+			status:    progress.Status,
+			progress:  progress.Progress,
+			current:   progress.Current,
+			total:     progress.Total,
+			layers:    progress.Layers,
+			done:      progress.Done,
+			err:       progress.Error,
+		}
 	}
 }
 
-func startGroup(client *docker.Client, groupManager *config.GroupManager, groupID string) tea.Cmd {
+// groupLogLines is how many log lines are read per container of the group
+// logs dashboard, more than a pane usually shows
+const groupLogLines = 50
+
+// fetchGroupLogs reads the last log lines of every container of a group
+func fetchGroupLogs(client *docker.Client, group models.Group) tea.Cmd {
 	return func() tea.Msg {
-		if client == nil || groupManager == nil {
+		if client == nil {
 			return nil
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 
-		operation := func(ctx context.Context, containerID string) error {
-			return client.StartContainer(ctx, containerID)
+		containers, err := client.ListContainers(ctx, true)
+		if err != nil {
+			return GroupLogsLoadedMsg{groupID: group.ID, err: err}
+		}
+		byID := make(map[string]models.Container, len(containers))
+		for _, c := range containers {
+			byID[c.ID] = c
 		}
 
-		err := groupManager.ExecuteGroupOperation(ctx, groupID, operation)
-		return GroupStartedMsg{groupID: groupID, err: err}
+		// Containers removed since they were added to the group are skipped
+		var members []models.Container
+		for _, id := range group.ContainerIDs {
+			if c, ok := byID[id]; ok {
+				members = append(members, c)
+			}
+		}
+
+		panes := make([]models.GroupLogPane, len(members))
+		var wg sync.WaitGroup
+		for i, c := range members {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				panes[i] = models.GroupLogPane{Name: c.Name, State: c.State}
+				lines, err := client.TailLogs(ctx, c.ID, groupLogLines)
+				if err != nil {
+					panes[i].Err = err.Error()
+				}
+				panes[i].Lines = lines
+			}()
+		}
+		wg.Wait()
+		return GroupLogsLoadedMsg{groupID: group.ID, panes: panes}
 	}
 }
 
-func stopGroup(client *docker.Client, groupManager *config.GroupManager, groupID string) tea.Cmd {
+// Network commands
+func fetchNetworks(client *docker.Client) tea.Cmd {
 	return func() tea.Msg {
-		if client == nil || groupManager == nil {
+		if client == nil {
 			return nil
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
 
-		operation := func(ctx context.Context, containerID string) error {
-			return client.StopContainer(ctx, containerID, 10)
+		networks, err := client.ListNetworks(ctx)
+		if err != nil {
+			return ErrorMsg{err: err}
 		}
 
-		err := groupManager.ExecuteGroupOperation(ctx, groupID, operation)
-		return GroupStoppedMsg{groupID: groupID, err: err}
+		return NetworksLoadedMsg{networks: networks}
 	}
 }
 
-func addContainerToGroup(gm *config.GroupManager, groupID, containerID string) tea.Cmd {
+func connectContainerToNetwork(client *docker.Client, networkID, containerID string, aliases []string, ipv4 string) tea.Cmd {
 	return func() tea.Msg {
-		err := gm.AddContainerToGroup(groupID, containerID)
-		return ContainerAddedToGroupMsg{
-			groupID:     groupID,
-			containerID: containerID,
-			err:         err,
-		}
-	}
-}
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
 
-func removeContainerFromGroup(gm *config.GroupManager, groupID, containerID string) tea.Cmd {
-	return func() tea.Msg {
-		err := gm.RemoveContainerFromGroup(groupID, containerID)
-		return ContainerRemovedFromGroupMsg{
-			groupID:     groupID,
-			containerID: containerID,
-			err:         err,
-		}
+		err := client.ConnectContainer(ctx, networkID, containerID, aliases, ipv4)
+		return ContainerConnectedToNetworkMsg{networkID: networkID, containerID: containerID, err: err}
 	}
 }
 
-func replaceContainerIDInGroups(gm *config.GroupManager, oldID, newID string) tea.Cmd {
+func disconnectContainerFromNetwork(client *docker.Client, networkID, containerID string) tea.Cmd {
 	return func() tea.Msg {
-		err := gm.ReplaceContainerID(oldID, newID)
-		return ContainerIDReplacedMsg{
-			oldID: oldID,
-			newID: newID,
-			err:   err,
-		}
-	}
-}
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
 
-func removeContainerFromAllGroups(gm *config.GroupManager, containerID string) tea.Cmd {
-	return func() tea.Msg {
-		err := gm.RemoveContainerFromAllGroups(containerID)
-		return ContainerRemovedFromAllGroupsMsg{
-			containerID: containerID,
-			err:         err,
-		}
+		err := client.DisconnectContainer(ctx, networkID, containerID, false)
+		return ContainerDisconnectedFromNetworkMsg{networkID: networkID, containerID: containerID, err: err}
 	}
 }
 
-func startLogStreaming(client *docker.Client, logsView *views.LogsView, container *models.Container) tea.Cmd {
-	// Set container synchronously to reset the view state before the async Cmd runs
-	// This prevents race conditions where View() is called with stale data
-	logsView.SetContainer(container.ID, container.Name)
-
-	streamCmd := func() tea.Msg {
+// loadHostInterfaces lists the interfaces of the Docker host, when it is
+// this machine
+func loadHostInterfaces(client *docker.Client) tea.Cmd {
+	return func() tea.Msg {
 		if client == nil {
-			return nil
+			return ErrorMsg{err: fmt.Errorf("docker client not initialized")}
+		}
+		if client.IsRemote() {
+			return HostInterfacesLoadedMsg{remote: true}
 		}
 
-		ctx := context.Background()
-		logsChan, errorChan := client.StreamLogs(ctx, container.ID, true, time.Time{}, "100")
-		logsView.StartStreaming(logsChan, errorChan)
-
-		// Return the first log wait command
-		return waitForLogEntry(logsChan, errorChan)()
-	}
-
-	// Disable mouse on enter for text selection, batch with stream command
-	return tea.Batch(tea.DisableMouse, streamCmd)
-}
-
-func waitForLogEntry(logsChan <-chan docker.LogEntry, errorChan <-chan error) tea.Cmd {
-	return func() tea.Msg {
-		select {
-		case entry, ok := <-logsChan:
-			if !ok {
-				return nil
-			}
-			return entry
-		case err, ok := <-errorChan:
-			if !ok {
-				return nil
-			}
-			return ErrorMsg{err: err}
+		interfaces, err := utils.ListHostInterfaces()
+		if err != nil {
+			return HostInterfacesLoadedMsg{err: err}
 		}
+		return HostInterfacesLoadedMsg{interfaces: interfaces}
 	}
 }
 
-func startStatsStreaming(client *docker.Client, statsView *views.StatsView, container *models.Container) tea.Cmd {
-	// Set container synchronously to reset the view state before the async Cmd runs
-	// This prevents race conditions where View() is called with stale data
-	statsView.SetContainer(container.ID, container.Name)
-
+func createMacvlanNetwork(client *docker.Client, n models.MacvlanNetwork) tea.Cmd {
 	return func() tea.Msg {
-		if client == nil {
-			return nil
-		}
-
-		ctx := context.Background()
-		statsChan, errorChan := client.StreamStats(ctx, container.ID)
-		statsView.StartStreaming(statsChan, errorChan)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
 
-		// Return the first stats wait command
-		return waitForStats(statsChan, errorChan)()
+		err := client.CreateMacvlanNetwork(ctx, n)
+		return NetworkCreatedMsg{name: n.Name, err: err}
 	}
 }
 
-func waitForStats(statsChan <-chan *models.ContainerStats, errorChan <-chan error) tea.Cmd {
+// setNetworkAliases reconnects a container to a network with new aliases
+func setNetworkAliases(client *docker.Client, net models.Network, container models.Container, aliases []string) tea.Cmd {
 	return func() tea.Msg {
-		select {
-		case stats, ok := <-statsChan:
-			if !ok {
-				return nil
-			}
-			return stats
-		case err, ok := <-errorChan:
-			if !ok {
-				return nil
-			}
-			return ErrorMsg{err: err}
-		}
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		err := client.SetNetworkAliases(ctx, net.ID, container.ID, aliases)
+		return NetworkAliasesSetMsg{containerName: container.Name, networkName: net.Name, aliases: aliases, err: err}
 	}
 }
 
-func execShell(containerID, containerName string) tea.Cmd {
-	// Try sh first (most compatible)
-	cmd := exec.Command("docker", "exec", "-it", containerID, "sh")
+func createNetwork(client *docker.Client, name, driver string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
 
-	return tea.ExecProcess(cmd, func(err error) tea.Msg {
-		if err != nil {
-			return ErrorMsg{err: fmt.Errorf("failed to exec shell in %s: %w", containerName, err)}
-		}
-		return StatusMsg{message: fmt.Sprintf("Exited shell for %s", containerName)}
-	})
+		err := client.CreateNetwork(ctx, name, driver)
+		return NetworkCreatedMsg{name: name, err: err}
+	}
 }
 
-func removeContainer(client *docker.Client, containerID string) tea.Cmd {
+func pruneNetworks(client *docker.Client) tea.Cmd {
 	return func() tea.Msg {
+		if client == nil {
+			return ErrorMsg{err: fmt.Errorf("docker client not initialized")}
+		}
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
 
-		err := client.RemoveContainer(ctx, containerID, true) // force=true
-		return ContainerRemovedMsg{containerID: containerID, err: err}
+		removed, err := client.PruneNetworks(ctx)
+		return NetworksPrunedMsg{removed: removed, err: err}
 	}
 }
 
-func deleteGroup(groupManager *config.GroupManager, groupID string) tea.Cmd {
+func removeNetwork(client *docker.Client, networkID string) tea.Cmd {
 	return func() tea.Msg {
-		if groupManager == nil {
-			return ErrorMsg{err: fmt.Errorf("group manager not initialized")}
-		}
-
-		err := groupManager.DeleteGroup(groupID)
-		if err != nil {
-			return ErrorMsg{err: fmt.Errorf("failed to delete group: %w", err)}
-		}
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
 
-		return StatusMsg{message: "Group deleted successfully"}
+		err := client.RemoveNetwork(ctx, networkID)
+		return NetworkRemovedMsg{networkID: networkID, err: err}
 	}
 }
 
-func createGroup(groupManager *config.GroupManager, name, description string, containerIDs []string) tea.Cmd {
+// Container env var editing commands
+func loadContainerConfig(client *docker.Client, containerID string) tea.Cmd {
 	return func() tea.Msg {
-		if groupManager == nil {
-			return ErrorMsg{err: fmt.Errorf("group manager not initialized")}
+		if client == nil {
+			return ErrorMsg{err: fmt.Errorf("docker client not initialized")}
 		}
 
-		_, err := groupManager.CreateGroup(name, description, containerIDs)
-		if err != nil {
-			return ErrorMsg{err: fmt.Errorf("failed to create group: %w", err)}
-		}
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
 
-		// Return a message that will trigger group reload
-		return GroupCreatedMsg{name: name}
+		config, err := client.InspectContainerFull(ctx, containerID)
+		// Free port suggestions skip the ports other containers publish;
+		// without them the suggestion may just collide
+		var published []models.PortBinding
+		if err == nil && !client.IsRemote() {
+			published, _ = client.ListPortBindings(ctx)
+		}
+		return ContainerConfigLoadedMsg{
+			containerID: containerID,
+			config:      config,
+			published:   published,
+			err:         err,
+		}
 	}
 }
 
-// Volume commands
-func fetchVolumes(client *docker.Client) tea.Cmd {
+// applyEnvChange applies an env var change to one container, recreating it
+// only if its env actually changes
+func applyEnvChange(client *docker.Client, ctr models.Container, change models.EnvVarChange) tea.Cmd {
 	return func() tea.Msg {
+		result := models.BulkEnvResult{ContainerID: ctr.ID, ContainerName: ctr.Name}
 		if client == nil {
-			return nil
+			result.Err = fmt.Errorf("docker client not initialized")
+			return BulkEnvStepMsg{result: result}
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
 		defer cancel()
 
-		volumes, err := client.ListVolumes(ctx)
+		config, err := client.InspectContainerFull(ctx, ctr.ID)
 		if err != nil {
-			return ErrorMsg{err: err}
+			result.Err = err
+			return BulkEnvStepMsg{result: result}
 		}
 
-		return VolumesLoadedMsg{volumes: volumes}
+		env, changed := change.Apply(config.Env)
+		if !changed {
+			result.Skipped = true
+			return BulkEnvStepMsg{result: result}
+		}
+
+		config.Env = env
+		// The new ID is returned even if only the start failed
+		result.NewID, result.Err = client.RecreateContainer(ctx, ctr.ID, config)
+		return BulkEnvStepMsg{result: result}
 	}
 }
 
-func removeVolume(client *docker.Client, volumeName string) tea.Cmd {
+// recreateProjectContainer stops, removes and recreates one container of a
+// compose project from its current config
+func recreateProjectContainer(client *docker.Client, ctr models.Container) tea.Cmd {
 	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		result := models.BulkEnvResult{ContainerID: ctr.ID, ContainerName: ctr.Name}
+		if client == nil {
+			result.Err = fmt.Errorf("docker client not initialized")
+			return ProjectRecreateStepMsg{result: result}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
 		defer cancel()
 
-		err := client.RemoveVolume(ctx, volumeName, false)
-		return VolumeRemovedMsg{volumeName: volumeName, err: err}
+		config, err := client.InspectContainerFull(ctx, ctr.ID)
+		if err != nil {
+			result.Err = err
+			return ProjectRecreateStepMsg{result: result}
+		}
+		// The new ID is returned even if only the start failed
+		result.NewID, result.Err = client.RecreateContainer(ctx, ctr.ID, config)
+		return ProjectRecreateStepMsg{result: result}
 	}
 }
 
-func pruneVolumes(client *docker.Client) tea.Cmd {
+func recreateContainer(client *docker.Client, containerID string, config *models.ContainerFullConfig) tea.Cmd {
 	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		if client == nil {
+			return ErrorMsg{err: fmt.Errorf("docker client not initialized")}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
 		defer cancel()
 
-		_, err := client.PruneUnusedVolumes(ctx)
-		if err != nil {
-			return ErrorMsg{err: err}
+		newID, err := client.RecreateContainer(ctx, containerID, config)
+		return ContainerRecreatedMsg{
+			oldID:         containerID,
+			newID:         newID,
+			containerName: config.Name,
+			err:           err,
 		}
-
-		return StatusMsg{message: "Unused volumes pruned successfully"}
 	}
 }
 
-// Compose commands
-func fetchComposeProjects(client *docker.Client) tea.Cmd {
+// loadComposeEnvMatrix loads the env vars of each service in a compose project
+func loadComposeEnvMatrix(client *docker.Client, project models.ComposeProject) tea.Cmd {
 	return func() tea.Msg {
 		if client == nil {
-			return nil
+			return ErrorMsg{err: fmt.Errorf("docker client not initialized")}
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
 		defer cancel()
 
-		projects, err := client.ListComposeProjects(ctx)
-		if err != nil {
-			return ErrorMsg{err: err}
+		// Scaled replicas share the service definition, so inspecting the
+		// first container of each service is enough
+		serviceEnv := make(map[string][]string, len(project.Services))
+		for _, service := range project.Services {
+			if len(service.Containers) == 0 {
+				continue
+			}
+			config, err := client.InspectContainerFull(ctx, service.Containers[0].ID)
+			if err != nil {
+				return ComposeEnvLoadedMsg{projectName: project.Name, err: err}
+			}
+			serviceEnv[service.Name] = config.Env
 		}
 
-		return ComposeProjectsLoadedMsg{projects: projects}
+		return ComposeEnvLoadedMsg{
+			projectName: project.Name,
+			matrix:      models.NewEnvMatrix(serviceEnv),
+		}
 	}
 }
 
-func startComposeProject(client *docker.Client, projectName string) tea.Cmd {
+// validateComposeConfig runs `docker compose config` on a project's files
+func validateComposeConfig(client *docker.Client, project models.ComposeProject) tea.Cmd {
 	return func() tea.Msg {
+		if client == nil {
+			return ErrorMsg{err: fmt.Errorf("docker client not initialized")}
+		}
+
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
 
-		err := client.StartComposeProject(ctx, projectName)
-		return ComposeProjectStartedMsg{projectName: projectName, err: err}
+		check, err := client.ValidateComposeConfig(ctx, project)
+		return ComposeConfigCheckedMsg{projectName: project.Name, check: check, err: err}
 	}
 }
 
-func stopComposeProject(client *docker.Client, projectName string) tea.Cmd {
+// checkComposeChanges finds the services of a project that differ from its
+// compose files
+func checkComposeChanges(client *docker.Client, project models.ComposeProject) tea.Cmd {
 	return func() tea.Msg {
+		if client == nil {
+			return ErrorMsg{err: fmt.Errorf("docker client not initialized")}
+		}
+
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
 
-		err := client.StopComposeProject(ctx, projectName, 10)
-		return ComposeProjectStoppedMsg{projectName: projectName, err: err}
+		changes, err := client.ChangedComposeServices(ctx, project)
+		return ComposeChangesLoadedMsg{project: project, changes: changes, err: err}
 	}
 }
 
-func restartComposeProject(client *docker.Client, projectName string) tea.Cmd {
+// fetchComposeConfigHashes hashes the current compose config of a project's
+// services
+func fetchComposeConfigHashes(client *docker.Client, project models.ComposeProject) tea.Cmd {
 	return func() tea.Msg {
+		if client == nil {
+			return ErrorMsg{err: fmt.Errorf("docker client not initialized")}
+		}
+
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
 
-		err := client.RestartComposeProject(ctx, projectName, 10)
-		return ComposeProjectRestartedMsg{projectName: projectName, err: err}
+		hashes, err := client.ComposeConfigHashes(ctx, project)
+		return ComposeConfigHashesLoadedMsg{projectName: project.Name, hashes: hashes, err: err}
 	}
 }
 
-// startImagePull starts an image pull with progress channel
-func startImagePull(client *docker.Client, imageName string) (<-chan docker.PullProgress, tea.Cmd) {
-	ctx := context.Background() // No timeout - let Docker handle it
-	progressChan := client.PullImageWithProgress(ctx, imageName)
+// recreateComposeServices runs `docker compose up -d` for the changed services
+func recreateComposeServices(client *docker.Client, project models.ComposeProject, services []string) tea.Cmd {
+	return func() tea.Msg {
+		if client == nil {
+			return ErrorMsg{err: fmt.Errorf("docker client not initialized")}
+		}
+
+		// Recreating can pull images, give it time
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+		defer cancel()
 
-	return progressChan, waitForPullProgress(imageName, progressChan)
+		err := client.RecreateComposeServices(ctx, project, services)
+		return ComposeServicesRecreatedMsg{projectName: project.Name, services: services, err: err}
+	}
 }
 
-// waitForPullProgress waits for the next progress update
-func waitForPullProgress(imageName string, progressChan <-chan docker.PullProgress) tea.Cmd {
+// composeUp runs `docker compose up -d` with the given compose files
+func composeUp(client *docker.Client, name string, files []string) tea.Cmd {
 	return func() tea.Msg {
-		progress, ok := <-progressChan
-		if !ok {
-			return ImagePullCompletedMsg{imageName: imageName, err: nil}
+		if client == nil {
+			return ErrorMsg{err: fmt.Errorf("docker client not initialized")}
 		}
 
-		return ImagePullProgressMsg{
-			imageName: imageName,
-			status:    progress.Status,
-			progress:  progress.Progress,
-			current:   progress.Current,
-			total:     progress.Total,
-			done:      progress.Done,
-			err:       progress.Error,
-		}
+		// Bringing a project up can pull and build images, give it time
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Minute)
+		defer cancel()
+
+		err := client.ComposeUp(ctx, name, files)
+		return ComposeUpMsg{name: name, files: files, err: err}
 	}
 }
 
-// Network commands
-func fetchNetworks(client *docker.Client) tea.Cmd {
+// applyComposeProject runs `docker compose up -d` on a project with its own
+// compose files
+func applyComposeProject(client *docker.Client, project models.ComposeProject) tea.Cmd {
 	return func() tea.Msg {
 		if client == nil {
-			return nil
+			return ErrorMsg{err: fmt.Errorf("docker client not initialized")}
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Minute)
 		defer cancel()
 
-		networks, err := client.ListNetworks(ctx)
-		if err != nil {
-			return ErrorMsg{err: err}
-		}
+		err := client.ApplyComposeProject(ctx, project)
+		return ComposeUpMsg{name: project.Name, files: project.ConfigFiles, err: err}
+	}
+}
 
-		return NetworksLoadedMsg{networks: networks}
+// loadComposeDotEnv reads the .env file of a project
+func loadComposeDotEnv(project models.ComposeProject) tea.Cmd {
+	return func() tea.Msg {
+		file, err := docker.ReadComposeDotEnv(project)
+		return ComposeDotEnvLoadedMsg{project: project, file: file, err: err}
 	}
 }
 
-func connectContainerToNetwork(client *docker.Client, networkID, containerID string) tea.Cmd {
+// saveComposeDotEnv writes the edited .env file of a project
+func saveComposeDotEnv(project models.ComposeProject, file models.DotEnvFile) tea.Cmd {
 	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-		defer cancel()
+		err := docker.WriteComposeDotEnv(file)
+		return ComposeDotEnvSavedMsg{project: project, path: file.Path, err: err}
+	}
+}
 
-		err := client.ConnectContainer(ctx, networkID, containerID)
-		return ContainerConnectedToNetworkMsg{networkID: networkID, containerID: containerID, err: err}
+// loadComposeFiles reads the compose files of a project
+func loadComposeFiles(project models.ComposeProject) tea.Cmd {
+	return func() tea.Msg {
+		files, err := docker.ReadComposeFiles(project)
+		return ComposeFilesLoadedMsg{project: project, files: files, err: err}
 	}
 }
 
-func disconnectContainerFromNetwork(client *docker.Client, networkID, containerID string) tea.Cmd {
+// editComposeFile opens a compose file in $VISUAL or $EDITOR (vi if neither
+// is set) and reports whether it was changed once the editor exits
+func editComposeFile(project models.ComposeProject, path string) tea.Cmd {
+	before, err := os.ReadFile(path)
+	if err != nil {
+		return func() tea.Msg {
+			return ComposeFileEditedMsg{project: project, path: path, err: fmt.Errorf("failed to read %s: %w", path, err)}
+		}
+	}
+
+	editor := strings.Fields(os.Getenv("VISUAL"))
+	if len(editor) == 0 {
+		editor = strings.Fields(os.Getenv("EDITOR"))
+	}
+	if len(editor) == 0 {
+		editor = []string{"vi"}
+	}
+	cmd := exec.Command(editor[0], append(editor[1:], path)...)
+
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		if err != nil {
+			return ComposeFileEditedMsg{project: project, path: path, err: fmt.Errorf("failed to run %s: %w", editor[0], err)}
+		}
+		after, err := os.ReadFile(path)
+		if err != nil {
+			return ComposeFileEditedMsg{project: project, path: path, err: fmt.Errorf("failed to read %s: %w", path, err)}
+		}
+		return ComposeFileEditedMsg{project: project, path: path, changed: !bytes.Equal(before, after)}
+	})
+}
+
+// composeDown runs `docker compose down` on a project
+func composeDown(client *docker.Client, projectName string, volumes, images bool) tea.Cmd {
 	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		if client == nil {
+			return ErrorMsg{err: fmt.Errorf("docker client not initialized")}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
 		defer cancel()
 
-		err := client.DisconnectContainer(ctx, networkID, containerID, false)
-		return ContainerDisconnectedFromNetworkMsg{networkID: networkID, containerID: containerID, err: err}
+		err := client.DownComposeProject(ctx, projectName, volumes, images)
+		return ComposeDownMsg{projectName: projectName, volumes: volumes, images: images, err: err}
 	}
 }
 
-func createNetwork(client *docker.Client, name, driver string) tea.Cmd {
+// loadCpusetConfig loads a container's current cpuset along with the host CPU count
+func loadCpusetConfig(client *docker.Client, containerID string) tea.Cmd {
 	return func() tea.Msg {
+		if client == nil {
+			return ErrorMsg{err: fmt.Errorf("docker client not initialized")}
+		}
+
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 
-		err := client.CreateNetwork(ctx, name, driver)
-		return NetworkCreatedMsg{name: name, err: err}
+		config, err := client.InspectContainerFull(ctx, containerID)
+		if err != nil {
+			return CpusetConfigLoadedMsg{containerID: containerID, err: err}
+		}
+
+		hostInfo, err := client.GetHostInfo(ctx)
+		if err != nil {
+			return CpusetConfigLoadedMsg{containerID: containerID, err: err}
+		}
+
+		return CpusetConfigLoadedMsg{
+			containerID:   containerID,
+			containerName: config.Name,
+			cpus:          config.CpusetCpus,
+			mems:          config.CpusetMems,
+			hostCPUs:      hostInfo.NCPU,
+		}
 	}
 }
 
-func removeNetwork(client *docker.Client, networkID string) tea.Cmd {
+func updateContainerCpuset(client *docker.Client, containerID, cpus, mems string) tea.Cmd {
 	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 
-		err := client.RemoveNetwork(ctx, networkID)
-		return NetworkRemovedMsg{networkID: networkID, err: err}
+		err := client.UpdateContainerCpuset(ctx, containerID, cpus, mems)
+		return ContainerCpusetUpdatedMsg{containerID: containerID, cpus: cpus, err: err}
 	}
 }
 
-// Container env var editing commands
-func loadContainerConfig(client *docker.Client, containerID string) tea.Cmd {
+// checkClock compares the clock of a container with the daemon host's and
+// this machine's
+func checkClock(client *docker.Client, containerID, name string) tea.Cmd {
 	return func() tea.Msg {
 		if client == nil {
 			return ErrorMsg{err: fmt.Errorf("docker client not initialized")}
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
 		defer cancel()
 
-		config, err := client.InspectContainerFull(ctx, containerID)
-		return ContainerConfigLoadedMsg{
-			containerID: containerID,
-			config:      config,
-			err:         err,
+		check, err := client.CheckClock(ctx, containerID)
+		if check != nil {
+			check.Container = name
 		}
+		return ClockCheckedMsg{check: check, err: err}
 	}
 }
 
-func recreateContainer(client *docker.Client, containerID string, config *models.ContainerFullConfig) tea.Cmd {
+// maxNetworkEndpoints is how many endpoints the network details list, the
+// modal doesn't scroll
+const maxNetworkEndpoints = 15
+
+// renderNetworkDetail renders what `docker network inspect` shows, in
+// sections: settings, IPAM pools, options, labels and endpoints
+func (a *App) renderNetworkDetail(d *models.NetworkDetail) string {
+	maxWidth := a.width - 14
+	clip := func(line string) string {
+		if maxWidth > 0 && len([]rune(line)) > maxWidth {
+			return string([]rune(line)[:maxWidth-1]) + "…"
+		}
+		return line
+	}
+	row := func(label, value string) string {
+		return clip(styles.KeyStyle.Render(fmt.Sprintf("%-13s", label)) + value)
+	}
+	yesNo := func(b bool) string {
+		if b {
+			return "yes"
+		}
+		return "no"
+	}
+
+	var lines []string
+	lines = append(lines,
+		row("ID:", d.GetShortID()),
+		row("Driver:", d.Driver),
+		row("Scope:", d.Scope),
+		row("Created:", d.Created.Format("2006-01-02 15:04")),
+		row("Internal:", yesNo(d.Internal)),
+		row("Attachable:", yesNo(d.Attachable)),
+		row("IPv6:", yesNo(d.EnableIPv6)),
+	)
+	if d.ConfigFrom != "" {
+		lines = append(lines, row("Config from:", d.ConfigFrom))
+	}
+	if d.ConfigOnly {
+		lines = append(lines, styles.DescStyle.Render("Config-only network: holds settings for other networks, no containers"))
+	}
+
+	ipamDriver := d.IPAM.Driver
+	if ipamDriver == "" {
+		ipamDriver = "default"
+	}
+	lines = append(lines, "", styles.SubtitleStyle.Render("IPAM ("+ipamDriver+")"))
+	if len(d.Subnets) == 0 {
+		lines = append(lines, styles.DescStyle.Render("No subnets configured"))
+	}
+	for _, s := range d.Subnets {
+		lines = append(lines, row("Subnet:", s.Subnet))
+		if s.Gateway != "" {
+			lines = append(lines, row("  Gateway:", s.Gateway))
+		}
+		if s.IPRange != "" {
+			lines = append(lines, row("  IP range:", s.IPRange))
+		}
+		for _, host := range slices.Sorted(maps.Keys(s.AuxAddresses)) {
+			lines = append(lines, row("  Reserved:", host+" "+s.AuxAddresses[host]))
+		}
+	}
+	for _, k := range slices.Sorted(maps.Keys(d.IPAMOptions)) {
+		lines = append(lines, row("Option:", k+"="+d.IPAMOptions[k]))
+	}
+
+	if len(d.Options) > 0 {
+		lines = append(lines, "", styles.SubtitleStyle.Render("Options"))
+		for _, k := range slices.Sorted(maps.Keys(d.Options)) {
+			lines = append(lines, clip(k+"="+d.Options[k]))
+		}
+	}
+	if len(d.Labels) > 0 {
+		lines = append(lines, "", styles.SubtitleStyle.Render("Labels"))
+		for _, k := range slices.Sorted(maps.Keys(d.Labels)) {
+			lines = append(lines, clip(k+"="+d.Labels[k]))
+		}
+	}
+
+	lines = append(lines, "", styles.SubtitleStyle.Render(fmt.Sprintf("Endpoints (%d)", len(d.Endpoints))))
+	if len(d.Endpoints) == 0 {
+		lines = append(lines, styles.DescStyle.Render("No containers attached"))
+	}
+	for i, ep := range d.Endpoints {
+		if i == maxNetworkEndpoints {
+			lines = append(lines, styles.DescStyle.Render(fmt.Sprintf("+%d more", len(d.Endpoints)-maxNetworkEndpoints)))
+			break
+		}
+		addrs := []string{ep.IPv4}
+		if ep.IPv6 != "" {
+			addrs = append(addrs, ep.IPv6)
+		}
+		if ep.MAC != "" {
+			addrs = append(addrs, ep.MAC)
+		}
+		lines = append(lines, clip(styles.KeyStyle.Render(ep.Name)+"  "+strings.Join(addrs, "  ")))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderContainerRuntime shows how a container is isolated from its host
+func renderContainerRuntime(ctr models.Container) string {
+	lines := []string{
+		fmt.Sprintf("ID: %s", ctr.ShortID),
+		fmt.Sprintf("Image: %s", ctr.Image),
+		fmt.Sprintf("Status: %s", ctr.Status),
+		"",
+	}
+	info := ctr.Runtime
+	if !info.Known() {
+		lines = append(lines, styles.SubtitleStyle.Render("Runtime unknown: the container couldn't be inspected."))
+		return strings.Join(lines, "\n")
+	}
+	lines = append(lines, views.RuntimeBadges(info), "")
+
+	runtime := models.RuntimeName(info.Runtime)
+	if runtime == "" {
+		runtime = "daemon default"
+	}
+	if info.Sandboxed() {
+		lines = append(lines, styles.SuccessStyle.Render("✓ Runtime: "+runtime+" (sandboxed, own kernel)"))
+	} else {
+		lines = append(lines, "  Runtime: "+runtime+" (shares the host kernel)")
+	}
+	if info.Platform != "" {
+		lines = append(lines, "  Platform: "+info.Platform)
+	}
+	if info.Privileged {
+		lines = append(lines, styles.ErrorStyle.Render("✗ Privileged: all host devices and capabilities,"),
+			"  no seccomp/AppArmor confinement. Root in it is effectively root on the host.")
+	} else {
+		lines = append(lines, styles.SuccessStyle.Render("✓ Not privileged"))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderClockCheck renders the clock comparison of a container
+func renderClockCheck(check *models.ClockCheck) string {
+	var lines []string
+	lines = append(lines,
+		fmt.Sprintf("Container %s reads %s", check.Container, check.ContainerTime.UTC().Format("2006-01-02 15:04:05 UTC")),
+		"",
+	)
+
+	line := "Container vs daemon host: " + models.FormatSkew(check.Skew)
+	if check.Drifted() {
+		lines = append(lines, styles.ErrorStyle.Render("✗ "+line),
+			"  TLS handshakes and JWT validation (exp/nbf/iat) may fail in this container.",
+			"  Look for libfaketime (LD_PRELOAD, FAKETIME) or a time namespace.")
+	} else {
+		lines = append(lines, styles.SuccessStyle.Render("✓ "+line))
+	}
+
+	line = "Daemon host vs this machine: " + models.FormatSkew(check.HostOffset)
+	if check.HostDrifted() {
+		lines = append(lines, styles.WarningStyle.Render("! "+line),
+			"  The daemon host's clock is off; every container on it inherits that.",
+			"  With Docker Desktop the VM clock can fall behind after sleep; restart it or resync NTP.")
+	} else {
+		lines = append(lines, styles.SuccessStyle.Render("✓ "+line))
+	}
+
+	lines = append(lines, "", styles.SubtitleStyle.Render(fmt.Sprintf(
+		"Tolerance %s, measurement error up to ±%s.", models.ClockSkewTolerance, check.Uncertainty.Round(10*time.Millisecond))))
+	return strings.Join(lines, "\n")
+}
+
+// checkPortConflicts lists the host ports of all containers and, with a
+// local daemon, the host's listening sockets, and reports the ports that are
+// or will be double-booked
+func checkPortConflicts(client *docker.Client) tea.Cmd {
 	return func() tea.Msg {
 		if client == nil {
 			return ErrorMsg{err: fmt.Errorf("docker client not initialized")}
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
 
-		newID, err := client.RecreateContainer(ctx, containerID, config)
-		return ContainerRecreatedMsg{
-			oldID:         containerID,
-			newID:         newID,
-			containerName: config.Name,
-			err:           err,
+		bindings, err := client.ListPortBindings(ctx)
+		if err != nil {
+			return PortConflictsCheckedMsg{err: err}
+		}
+
+		// Host sockets can only be inspected when the daemon runs on this machine
+		var listeners []utils.HostListener
+		hostChecked := false
+		if !client.IsRemote() {
+			if hostListeners, err := utils.ListHostListeners(); err == nil && len(hostListeners) > 0 {
+				hostChecked = true
+				listeners = hostListeners
+			}
+		}
+
+		return PortConflictsCheckedMsg{
+			conflicts:   models.DetectPortConflicts(bindings, listeners),
+			hostChecked: hostChecked,
+		}
+	}
+}
+
+// selectedContainer returns the container selected in the current view, if any
+func (a *App) selectedContainer() *models.Container {
+	switch a.state.CurrentView {
+	case models.ViewContainers:
+		return a.containersView.GetSelectedContainer()
+	case models.ViewGroups:
+		switch a.groupsView.GetCurrentTab() {
+		case models.GroupsContainersTab:
+			return a.groupsView.GetSelectedInGroupContainer()
+		case models.GroupsAvailableTab:
+			return a.groupsView.GetSelectedAvailableContainer()
+		}
+	case models.ViewCompose:
+		if a.composeView.IsViewingServices() || a.composeView.IsViewingContainers() {
+			return a.composeView.GetSelectedContainer()
+		}
+	case models.ViewNetworks:
+		switch a.networksView.GetCurrentTab() {
+		case models.NetworksContainersTab:
+			return a.networksView.GetSelectedInNetworkContainer()
+		case models.NetworksAvailableTab:
+			return a.networksView.GetSelectedAvailableContainer()
+		}
+	case models.ViewVolumes:
+		if a.volumesView.GetCurrentTab() == models.VolumesUsedByTab {
+			return a.volumesView.GetSelectedUsedByContainer()
 		}
+	case models.ViewLogs, models.ViewStats:
+		return a.state.SelectedContainer
+	}
+	return nil
+}
+
+// containerCommands builds docker CLI commands for a container, prefixed with
+// DOCKER_HOST when connected to a remote daemon so they work from any shell
+func containerCommands(client *docker.Client, containerID string) []string {
+	prefix := ""
+	if client != nil && client.IsRemote() {
+		prefix = fmt.Sprintf("DOCKER_HOST=%s ", client.DaemonHost())
+	}
+
+	return []string{
+		prefix + "docker logs -f " + containerID,
+		prefix + "docker exec -it " + containerID + " sh",
+		prefix + "docker inspect " + containerID,
+	}
+}
+
+// copyToClipboard copies a resource identifier to the system clipboard
+func copyToClipboard(label, value string) tea.Cmd {
+	return func() tea.Msg {
+		err := utils.CopyToClipboard(value)
+		return ClipboardCopiedMsg{label: label, value: value, err: err}
 	}
 }
 
@@ -1,9 +1,13 @@
 package app
 
 import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/rizface/doui/internal/config"
 	"github.com/rizface/doui/internal/docker"
 	"github.com/rizface/doui/internal/models"
+	"github.com/rizface/doui/pkg/utils"
 )
 
 // Message types for bubbletea
@@ -13,6 +17,44 @@ type DockerClientReadyMsg struct {
 	client *docker.Client
 }
 
+// AuditLogLoadedMsg carries the audit log entries to show, oldest first
+type AuditLogLoadedMsg struct {
+	title   string
+	entries []models.AuditEntry
+	err     error
+}
+
+// WatchEventMsg carries an exit or failed health check of any container,
+// from the stream started for the current client
+type WatchEventMsg struct {
+	gen    int // Stream generation, events of replaced streams are dropped
+	event  *models.ContainerEvent
+	events <-chan *models.ContainerEvent
+	errs   <-chan error
+}
+
+// RunFinishedMsg is sent when a one-shot run exited and was removed
+type RunFinishedMsg struct {
+	result *models.RunResult
+}
+
+// Docker context switching messages
+type DockerContextsLoadedMsg struct {
+	contexts []models.DockerContext
+	err      error
+}
+
+type DockerContextSwitchedMsg struct {
+	context models.DockerContext
+	client  *docker.Client
+	err     error
+}
+
+// DockerInitFailedMsg is sent when the Docker client could not be initialized
+type DockerInitFailedMsg struct {
+	err error
+}
+
 // GroupManagerReadyMsg is sent when GroupManager is initialized
 type GroupManagerReadyMsg struct {
 	manager *config.GroupManager
@@ -39,6 +81,18 @@ type ContainerStartedMsg struct {
 	err         error
 }
 
+// Container failed to start or exited right after starting
+type ContainerStartFailedMsg struct {
+	containerID string
+	reason      string
+	logs        []string
+}
+
+// StartupCheckMsg triggers a check whether a just-started container is still up
+type StartupCheckMsg struct {
+	containerID string
+}
+
 type ContainerStoppedMsg struct {
 	containerID string
 	err         error
@@ -60,6 +114,40 @@ type ImageRemovedMsg struct {
 	err     error
 }
 
+type ImageTaggedMsg struct {
+	source string
+	target string
+	untag  bool
+	err    error
+}
+
+type QuickRunStartedMsg struct {
+	image  string
+	id     string
+	name   string
+	detach bool
+	err    error
+}
+
+type ImageSavedMsg struct {
+	ref  string
+	path string
+	size int64
+	err  error
+}
+
+type VulnScanMsg struct {
+	image models.Image
+	scan  *models.VulnScan
+	err   error
+}
+
+type ArchiveLoadedMsg struct {
+	path   string
+	loaded []string // Tags, or IDs of untagged images
+	err    error
+}
+
 type ImagesBulkRemovedMsg struct {
 	count   int
 	failed  int
@@ -69,6 +157,7 @@ type ImagesBulkRemovedMsg struct {
 type ImagesPrunedMsg struct {
 	count       int
 	spaceFreed  int64
+	all         bool // All unused images, not only dangling ones
 	err         error
 }
 
@@ -83,10 +172,29 @@ type GroupStoppedMsg struct {
 	err     error
 }
 
+type GroupRestartedMsg struct {
+	groupID string
+	err     error
+}
+
 type GroupCreatedMsg struct {
 	name string
 }
 
+// Name, description and color of a group edited
+type GroupEditedMsg struct {
+	name string
+	err  error
+}
+
+// Group exported as a compose file
+type GroupComposeExportedMsg struct {
+	name     string
+	path     string
+	services int
+	err      error
+}
+
 // Container added to group
 type ContainerAddedToGroupMsg struct {
 	groupID     string
@@ -101,6 +209,19 @@ type ContainerRemovedFromGroupMsg struct {
 	err         error
 }
 
+// Start order dependencies set for a container in a group
+type GroupDependenciesSetMsg struct {
+	groupID     string
+	containerID string
+	err         error
+}
+
+type GroupOrderedStopSetMsg struct {
+	groupID string
+	ordered bool
+	err     error
+}
+
 // Container ID replaced in groups (after container recreate)
 type ContainerIDReplacedMsg struct {
 	oldID string
@@ -127,16 +248,100 @@ type StatusMsg struct {
 
 type ClearStatusMsg struct{}
 
+// Docker daemon reconnect messages
+type ReconnectTickMsg struct{}
+
+type DockerReconnectFailedMsg struct {
+	err error
+}
+
+// Remote daemon health check messages
+type HealthTickMsg struct{}
+
+// Start of a minute, to run the group schedules due
+type ScheduleTickMsg struct {
+	time time.Time
+}
+
+type DaemonHealthMsg struct {
+	client  *docker.Client
+	latency time.Duration
+	err     error
+}
+
 // Volume operation messages
 type VolumesLoadedMsg struct {
 	volumes []models.Volume
 }
 
+type VolumeCreatedMsg struct {
+	name string
+	err  error
+}
+
+type VolumeBackedUpMsg struct {
+	name string
+	path string
+	size int64
+	err  error
+}
+
+type VolumeRestoredMsg struct {
+	name    string
+	path    string
+	created bool
+	err     error
+}
+
+type VolumeSizesLoadedMsg struct {
+	sizes map[string]int64
+	err   error
+}
+
+type VolumesRemovedMsg struct {
+	removed []string
+	err     error
+}
+
 type VolumeRemovedMsg struct {
 	volumeName string
 	err        error
 }
 
+// Image provenance messages
+type ImagesComparedMsg struct {
+	comparison models.ImageComparison
+	err        error
+}
+
+type ImageHistoryLoadedMsg struct {
+	image  models.Image
+	layers []models.ImageLayer
+	err    error
+}
+
+type ImageProvenanceLoadedMsg struct {
+	image      models.Image
+	provenance *models.ImageProvenance
+}
+
+// Plugin operation messages
+type PluginsLoadedMsg struct {
+	plugins     []models.Plugin
+	unsupported string // Set when the daemon is too old for plugins
+}
+
+type PluginToggledMsg struct {
+	name    string
+	enabled bool
+	err     error
+}
+
+type PluginRemovedMsg struct {
+	name string
+	err  error
+}
+
 // Compose operation messages
 type ComposeProjectsLoadedMsg struct {
 	projects []models.ComposeProject
@@ -164,6 +369,7 @@ type ImagePullProgressMsg struct {
 	progress  string
 	current   int64
 	total     int64
+	layers    []docker.LayerProgress
 	done      bool
 	err       error
 }
@@ -173,6 +379,34 @@ type ImagePullCompletedMsg struct {
 	err       error
 }
 
+// Batch pull (images listed in a file) messages
+type PullListLoadedMsg struct {
+	path   string
+	images []string
+	err    error
+}
+
+type BatchPullProgressMsg struct {
+	index    int
+	progress docker.PullProgress
+	closed   bool // Progress channel closed without a final update
+}
+
+// Registry login and rate limit messages
+type RegistryLoggedInMsg struct {
+	registry string
+	username string
+	savedTo  string // Credential helper or config.json the login was saved to
+	err      error
+	saveErr  error
+}
+
+type RateLimitCheckedMsg struct {
+	limit *models.RateLimit
+	user  string // Logged in Docker Hub user, "" if anonymous
+	err   error
+}
+
 // Network operation messages
 type NetworksLoadedMsg struct {
 	networks []models.Network
@@ -190,6 +424,37 @@ type ContainerDisconnectedFromNetworkMsg struct {
 	err         error
 }
 
+type NetworkAliasesSetMsg struct {
+	containerName string
+	networkName   string
+	aliases       []string
+	err           error
+}
+
+// HostInterfacesLoadedMsg lists the parent interfaces for a macvlan/ipvlan
+// network (none when the daemon is remote)
+type HostInterfacesLoadedMsg struct {
+	interfaces []utils.HostInterface
+	remote     bool
+	err        error
+}
+
+type NetworkInspectedMsg struct {
+	detail *models.NetworkDetail
+	err    error
+}
+
+type NetworkEndpointsLoadedMsg struct {
+	networkID string
+	endpoints []models.NetworkEndpoint
+	err       error
+}
+
+type NetworksPrunedMsg struct {
+	removed []string
+	err     error
+}
+
 type NetworkCreatedMsg struct {
 	name string
 	err  error
@@ -204,6 +469,7 @@ type NetworkRemovedMsg struct {
 type ContainerConfigLoadedMsg struct {
 	containerID string
 	config      *models.ContainerFullConfig
+	published   []models.PortBinding // Host ports published by all containers
 	err         error
 }
 
@@ -213,3 +479,133 @@ type ContainerRecreatedMsg struct {
 	containerName string
 	err           error
 }
+
+// Clipboard messages
+type ClipboardCopiedMsg struct {
+	label string
+	value string
+	err   error
+}
+
+// Group bulk env messages
+type BulkEnvStepMsg struct {
+	result models.BulkEnvResult
+}
+
+// Compose project force-recreate messages
+type ProjectRecreateStepMsg struct {
+	result models.BulkEnvResult
+}
+
+// Compose env matrix messages
+type ComposeEnvLoadedMsg struct {
+	projectName string
+	matrix      *models.EnvMatrix
+	err         error
+}
+
+// Compose config validation messages
+type ComposeConfigCheckedMsg struct {
+	projectName string
+	check       *models.ComposeConfigCheck
+	err         error
+}
+
+// Compose changed services messages
+type ComposeChangesLoadedMsg struct {
+	project models.ComposeProject
+	changes []models.ComposeServiceChange
+	err     error
+}
+
+type ComposeServicesRecreatedMsg struct {
+	projectName string
+	services    []string
+	err         error
+}
+
+type ComposeFilesLoadedMsg struct {
+	project models.ComposeProject
+	files   []models.ComposeFile
+	err     error
+}
+
+type ComposeConfigHashesLoadedMsg struct {
+	projectName string
+	hashes      map[string]string
+	err         error
+}
+
+type ComposeDotEnvLoadedMsg struct {
+	project models.ComposeProject
+	file    models.DotEnvFile
+	err     error
+}
+
+type ComposeDotEnvSavedMsg struct {
+	project models.ComposeProject
+	path    string
+	err     error
+}
+
+type ComposeFileEditedMsg struct {
+	project models.ComposeProject
+	path    string
+	changed bool
+	err     error
+}
+
+type ComposeDownMsg struct {
+	projectName string
+	volumes     bool
+	images      bool
+	err         error
+}
+
+type ComposeUpMsg struct {
+	name  string
+	files []string
+	err   error
+}
+
+// Container cpuset messages
+type CpusetConfigLoadedMsg struct {
+	containerID   string
+	containerName string
+	cpus          string
+	mems          string
+	hostCPUs      int
+	err           error
+}
+
+type ContainerCpusetUpdatedMsg struct {
+	containerID string
+	cpus        string
+	err         error
+}
+
+// OperationDoneMsg carries the message of a tracked long operation
+type OperationDoneMsg struct {
+	id  int
+	msg tea.Msg
+}
+
+// Group logs dashboard messages
+type GroupLogsLoadedMsg struct {
+	groupID string
+	panes   []models.GroupLogPane
+	err     error
+}
+
+// Clock check messages
+type ClockCheckedMsg struct {
+	check *models.ClockCheck
+	err   error
+}
+
+// Port diagnostics messages
+type PortConflictsCheckedMsg struct {
+	conflicts   []models.PortConflict
+	hostChecked bool
+	err         error
+}
@@ -0,0 +1,360 @@
+// Package cli implements doui's non-interactive subcommands, for scripting
+// containers and groups without starting the TUI
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/rizface/doui/internal/config"
+	"github.com/rizface/doui/internal/docker"
+	"github.com/rizface/doui/internal/models"
+)
+
+// Exit codes
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+)
+
+// Usage lists the subcommands
+const Usage = `Usage:
+  doui [flags]                     start the TUI
+  doui ps [--all] [--json]         list containers with their groups
+  doui groups list [--json]        list groups and their containers
+  doui group start <name> [--json] start a group in dependency order
+  doui group stop <name> [--json]  stop a group (in reverse order if configured)
+`
+
+// Run executes a subcommand (args without the program name) and returns the
+// process exit code
+func Run(args []string) int {
+	r := &runner{stdout: os.Stdout, stderr: os.Stderr}
+	if len(args) == 0 {
+		fmt.Fprint(r.stderr, Usage)
+		return exitUsage
+	}
+
+	switch args[0] {
+	case "ps":
+		return r.ps(args[1:])
+	case "groups":
+		if len(args) < 2 || args[1] != "list" {
+			return r.usageError("groups: expected 'list'")
+		}
+		return r.groupsList(args[2:])
+	case "group":
+		if len(args) < 2 || (args[1] != "start" && args[1] != "stop") {
+			return r.usageError("group: expected 'start' or 'stop'")
+		}
+		return r.groupAction(args[1], args[2:])
+	case "help":
+		fmt.Fprint(r.stdout, Usage)
+		return exitOK
+	}
+	return r.usageError(fmt.Sprintf("unknown command %q", args[0]))
+}
+
+type runner struct {
+	stdout io.Writer
+	stderr io.Writer
+	json   bool
+}
+
+func (r *runner) usageError(msg string) int {
+	fmt.Fprintf(r.stderr, "Error: %s\n\n%s", msg, Usage)
+	return exitUsage
+}
+
+// fail reports an error, as a JSON object with --json so scripts can always
+// parse the output
+func (r *runner) fail(err error) int {
+	if r.json {
+		r.writeJSON(map[string]string{"error": err.Error()})
+	} else {
+		fmt.Fprintf(r.stderr, "Error: %v\n", err)
+	}
+	return exitError
+}
+
+func (r *runner) writeJSON(v any) {
+	enc := json.NewEncoder(r.stdout)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(v)
+}
+
+// parseFlags parses the flags of a subcommand, which may come before or after
+// its positional arguments
+func (r *runner) parseFlags(fs *flag.FlagSet, args []string) ([]string, error) {
+	fs.SetOutput(r.stderr)
+	fs.BoolVar(&r.json, "json", false, "print JSON")
+
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		if fs.NArg() == 0 {
+			return positional, nil
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
+
+// containerJSON is a container in the ps and groups output
+type containerJSON struct {
+	ID      string   `json:"id"`
+	Name    string   `json:"name"`
+	Image   string   `json:"image,omitempty"`
+	State   string   `json:"state"`
+	Status  string   `json:"status,omitempty"`
+	Ports   string   `json:"ports,omitempty"`
+	Project string   `json:"compose_project,omitempty"`
+	Groups  []string `json:"groups,omitempty"`
+}
+
+// groupJSON is a group in the groups output
+type groupJSON struct {
+	ID          string          `json:"id"`
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	OrderedStop bool            `json:"ordered_stop"`
+	Containers  []containerJSON `json:"containers"`
+}
+
+func (r *runner) ps(args []string) int {
+	fs := flag.NewFlagSet("ps", flag.ContinueOnError)
+	all := fs.Bool("all", false, "include stopped containers")
+	fs.BoolVar(all, "a", false, "include stopped containers")
+	if rest, err := r.parseFlags(fs, args); err != nil {
+		return exitUsage
+	} else if len(rest) > 0 {
+		return r.usageError("ps takes no arguments")
+	}
+
+	client, err := docker.NewClient()
+	if err != nil {
+		return r.fail(err)
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	containers, err := client.ListContainers(ctx, *all)
+	if err != nil {
+		return r.fail(err)
+	}
+
+	// Groups are optional here, a broken config file shouldn't hide containers
+	groupNames := make(map[string][]string)
+	if gm, err := config.NewGroupManager(); err == nil {
+		for _, g := range gm.GetAllGroups() {
+			ids, err := gm.GroupContainerIDs(g.ID)
+			if err != nil {
+				ids = g.ContainerIDs
+			}
+			for _, id := range ids {
+				groupNames[id] = append(groupNames[id], g.Name)
+			}
+		}
+	}
+
+	result := make([]containerJSON, 0, len(containers))
+	for _, c := range containers {
+		result = append(result, containerJSON{
+			ID:      c.ID,
+			Name:    c.Name,
+			Image:   c.Image,
+			State:   c.State,
+			Status:  c.Status,
+			Ports:   c.GetPortsString(),
+			Project: c.Labels["com.docker.compose.project"],
+			Groups:  groupNames[c.ID],
+		})
+	}
+
+	if r.json {
+		r.writeJSON(result)
+		return exitOK
+	}
+	w := tabwriter.NewWriter(r.stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tIMAGE\tSTATUS\tPORTS\tGROUPS")
+	for _, c := range result {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", shortID(c.ID), c.Name, c.Image, c.Status, c.Ports, strings.Join(c.Groups, ","))
+	}
+	w.Flush()
+	return exitOK
+}
+
+func (r *runner) groupsList(args []string) int {
+	fs := flag.NewFlagSet("groups list", flag.ContinueOnError)
+	if rest, err := r.parseFlags(fs, args); err != nil {
+		return exitUsage
+	} else if len(rest) > 0 {
+		return r.usageError("groups list takes no arguments")
+	}
+
+	gm, err := config.NewGroupManager()
+	if err != nil {
+		return r.fail(err)
+	}
+
+	// Container names and states need the daemon; without it groups are
+	// still listed with container IDs
+	byID := make(map[string]models.Container)
+	if client, err := docker.NewClient(); err == nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		containers, err := client.ListContainers(ctx, true)
+		cancel()
+		client.Close()
+		if err == nil {
+			for _, c := range containers {
+				byID[c.ID] = c
+			}
+		}
+	} else if !r.json {
+		fmt.Fprintf(r.stderr, "Warning: %v\n", err)
+	}
+
+	groups := gm.GetAllGroups()
+	result := make([]groupJSON, 0, len(groups))
+	for _, g := range groups {
+		// Members of included groups too; a cycle is reported when the
+		// group is started or stopped
+		ids, err := gm.GroupContainerIDs(g.ID)
+		if err != nil {
+			ids = g.ContainerIDs
+		}
+		group := groupJSON{
+			ID:          g.ID,
+			Name:        g.Name,
+			Description: g.Description,
+			OrderedStop: g.OrderedStop,
+			Containers:  make([]containerJSON, 0, len(ids)),
+		}
+		for _, id := range ids {
+			c := containerJSON{ID: id, State: "unknown"}
+			if ctr, ok := byID[id]; ok {
+				c.Name, c.Image, c.State, c.Status = ctr.Name, ctr.Image, ctr.State, ctr.Status
+			} else if len(byID) > 0 {
+				c.State = "missing"
+			}
+			group.Containers = append(group.Containers, c)
+		}
+		result = append(result, group)
+	}
+
+	if r.json {
+		r.writeJSON(result)
+		return exitOK
+	}
+	w := tabwriter.NewWriter(r.stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tCONTAINERS\tRUNNING\tDESCRIPTION")
+	for _, g := range result {
+		running := 0
+		for _, c := range g.Containers {
+			if c.State == "running" {
+				running++
+			}
+		}
+		fmt.Fprintf(w, "%s\t%d\t%d\t%s\n", g.Name, len(g.Containers), running, g.Description)
+	}
+	w.Flush()
+	return exitOK
+}
+
+func (r *runner) groupAction(action string, args []string) int {
+	fs := flag.NewFlagSet("group "+action, flag.ContinueOnError)
+	rest, err := r.parseFlags(fs, args)
+	if err != nil {
+		return exitUsage
+	}
+	if len(rest) != 1 {
+		return r.usageError(fmt.Sprintf("group %s takes one group name", action))
+	}
+
+	gm, err := config.NewGroupManager()
+	if err != nil {
+		return r.fail(err)
+	}
+	group := gm.GetGroupByName(rest[0])
+	if group == nil {
+		return r.fail(fmt.Errorf("group not found: %s", rest[0]))
+	}
+
+	client, err := docker.NewClient()
+	if err != nil {
+		return r.fail(err)
+	}
+	defer client.Close()
+
+	// Follow members recreated outside doui, like the TUI does before a group
+	// operation
+	listCtx, listCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	containers, err := client.ListContainers(listCtx, true)
+	listCancel()
+	if err != nil {
+		return r.fail(err)
+	}
+	if _, err := gm.ResolveContainers(containers); err != nil {
+		return r.fail(err)
+	}
+
+	// Same timeouts as the TUI: waiting for healthy dependencies on start,
+	// the configured grace periods on stop
+	if action == "start" {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+		start := func(ctx context.Context, containerID string) error {
+			return client.StartContainer(ctx, containerID)
+		}
+		waitHealthy := func(ctx context.Context, containerID string) error {
+			return client.WaitForHealthy(ctx, containerID)
+		}
+		err = gm.ExecuteGroupStartOrdered(ctx, group.ID, start, waitHealthy)
+	} else {
+		ctx, cancel := context.WithTimeout(context.Background(), group.StopTimeout())
+		defer cancel()
+		stop := func(ctx context.Context, containerID string, gracePeriod int) error {
+			return client.StopContainer(ctx, containerID, gracePeriod)
+		}
+		err = gm.ExecuteGroupStop(ctx, group.ID, stop)
+	}
+	if err != nil {
+		return r.fail(fmt.Errorf("failed to %s group %s: %w", action, group.Name, err))
+	}
+	ids, err := gm.GroupContainerIDs(group.ID)
+	if err != nil {
+		return r.fail(err)
+	}
+
+	if r.json {
+		r.writeJSON(map[string]any{"group": group.Name, "action": action, "containers": len(ids)})
+	} else {
+		fmt.Fprintf(r.stdout, "Group '%s': %s %d container(s)\n", group.Name, pastTense(action), len(ids))
+	}
+	return exitOK
+}
+
+func pastTense(action string) string {
+	if action == "stop" {
+		return "stopped"
+	}
+	return action + "ed"
+}
+
+func shortID(id string) string {
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
@@ -0,0 +1,84 @@
+package config
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/rizface/doui/internal/models"
+)
+
+// auditMu serializes appends, actions finish concurrently in tea commands
+var auditMu sync.Mutex
+
+// getAuditFilePath returns the path of the audit log in the config directory
+func getAuditFilePath() (string, error) {
+	configDir, err := EnsureConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "audit.log"), nil
+}
+
+// AppendAuditEntry adds an entry to the audit log, one JSON object per line.
+// The log is only ever appended to, delete the file to start over.
+func AppendAuditEntry(entry models.AuditEntry) error {
+	path, err := getAuditFilePath()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit entry: %w", err)
+	}
+
+	auditMu.Lock()
+	defer auditMu.Unlock()
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return nil
+}
+
+// LoadAuditLog returns every entry of the audit log, oldest first. Lines that
+// can't be parsed (e.g. cut short by a crash) are skipped.
+func LoadAuditLog() ([]models.AuditEntry, error) {
+	path, err := getAuditFilePath()
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer f.Close()
+
+	var entries []models.AuditEntry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var entry models.AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err == nil {
+			entries = append(entries, entry)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+	return entries, nil
+}
@@ -4,8 +4,30 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 )
 
+// Default range for free host port suggestions (below the Linux ephemeral range)
+const (
+	DefaultFreePortMin = 20000
+	DefaultFreePortMax = 32767
+)
+
+// DefaultRefreshInterval is how often the current view is refreshed
+const DefaultRefreshInterval = 2 * time.Second
+
+// MinRefreshInterval keeps auto-refresh from hammering the daemon
+const MinRefreshInterval = 500 * time.Millisecond
+
+// DefaultIdleAfter is how long without keyboard/mouse input before
+// auto-refresh slows down to IdleRefreshInterval
+const DefaultIdleAfter = 2 * time.Minute
+
+// IdleRefreshInterval is the auto-refresh interval while idle
+const IdleRefreshInterval = 30 * time.Second
+
 // GetConfigDir returns the configuration directory path
 // Priority: DOUI_CONFIG_PATH > $HOME/.config/doui > $HOME/.doui
 func GetConfigDir() (string, error) {
@@ -60,3 +82,78 @@ func GetConfigFilePath() (string, error) {
 
 	return filepath.Join(configDir, "config.json"), nil
 }
+
+// GetFreePortRange returns the host port range used when suggesting free ports
+// Override with DOUI_FREE_PORT_RANGE (e.g. "8000-9000")
+func GetFreePortRange() (int, int) {
+	spec := os.Getenv("DOUI_FREE_PORT_RANGE")
+	if spec == "" {
+		return DefaultFreePortMin, DefaultFreePortMax
+	}
+
+	minStr, maxStr, ok := strings.Cut(spec, "-")
+	if !ok {
+		return DefaultFreePortMin, DefaultFreePortMax
+	}
+	min, err1 := strconv.Atoi(strings.TrimSpace(minStr))
+	max, err2 := strconv.Atoi(strings.TrimSpace(maxStr))
+	if err1 != nil || err2 != nil || min < 1 || max > 65535 || min > max {
+		return DefaultFreePortMin, DefaultFreePortMax
+	}
+	return min, max
+}
+
+// GetRefreshInterval returns the auto-refresh interval from DOUI_REFRESH_INTERVAL,
+// falling back to the default if it is unset or invalid
+func GetRefreshInterval() time.Duration {
+	interval, err := ParseRefreshInterval(os.Getenv("DOUI_REFRESH_INTERVAL"))
+	if err != nil {
+		return DefaultRefreshInterval
+	}
+	return interval
+}
+
+// GetIdleAfter returns how long without input before auto-refresh slows down,
+// from DOUI_IDLE_AFTER (e.g. "5m"). "off" or "0" never slows down (returns 0).
+func GetIdleAfter() time.Duration {
+	spec := strings.ToLower(strings.TrimSpace(os.Getenv("DOUI_IDLE_AFTER")))
+	switch spec {
+	case "":
+		return DefaultIdleAfter
+	case "off", "0":
+		return 0
+	}
+
+	idleAfter, err := time.ParseDuration(spec)
+	if err != nil || idleAfter <= 0 {
+		return DefaultIdleAfter
+	}
+	return idleAfter
+}
+
+// ParseRefreshInterval parses a refresh interval such as "2s", "1m" or "10"
+// (seconds). "off", "manual" and "0" disable auto-refresh and return 0.
+func ParseRefreshInterval(spec string) (time.Duration, error) {
+	spec = strings.ToLower(strings.TrimSpace(spec))
+	switch spec {
+	case "":
+		return DefaultRefreshInterval, nil
+	case "off", "manual", "0":
+		return 0, nil
+	}
+
+	var interval time.Duration
+	if seconds, err := strconv.Atoi(spec); err == nil {
+		interval = time.Duration(seconds) * time.Second
+	} else {
+		interval, err = time.ParseDuration(spec)
+		if err != nil {
+			return 0, fmt.Errorf("invalid refresh interval %q: use a duration like 5s, or off", spec)
+		}
+	}
+
+	if interval < MinRefreshInterval {
+		return 0, fmt.Errorf("refresh interval %s is too short (minimum %s)", interval, MinRefreshInterval)
+	}
+	return interval, nil
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sync"
 	"time"
 
@@ -47,11 +48,22 @@ func (m *GroupManager) GetGroup(id string) *models.Group {
 	return m.config.FindGroup(id)
 }
 
-// CreateGroup creates a new group
-func (m *GroupManager) CreateGroup(name, description string, containerIDs []string) (*models.Group, error) {
+// GetGroupByName returns a group by name or ID
+func (m *GroupManager) GetGroupByName(name string) *models.Group {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.config.FindGroupByName(name)
+}
+
+// CreateGroup creates a new group, with the next color if color is empty
+func (m *GroupManager) CreateGroup(name, description, color string, containerIDs []string) (*models.Group, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	if color == "" {
+		color = selectColor(len(m.config.Groups))
+	}
 	group := models.Group{
 		ID:           uuid.New().String(),
 		Name:         name,
@@ -59,7 +71,7 @@ func (m *GroupManager) CreateGroup(name, description string, containerIDs []stri
 		ContainerIDs: containerIDs,
 		Created:      time.Now(),
 		Modified:     time.Now(),
-		Color:        selectColor(len(m.config.Groups)),
+		Color:        color,
 	}
 
 	m.config.AddGroup(group)
@@ -71,6 +83,75 @@ func (m *GroupManager) CreateGroup(name, description string, containerIDs []stri
 	return &group, nil
 }
 
+// NextColor returns the color a new group gets by default
+func (m *GroupManager) NextColor() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return selectColor(len(m.config.Groups))
+}
+
+// GetGroupByHotkey returns the group bound to a hotkey, nil if none
+func (m *GroupManager) GetGroupByHotkey(key string) *models.Group {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for i := range m.config.Groups {
+		if key != "" && m.config.Groups[i].Hotkey == key {
+			group := m.config.Groups[i]
+			return &group
+		}
+	}
+	return nil
+}
+
+// EditGroup sets the name, description, color, hotkey, included groups and
+// schedules of the group with the ID of details to those of details
+func (m *GroupManager) EditGroup(details models.Group) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	groupID := details.ID
+	group := m.config.FindGroup(groupID)
+	if group == nil {
+		return fmt.Errorf("group not found: %s", groupID)
+	}
+	if err := details.ValidateSchedules(); err != nil {
+		return err
+	}
+	if details.Hotkey != "" {
+		if !models.ValidHotkey(details.Hotkey) {
+			return fmt.Errorf("invalid hotkey %q: use a letter or digit", details.Hotkey)
+		}
+		for _, g := range m.config.Groups {
+			if g.ID != groupID && g.Hotkey == details.Hotkey {
+				return fmt.Errorf("hotkey %s is already used by group %s", details.Hotkey, g.Name)
+			}
+		}
+	}
+
+	previous := *group
+	updated := *group
+	updated.Name = details.Name
+	updated.Description = details.Description
+	updated.Color = details.Color
+	updated.Hotkey = details.Hotkey
+	updated.GroupIDs = details.GroupIDs
+	updated.StartSchedule = details.StartSchedule
+	updated.StopSchedule = details.StopSchedule
+
+	if !m.config.UpdateGroup(updated) {
+		return fmt.Errorf("failed to update group")
+	}
+	// A cycle leaves the stored group untouched
+	if err := m.config.CheckIncludes(groupID); err != nil {
+		m.config.UpdateGroup(previous)
+		return err
+	}
+
+	return m.save()
+}
+
 // UpdateGroup updates an existing group
 func (m *GroupManager) UpdateGroup(group models.Group) error {
 	m.mu.Lock()
@@ -141,6 +222,7 @@ func (m *GroupManager) RemoveContainerFromGroup(groupID, containerID string) err
 	}
 
 	group.ContainerIDs = newContainerIDs
+	group.RemoveDependencyID(containerID)
 	group.Modified = time.Now()
 
 	if !m.config.UpdateGroup(*group) {
@@ -150,6 +232,88 @@ func (m *GroupManager) RemoveContainerFromGroup(groupID, containerID string) err
 	return m.save()
 }
 
+// SetContainerDependencies sets which containers must start before containerID,
+// whether its dependents wait for it to become healthy, how many seconds they
+// wait after it started and its stop grace period
+func (m *GroupManager) SetContainerDependencies(groupID, containerID string, dependsOn []string, waitHealthy bool, startDelay, stopGracePeriod int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	group := m.config.FindGroup(groupID)
+	if group == nil {
+		return fmt.Errorf("group not found: %s", groupID)
+	}
+
+	// Validate against a copy so a cycle leaves the stored group untouched
+	updated := *group
+	updated.DependsOn = make(map[string][]string, len(group.DependsOn)+1)
+	for id, deps := range group.DependsOn {
+		updated.DependsOn[id] = deps
+	}
+	updated.WaitHealthy = make(map[string]bool, len(group.WaitHealthy)+1)
+	for id, wait := range group.WaitHealthy {
+		updated.WaitHealthy[id] = wait
+	}
+
+	if len(dependsOn) > 0 {
+		updated.DependsOn[containerID] = dependsOn
+	} else {
+		delete(updated.DependsOn, containerID)
+	}
+	if waitHealthy {
+		updated.WaitHealthy[containerID] = true
+	} else {
+		delete(updated.WaitHealthy, containerID)
+	}
+	updated.StartDelay = make(map[string]int, len(group.StartDelay)+1)
+	for id, delay := range group.StartDelay {
+		updated.StartDelay[id] = delay
+	}
+	if startDelay > 0 {
+		updated.StartDelay[containerID] = startDelay
+	} else {
+		delete(updated.StartDelay, containerID)
+	}
+	updated.StopGracePeriod = make(map[string]int, len(group.StopGracePeriod)+1)
+	for id, grace := range group.StopGracePeriod {
+		updated.StopGracePeriod[id] = grace
+	}
+	if stopGracePeriod != models.DefaultStopGracePeriod {
+		updated.StopGracePeriod[containerID] = stopGracePeriod
+	} else {
+		delete(updated.StopGracePeriod, containerID)
+	}
+
+	if _, err := updated.StartOrder(); err != nil {
+		return err
+	}
+
+	if !m.config.UpdateGroup(updated) {
+		return fmt.Errorf("failed to update group")
+	}
+
+	return m.save()
+}
+
+// SetOrderedStop sets whether a group stops in reverse start order
+func (m *GroupManager) SetOrderedStop(groupID string, ordered bool) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	group := m.config.FindGroup(groupID)
+	if group == nil {
+		return fmt.Errorf("group not found: %s", groupID)
+	}
+
+	updated := *group
+	updated.OrderedStop = ordered
+	if !m.config.UpdateGroup(updated) {
+		return fmt.Errorf("failed to update group")
+	}
+
+	return m.save()
+}
+
 // ReplaceContainerID replaces oldID with newID in all groups
 // This is used when a container is recreated (e.g., after env var changes)
 func (m *GroupManager) ReplaceContainerID(oldID, newID string) error {
@@ -162,6 +326,7 @@ func (m *GroupManager) ReplaceContainerID(oldID, newID string) error {
 		for j, id := range group.ContainerIDs {
 			if id == oldID {
 				group.ContainerIDs[j] = newID
+				group.ReplaceDependencyID(oldID, newID)
 				group.Modified = time.Now()
 				modified = true
 				break // Container can only be in the group once
@@ -175,6 +340,26 @@ func (m *GroupManager) ReplaceContainerID(oldID, newID string) error {
 	return nil
 }
 
+// ResolveContainers matches the members of every group with the existing
+// containers, following members recreated outside doui by name (see
+// Group.ResolveContainers). Returns true if a group changed.
+func (m *GroupManager) ResolveContainers(containers []models.Container) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	modified := false
+	for i := range m.config.Groups {
+		if m.config.Groups[i].ResolveContainers(containers) {
+			modified = true
+		}
+	}
+
+	if modified {
+		return true, m.save()
+	}
+	return false, nil
+}
+
 // RemoveContainerFromAllGroups removes a container ID from all groups
 // This is used when a container is deleted
 func (m *GroupManager) RemoveContainerFromAllGroups(containerID string) error {
@@ -194,6 +379,7 @@ func (m *GroupManager) RemoveContainerFromAllGroups(containerID string) error {
 		}
 		if len(newContainerIDs) != len(group.ContainerIDs) {
 			group.ContainerIDs = newContainerIDs
+			group.RemoveDependencyID(containerID)
 			group.Modified = time.Now()
 		}
 	}
@@ -204,31 +390,123 @@ func (m *GroupManager) RemoveContainerFromAllGroups(containerID string) error {
 	return nil
 }
 
+// IsWatched returns true if the container is watched for exits
+func (m *GroupManager) IsWatched(name string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return slices.Contains(m.config.Watched, name)
+}
+
+// WatchedNames returns the names of the watched containers
+func (m *GroupManager) WatchedNames() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return slices.Clone(m.config.Watched)
+}
+
+// ToggleWatched starts or stops watching a container and returns whether it
+// is watched now
+func (m *GroupManager) ToggleWatched(name string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if i := slices.Index(m.config.Watched, name); i >= 0 {
+		m.config.Watched = slices.Delete(m.config.Watched, i, i+1)
+		return false, m.save()
+	}
+	m.config.Watched = append(m.config.Watched, name)
+	return true, m.save()
+}
+
+// ProtectedImages returns the tags (or IDs) of the protected images
+func (m *GroupManager) ProtectedImages() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return slices.Clone(m.config.Protected)
+}
+
+// ToggleProtected protects an image from removal in doui, or lifts the
+// protection, and returns whether it is protected now
+func (m *GroupManager) ToggleProtected(img models.Image) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if img.IsProtected(m.config.Protected) {
+		m.config.Protected = slices.DeleteFunc(m.config.Protected, func(ref string) bool {
+			return img.IsProtected([]string{ref})
+		})
+		return false, m.save()
+	}
+	m.config.Protected = append(m.config.Protected, img.ProtectionRef())
+	return true, m.save()
+}
+
 // save persists the config to disk (caller must hold lock)
 func (m *GroupManager) save() error {
 	m.config.LastModified = time.Now()
 	return SaveConfig(m.config)
 }
 
-// StartGroup starts all containers in a group
+// ContainerOperation runs an action on a container
 type ContainerOperation func(context.Context, string) error
 
+// ExecuteGroupOperation runs operation on all of a group's containers in
+// parallel, including those of the groups it includes. Starting a group goes
+// through ExecuteGroupStartOrdered instead, which honors the start order.
 func (m *GroupManager) ExecuteGroupOperation(ctx context.Context, groupID string, operation ContainerOperation) error {
-	group := m.GetGroup(groupID)
+	containerIDs, err := m.GroupContainerIDs(groupID)
+	if err != nil {
+		return err
+	}
+
+	return executeParallel(ctx, containerIDs, operation)
+}
+
+// GroupContainerIDs returns the containers of a group and of the groups it
+// includes, recursively, each once
+func (m *GroupManager) GroupContainerIDs(groupID string) ([]string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.config.FindGroup(groupID) == nil {
+		return nil, fmt.Errorf("group not found: %s", groupID)
+	}
+	if err := m.config.CheckIncludes(groupID); err != nil {
+		return nil, err
+	}
+	return m.config.AllContainerIDs(groupID), nil
+}
+
+// includedGroups returns the groups a group includes, erroring if it
+// includes itself
+func (m *GroupManager) includedGroups(groupID string) (*models.Group, []string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	group := m.config.FindGroup(groupID)
 	if group == nil {
-		return fmt.Errorf("group not found: %s", groupID)
+		return nil, nil, fmt.Errorf("group not found: %s", groupID)
+	}
+	if err := m.config.CheckIncludes(groupID); err != nil {
+		return nil, nil, err
 	}
+	copied := *group
+	return &copied, slices.Clone(group.GroupIDs), nil
+}
 
+// executeParallel runs operation for each container ID in parallel and
+// collects the errors
+func executeParallel(ctx context.Context, containerIDs []string, operation ContainerOperation) error {
 	type result struct {
 		containerID string
 		err         error
 	}
 
-	results := make(chan result, len(group.ContainerIDs))
+	results := make(chan result, len(containerIDs))
 	var wg sync.WaitGroup
 
 	// Execute operations in parallel
-	for _, containerID := range group.ContainerIDs {
+	for _, containerID := range containerIDs {
 		wg.Add(1)
 		go func(id string) {
 			defer wg.Done()
@@ -255,8 +533,93 @@ func (m *GroupManager) ExecuteGroupOperation(ctx context.Context, groupID string
 	return nil
 }
 
+// ExecuteGroupStartOrdered starts a group's containers step by step in dependency
+// order. Containers within a step start in parallel; before moving to the next
+// step, waitHealthy is called for every container in the step marked to be
+// waited on, then the longest start delay of the step is waited out. The
+// groups it includes are started first, in parallel, each in its own order.
+func (m *GroupManager) ExecuteGroupStartOrdered(ctx context.Context, groupID string, start, waitHealthy ContainerOperation) error {
+	group, included, err := m.includedGroups(groupID)
+	if err != nil {
+		return err
+	}
+
+	startIncluded := func(ctx context.Context, id string) error {
+		return m.ExecuteGroupStartOrdered(ctx, id, start, waitHealthy)
+	}
+	if err := executeParallel(ctx, included, startIncluded); err != nil {
+		return err
+	}
+
+	steps, err := group.StartOrder()
+	if err != nil {
+		return err
+	}
+
+	for i, step := range steps {
+		if err := executeParallel(ctx, step, start); err != nil {
+			return err
+		}
+
+		var waitIDs []string
+		delay := 0
+		for _, id := range step {
+			if group.WaitHealthy[id] {
+				waitIDs = append(waitIDs, id)
+			}
+			delay = max(delay, group.GetStartDelay(id))
+		}
+		if err := executeParallel(ctx, waitIDs, waitHealthy); err != nil {
+			return err
+		}
+
+		// Nothing waits on the last step
+		if delay > 0 && i < len(steps)-1 {
+			select {
+			case <-time.After(time.Duration(delay) * time.Second):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+
+	return nil
+}
+
+// StopOperation stops a container, giving it gracePeriod seconds before it is killed
+type StopOperation func(ctx context.Context, containerID string, gracePeriod int) error
+
+// ExecuteGroupStop stops a group's containers, step by step in reverse start
+// order if the group has OrderedStop set, otherwise all in parallel. Each
+// container gets its configured grace period. The groups it includes are
+// stopped last, in parallel.
+func (m *GroupManager) ExecuteGroupStop(ctx context.Context, groupID string, stop StopOperation) error {
+	group, included, err := m.includedGroups(groupID)
+	if err != nil {
+		return err
+	}
+
+	steps, err := group.StopOrder()
+	if err != nil {
+		return err
+	}
+
+	operation := func(ctx context.Context, containerID string) error {
+		return stop(ctx, containerID, group.GetStopGracePeriod(containerID))
+	}
+	for _, step := range steps {
+		if err := executeParallel(ctx, step, operation); err != nil {
+			return err
+		}
+	}
+
+	stopIncluded := func(ctx context.Context, id string) error {
+		return m.ExecuteGroupStop(ctx, id, stop)
+	}
+	return executeParallel(ctx, included, stopIncluded)
+}
+
 // selectColor selects a color for a new group based on index
 func selectColor(index int) string {
-	colors := []string{"blue", "green", "yellow", "magenta", "cyan", "red"}
-	return colors[index%len(colors)]
+	return models.GroupColors[index%len(models.GroupColors)]
 }
@@ -4,8 +4,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"slices"
+	"strings"
 
 	"github.com/rizface/doui/internal/models"
+	"github.com/rizface/doui/pkg/utils"
 )
 
 // LoadConfig loads the configuration from disk
@@ -35,6 +38,58 @@ func LoadConfig() (*models.GroupConfig, error) {
 	return &config, nil
 }
 
+// LoadKeybindings returns the key remaps from the config file
+func LoadKeybindings() (map[string][]string, error) {
+	config, err := LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	return config.Keybindings, nil
+}
+
+// LoadQuickActions returns the actions pinned to the quick-action bar of
+// each view from the config file
+func LoadQuickActions() (map[string][]string, error) {
+	config, err := LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	return config.QuickActions, nil
+}
+
+// LoadTheme returns the theme name and custom themes from the config file.
+// DOUI_THEME overrides the theme name.
+func LoadTheme() (string, map[string]map[string]string, error) {
+	config, err := LoadConfig()
+	if err != nil {
+		return os.Getenv("DOUI_THEME"), nil, err
+	}
+	name := config.Theme
+	if env := os.Getenv("DOUI_THEME"); env != "" {
+		name = env
+	}
+	return name, config.Themes, nil
+}
+
+// LoadNotifyMethod returns how watched containers are notified about, from
+// the config file or DOUI_NOTIFY, "auto" if unset
+func LoadNotifyMethod() (string, error) {
+	method := os.Getenv("DOUI_NOTIFY")
+	if method == "" {
+		// Errors reading the config file itself are reported by the group manager
+		if config, err := LoadConfig(); err == nil {
+			method = config.Notify
+		}
+	}
+	if method == "" {
+		return utils.NotifyAuto, nil
+	}
+	if !slices.Contains(utils.NotifyMethods, method) {
+		return utils.NotifyAuto, fmt.Errorf("unknown notification method %q (expected one of %s)", method, strings.Join(utils.NotifyMethods, ", "))
+	}
+	return method, nil
+}
+
 // SaveConfig saves the configuration to disk using atomic write
 func SaveConfig(config *models.GroupConfig) error {
 	configPath, err := GetConfigFilePath()
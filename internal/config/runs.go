@@ -0,0 +1,32 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/rizface/doui/internal/models"
+)
+
+// SaveRunOutput writes the full output of a one-shot run to the runs
+// directory of the config dir and returns the file path
+func SaveRunOutput(result *models.RunResult) (string, error) {
+	configDir, err := EnsureConfigDir()
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(configDir, "runs")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create runs directory: %w", err)
+	}
+
+	id := result.ContainerID
+	if len(id) > 12 {
+		id = id[:12]
+	}
+	path := filepath.Join(dir, fmt.Sprintf("%s-%s.log", result.Started.Format("20060102-150405"), id))
+	if err := os.WriteFile(path, []byte(result.Output), 0644); err != nil {
+		return "", fmt.Errorf("failed to save run output: %w", err)
+	}
+	return path, nil
+}
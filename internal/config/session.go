@@ -0,0 +1,65 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/rizface/doui/internal/models"
+)
+
+// getSessionFilePath returns the path of the file the last session is kept in,
+// next to the config file
+func getSessionFilePath() (string, error) {
+	configDir, err := EnsureConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "session.json"), nil
+}
+
+// LoadSession returns the session saved on the last exit, or nil if there is none
+func LoadSession() (*models.Session, error) {
+	path, err := getSessionFilePath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read session file: %w", err)
+	}
+
+	var session models.Session
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, fmt.Errorf("failed to parse session file: %w", err)
+	}
+	return &session, nil
+}
+
+// SaveSession saves the session to restore on the next launch
+func SaveSession(session models.Session) error {
+	path, err := getSessionFilePath()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(session, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal session: %w", err)
+	}
+
+	// Write to a temporary file first so a crash can't leave half a file
+	tmpFile := path + ".tmp"
+	if err := os.WriteFile(tmpFile, data, 0644); err != nil {
+		return fmt.Errorf("failed to write session file: %w", err)
+	}
+	if err := os.Rename(tmpFile, path); err != nil {
+		return fmt.Errorf("failed to rename temp session file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+)
+
+// countingWriter counts the bytes written through it, for progress display
+type countingWriter struct {
+	w io.Writer
+	n *atomic.Int64
+}
+
+func (c countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n.Add(int64(n))
+	return n, err
+}
+
+// countingReader counts the bytes read through it, for progress display
+type countingReader struct {
+	r io.Reader
+	n *atomic.Int64
+}
+
+func (c countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n.Add(int64(n))
+	return n, err
+}
+
+// SaveImage writes an image to a tar archive on this machine, like
+// `docker save -o path ref`, adding the bytes written so far to written. The
+// archive is written next to path first, so a failed save leaves no file
+// behind, and an existing file is never overwritten.
+func (c *Client) SaveImage(ctx context.Context, ref, path string, written *atomic.Int64) (err error) {
+	defer func() { c.logAction("image.save", ref, path, err) }()
+
+	path, err = expandHome(path)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("%s already exists", path)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	defer func() {
+		if err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	archive, err := c.cli.ImageSave(ctx, []string{ref})
+	if err != nil {
+		return fmt.Errorf("failed to save image %s: %w", ref, err)
+	}
+	defer archive.Close()
+
+	if _, err = io.Copy(countingWriter{w: tmp, n: written}, archive); err != nil {
+		return fmt.Errorf("failed to save image %s: %w", ref, err)
+	}
+	if err = tmp.Chmod(0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// loadEvent is a message of the daemon's image load stream
+type loadEvent struct {
+	Stream string `json:"stream"`
+	Error  string `json:"error"`
+}
+
+// LoadImage loads the images of a tar archive on this machine, like
+// `docker load -i path`, and returns the tags (or IDs of untagged images)
+// loaded. The archive's size is stored in total and the bytes sent so far
+// added to read.
+func (c *Client) LoadImage(ctx context.Context, path string, read, total *atomic.Int64) (loaded []string, err error) {
+	defer func() { c.logAction("image.load", path, strings.Join(loaded, ","), err) }()
+
+	path, err = expandHome(path)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer file.Close()
+	if info, err := file.Stat(); err == nil {
+		total.Store(info.Size())
+	}
+
+	resp, err := c.cli.ImageLoad(ctx, countingReader{r: file, n: read}, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	decoder := json.NewDecoder(resp.Body)
+	for {
+		var event loadEvent
+		if err := decoder.Decode(&event); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return loaded, fmt.Errorf("failed to read load response: %w", err)
+		}
+		if event.Error != "" {
+			return loaded, fmt.Errorf("failed to load %s: %s", path, event.Error)
+		}
+		for _, line := range strings.Split(event.Stream, "\n") {
+			if ref, ok := strings.CutPrefix(line, "Loaded image: "); ok {
+				loaded = append(loaded, strings.TrimSpace(ref))
+			} else if id, ok := strings.CutPrefix(line, "Loaded image ID: "); ok {
+				loaded = append(loaded, strings.TrimSpace(id))
+			}
+		}
+	}
+	if len(loaded) == 0 {
+		return nil, fmt.Errorf("no images found in %s", path)
+	}
+	return loaded, nil
+}
@@ -0,0 +1,51 @@
+package docker
+
+import (
+	"os/user"
+	"sync"
+	"time"
+
+	"github.com/rizface/doui/internal/models"
+)
+
+// auditLog records the mutating actions of every client, nil to not record
+var auditLog func(models.AuditEntry) error
+
+// SetAuditLog sets where the mutating actions of all clients are recorded
+func SetAuditLog(log func(models.AuditEntry) error) {
+	auditLog = log
+}
+
+// auditUser is the OS user running doui, looked up once
+var auditUser = sync.OnceValue(func() string {
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+	return ""
+})
+
+// audit records the outcome of a mutating action, meant to be deferred with
+// the function's named error result
+func (c *Client) audit(action, target string, err *error) {
+	c.logAction(action, target, "", *err)
+}
+
+// logAction records a mutating action. A failing audit log never fails the
+// action itself.
+func (c *Client) logAction(action, target, detail string, err error) {
+	if auditLog == nil {
+		return
+	}
+	entry := models.AuditEntry{
+		Time:   time.Now(),
+		Action: action,
+		Target: target,
+		Detail: detail,
+		Host:   c.DaemonHost(),
+		User:   auditUser(),
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	_ = auditLog(entry)
+}
@@ -2,37 +2,171 @@ package docker
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/docker/docker/api/types/registry"
 	"github.com/docker/docker/client"
+	"github.com/rizface/doui/internal/models"
 )
 
 // Client wraps the Docker SDK client
 type Client struct {
-	cli *client.Client
+	cli  *client.Client
+	host string // Address as configured, e.g. ssh://user@host
+
+	// Platform info for architecture mismatch warnings, cached because
+	// image IDs are content addressed and never change platform
+	platformMu sync.Mutex
+	hostArch   string
+	imageArch  map[string]string // Image ID -> "os/arch"
+
+	// Runtime and privileges of containers, which can't change after create
+	runtimes map[string]models.RuntimeInfo // Container ID -> runtime
+
+	// Vulnerability scans of this session, by image ID
+	scanMu sync.Mutex
+	scans  map[string]*models.VulnScan
+
+	// Registry logins of this session, by registry host
+	authMu sync.Mutex
+	auths  map[string]registry.AuthConfig
 }
 
 // NewClient creates a new Docker client with connectivity verification
 func NewClient() (*Client, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	if host := ConfiguredHost(); IsSSHHost(host) {
+		return newClientForHost(host)
+	}
+	return newClient("", client.FromEnv, client.WithAPIVersionNegotiation())
+}
+
+// newClientForHost creates a client for an explicit daemon address
+func newClientForHost(host string, extra ...client.Opt) (*Client, error) {
+	opts := []client.Opt{client.WithAPIVersionNegotiation()}
+	if IsSSHHost(host) {
+		sshOpts, err := sshClientOpts(host)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, sshOpts...)
+	} else {
+		opts = append(opts, client.WithHost(host))
+	}
+	return newClient(host, append(opts, extra...)...)
+}
+
+// NewClientForContext creates a Docker client for a docker CLI context
+func NewClientForContext(dockerCtx models.DockerContext) (*Client, error) {
+	if dockerCtx.IsDefault() {
+		return NewClient()
+	}
+
+	var tlsOpts []client.Opt
+	if dockerCtx.TLSDir != "" {
+		tlsOpts = append(tlsOpts, client.WithTLSClientConfig(
+			filepath.Join(dockerCtx.TLSDir, "ca.pem"),
+			filepath.Join(dockerCtx.TLSDir, "cert.pem"),
+			filepath.Join(dockerCtx.TLSDir, "key.pem"),
+		))
+	}
+	return newClientForHost(dockerCtx.Host, tlsOpts...)
+}
+
+// newClient creates a client with the given options and pings the daemon
+func newClient(host string, opts ...client.Opt) (*Client, error) {
+	// SSH needs time for the handshake (and maybe a host key prompt in ssh-agent)
+	timeout := 5 * time.Second
+	if IsSSHHost(host) {
+		timeout = 30 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
-	cli, err := client.NewClientWithOpts(
-		client.FromEnv,
-		client.WithAPIVersionNegotiation(),
-	)
+	cli, err := client.NewClientWithOpts(opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create docker client: %w", err)
 	}
 
-	// Verify connectivity
-	_, err = cli.Ping(ctx)
+	// Verify connectivity, and settle the API version right away so features
+	// can be gated before the first real request
+	ping, err := cli.Ping(ctx)
 	if err != nil {
+		cli.Close()
 		return nil, fmt.Errorf("docker daemon not reachable: %w", err)
 	}
+	cli.NegotiateAPIVersionPing(ping)
+
+	return &Client{cli: cli, host: host}, nil
+}
 
-	return &Client{cli: cli}, nil
+// ConfiguredHost returns the daemon address doui connects to: DOCKER_HOST if
+// set, otherwise the platform default socket
+func ConfiguredHost() string {
+	if host := os.Getenv("DOCKER_HOST"); host != "" {
+		return host
+	}
+	return client.DefaultDockerHost
+}
+
+// IsPermissionError returns true if the daemon socket exists but the current
+// user may not access it
+func IsPermissionError(err error) bool {
+	return err != nil && strings.Contains(strings.ToLower(err.Error()), "permission denied")
+}
+
+// IsConnectionError returns true if err means the Docker daemon could not be
+// reached (e.g. it is restarting, or its ssh tunnel dropped), as opposed to a
+// failed operation
+func IsConnectionError(err error) bool {
+	var tunnelErr *sshTunnelError
+	return client.IsErrConnectionFailed(err) || errors.As(err, &tunnelErr)
+}
+
+// Ping checks that the daemon still answers and returns the round trip time
+func (c *Client) Ping(ctx context.Context) (time.Duration, error) {
+	start := time.Now()
+	if _, err := c.cli.Ping(ctx); err != nil {
+		return 0, err
+	}
+	return time.Since(start), nil
+}
+
+// DaemonHost returns the address of the Docker daemon (e.g. unix:///var/run/docker.sock)
+func (c *Client) DaemonHost() string {
+	if c.host != "" {
+		return c.host
+	}
+	return c.cli.DaemonHost()
+}
+
+// IsRemote returns true if the daemon is reached over the network rather than a local socket
+func (c *Client) IsRemote() bool {
+	host := c.DaemonHost()
+	return !strings.HasPrefix(host, "unix://") && !strings.HasPrefix(host, "npipe://")
+}
+
+// APIVersion returns the API version negotiated with the daemon
+func (c *Client) APIVersion() string {
+	return c.cli.ClientVersion()
+}
+
+// Supports returns true if the daemon's API version has the feature
+func (c *Client) Supports(f models.Feature) bool {
+	return models.SupportsFeature(c.APIVersion(), f)
+}
+
+// require returns an UnsupportedFeatureError if the daemon is too old for f
+func (c *Client) require(f models.Feature) error {
+	if !c.Supports(f) {
+		return &models.UnsupportedFeatureError{Feature: f, APIVersion: c.APIVersion()}
+	}
+	return nil
 }
 
 // Close closes the Docker client connection
@@ -0,0 +1,83 @@
+package docker
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/rizface/doui/internal/models"
+)
+
+// CheckClock reads the clock of a running container with exec `date +%s` and
+// compares it with the daemon host's (from docker info) and doui's own.
+// Containers share the host kernel's clock, so drift points at libfaketime,
+// a time namespace or a VM (Docker Desktop) whose clock fell behind.
+func (c *Client) CheckClock(ctx context.Context, containerID string) (*models.ClockCheck, error) {
+	containerTime, containerAt, containerRTT, err := c.containerClock(ctx, containerID)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	info, err := c.cli.Info(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get daemon time: %w", err)
+	}
+	infoRTT := time.Since(start)
+	daemonTime, err := time.Parse(time.RFC3339Nano, info.SystemTime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse daemon time %q: %w", info.SystemTime, err)
+	}
+	hostOffset := daemonTime.Sub(start.Add(infoRTT / 2))
+
+	// date truncates to the second, so its reading is on average half a
+	// second behind
+	skew := containerTime.Add(500*time.Millisecond).Sub(containerAt) - hostOffset
+
+	return &models.ClockCheck{
+		ContainerTime: containerTime,
+		Skew:          skew,
+		HostOffset:    hostOffset,
+		Uncertainty:   time.Second + (containerRTT+infoRTT)/2,
+	}, nil
+}
+
+// containerClock returns the container's time, doui's time halfway through
+// the exec, and how long the exec took
+func (c *Client) containerClock(ctx context.Context, containerID string) (time.Time, time.Time, time.Duration, error) {
+	exec, err := c.cli.ContainerExecCreate(ctx, containerID, container.ExecOptions{
+		Cmd:          []string{"date", "+%s"},
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		return time.Time{}, time.Time{}, 0, fmt.Errorf("failed to exec date in container %s: %w", containerID, err)
+	}
+
+	start := time.Now()
+	resp, err := c.cli.ContainerExecAttach(ctx, exec.ID, container.ExecAttachOptions{})
+	if err != nil {
+		return time.Time{}, time.Time{}, 0, fmt.Errorf("failed to attach to date in container %s: %w", containerID, err)
+	}
+	defer resp.Close()
+
+	var stdout, stderr bytes.Buffer
+	if _, err := stdcopy.StdCopy(&stdout, &stderr, resp.Reader); err != nil {
+		return time.Time{}, time.Time{}, 0, fmt.Errorf("failed to read date output: %w", err)
+	}
+	rtt := time.Since(start)
+
+	seconds, err := strconv.ParseInt(strings.TrimSpace(stdout.String()), 10, 64)
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return time.Time{}, time.Time{}, 0, fmt.Errorf("date failed in the container: %s", msg)
+		}
+		return time.Time{}, time.Time{}, 0, fmt.Errorf("unexpected date output %q (is there a date binary in the image?)", strings.TrimSpace(stdout.String()))
+	}
+	return time.Unix(seconds, 0), start.Add(rtt / 2), rtt, nil
+}
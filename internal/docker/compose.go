@@ -1,9 +1,15 @@
 package docker
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/docker/docker/api/types/container"
@@ -33,6 +39,7 @@ func (c *Client) ListComposeProjects(ctx context.Context) ([]models.ComposeProje
 		serviceName := ctr.Labels["com.docker.compose.service"]
 		configHash := ctr.Labels["com.docker.compose.config-hash"]
 		workingDir := ctr.Labels["com.docker.compose.project.working_dir"]
+		configFiles := ctr.Labels["com.docker.compose.project.config_files"]
 
 		// Get or create project
 		project, exists := projectMap[projectName]
@@ -42,6 +49,7 @@ func (c *Client) ListComposeProjects(ctx context.Context) ([]models.ComposeProje
 				Services:     []models.ComposeService{},
 				ConfigHash:   configHash,
 				WorkingDir:   workingDir,
+				ConfigFiles:  splitConfigFiles(configFiles),
 				ContainerIDs: []string{},
 			}
 			projectMap[projectName] = project
@@ -102,6 +110,394 @@ func (c *Client) ListComposeProjects(ctx context.Context) ([]models.ComposeProje
 	return result, nil
 }
 
+// splitConfigFiles splits the comma separated config_files compose label
+func splitConfigFiles(label string) []string {
+	var files []string
+	for _, file := range strings.Split(label, ",") {
+		if file = strings.TrimSpace(file); file != "" {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+// ValidateComposeConfig runs `docker compose config` on a project's compose
+// files and collects the reported errors and warnings. The files are read on
+// this machine, so projects started elsewhere (remote daemons) can't be checked.
+func (c *Client) ValidateComposeConfig(ctx context.Context, project models.ComposeProject) (*models.ComposeConfigCheck, error) {
+	active, all := composeProfiles(ctx, project)
+	cmd, err := composeCommand(ctx, project, active, "config", "--quiet")
+	if err != nil {
+		return nil, err
+	}
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	err = cmd.Run()
+
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		return nil, fmt.Errorf("failed to run docker compose config: %w", err)
+	}
+
+	files, override := composeFiles(project)
+	check := &models.ComposeConfigCheck{
+		Files:       files,
+		Override:    override,
+		Profiles:    active,
+		AllProfiles: all,
+	}
+	check.Warnings, check.Errors = models.ParseComposeConfigOutput(stderr.String(), err != nil)
+	if err != nil && len(check.Errors) == 0 {
+		check.Errors = []string{err.Error()}
+	}
+	return check, nil
+}
+
+// composeFiles returns the files a project is run with: those it was started
+// from plus an override file compose would now pick up from its working dir
+func composeFiles(project models.ComposeProject) (files []string, override string) {
+	files = project.ConfigFiles
+	override = models.ComposeOverrideFile(project.WorkingDir, files, func(path string) bool {
+		_, err := os.Stat(path)
+		return err == nil
+	})
+	if override != "" {
+		files = append(files[:len(files):len(files)], override)
+	}
+	return files, override
+}
+
+// maxComposeFileSize is the largest compose file ReadComposeFiles reads
+const maxComposeFileSize = 1 << 20
+
+// ReadComposeFiles reads the compose files a project is run with, including
+// an override file picked up from its working dir. Without a config files
+// label, the default compose file of the working dir is read. The files are
+// read on this machine.
+func ReadComposeFiles(project models.ComposeProject) ([]models.ComposeFile, error) {
+	files, override := composeFiles(project)
+	if len(files) == 0 && project.WorkingDir != "" {
+		if file := models.DefaultComposeFile(project.WorkingDir, fileExists); file != "" {
+			files = []string{file}
+		}
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("compose project %s has no working dir or config files label", project.Name)
+	}
+
+	result := make([]models.ComposeFile, 0, len(files))
+	for _, path := range files {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("compose file %s not found on this machine: %w", path, err)
+		}
+		if info.Size() > maxComposeFileSize {
+			return nil, fmt.Errorf("compose file %s is too large (%d bytes)", path, info.Size())
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		result = append(result, models.ComposeFile{Path: path, Content: string(data), Override: path == override})
+	}
+	return result, nil
+}
+
+// ReadComposeDotEnv reads the .env file in a project's working dir. The
+// file is read on this machine.
+func ReadComposeDotEnv(project models.ComposeProject) (models.DotEnvFile, error) {
+	if project.WorkingDir == "" {
+		return models.DotEnvFile{}, fmt.Errorf("compose project %s has no working dir label", project.Name)
+	}
+	path := filepath.Join(project.WorkingDir, ".env")
+	info, err := os.Stat(path)
+	if err != nil {
+		return models.DotEnvFile{}, fmt.Errorf("no .env file in %s on this machine", project.WorkingDir)
+	}
+	if info.Size() > maxComposeFileSize {
+		return models.DotEnvFile{}, fmt.Errorf("%s is too large (%d bytes)", path, info.Size())
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return models.DotEnvFile{}, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return models.DotEnvFile{Path: path, Content: string(data)}, nil
+}
+
+// WriteComposeDotEnv writes a .env file back, keeping its permissions
+func WriteComposeDotEnv(file models.DotEnvFile) error {
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(file.Path); err == nil {
+		mode = info.Mode().Perm()
+	}
+	if err := os.WriteFile(file.Path, []byte(file.Content), mode); err != nil {
+		return fmt.Errorf("failed to write %s: %w", file.Path, err)
+	}
+	return nil
+}
+
+// ExportCompose writes containers as a compose file at path (on this
+// machine), creating its directory if needed and never overwriting a file.
+// Returns the number of services written.
+func (c *Client) ExportCompose(ctx context.Context, containerIDs []string, path, header string) (services int, err error) {
+	defer func() { c.logAction("compose.export", path, fmt.Sprintf("%d services", services), err) }()
+
+	path, err = expandHome(path)
+	if err != nil {
+		return 0, err
+	}
+	if fileExists(path) {
+		return 0, fmt.Errorf("%s already exists", path)
+	}
+
+	var configs []models.ContainerFullConfig
+	defaults := make(map[string]models.ImageDefaults)
+	for _, id := range containerIDs {
+		config, err := c.InspectContainerFull(ctx, id)
+		if err != nil {
+			return 0, err
+		}
+		configs = append(configs, *config)
+
+		// Without the image, everything is written out
+		img, _, err := c.cli.ImageInspectWithRaw(ctx, config.Image)
+		if err != nil || img.Config == nil {
+			continue
+		}
+		defaults[config.Name] = models.ImageDefaults{
+			Env:        img.Config.Env,
+			Cmd:        img.Config.Cmd,
+			Entrypoint: img.Config.Entrypoint,
+			WorkingDir: img.Config.WorkingDir,
+			User:       img.Config.User,
+			Labels:     img.Config.Labels,
+		}
+	}
+	if len(configs) == 0 {
+		return 0, fmt.Errorf("no containers to export")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return 0, fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	content := models.GenerateComposeFile(header, configs, defaults)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return 0, fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return len(configs), nil
+}
+
+// MainComposeFile returns the path of the first compose file of a project,
+// the one edited to change it, which must exist on this machine
+func MainComposeFile(project models.ComposeProject) (string, error) {
+	files, _ := composeFiles(project)
+	if len(files) == 0 && project.WorkingDir != "" {
+		if file := models.DefaultComposeFile(project.WorkingDir, fileExists); file != "" {
+			files = []string{file}
+		}
+	}
+	if len(files) == 0 {
+		return "", fmt.Errorf("compose project %s has no working dir or config files label", project.Name)
+	}
+	if !fileExists(files[0]) {
+		return "", fmt.Errorf("compose file %s not found on this machine", files[0])
+	}
+	return files[0], nil
+}
+
+// composeProfiles returns the profiles of a project that have running
+// services, and all the profiles its files define. Best effort: both are
+// nil if the files can't be read.
+func composeProfiles(ctx context.Context, project models.ComposeProject) (active, all []string) {
+	cmd, err := composeCommand(ctx, project, []string{"*"}, "config", "--format", "json")
+	if err != nil {
+		return nil, nil
+	}
+	out, err := runCompose(cmd, "read compose config of "+project.Name)
+	if err != nil {
+		return nil, nil
+	}
+	active, all, err = models.ComposeProfiles(out, project)
+	if err != nil {
+		return nil, nil
+	}
+	return active, all
+}
+
+// composeCommand builds a `docker compose` command for a project, run with
+// the files and working dir it was started from and the given profiles
+func composeCommand(ctx context.Context, project models.ComposeProject, profiles []string, args ...string) (*exec.Cmd, error) {
+	if project.WorkingDir == "" && len(project.ConfigFiles) == 0 {
+		return nil, fmt.Errorf("compose project %s has no working dir or config files label", project.Name)
+	}
+
+	// Without a name compose derives it from the working dir
+	cmdArgs := []string{"compose"}
+	if project.Name != "" {
+		cmdArgs = append(cmdArgs, "-p", project.Name)
+	}
+	files, _ := composeFiles(project)
+	for _, file := range files {
+		if _, err := os.Stat(file); err != nil {
+			return nil, fmt.Errorf("compose file %s not found on this machine: %w", file, err)
+		}
+		cmdArgs = append(cmdArgs, "-f", file)
+	}
+	for _, profile := range profiles {
+		cmdArgs = append(cmdArgs, "--profile", profile)
+	}
+	cmdArgs = append(cmdArgs, args...)
+
+	cmd := exec.CommandContext(ctx, "docker", cmdArgs...)
+	if project.WorkingDir != "" {
+		if _, err := os.Stat(project.WorkingDir); err != nil {
+			return nil, fmt.Errorf("compose working dir %s not found on this machine: %w", project.WorkingDir, err)
+		}
+		cmd.Dir = project.WorkingDir
+	}
+	return cmd, nil
+}
+
+// runCompose runs a compose command and returns its stdout. On failure the
+// last line of stderr (after the progress output) is the error.
+func runCompose(cmd *exec.Cmd, action string) ([]byte, error) {
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		_, errs := models.ParseComposeConfigOutput(stderr.String(), true)
+		if len(errs) > 0 {
+			return nil, fmt.Errorf("failed to %s: %s", action, errs[len(errs)-1])
+		}
+		return nil, fmt.Errorf("failed to %s: %w", action, err)
+	}
+	return out, nil
+}
+
+// ComposeConfigHashes returns the config hash of each service of a project
+// as the current compose files define it, service -> hash
+func (c *Client) ComposeConfigHashes(ctx context.Context, project models.ComposeProject) (map[string]string, error) {
+	active, _ := composeProfiles(ctx, project)
+	cmd, err := composeCommand(ctx, project, active, "config", "--hash", "*")
+	if err != nil {
+		return nil, err
+	}
+	out, err := runCompose(cmd, "hash compose config of "+project.Name)
+	if err != nil {
+		return nil, err
+	}
+	return models.ParseComposeConfigHashes(string(out)), nil
+}
+
+// ChangedComposeServices compares the config hash label of a project's
+// containers to the current compose files, like `docker compose up` does
+func (c *Client) ChangedComposeServices(ctx context.Context, project models.ComposeProject) ([]models.ComposeServiceChange, error) {
+	hashes, err := c.ComposeConfigHashes(ctx, project)
+	if err != nil {
+		return nil, err
+	}
+	return models.ChangedComposeServices(project, hashes), nil
+}
+
+// RecreateComposeServices runs `docker compose up -d` for the given services
+// only, leaving their dependencies and the other services untouched
+func (c *Client) RecreateComposeServices(ctx context.Context, project models.ComposeProject, services []string) (err error) {
+	defer func() { c.logAction("compose.recreate", project.Name, strings.Join(services, ","), err) }()
+
+	active, _ := composeProfiles(ctx, project)
+	args := append([]string{"up", "--detach", "--no-deps"}, services...)
+	cmd, err := composeCommand(ctx, project, active, args...)
+	if err != nil {
+		return err
+	}
+	_, err = runCompose(cmd, "recreate services of "+project.Name)
+	return err
+}
+
+// ApplyComposeProject runs `docker compose up --detach` on a project with
+// its own compose files and active profiles, recreating the services whose
+// config changed since they were started
+func (c *Client) ApplyComposeProject(ctx context.Context, project models.ComposeProject) (err error) {
+	defer func() { c.logAction("compose.up", project.Name, "", err) }()
+
+	active, _ := composeProfiles(ctx, project)
+	cmd, err := composeCommand(ctx, project, active, "up", "--detach")
+	if err != nil {
+		return err
+	}
+	_, err = runCompose(cmd, "apply changes to "+project.Name)
+	return err
+}
+
+// ComposeUp runs `docker compose up --detach` with the given compose files,
+// creating and starting the containers of a project that may not exist yet.
+// A directory stands for the default compose file in it. Without a name,
+// compose names the project after the directory of the first file.
+func (c *Client) ComposeUp(ctx context.Context, name string, files []string) (err error) {
+	defer func() { c.logAction("compose.up", name, strings.Join(files, ","), err) }()
+
+	if len(files) == 0 {
+		return fmt.Errorf("no compose file given")
+	}
+	project := models.ComposeProject{Name: name}
+	for _, file := range files {
+		path, err := expandHome(file)
+		if err != nil {
+			return err
+		}
+		if path, err = filepath.Abs(path); err != nil {
+			return fmt.Errorf("failed to resolve %s: %w", file, err)
+		}
+		if info, err := os.Stat(path); err == nil && info.IsDir() {
+			dir := path
+			if path = models.DefaultComposeFile(dir, fileExists); path == "" {
+				return fmt.Errorf("no compose.yaml or docker-compose.yml in %s", dir)
+			}
+		}
+		project.ConfigFiles = append(project.ConfigFiles, path)
+	}
+	project.WorkingDir = filepath.Dir(project.ConfigFiles[0])
+
+	cmd, err := composeCommand(ctx, project, nil, "up", "--detach")
+	if err != nil {
+		return err
+	}
+	_, err = runCompose(cmd, "bring up "+project.ConfigFiles[0])
+	return err
+}
+
+// DownComposeProject runs `docker compose down` on a project, removing its
+// containers and networks, and also its named volumes and the images of its
+// services if asked. The project is found by its labels, so its compose
+// files aren't needed.
+func (c *Client) DownComposeProject(ctx context.Context, projectName string, volumes, images bool) (err error) {
+	detail := ""
+	if volumes {
+		detail += " --volumes"
+	}
+	if images {
+		detail += " --rmi all"
+	}
+	defer func() { c.logAction("compose.down", projectName, strings.TrimSpace(detail), err) }()
+
+	args := []string{"compose", "-p", projectName, "down", "--remove-orphans"}
+	if volumes {
+		args = append(args, "--volumes")
+	}
+	if images {
+		args = append(args, "--rmi", "all")
+	}
+	_, err = runCompose(exec.CommandContext(ctx, "docker", args...), "take down "+projectName)
+	return err
+}
+
+// fileExists returns true if path exists
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
 // StartComposeProject starts all containers in a compose project
 func (c *Client) StartComposeProject(ctx context.Context, projectName string) error {
 	// Find all containers for this project
@@ -119,7 +515,9 @@ func (c *Client) StartComposeProject(ctx context.Context, projectName string) er
 	// Start all containers
 	for _, ctr := range containers {
 		if ctr.State != "running" {
-			if err := c.cli.ContainerStart(ctx, ctr.ID, container.StartOptions{}); err != nil {
+			err := c.cli.ContainerStart(ctx, ctr.ID, container.StartOptions{})
+			c.logAction("container.start", ctr.ID, "compose project "+projectName, err)
+			if err != nil {
 				return fmt.Errorf("failed to start container %s: %w", ctr.ID, err)
 			}
 		}
@@ -146,9 +544,11 @@ func (c *Client) StopComposeProject(ctx context.Context, projectName string, tim
 	// Stop all containers
 	for _, ctr := range containers {
 		if ctr.State == "running" {
-			if err := c.cli.ContainerStop(ctx, ctr.ID, container.StopOptions{
+			err := c.cli.ContainerStop(ctx, ctr.ID, container.StopOptions{
 				Timeout: &stopTimeout,
-			}); err != nil {
+			})
+			c.logAction("container.stop", ctr.ID, "compose project "+projectName, err)
+			if err != nil {
 				return fmt.Errorf("failed to stop container %s: %w", ctr.ID, err)
 			}
 		}
@@ -174,9 +574,11 @@ func (c *Client) RestartComposeProject(ctx context.Context, projectName string,
 	restartTimeout := timeout
 	// Restart all containers
 	for _, ctr := range containers {
-		if err := c.cli.ContainerRestart(ctx, ctr.ID, container.StopOptions{
+		err := c.cli.ContainerRestart(ctx, ctr.ID, container.StopOptions{
 			Timeout: &restartTimeout,
-		}); err != nil {
+		})
+		c.logAction("container.restart", ctr.ID, "compose project "+projectName, err)
+		if err != nil {
 			return fmt.Errorf("failed to restart container %s: %w", ctr.ID, err)
 		}
 	}
@@ -6,7 +6,9 @@ import (
 	"strings"
 	"time"
 
+	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
 	"github.com/docker/docker/api/types/network"
 	"github.com/docker/go-connections/nat"
 	"github.com/rizface/doui/internal/models"
@@ -22,7 +24,10 @@ func (c *Client) ListContainers(ctx context.Context, all bool) ([]models.Contain
 	}
 
 	result := make([]models.Container, 0, len(containers))
+	seen := make(map[string]bool, len(containers))
 	for _, ctr := range containers {
+		seen[ctr.ID] = true
+
 		// Extract container name (remove leading /)
 		name := ""
 		if len(ctr.Names) > 0 {
@@ -40,10 +45,18 @@ func (c *Client) ListContainers(ctx context.Context, all bool) ([]models.Contain
 			})
 		}
 
-		// Extract networks
+		// Extract networks and their IP addresses
 		networks := make([]string, 0, len(ctr.NetworkSettings.Networks))
-		for name := range ctr.NetworkSettings.Networks {
+		ipAddresses := make(map[string]string, len(ctr.NetworkSettings.Networks))
+		aliases := make(map[string][]string)
+		for name, settings := range ctr.NetworkSettings.Networks {
 			networks = append(networks, name)
+			if settings != nil && settings.IPAddress != "" {
+				ipAddresses[name] = settings.IPAddress
+			}
+			if settings != nil && len(settings.Aliases) > 0 {
+				aliases[name] = settings.Aliases
+			}
 		}
 
 		// Extract mounts
@@ -59,28 +72,98 @@ func (c *Client) ListContainers(ctx context.Context, all bool) ([]models.Contain
 		}
 
 		result = append(result, models.Container{
-			ID:         ctr.ID,
-			ShortID:    ctr.ID[:12],
-			Name:       name,
-			Image:      ctr.Image,
-			Status:     ctr.Status,
-			State:      ctr.State,
-			Created:    time.Unix(ctr.Created, 0),
-			Ports:      ports,
-			Networks:   networks,
-			Mounts:     mounts,
-			Labels:     ctr.Labels,
-			SizeRw:     ctr.SizeRw,
-			SizeRootFs: ctr.SizeRootFs,
+			ID:          ctr.ID,
+			ShortID:     ctr.ID[:12],
+			Name:        name,
+			Image:       ctr.Image,
+			ImageID:     ctr.ImageID,
+			Status:      ctr.Status,
+			State:       ctr.State,
+			Created:     time.Unix(ctr.Created, 0),
+			Ports:       ports,
+			Networks:    networks,
+			IPAddresses: ipAddresses,
+			Aliases:     aliases,
+			Mounts:      mounts,
+			Labels:      ctr.Labels,
+			SizeRw:      ctr.SizeRw,
+			SizeRootFs:  ctr.SizeRootFs,
+			ArchWarning: c.archWarning(ctx, ctr.ImageID),
+			Runtime:     c.containerRuntime(ctx, ctr.ID, ctr.ImageID),
 		})
 	}
+	if all {
+		c.forgetRuntimes(seen)
+	}
 
 	return result, nil
 }
 
+// ListPortBindings returns the host ports published by all containers
+// Stopped containers are inspected for their configured bindings, since the
+// container list only reports ports for running containers
+func (c *Client) ListPortBindings(ctx context.Context) ([]models.PortBinding, error) {
+	containers, err := c.cli.ContainerList(ctx, container.ListOptions{All: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	var bindings []models.PortBinding
+	for _, ctr := range containers {
+		name := ""
+		if len(ctr.Names) > 0 {
+			name = strings.TrimPrefix(ctr.Names[0], "/")
+		}
+
+		if ctr.State == "running" {
+			for _, port := range ctr.Ports {
+				if port.PublicPort == 0 {
+					continue
+				}
+				bindings = append(bindings, models.PortBinding{
+					ContainerID:   ctr.ID,
+					ContainerName: name,
+					Running:       true,
+					HostIP:        port.IP,
+					HostPort:      int(port.PublicPort),
+					Proto:         port.Type,
+				})
+			}
+			continue
+		}
+
+		inspect, err := c.cli.ContainerInspect(ctx, ctr.ID)
+		if err != nil || inspect.HostConfig == nil {
+			continue
+		}
+		for containerPort, hostBindings := range inspect.HostConfig.PortBindings {
+			for _, hb := range hostBindings {
+				// Empty host port means Docker picks a random free port
+				start, end, err := nat.ParsePortRangeToInt(hb.HostPort)
+				if err != nil || start == 0 {
+					continue
+				}
+				for port := start; port <= end; port++ {
+					bindings = append(bindings, models.PortBinding{
+						ContainerID:   ctr.ID,
+						ContainerName: name,
+						HostIP:        hb.HostIP,
+						HostPort:      port,
+						Proto:         containerPort.Proto(),
+					})
+				}
+			}
+		}
+	}
+
+	return bindings, nil
+}
+
 // StartContainer starts a container by ID
-func (c *Client) StartContainer(ctx context.Context, containerID string) error {
-	err := c.cli.ContainerStart(ctx, containerID, container.StartOptions{})
+func (c *Client) StartContainer(ctx context.Context, containerID string) (err error) {
+	defer c.audit("container.start", containerID, &err)
+
+	err = c.cli.ContainerStart(ctx, containerID, container.StartOptions{})
 	if err != nil {
 		return fmt.Errorf("failed to start container %s: %w", containerID, err)
 	}
@@ -88,9 +171,11 @@ func (c *Client) StartContainer(ctx context.Context, containerID string) error {
 }
 
 // StopContainer stops a container by ID with a timeout
-func (c *Client) StopContainer(ctx context.Context, containerID string, timeout int) error {
+func (c *Client) StopContainer(ctx context.Context, containerID string, timeout int) (err error) {
+	defer c.audit("container.stop", containerID, &err)
+
 	stopTimeout := timeout
-	err := c.cli.ContainerStop(ctx, containerID, container.StopOptions{
+	err = c.cli.ContainerStop(ctx, containerID, container.StopOptions{
 		Timeout: &stopTimeout,
 	})
 	if err != nil {
@@ -100,9 +185,11 @@ func (c *Client) StopContainer(ctx context.Context, containerID string, timeout
 }
 
 // RestartContainer restarts a container by ID with a timeout
-func (c *Client) RestartContainer(ctx context.Context, containerID string, timeout int) error {
+func (c *Client) RestartContainer(ctx context.Context, containerID string, timeout int) (err error) {
+	defer c.audit("container.restart", containerID, &err)
+
 	restartTimeout := timeout
-	err := c.cli.ContainerRestart(ctx, containerID, container.StopOptions{
+	err = c.cli.ContainerRestart(ctx, containerID, container.StopOptions{
 		Timeout: &restartTimeout,
 	})
 	if err != nil {
@@ -112,8 +199,10 @@ func (c *Client) RestartContainer(ctx context.Context, containerID string, timeo
 }
 
 // RemoveContainer removes a container by ID
-func (c *Client) RemoveContainer(ctx context.Context, containerID string, force bool) error {
-	err := c.cli.ContainerRemove(ctx, containerID, container.RemoveOptions{
+func (c *Client) RemoveContainer(ctx context.Context, containerID string, force bool) (err error) {
+	defer c.audit("container.remove", containerID, &err)
+
+	err = c.cli.ContainerRemove(ctx, containerID, container.RemoveOptions{
 		Force: force,
 	})
 	if err != nil {
@@ -122,6 +211,67 @@ func (c *Client) RemoveContainer(ctx context.Context, containerID string, force
 	return nil
 }
 
+// GetExitState returns whether a container has stopped and, if so, its exit
+// code and the error docker recorded for it (e.g. a missing entrypoint)
+func (c *Client) GetExitState(ctx context.Context, containerID string) (exited bool, exitCode int, stateErr string, err error) {
+	inspect, err := c.cli.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return false, 0, "", fmt.Errorf("failed to inspect container %s: %w", containerID, err)
+	}
+	if inspect.State == nil || inspect.State.Running || inspect.State.Restarting {
+		return false, 0, "", nil
+	}
+	return true, inspect.State.ExitCode, inspect.State.Error, nil
+}
+
+// WaitForHealthy blocks until a container reports healthy
+// Containers without a healthcheck are considered ready once running
+func (c *Client) WaitForHealthy(ctx context.Context, containerID string) error {
+	ticker := time.NewTicker(1 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		inspect, err := c.cli.ContainerInspect(ctx, containerID)
+		if err != nil {
+			return fmt.Errorf("failed to inspect container %s: %w", containerID, err)
+		}
+
+		state := inspect.State
+		if state == nil || !state.Running {
+			return fmt.Errorf("container %s is not running", containerID)
+		}
+		if state.Health == nil || state.Health.Status == types.Healthy {
+			return nil
+		}
+		if state.Health.Status == types.Unhealthy {
+			return fmt.Errorf("container %s is unhealthy", containerID)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for container %s to become healthy: %w", containerID, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// UpdateContainerCpuset changes the CPUs and memory nodes a container may use
+// The update is applied live and persisted in the container's host config
+func (c *Client) UpdateContainerCpuset(ctx context.Context, containerID, cpus, mems string) (err error) {
+	defer c.audit("container.update", containerID, &err)
+
+	_, err = c.cli.ContainerUpdate(ctx, containerID, container.UpdateConfig{
+		Resources: container.Resources{
+			CpusetCpus: cpus,
+			CpusetMems: mems,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update cpuset for container %s: %w", containerID, err)
+	}
+	return nil
+}
+
 // InspectContainerFull returns the full container configuration needed for recreation
 func (c *Client) InspectContainerFull(ctx context.Context, containerID string) (*models.ContainerFullConfig, error) {
 	inspect, err := c.cli.ContainerInspect(ctx, containerID)
@@ -144,10 +294,20 @@ func (c *Client) InspectContainerFull(ctx context.Context, containerID string) (
 	// Host config
 	if inspect.HostConfig != nil {
 		config.Binds = inspect.HostConfig.Binds
+		for _, m := range inspect.HostConfig.Mounts {
+			config.Mounts = append(config.Mounts, models.ContainerMount{
+				Type:     string(m.Type),
+				Source:   m.Source,
+				Target:   m.Target,
+				ReadOnly: m.ReadOnly,
+			})
+		}
 		config.NetworkMode = string(inspect.HostConfig.NetworkMode)
 		config.Privileged = inspect.HostConfig.Privileged
 		config.CapAdd = inspect.HostConfig.CapAdd
 		config.CapDrop = inspect.HostConfig.CapDrop
+		config.CpusetCpus = inspect.HostConfig.CpusetCpus
+		config.CpusetMems = inspect.HostConfig.CpusetMems
 		config.RestartPolicy = models.ContainerRestartPolicy{
 			Name:              string(inspect.HostConfig.RestartPolicy.Name),
 			MaximumRetryCount: inspect.HostConfig.RestartPolicy.MaximumRetryCount,
@@ -181,7 +341,15 @@ func (c *Client) InspectContainerFull(ctx context.Context, containerID string) (
 }
 
 // RecreateContainer stops, removes, creates, and starts a container with new config
-func (c *Client) RecreateContainer(ctx context.Context, containerID string, newConfig *models.ContainerFullConfig) (string, error) {
+func (c *Client) RecreateContainer(ctx context.Context, containerID string, newConfig *models.ContainerFullConfig) (newID string, err error) {
+	defer func() {
+		detail := ""
+		if len(newID) >= 12 {
+			detail = "new container " + newID[:12]
+		}
+		c.logAction("container.recreate", containerID, detail, err)
+	}()
+
 	// 1. Stop the container (if running) - ignore errors as container might already be stopped
 	timeout := 10
 	_ = c.cli.ContainerStop(ctx, containerID, container.StopOptions{Timeout: &timeout})
@@ -219,6 +387,19 @@ func (c *Client) RecreateContainer(ctx context.Context, containerID string, newC
 			Name:              container.RestartPolicyMode(newConfig.RestartPolicy.Name),
 			MaximumRetryCount: newConfig.RestartPolicy.MaximumRetryCount,
 		},
+		Resources: container.Resources{
+			CpusetCpus: newConfig.CpusetCpus,
+			CpusetMems: newConfig.CpusetMems,
+		},
+	}
+
+	for _, m := range newConfig.Mounts {
+		hostConfig.Mounts = append(hostConfig.Mounts, mount.Mount{
+			Type:     mount.Type(m.Type),
+			Source:   m.Source,
+			Target:   m.Target,
+			ReadOnly: m.ReadOnly,
+		})
 	}
 
 	// Convert port bindings
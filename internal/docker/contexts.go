@@ -0,0 +1,125 @@
+package docker
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/rizface/doui/internal/models"
+)
+
+// contextMeta mirrors the meta.json files the docker CLI writes per context
+type contextMeta struct {
+	Name     string `json:"Name"`
+	Metadata struct {
+		Description string `json:"Description"`
+	} `json:"Metadata"`
+	Endpoints map[string]struct {
+		Host string `json:"Host"`
+	} `json:"Endpoints"`
+}
+
+// dockerConfigDir returns the docker CLI config directory ($DOCKER_CONFIG or ~/.docker)
+func dockerConfigDir() (string, error) {
+	if dir := os.Getenv("DOCKER_CONFIG"); dir != "" {
+		return dir, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, ".docker"), nil
+}
+
+// currentContextName returns the context selected with DOCKER_CONTEXT or `docker context use`
+func currentContextName(configDir string) string {
+	if name := os.Getenv("DOCKER_CONTEXT"); name != "" {
+		return name
+	}
+
+	data, err := os.ReadFile(filepath.Join(configDir, "config.json"))
+	if err != nil {
+		return models.DefaultContextName
+	}
+	var cfg struct {
+		CurrentContext string `json:"currentContext"`
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil || cfg.CurrentContext == "" {
+		return models.DefaultContextName
+	}
+	return cfg.CurrentContext
+}
+
+// ListContexts returns the docker CLI contexts, the default context first
+func ListContexts() ([]models.DockerContext, error) {
+	configDir, err := dockerConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	current := currentContextName(configDir)
+
+	contexts := []models.DockerContext{{
+		Name:        models.DefaultContextName,
+		Description: "DOCKER_HOST or the default socket",
+		Host:        ConfiguredHost(),
+		Current:     current == models.DefaultContextName,
+	}}
+
+	metaDir := filepath.Join(configDir, "contexts", "meta")
+	entries, err := os.ReadDir(metaDir)
+	if os.IsNotExist(err) {
+		return contexts, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read docker contexts: %w", err)
+	}
+
+	var named []models.DockerContext
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(metaDir, entry.Name(), "meta.json"))
+		if err != nil {
+			continue
+		}
+		var meta contextMeta
+		if err := json.Unmarshal(data, &meta); err != nil {
+			continue
+		}
+		endpoint, ok := meta.Endpoints["docker"]
+		if !ok || meta.Name == "" {
+			continue
+		}
+
+		ctx := models.DockerContext{
+			Name:        meta.Name,
+			Description: meta.Metadata.Description,
+			Host:        endpoint.Host,
+			Current:     meta.Name == current,
+		}
+
+		// TLS material lives next to the metadata, keyed by the same digest
+		tlsDir := filepath.Join(configDir, "contexts", "tls", contextDigest(meta.Name), "docker")
+		if _, err := os.Stat(tlsDir); err == nil {
+			ctx.TLSDir = tlsDir
+		}
+
+		named = append(named, ctx)
+	}
+
+	sort.Slice(named, func(i, j int) bool {
+		return named[i].Name < named[j].Name
+	})
+	return append(contexts, named...), nil
+}
+
+// contextDigest returns the directory name the docker CLI uses for a context
+func contextDigest(name string) string {
+	sum := sha256.Sum256([]byte(name))
+	return hex.EncodeToString(sum[:])
+}
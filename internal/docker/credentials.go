@@ -0,0 +1,217 @@
+package docker
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/docker/docker/api/types/registry"
+	"github.com/rizface/doui/internal/models"
+)
+
+// dockerConfigFile is the part of the docker CLI's config.json about logins
+type dockerConfigFile struct {
+	Auths       map[string]dockerConfigAuth `json:"auths"`
+	CredsStore  string                      `json:"credsStore"`
+	CredHelpers map[string]string           `json:"credHelpers"`
+}
+
+type dockerConfigAuth struct {
+	Auth          string `json:"auth"`
+	IdentityToken string `json:"identitytoken,omitempty"`
+}
+
+// credentialHelperCreds is what docker-credential-* helpers read and print
+type credentialHelperCreds struct {
+	ServerURL string `json:"ServerURL"`
+	Username  string `json:"Username"`
+	Secret    string `json:"Secret"`
+}
+
+// identityTokenUser is the username helpers store identity tokens under
+const identityTokenUser = "<token>"
+
+// dockerConfigPath returns the docker CLI's config.json, in $DOCKER_CONFIG
+// or ~/.docker
+func dockerConfigPath() (string, error) {
+	if dir := os.Getenv("DOCKER_CONFIG"); dir != "" {
+		return filepath.Join(dir, "config.json"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, ".docker", "config.json"), nil
+}
+
+// loadDockerConfig reads the docker CLI's config.json, empty if it doesn't exist
+func loadDockerConfig() (*dockerConfigFile, error) {
+	path, err := dockerConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &dockerConfigFile{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	var config dockerConfigFile
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return &config, nil
+}
+
+// credentialServer returns the server address logins to a registry are
+// stored under by the docker CLI
+func credentialServer(registryHost string) string {
+	if registryHost == models.DockerHub {
+		return dockerHubIndex
+	}
+	return registryHost
+}
+
+// credentialHelper returns the helper the docker CLI uses for a registry
+// (e.g. "desktop" for docker-credential-desktop), or "" for config.json
+func (f *dockerConfigFile) credentialHelper(registryHost string) string {
+	for server, helper := range f.CredHelpers {
+		if models.NormalizeRegistry(server) == registryHost {
+			return helper
+		}
+	}
+	return f.CredsStore
+}
+
+// storedRegistryAuth returns the login the docker CLI stored for a registry,
+// from its credential helper or config.json
+func storedRegistryAuth(registryHost string) (registry.AuthConfig, bool) {
+	registryHost = models.NormalizeRegistry(registryHost)
+	config, err := loadDockerConfig()
+	if err != nil {
+		return registry.AuthConfig{}, false
+	}
+	auth := registry.AuthConfig{ServerAddress: credentialServer(registryHost)}
+
+	if helper := config.credentialHelper(registryHost); helper != "" {
+		var creds credentialHelperCreds
+		out, err := runCredentialHelper(helper, "get", strings.NewReader(auth.ServerAddress))
+		if err != nil || json.Unmarshal(out, &creds) != nil || creds.Secret == "" {
+			return registry.AuthConfig{}, false
+		}
+		if creds.Username == identityTokenUser {
+			auth.IdentityToken = creds.Secret
+		} else {
+			auth.Username, auth.Password = creds.Username, creds.Secret
+		}
+		return auth, true
+	}
+
+	for server, entry := range config.Auths {
+		if models.NormalizeRegistry(server) != registryHost {
+			continue
+		}
+		if entry.IdentityToken != "" {
+			auth.IdentityToken = entry.IdentityToken
+		}
+		decoded, err := base64.StdEncoding.DecodeString(entry.Auth)
+		if err == nil {
+			auth.Username, auth.Password, _ = strings.Cut(string(decoded), ":")
+		}
+		if auth.Username != "" || auth.IdentityToken != "" {
+			return auth, true
+		}
+	}
+	return registry.AuthConfig{}, false
+}
+
+// storeRegistryAuth saves a login like `docker login` does: with the
+// configured credential helper, or else base64 encoded in config.json.
+// Returns where it was saved.
+func storeRegistryAuth(registryHost string, auth registry.AuthConfig) (string, error) {
+	config, err := loadDockerConfig()
+	if err != nil {
+		return "", err
+	}
+	server := credentialServer(registryHost)
+
+	if helper := config.credentialHelper(registryHost); helper != "" {
+		creds := credentialHelperCreds{ServerURL: server, Username: auth.Username, Secret: auth.Password}
+		if auth.IdentityToken != "" {
+			creds.Username, creds.Secret = identityTokenUser, auth.IdentityToken
+		}
+		data, err := json.Marshal(creds)
+		if err != nil {
+			return "", err
+		}
+		if _, err := runCredentialHelper(helper, "store", bytes.NewReader(data)); err != nil {
+			return "", err
+		}
+		return "docker-credential-" + helper, nil
+	}
+
+	path, err := dockerConfigPath()
+	if err != nil {
+		return "", err
+	}
+	// Keep everything else in the file as is
+	raw := make(map[string]json.RawMessage)
+	if data, err := os.ReadFile(path); err == nil {
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return "", fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+	}
+	auths := make(map[string]json.RawMessage)
+	if existing, ok := raw["auths"]; ok {
+		if err := json.Unmarshal(existing, &auths); err != nil {
+			return "", fmt.Errorf("failed to parse auths of %s: %w", path, err)
+		}
+	}
+	entry, err := json.Marshal(dockerConfigAuth{
+		Auth:          base64.StdEncoding.EncodeToString([]byte(auth.Username + ":" + auth.Password)),
+		IdentityToken: auth.IdentityToken,
+	})
+	if err != nil {
+		return "", err
+	}
+	auths[server] = entry
+	if raw["auths"], err = json.Marshal(auths); err != nil {
+		return "", err
+	}
+
+	data, err := json.MarshalIndent(raw, "", "\t")
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return path, nil
+}
+
+// runCredentialHelper runs `docker-credential-<helper> <action>` with input
+// on stdin. Helpers print their errors (e.g. "credentials not found") on stdout.
+func runCredentialHelper(helper, action string, input io.Reader) ([]byte, error) {
+	cmd := exec.Command("docker-credential-"+helper, action)
+	cmd.Stdin = input
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stdout.String() + " " + stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		return nil, fmt.Errorf("failed to %s credentials with docker-credential-%s: %s", action, helper, msg)
+	}
+	return stdout.Bytes(), nil
+}
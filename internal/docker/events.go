@@ -0,0 +1,112 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/rizface/doui/internal/models"
+)
+
+// StreamContainerEvents streams OOM and die events for a container
+func (c *Client) StreamContainerEvents(ctx context.Context, containerID string) (<-chan *models.ContainerEvent, <-chan error) {
+	eventChan := make(chan *models.ContainerEvent, 10)
+	errorChan := make(chan error, 1)
+
+	filterArgs := filters.NewArgs()
+	filterArgs.Add("type", string(events.ContainerEventType))
+	filterArgs.Add("container", containerID)
+	filterArgs.Add("event", string(events.ActionOOM))
+	filterArgs.Add("event", string(events.ActionDie))
+
+	go func() {
+		defer close(eventChan)
+		defer close(errorChan)
+
+		msgs, errs := c.cli.Events(ctx, events.ListOptions{Filters: filterArgs})
+		for {
+			select {
+			case msg := <-msgs:
+				event := &models.ContainerEvent{
+					ContainerID: containerID,
+					Action:      string(msg.Action),
+					ExitCode:    msg.Actor.Attributes["exitCode"],
+					Timestamp:   time.Unix(0, msg.TimeNano),
+				}
+				select {
+				case eventChan <- event:
+				case <-ctx.Done():
+					return
+				}
+			case err := <-errs:
+				if ctx.Err() != nil {
+					// Context cancelled, normal exit
+					return
+				}
+				errorChan <- fmt.Errorf("failed to watch container events: %w", err)
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return eventChan, errorChan
+}
+
+// StreamWatchEvents streams the exits and failed health checks of all
+// containers, to notify about watched ones
+func (c *Client) StreamWatchEvents(ctx context.Context) (<-chan *models.ContainerEvent, <-chan error) {
+	eventChan := make(chan *models.ContainerEvent, 10)
+	errorChan := make(chan error, 1)
+
+	filterArgs := filters.NewArgs()
+	filterArgs.Add("type", string(events.ContainerEventType))
+	filterArgs.Add("event", string(events.ActionDie))
+	filterArgs.Add("event", string(events.ActionHealthStatus))
+
+	go func() {
+		defer close(eventChan)
+		defer close(errorChan)
+
+		msgs, errs := c.cli.Events(ctx, events.ListOptions{Filters: filterArgs})
+		for {
+			select {
+			case msg := <-msgs:
+				action := string(msg.Action)
+				if strings.HasPrefix(action, string(events.ActionHealthStatus)) {
+					// Only the change to unhealthy, not every passing check
+					if msg.Action != events.ActionHealthStatusUnhealthy {
+						continue
+					}
+					action = "unhealthy"
+				}
+				event := &models.ContainerEvent{
+					ContainerID: msg.Actor.ID,
+					Name:        msg.Actor.Attributes["name"],
+					Action:      action,
+					ExitCode:    msg.Actor.Attributes["exitCode"],
+					Timestamp:   time.Unix(0, msg.TimeNano),
+				}
+				select {
+				case eventChan <- event:
+				case <-ctx.Done():
+					return
+				}
+			case err := <-errs:
+				if ctx.Err() != nil {
+					return
+				}
+				errorChan <- fmt.Errorf("failed to watch container events: %w", err)
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return eventChan, errorChan
+}
@@ -2,16 +2,22 @@ package docker
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/image"
 	"github.com/rizface/doui/internal/models"
+	"github.com/rizface/doui/pkg/utils"
 )
 
 // ListImages returns all images
@@ -50,6 +56,7 @@ func (c *Client) ListImages(ctx context.Context) ([]models.Image, error) {
 			VirtualSize: img.VirtualSize,
 			Labels:      img.Labels,
 			Containers:  imageContainerCount[img.ID],
+			ArchWarning: c.archWarning(ctx, img.ID),
 		})
 	}
 
@@ -76,8 +83,10 @@ func (c *Client) ListImages(ctx context.Context) ([]models.Image, error) {
 }
 
 // RemoveImage removes an image by ID
-func (c *Client) RemoveImage(ctx context.Context, imageID string, force bool) error {
-	_, err := c.cli.ImageRemove(ctx, imageID, image.RemoveOptions{
+func (c *Client) RemoveImage(ctx context.Context, imageID string, force bool) (err error) {
+	defer c.audit("image.remove", imageID, &err)
+
+	_, err = c.cli.ImageRemove(ctx, imageID, image.RemoveOptions{
 		Force: force,
 	})
 	if err != nil {
@@ -86,16 +95,42 @@ func (c *Client) RemoveImage(ctx context.Context, imageID string, force bool) er
 	return nil
 }
 
+// TagImage adds the tag target (repo:tag) to the image source (ID or tag).
+// With untag the source tag is removed afterwards, renaming the image.
+func (c *Client) TagImage(ctx context.Context, source, target string, untag bool) (err error) {
+	defer func() { c.logAction("image.tag", source, target, err) }()
+
+	if err = c.cli.ImageTag(ctx, source, target); err != nil {
+		return fmt.Errorf("failed to tag %s as %s: %w", source, target, err)
+	}
+	if untag && source != target {
+		// Only removes the reference, the image keeps its new tag
+		if _, err = c.cli.ImageRemove(ctx, source, image.RemoveOptions{}); err != nil {
+			return fmt.Errorf("tagged %s but failed to remove the old tag: %w", target, err)
+		}
+	}
+	return nil
+}
+
 // PullProgress represents progress of an image pull operation
 type PullProgress struct {
 	Status   string
 	Progress string // Progress bar string from Docker
 	Current  int64
 	Total    int64
+	Layers   []LayerProgress // In the order the registry listed them
 	Done     bool
 	Error    error
 }
 
+// LayerProgress is the state of one layer of a pull
+type LayerProgress struct {
+	ID      string
+	Status  string // e.g. Waiting, Downloading, Extracting, Pull complete
+	Current int64
+	Total   int64
+}
+
 // pullEvent represents a single event from Docker's image pull stream
 type pullEvent struct {
 	Status         string `json:"status"`
@@ -108,22 +143,32 @@ type pullEvent struct {
 	Error string `json:"error"`
 }
 
-// PullImageWithProgress pulls an image and streams progress updates
-func (c *Client) PullImageWithProgress(ctx context.Context, imageName string) <-chan PullProgress {
+// PullImageWithProgress pulls an image and streams progress updates. A
+// platform like "linux/arm64" pulls that variant of a multi-arch image, ""
+// the one matching the daemon's host.
+func (c *Client) PullImageWithProgress(ctx context.Context, imageName, platform string) <-chan PullProgress {
 	progressChan := make(chan PullProgress)
 
 	go func() {
 		defer close(progressChan)
 
-		out, err := c.cli.ImagePull(ctx, imageName, image.PullOptions{})
+		var pullErr error
+		defer func() { c.logAction("image.pull", imageName, platform, pullErr) }()
+
+		out, err := c.cli.ImagePull(ctx, imageName, image.PullOptions{
+			RegistryAuth: c.registryAuth(imageName),
+			Platform:     platform,
+		})
 		if err != nil {
-			progressChan <- PullProgress{Error: fmt.Errorf("failed to pull image %s: %w", imageName, err), Done: true}
+			pullErr = fmt.Errorf("failed to pull image %s: %w", imageName, err)
+			progressChan <- PullProgress{Error: pullErr, Done: true}
 			return
 		}
 		defer out.Close()
 
 		// Track progress per layer
 		layerProgress := make(map[string]pullEvent)
+		var layerOrder []string
 		scanner := bufio.NewScanner(out)
 
 		for scanner.Scan() {
@@ -133,12 +178,16 @@ func (c *Client) PullImageWithProgress(ctx context.Context, imageName string) <-
 			}
 
 			if event.Error != "" {
-				progressChan <- PullProgress{Error: fmt.Errorf("%s", event.Error), Done: true}
+				pullErr = fmt.Errorf("%s", event.Error)
+				progressChan <- PullProgress{Error: pullErr, Done: true}
 				return
 			}
 
-			// Track layer progress
-			if event.ID != "" {
+			// Track layer progress ("Pulling from" carries the tag as ID)
+			if event.ID != "" && !strings.HasPrefix(event.Status, "Pulling from") {
+				if _, seen := layerProgress[event.ID]; !seen {
+					layerOrder = append(layerOrder, event.ID)
+				}
 				layerProgress[event.ID] = event
 			}
 
@@ -153,6 +202,16 @@ func (c *Client) PullImageWithProgress(ctx context.Context, imageName string) <-
 				Status:  event.Status,
 				Current: totalCurrent,
 				Total:   totalTotal,
+				Layers:  make([]LayerProgress, 0, len(layerOrder)),
+			}
+			for _, id := range layerOrder {
+				layer := layerProgress[id]
+				progress.Layers = append(progress.Layers, LayerProgress{
+					ID:      id,
+					Status:  layer.Status,
+					Current: layer.ProgressDetail.Current,
+					Total:   layer.ProgressDetail.Total,
+				})
 			}
 
 			// Build progress string
@@ -164,7 +223,8 @@ func (c *Client) PullImageWithProgress(ctx context.Context, imageName string) <-
 		}
 
 		if err := scanner.Err(); err != nil {
-			progressChan <- PullProgress{Error: fmt.Errorf("failed to read pull output: %w", err), Done: true}
+			pullErr = fmt.Errorf("failed to read pull output: %w", err)
+			progressChan <- PullProgress{Error: pullErr, Done: true}
 			return
 		}
 
@@ -174,12 +234,182 @@ func (c *Client) PullImageWithProgress(ctx context.Context, imageName string) <-
 	return progressChan
 }
 
-// PruneImages removes all dangling images
-func (c *Client) PruneImages(ctx context.Context) (int, int64, error) {
-	report, err := c.cli.ImagesPrune(ctx, filters.NewArgs())
+// ImageHistory returns the build steps of an image, newest first
+func (c *Client) ImageHistory(ctx context.Context, imageID string) ([]models.ImageLayer, error) {
+	history, err := c.cli.ImageHistory(ctx, imageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get history of image %s: %w", imageID, err)
+	}
+
+	layers := make([]models.ImageLayer, 0, len(history))
+	for _, item := range history {
+		layers = append(layers, models.ImageLayer{
+			ID:        item.ID,
+			Created:   time.Unix(item.Created, 0),
+			CreatedBy: item.CreatedBy,
+			Size:      item.Size,
+			Comment:   item.Comment,
+		})
+	}
+	return layers, nil
+}
+
+// ImageSpec returns the layers and config of an image, for comparing images
+func (c *Client) ImageSpec(ctx context.Context, img models.Image) (models.ImageSpec, error) {
+	inspect, _, err := c.cli.ImageInspectWithRaw(ctx, img.ID)
+	if err != nil {
+		return models.ImageSpec{}, fmt.Errorf("failed to inspect image %s: %w", img.GetPrimaryTag(), err)
+	}
+
+	spec := models.ImageSpec{
+		Ref:    img.GetPrimaryTag(),
+		ID:     img.ID,
+		Size:   img.Size,
+		Layers: inspect.RootFS.Layers,
+		Config: make(map[string]string),
+	}
+	if img.IsDangling() {
+		spec.Ref = img.ShortID
+	}
+	if inspect.Os != "" {
+		spec.Config["platform"] = inspect.Os + "/" + inspect.Architecture
+	}
+
+	cfg := inspect.Config
+	if cfg == nil {
+		return spec, nil
+	}
+	for _, env := range cfg.Env {
+		key, value, _ := strings.Cut(env, "=")
+		spec.Config["env "+key] = value
+	}
+	for key, value := range cfg.Labels {
+		spec.Config["label "+key] = value
+	}
+	for port := range cfg.ExposedPorts {
+		spec.Config["port "+string(port)] = "exposed"
+	}
+	for volume := range cfg.Volumes {
+		spec.Config["volume "+volume] = "declared"
+	}
+	settings := map[string]string{
+		"entrypoint":  strings.Join(cfg.Entrypoint, " "),
+		"cmd":         strings.Join(cfg.Cmd, " "),
+		"workdir":     cfg.WorkingDir,
+		"user":        cfg.User,
+		"stop signal": cfg.StopSignal,
+	}
+	if cfg.Healthcheck != nil {
+		settings["healthcheck"] = strings.Join(cfg.Healthcheck.Test, " ")
+	}
+	for key, value := range settings {
+		if value != "" {
+			spec.Config[key] = value
+		}
+	}
+	return spec, nil
+}
+
+// PruneImages removes all dangling images, or with all every image no
+// container uses. Images whose ID is in keep are left alone: the daemon's
+// prune can't skip images, so then the others are removed one by one.
+func (c *Client) PruneImages(ctx context.Context, all bool, keep map[string]bool) (deleted int, reclaimed int64, err error) {
+	scope := "dangling images"
+	args := filters.NewArgs()
+	if all {
+		// Without dangling=false only untagged images are pruned
+		scope = "unused images"
+		args.Add("dangling", "false")
+	}
+	defer func() {
+		c.logAction("image.prune", scope, fmt.Sprintf("%d removed, %s reclaimed", deleted, utils.FormatBytes(reclaimed)), err)
+	}()
+
+	if len(keep) > 0 {
+		return c.pruneImagesExcept(ctx, all, keep)
+	}
+
+	report, err := c.cli.ImagesPrune(ctx, args)
 	if err != nil {
 		return 0, 0, fmt.Errorf("failed to prune images: %w", err)
 	}
 
 	return len(report.ImagesDeleted), int64(report.SpaceReclaimed), nil
 }
+
+// pruneImagesExcept removes the unused (or only the dangling) images that
+// aren't in keep. Images that can't be removed, e.g. because another image
+// is built on them, are skipped like prune does. reclaimed adds up the sizes
+// of the removed images, so layers they shared are counted more than once.
+func (c *Client) pruneImagesExcept(ctx context.Context, all bool, keep map[string]bool) (deleted int, reclaimed int64, err error) {
+	images, err := c.ListImages(ctx)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to prune images: %w", err)
+	}
+	for _, img := range images {
+		if keep[img.ID] || !img.IsUnused() || (!all && !img.IsDangling()) {
+			continue
+		}
+		// Force removes every tag of the image, like prune does; it has no
+		// containers, so nothing else is forced
+		if _, err := c.cli.ImageRemove(ctx, img.ID, image.RemoveOptions{Force: true, PruneChildren: true}); err != nil {
+			continue
+		}
+		deleted++
+		reclaimed += img.Size
+	}
+	return deleted, reclaimed, nil
+}
+
+// ReadImageList reads the images to pull from a local file: a compose file
+// (its services' images, resolved by `docker compose config`) or a text/lock
+// file with one reference per line
+func ReadImageList(ctx context.Context, path string) ([]string, error) {
+	path, err := expandHome(path)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image list %s: %w", path, err)
+	}
+
+	var images []string
+	if models.IsComposeFile(path) {
+		cmd := exec.CommandContext(ctx, "docker", "compose", "-f", path, "config", "--format", "json")
+		cmd.Dir = filepath.Dir(path)
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		out, err := cmd.Output()
+		if err != nil {
+			if msg := strings.TrimSpace(stderr.String()); msg != "" {
+				return nil, fmt.Errorf("failed to read compose file %s: %s", path, msg)
+			}
+			return nil, fmt.Errorf("failed to read compose file %s: %w", path, err)
+		}
+		if images, err = models.ComposeImages(out); err != nil {
+			return nil, err
+		}
+	} else {
+		images = models.ParseImageList(string(data))
+	}
+
+	if len(images) == 0 {
+		return nil, fmt.Errorf("no images to pull in %s", path)
+	}
+	return images, nil
+}
+
+// expandHome replaces a leading ~/ in a local path with the home directory
+func expandHome(path string) (string, error) {
+	rest, ok := strings.CutPrefix(path, "~/")
+	if !ok {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, rest), nil
+}
@@ -2,12 +2,18 @@ package docker
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"fmt"
 	"io"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/rizface/doui/internal/models"
 )
 
 // LogEntry represents a single log line
@@ -15,6 +21,7 @@ type LogEntry struct {
 	Line      string
 	Timestamp time.Time
 	IsError   bool
+	Source    string // Service that logged the line, when several containers are streamed
 }
 
 // StreamLogs streams logs from a container
@@ -28,7 +35,7 @@ func (c *Client) StreamLogs(ctx context.Context, containerID string, follow bool
 
 		sinceStr := ""
 		if !since.IsZero() {
-			sinceStr = since.Format(time.RFC3339)
+			sinceStr = since.Format(time.RFC3339Nano)
 		}
 
 		reader, err := c.cli.ContainerLogs(ctx, containerID, container.LogsOptions{
@@ -68,10 +75,202 @@ func (c *Client) StreamLogs(ctx context.Context, containerID string, follow bool
 			}
 		}
 
-		if err := scanner.Err(); err != nil && err != io.EOF {
+		if err := scanner.Err(); err != nil && err != io.EOF && ctx.Err() == nil {
 			errorChan <- fmt.Errorf("error reading logs: %w", err)
 		}
 	}()
 
 	return logsChan, errorChan
 }
+
+// StreamProjectLogs follows the logs of every container of a compose project
+// merged into one stream, like `docker compose logs -f`, each line tagged
+// with its service (and replica number for scaled services). A container
+// whose logs can't be read adds an error line instead of ending the stream.
+func (c *Client) StreamProjectLogs(ctx context.Context, project models.ComposeProject, tail string) (<-chan LogEntry, <-chan error) {
+	logsChan := make(chan LogEntry, 100)
+	errorChan := make(chan error, 1)
+
+	var wg sync.WaitGroup
+	for _, service := range project.Services {
+		for _, ctr := range service.Containers {
+			source := service.Name
+			if len(service.Containers) > 1 {
+				if n := ctr.Labels["com.docker.compose.container-number"]; n != "" {
+					source += "-" + n
+				}
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if err := c.followContainerLogs(ctx, ctr.ID, source, tail, logsChan); err != nil && ctx.Err() == nil {
+					select {
+					case logsChan <- LogEntry{Line: err.Error(), Timestamp: time.Now(), IsError: true, Source: source}:
+					case <-ctx.Done():
+					}
+				}
+			}()
+		}
+	}
+
+	go func() {
+		wg.Wait()
+		close(logsChan)
+		close(errorChan)
+	}()
+
+	return logsChan, errorChan
+}
+
+// followContainerLogs sends the demuxed log lines of a container, tagged
+// with source, until its logs end or ctx is done
+func (c *Client) followContainerLogs(ctx context.Context, containerID, source, tail string, logsChan chan<- LogEntry) error {
+	inspect, err := c.cli.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return fmt.Errorf("failed to inspect container %s: %w", containerID, err)
+	}
+
+	reader, err := c.cli.ContainerLogs(ctx, containerID, container.LogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Follow:     true,
+		Timestamps: true,
+		Tail:       tail,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get container logs: %w", err)
+	}
+	defer reader.Close()
+
+	// Without a TTY the stream is multiplexed and has to be demuxed
+	var lines io.Reader = reader
+	if inspect.Config == nil || !inspect.Config.Tty {
+		pr, pw := io.Pipe()
+		defer pr.Close()
+		go func() {
+			_, err := stdcopy.StdCopy(pw, pw, reader)
+			pw.CloseWithError(err)
+		}()
+		lines = pr
+	}
+
+	scanner := bufio.NewScanner(lines)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		select {
+		case logsChan <- LogEntry{Line: scanner.Text(), Timestamp: time.Now(), Source: source}:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+	if err := scanner.Err(); err != nil && err != io.EOF && ctx.Err() == nil {
+		return fmt.Errorf("error reading logs: %w", err)
+	}
+	return nil
+}
+
+// TailLogs returns the last lines of a container's logs (stdout and stderr)
+func (c *Client) TailLogs(ctx context.Context, containerID string, lines int) ([]string, error) {
+	inspect, err := c.cli.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect container %s: %w", containerID, err)
+	}
+
+	reader, err := c.cli.ContainerLogs(ctx, containerID, container.LogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Tail:       strconv.Itoa(lines),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get container logs: %w", err)
+	}
+	defer reader.Close()
+
+	// Without a TTY the stream is multiplexed and has to be demuxed
+	var buf bytes.Buffer
+	if inspect.Config != nil && inspect.Config.Tty {
+		_, err = io.Copy(&buf, reader)
+	} else {
+		_, err = stdcopy.StdCopy(&buf, &buf, reader)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading logs: %w", err)
+	}
+
+	output := strings.TrimRight(buf.String(), "\n")
+	if output == "" {
+		return nil, nil
+	}
+	return strings.Split(output, "\n"), nil
+}
+
+// TailFile follows a file inside a running container via exec `tail -F`, for
+// services that log to files instead of stdout. Cancel ctx to stop tailing.
+func (c *Client) TailFile(ctx context.Context, containerID, path string, lines int) (<-chan LogEntry, <-chan error) {
+	logsChan := make(chan LogEntry, 100)
+	errorChan := make(chan error, 1)
+
+	go func() {
+		defer close(logsChan)
+		defer close(errorChan)
+
+		exec, err := c.cli.ContainerExecCreate(ctx, containerID, container.ExecOptions{
+			Cmd:          []string{"tail", "-n", strconv.Itoa(lines), "-F", path},
+			AttachStdout: true,
+			AttachStderr: true,
+		})
+		if err != nil {
+			errorChan <- fmt.Errorf("failed to exec tail in container %s: %w", containerID, err)
+			return
+		}
+
+		resp, err := c.cli.ContainerExecAttach(ctx, exec.ID, container.ExecAttachOptions{})
+		if err != nil {
+			errorChan <- fmt.Errorf("failed to attach to tail in container %s: %w", containerID, err)
+			return
+		}
+		defer resp.Close()
+
+		// Closing the connection ends the stream (and tail with it) on cancel
+		go func() {
+			<-ctx.Done()
+			resp.Close()
+		}()
+
+		// The exec stream is multiplexed, demux stdout and stderr into one pipe
+		pr, pw := io.Pipe()
+		go func() {
+			_, err := stdcopy.StdCopy(pw, pw, resp.Reader)
+			pw.CloseWithError(err)
+		}()
+
+		scanner := bufio.NewScanner(pr)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+		for scanner.Scan() {
+			select {
+			case logsChan <- LogEntry{
+				Line:      scanner.Text(),
+				Timestamp: time.Now(),
+			}:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		if ctx.Err() != nil {
+			return
+		}
+		if err := scanner.Err(); err != nil && err != io.EOF {
+			errorChan <- fmt.Errorf("error reading %s: %w", path, err)
+			return
+		}
+
+		// tail only exits on its own when it failed (e.g. no tail binary)
+		if inspect, err := c.cli.ContainerExecInspect(ctx, exec.ID); err == nil && inspect.ExitCode != 0 {
+			errorChan <- fmt.Errorf("tail %s exited with code %d", path, inspect.ExitCode)
+		}
+	}()
+
+	return logsChan, errorChan
+}
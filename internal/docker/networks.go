@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strings"
 
+	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/network"
 	"github.com/rizface/doui/internal/models"
 )
@@ -55,9 +57,79 @@ func (c *Client) ListNetworks(ctx context.Context) ([]models.Network, error) {
 	return result, nil
 }
 
-// ConnectContainer connects a container to a network
-func (c *Client) ConnectContainer(ctx context.Context, networkID, containerID string) error {
-	err := c.cli.NetworkConnect(ctx, networkID, containerID, nil)
+// InspectNetwork returns the full configuration of a network and the
+// endpoints of the containers attached to it
+func (c *Client) InspectNetwork(ctx context.Context, networkID string) (*models.NetworkDetail, error) {
+	net, err := c.cli.NetworkInspect(ctx, networkID, network.InspectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect network %s: %w", networkID, err)
+	}
+
+	detail := &models.NetworkDetail{
+		Network: models.Network{
+			ID:         net.ID,
+			Name:       net.Name,
+			Driver:     net.Driver,
+			Scope:      net.Scope,
+			Internal:   net.Internal,
+			Attachable: net.Attachable,
+			Created:    net.Created,
+			Labels:     net.Labels,
+			IPAM:       models.NetworkIPAM{Driver: net.IPAM.Driver},
+		},
+		EnableIPv6:  net.EnableIPv6,
+		ConfigOnly:  net.ConfigOnly,
+		ConfigFrom:  net.ConfigFrom.Network,
+		IPAMOptions: net.IPAM.Options,
+		Options:     net.Options,
+	}
+	for _, cfg := range net.IPAM.Config {
+		detail.Subnets = append(detail.Subnets, models.NetworkSubnet{
+			Subnet:       cfg.Subnet,
+			Gateway:      cfg.Gateway,
+			IPRange:      cfg.IPRange,
+			AuxAddresses: cfg.AuxAddress,
+		})
+	}
+	if len(detail.Subnets) > 0 {
+		detail.IPAM.Subnet = detail.Subnets[0].Subnet
+		detail.IPAM.Gateway = detail.Subnets[0].Gateway
+	}
+	for id, ep := range net.Containers {
+		detail.Containers = append(detail.Containers, id)
+		detail.Endpoints = append(detail.Endpoints, models.NetworkEndpoint{
+			ContainerID: id,
+			Name:        ep.Name,
+			IPv4:        ep.IPv4Address,
+			IPv6:        ep.IPv6Address,
+			MAC:         ep.MacAddress,
+		})
+	}
+	detail.SortEndpoints()
+	return detail, nil
+}
+
+// ConnectContainer connects a container to a network, with DNS aliases and
+// a static IPv4 address if given. Without them Docker picks the defaults.
+func (c *Client) ConnectContainer(ctx context.Context, networkID, containerID string, aliases []string, ipv4 string) (err error) {
+	detail := "network " + networkID
+	if ipv4 != "" {
+		detail += " ip " + ipv4
+	}
+	if len(aliases) > 0 {
+		detail += " aliases " + strings.Join(aliases, ",")
+	}
+	defer func() { c.logAction("network.connect", containerID, detail, err) }()
+
+	var settings *network.EndpointSettings
+	if len(aliases) > 0 || ipv4 != "" {
+		settings = &network.EndpointSettings{Aliases: aliases}
+		if ipv4 != "" {
+			settings.IPAMConfig = &network.EndpointIPAMConfig{IPv4Address: ipv4}
+		}
+	}
+
+	err = c.cli.NetworkConnect(ctx, networkID, containerID, settings)
 	if err != nil {
 		return fmt.Errorf("failed to connect container %s to network %s: %w", containerID, networkID, err)
 	}
@@ -65,17 +137,68 @@ func (c *Client) ConnectContainer(ctx context.Context, networkID, containerID st
 }
 
 // DisconnectContainer disconnects a container from a network
-func (c *Client) DisconnectContainer(ctx context.Context, networkID, containerID string, force bool) error {
-	err := c.cli.NetworkDisconnect(ctx, networkID, containerID, force)
+func (c *Client) DisconnectContainer(ctx context.Context, networkID, containerID string, force bool) (err error) {
+	defer func() { c.logAction("network.disconnect", containerID, "network "+networkID, err) }()
+
+	err = c.cli.NetworkDisconnect(ctx, networkID, containerID, force)
+	if err != nil {
+		return fmt.Errorf("failed to disconnect container %s from network %s: %w", containerID, networkID, err)
+	}
+	return nil
+}
+
+// SetNetworkAliases replaces a container's DNS aliases on a network. Aliases
+// can't be changed on a live endpoint, so the container is disconnected and
+// reconnected, keeping its static IP and links.
+func (c *Client) SetNetworkAliases(ctx context.Context, networkID, containerID string, aliases []string) (err error) {
+	defer func() {
+		c.logAction("network.aliases", containerID, fmt.Sprintf("network %s: %s", networkID, strings.Join(aliases, ",")), err)
+	}()
+
+	inspect, err := c.cli.ContainerInspect(ctx, containerID)
 	if err != nil {
+		return fmt.Errorf("failed to inspect container %s: %w", containerID, err)
+	}
+
+	var endpoint *network.EndpointSettings
+	if inspect.NetworkSettings != nil {
+		for _, ep := range inspect.NetworkSettings.Networks {
+			if ep != nil && ep.NetworkID == networkID {
+				endpoint = ep
+				break
+			}
+		}
+	}
+	if endpoint == nil {
+		return fmt.Errorf("container %s is not connected to network %s", containerID, networkID)
+	}
+
+	settings := &network.EndpointSettings{
+		IPAMConfig: endpoint.IPAMConfig,
+		Links:      endpoint.Links,
+		DriverOpts: endpoint.DriverOpts,
+		Aliases:    aliases,
+	}
+
+	if err := c.cli.NetworkDisconnect(ctx, networkID, containerID, false); err != nil {
 		return fmt.Errorf("failed to disconnect container %s from network %s: %w", containerID, networkID, err)
 	}
+	if err := c.cli.NetworkConnect(ctx, networkID, containerID, settings); err != nil {
+		// Don't leave the container off the network, reconnect it as it was
+		settings.Aliases = endpoint.Aliases
+		if rerr := c.cli.NetworkConnect(ctx, networkID, containerID, settings); rerr != nil {
+			return fmt.Errorf("failed to reconnect container %s to network %s (it is now disconnected): %w", containerID, networkID, err)
+		}
+		return fmt.Errorf("failed to set aliases of container %s on network %s: %w", containerID, networkID, err)
+	}
 	return nil
 }
 
 // CreateNetwork creates a new Docker network
-func (c *Client) CreateNetwork(ctx context.Context, name, driver string) error {
-	_, err := c.cli.NetworkCreate(ctx, name, network.CreateOptions{
+func (c *Client) CreateNetwork(ctx context.Context, name, driver string) (err error) {
+	defer c.audit("network.create", name, &err)
+
+	_, err = c.cli.NetworkCreate(ctx, name, network.CreateOptions{
 		Driver: driver,
 	})
 	if err != nil {
@@ -85,10 +208,57 @@ func (c *Client) CreateNetwork(ctx context.Context, name, driver string) error {
 }
 
 // RemoveNetwork removes a Docker network by ID
-func (c *Client) RemoveNetwork(ctx context.Context, networkID string) error {
-	err := c.cli.NetworkRemove(ctx, networkID)
+func (c *Client) RemoveNetwork(ctx context.Context, networkID string) (err error) {
+	defer c.audit("network.remove", networkID, &err)
+
+	err = c.cli.NetworkRemove(ctx, networkID)
 	if err != nil {
 		return fmt.Errorf("failed to remove network %s: %w", networkID, err)
 	}
 	return nil
 }
+
+// PruneNetworks removes every network no container is connected to, like
+// `docker network prune`, and returns the names of those removed. The
+// daemon never prunes the predefined bridge, host and none networks.
+func (c *Client) PruneNetworks(ctx context.Context) (removed []string, err error) {
+	defer func() {
+		c.logAction("network.prune", "unused networks", strings.Join(removed, ","), err)
+	}()
+
+	report, err := c.cli.NetworksPrune(ctx, filters.NewArgs())
+	if err != nil {
+		return nil, fmt.Errorf("failed to prune networks: %w", err)
+	}
+	return report.NetworksDeleted, nil
+}
+
+// CreateMacvlanNetwork creates a macvlan or ipvlan network on a host interface
+func (c *Client) CreateMacvlanNetwork(ctx context.Context, n models.MacvlanNetwork) (err error) {
+	defer c.audit("network.create", n.Name, &err)
+
+	if n.Driver == "ipvlan" {
+		if err := c.require(models.FeatureIpvlan); err != nil {
+			return err
+		}
+	}
+
+	_, err = c.cli.NetworkCreate(ctx, n.Name, network.CreateOptions{
+		Driver: n.Driver,
+		Options: map[string]string{
+			"parent":           n.Parent,
+			n.Driver + "_mode": n.GetMode(),
+		},
+		IPAM: &network.IPAM{
+			Config: []network.IPAMConfig{{
+				Subnet:  n.Subnet,
+				Gateway: n.Gateway,
+				IPRange: n.IPRange,
+			}},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create network %s: %w", n.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/rizface/doui/internal/models"
+)
+
+// ListPlugins returns all installed Docker engine plugins
+func (c *Client) ListPlugins(ctx context.Context) ([]models.Plugin, error) {
+	if err := c.require(models.FeaturePlugins); err != nil {
+		return nil, err
+	}
+
+	plugins, err := c.cli.PluginList(ctx, filters.Args{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list plugins: %w", err)
+	}
+
+	result := make([]models.Plugin, 0, len(plugins))
+	for _, p := range plugins {
+		if p == nil {
+			continue
+		}
+
+		capabilities := make([]string, 0, len(p.Config.Interface.Types))
+		for _, t := range p.Config.Interface.Types {
+			capabilities = append(capabilities, t.Capability)
+		}
+
+		result = append(result, models.Plugin{
+			ID:           p.ID,
+			Name:         p.Name,
+			Description:  p.Config.Description,
+			Enabled:      p.Enabled,
+			Capabilities: capabilities,
+			Reference:    p.PluginReference,
+		})
+	}
+
+	// Sort plugins alphabetically by name for consistent ordering
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result, nil
+}
+
+// EnablePlugin enables a plugin by name
+func (c *Client) EnablePlugin(ctx context.Context, name string) (err error) {
+	defer c.audit("plugin.enable", name, &err)
+
+	if err := c.cli.PluginEnable(ctx, name, types.PluginEnableOptions{}); err != nil {
+		return fmt.Errorf("failed to enable plugin %s: %w", name, err)
+	}
+	return nil
+}
+
+// DisablePlugin disables a plugin by name, force disables it even if in use
+func (c *Client) DisablePlugin(ctx context.Context, name string, force bool) (err error) {
+	defer c.audit("plugin.disable", name, &err)
+
+	if err := c.cli.PluginDisable(ctx, name, types.PluginDisableOptions{Force: force}); err != nil {
+		return fmt.Errorf("failed to disable plugin %s: %w", name, err)
+	}
+	return nil
+}
+
+// RemovePlugin removes a plugin by name, force removes an enabled plugin
+func (c *Client) RemovePlugin(ctx context.Context, name string, force bool) (err error) {
+	defer c.audit("plugin.remove", name, &err)
+
+	if err := c.cli.PluginRemove(ctx, name, types.PluginRemoveOptions{Force: force}); err != nil {
+		return fmt.Errorf("failed to remove plugin %s: %w", name, err)
+	}
+	return nil
+}
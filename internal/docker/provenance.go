@@ -0,0 +1,108 @@
+package docker
+
+import (
+	"context"
+	"strings"
+
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/errdefs"
+	"github.com/rizface/doui/internal/models"
+)
+
+// GetImageProvenance gathers the provenance info of an image: OCI labels,
+// build attestations kept by the daemon and cosign signatures in the registry
+func (c *Client) GetImageProvenance(ctx context.Context, img models.Image) *models.ImageProvenance {
+	provenance := models.NewImageProvenance(img)
+	c.platformMu.Lock()
+	provenance.Platform = c.imagePlatform(ctx, img.ID)
+	c.platformMu.Unlock()
+
+	// Attestation manifests are only listed by the containerd image store (API 1.47+)
+	opts := image.ListOptions{Manifests: true}
+	if !img.IsDangling() {
+		opts.Filters = filters.NewArgs(filters.Arg("reference", img.GetPrimaryTag()))
+	}
+	images, err := c.cli.ImageList(ctx, opts)
+	if err == nil {
+		for _, summary := range images {
+			if summary.ID != img.ID || summary.Manifests == nil {
+				continue
+			}
+			provenance.AttestationsKnown = true
+			for _, manifest := range summary.Manifests {
+				if manifest.Kind == image.ManifestKindAttestation {
+					provenance.Attestations++
+				}
+			}
+		}
+	}
+
+	// Locally built images have no registry digest, so nothing to look up
+	if provenance.Digest == "" {
+		return provenance
+	}
+
+	provenance.CosignChecked = true
+	if err := c.require(models.FeatureRegistryLookup); err != nil {
+		provenance.CosignErr = err.Error()
+		provenance.PlatformsErr = err.Error()
+		return provenance
+	}
+
+	if platforms, err := c.registryPlatforms(ctx, provenance.Digest); err != nil {
+		provenance.PlatformsErr = err.Error()
+	} else {
+		provenance.Platforms = platforms
+	}
+
+	signature := models.CosignTag(provenance.Digest, ".sig")
+	found, err := c.registryHasTag(ctx, signature)
+	if err != nil {
+		provenance.CosignErr = err.Error()
+		return provenance
+	}
+	if found {
+		provenance.CosignSignature = signature
+	}
+
+	attestation := models.CosignTag(provenance.Digest, ".att")
+	if found, err := c.registryHasTag(ctx, attestation); err == nil && found {
+		provenance.CosignAttestation = attestation
+	}
+
+	return provenance
+}
+
+// registryPlatforms returns the platforms the registry has an image for,
+// from its manifest list. Attestation manifests (unknown/unknown) are skipped.
+func (c *Client) registryPlatforms(ctx context.Context, ref string) ([]string, error) {
+	inspect, err := c.cli.DistributionInspect(ctx, ref, c.registryAuth(ref))
+	if err != nil {
+		return nil, err
+	}
+	var platforms []string
+	for _, p := range inspect.Platforms {
+		if p.OS == "" || p.OS == "unknown" {
+			continue
+		}
+		platforms = append(platforms, models.FormatPlatform(p.OS, p.Architecture, p.Variant))
+	}
+	return platforms, nil
+}
+
+// registryHasTag asks the registry (through the daemon) whether ref exists
+func (c *Client) registryHasTag(ctx context.Context, ref string) (bool, error) {
+	if ref == "" {
+		return false, nil
+	}
+	_, err := c.cli.DistributionInspect(ctx, ref, "")
+	if err == nil {
+		return true, nil
+	}
+	msg := strings.ToLower(err.Error())
+	if errdefs.IsNotFound(err) || strings.Contains(msg, "manifest unknown") || strings.Contains(msg, "not found") {
+		return false, nil
+	}
+	return false, err
+}
@@ -0,0 +1,166 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/registry"
+	"github.com/rizface/doui/internal/models"
+)
+
+// dockerHubIndex is the server address the daemon expects for Docker Hub logins
+const dockerHubIndex = "https://index.docker.io/v1/"
+
+// RegistryLogin checks the credentials with the daemon and keeps them for the
+// pulls of this client. SaveRegistryLogin stores them for later sessions.
+func (c *Client) RegistryLogin(ctx context.Context, registryHost, username, password string) error {
+	registryHost = models.NormalizeRegistry(registryHost)
+	auth := registry.AuthConfig{
+		Username:      username,
+		Password:      password,
+		ServerAddress: credentialServer(registryHost),
+	}
+
+	resp, err := c.cli.RegistryLogin(ctx, auth)
+	if err != nil {
+		return fmt.Errorf("failed to log in to %s: %w", registryHost, err)
+	}
+	if resp.IdentityToken != "" {
+		auth.IdentityToken = resp.IdentityToken
+	}
+
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+	if c.auths == nil {
+		c.auths = make(map[string]registry.AuthConfig)
+	}
+	c.auths[registryHost] = auth
+	return nil
+}
+
+// SaveRegistryLogin stores this session's login to a registry like
+// `docker login` does, with the docker CLI's credential helper or in its
+// config.json, and returns where it was saved
+func (c *Client) SaveRegistryLogin(registryHost string) (string, error) {
+	registryHost = models.NormalizeRegistry(registryHost)
+	c.authMu.Lock()
+	auth, ok := c.auths[registryHost]
+	c.authMu.Unlock()
+	if !ok {
+		return "", fmt.Errorf("not logged in to %s", registryHost)
+	}
+	return storeRegistryAuth(registryHost, auth)
+}
+
+// RegistryUser returns the user logged in to a registry, in doui or with the
+// docker CLI, or "" if pulls from it are anonymous
+func (c *Client) RegistryUser(registryHost string) string {
+	auth, _ := c.lookupAuth(models.NormalizeRegistry(registryHost))
+	return auth.Username
+}
+
+// lookupAuth returns the login of this session for a registry, falling back
+// to the one stored by the docker CLI, which is then kept for the session
+func (c *Client) lookupAuth(registryHost string) (registry.AuthConfig, bool) {
+	c.authMu.Lock()
+	auth, ok := c.auths[registryHost]
+	c.authMu.Unlock()
+	if ok {
+		return auth, true
+	}
+
+	auth, ok = storedRegistryAuth(registryHost)
+	if !ok {
+		return auth, false
+	}
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+	if c.auths == nil {
+		c.auths = make(map[string]registry.AuthConfig)
+	}
+	c.auths[registryHost] = auth
+	return auth, true
+}
+
+// registryAuth returns the encoded credentials for pulling imageName, or ""
+func (c *Client) registryAuth(imageName string) string {
+	auth, ok := c.lookupAuth(models.ImageRegistry(imageName))
+	if !ok {
+		return ""
+	}
+	encoded, err := registry.EncodeAuthConfig(auth)
+	if err != nil {
+		return ""
+	}
+	return encoded
+}
+
+// IsRateLimitError returns true if a pull failed because the registry rate
+// limited it (Docker Hub's "toomanyrequests")
+func IsRateLimitError(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "toomanyrequests") || strings.Contains(msg, "pull rate limit")
+}
+
+// DockerHubRateLimit looks up the pull limit of the logged in Docker Hub user,
+// or of this machine's IP when anonymous, with a HEAD request that doesn't
+// count as a pull. For a remote daemon anonymous limits are those of doui's
+// host, which may differ from the daemon's.
+func (c *Client) DockerHubRateLimit(ctx context.Context) (*models.RateLimit, error) {
+	const repo = "ratelimitpreview/test"
+
+	tokenURL := "https://auth.docker.io/token?service=registry.docker.io&scope=repository:" + repo + ":pull"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, tokenURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if auth, loggedIn := c.lookupAuth(models.DockerHub); loggedIn && auth.Password != "" {
+		req.SetBasicAuth(auth.Username, auth.Password)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach Docker Hub: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get a Docker Hub token: %s", resp.Status)
+	}
+	var token struct {
+		Token string `json:"token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return nil, fmt.Errorf("failed to parse Docker Hub token: %w", err)
+	}
+
+	req, err = http.NewRequestWithContext(ctx, http.MethodHead, "https://registry-1.docker.io/v2/"+repo+"/manifests/latest", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token.Token)
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach Docker Hub: %w", err)
+	}
+	resp.Body.Close()
+
+	limit := &models.RateLimit{CheckedAt: time.Now()}
+	if n, window, ok := models.ParseRateLimitHeader(resp.Header.Get("ratelimit-limit")); ok {
+		limit.Limit, limit.Window = n, window
+	}
+	if n, _, ok := models.ParseRateLimitHeader(resp.Header.Get("ratelimit-remaining")); ok {
+		limit.Remaining = n
+	}
+	if s, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		limit.RetryAfter = time.Duration(s) * time.Second
+	}
+	return limit, nil
+}
@@ -0,0 +1,113 @@
+package docker
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/docker/go-connections/nat"
+	"github.com/rizface/doui/internal/models"
+)
+
+// QuickRun creates and starts a container from an image in the background,
+// like `docker run -d --name name -p 8080:80 image`, with ports in the
+// docker CLI's -p syntax. With autoRemove the container is removed once it
+// stops (--rm). Returns the container's ID and name.
+func (c *Client) QuickRun(ctx context.Context, imageName, name string, ports []string, autoRemove bool) (id, actualName string, err error) {
+	defer func() { c.logAction("container.create", imageName, actualName, err) }()
+
+	exposed, bindings, err := nat.ParsePortSpecs(ports)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid port mapping: %w", err)
+	}
+
+	config := &container.Config{Image: imageName, ExposedPorts: exposed}
+	hostConfig := &container.HostConfig{PortBindings: bindings, AutoRemove: autoRemove}
+	resp, err := c.cli.ContainerCreate(ctx, config, hostConfig, nil, nil, name)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create container: %w", err)
+	}
+
+	inspect, err := c.cli.ContainerInspect(ctx, resp.ID)
+	if err == nil {
+		actualName = strings.TrimPrefix(inspect.Name, "/")
+	}
+	if err := c.cli.ContainerStart(ctx, resp.ID, container.StartOptions{}); err != nil {
+		// Don't leave a container that never ran behind
+		_ = c.cli.ContainerRemove(context.Background(), resp.ID, container.RemoveOptions{Force: true})
+		return "", actualName, fmt.Errorf("failed to start container: %w", err)
+	}
+	return resp.ID, actualName, nil
+}
+
+// RunOnce runs a container from an image until it exits, like `docker run
+// --rm`, and returns its exit code and output. The container is removed by
+// doui rather than with AutoRemove, so the output can be read after it exits.
+func (c *Client) RunOnce(ctx context.Context, imageName string, cmd []string) (result *models.RunResult) {
+	result = &models.RunResult{Image: imageName, Command: strings.Join(cmd, " ")}
+	defer func() {
+		var err error
+		if result.Err != "" {
+			err = fmt.Errorf("%s", result.Err)
+		}
+		c.logAction("container.run", imageName, fmt.Sprintf("exit %d", result.ExitCode), err)
+	}()
+
+	resp, err := c.cli.ContainerCreate(ctx, &container.Config{Image: imageName, Cmd: cmd}, &container.HostConfig{}, nil, nil, "")
+	if err != nil {
+		result.Err = fmt.Sprintf("failed to create container: %v", err)
+		return result
+	}
+	result.ContainerID = resp.ID
+
+	// Remove it whatever happens, even once ctx is done
+	defer func() {
+		rmCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		_ = c.cli.ContainerRemove(rmCtx, resp.ID, container.RemoveOptions{Force: true})
+	}()
+
+	// Wait from before the start so a fast exit isn't missed
+	waitCh, waitErrCh := c.cli.ContainerWait(ctx, resp.ID, container.WaitConditionNextExit)
+	result.Started = time.Now()
+	if err := c.cli.ContainerStart(ctx, resp.ID, container.StartOptions{}); err != nil {
+		result.Err = fmt.Sprintf("failed to start container: %v", err)
+		return result
+	}
+
+	select {
+	case status := <-waitCh:
+		result.Finished = time.Now()
+		result.ExitCode = int(status.StatusCode)
+		if status.Error != nil && status.Error.Message != "" {
+			result.Err = status.Error.Message
+		}
+	case err := <-waitErrCh:
+		result.Finished = time.Now()
+		result.Err = fmt.Sprintf("failed to wait for container: %v", err)
+	}
+
+	// The logs are read even if waiting failed (e.g. timeout), they show how
+	// far it got
+	logsCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	reader, err := c.cli.ContainerLogs(logsCtx, resp.ID, container.LogsOptions{ShowStdout: true, ShowStderr: true})
+	if err != nil {
+		if result.Err == "" {
+			result.Err = fmt.Sprintf("failed to read output: %v", err)
+		}
+		return result
+	}
+	defer reader.Close()
+
+	var buf bytes.Buffer
+	if _, err := stdcopy.StdCopy(&buf, &buf, reader); err != nil && result.Err == "" {
+		result.Err = fmt.Sprintf("failed to read output: %v", err)
+	}
+	result.Output = buf.String()
+	return result
+}
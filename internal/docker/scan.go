@@ -0,0 +1,109 @@
+package docker
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/rizface/doui/internal/models"
+)
+
+// scanner is an installed vulnerability scanner CLI
+type scanner struct {
+	name  string // "trivy" or "docker scout"
+	cmd   string
+	args  func(ref string) []string
+	parse func(data []byte) ([]models.Vulnerability, error)
+}
+
+var scanners = []scanner{
+	{
+		name: "trivy",
+		cmd:  "trivy",
+		args: func(ref string) []string {
+			return []string{"image", "--format", "json", "--quiet", "--scanners", "vuln", ref}
+		},
+		parse: models.ParseTrivyReport,
+	},
+	{
+		name: "docker scout",
+		cmd:  "docker",
+		args: func(ref string) []string {
+			return []string{"scout", "cves", "--format", "gitlab", "local://" + ref}
+		},
+		parse: models.ParseScoutReport,
+	},
+}
+
+// installedScanner returns the first scanner that is installed: trivy, or
+// the docker scout CLI plugin
+func installedScanner(ctx context.Context) (scanner, error) {
+	if _, err := exec.LookPath("trivy"); err == nil {
+		return scanners[0], nil
+	}
+	if exec.CommandContext(ctx, "docker", "scout", "version").Run() == nil {
+		return scanners[1], nil
+	}
+	return scanner{}, errors.New("no vulnerability scanner found, install trivy or the docker scout CLI plugin")
+}
+
+// CachedScan returns the last scan of an image, if it was scanned this session
+func (c *Client) CachedScan(imageID string) *models.VulnScan {
+	c.scanMu.Lock()
+	defer c.scanMu.Unlock()
+	return c.scans[imageID]
+}
+
+// ScanImage scans an image for known vulnerabilities with trivy or docker
+// scout, whichever is installed. Scans are cached per image ID, the digest of
+// the image's content, so a retag doesn't rescan but a new build does.
+func (c *Client) ScanImage(ctx context.Context, img models.Image) (*models.VulnScan, error) {
+	found, err := installedScanner(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ref := img.GetPrimaryTag()
+	if img.IsDangling() {
+		ref = img.ID
+	}
+	cmd := exec.CommandContext(ctx, found.cmd, found.args(ref)...)
+	// Scanners read the image from the daemon doui is connected to
+	if c.host != "" {
+		cmd.Env = append(os.Environ(), "DOCKER_HOST="+c.host)
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		lines := strings.Split(strings.TrimSpace(stderr.String()), "\n")
+		if msg := strings.TrimSpace(lines[len(lines)-1]); msg != "" {
+			return nil, fmt.Errorf("failed to scan %s with %s: %s", ref, found.name, msg)
+		}
+		return nil, fmt.Errorf("failed to scan %s with %s: %w", ref, found.name, err)
+	}
+
+	findings, err := found.parse(out)
+	if err != nil {
+		return nil, err
+	}
+	scan := &models.VulnScan{
+		Image:     ref,
+		Scanner:   found.name,
+		Findings:  findings,
+		ScannedAt: time.Now(),
+	}
+
+	c.scanMu.Lock()
+	defer c.scanMu.Unlock()
+	if c.scans == nil {
+		c.scans = make(map[string]*models.VulnScan)
+	}
+	c.scans[img.ID] = scan
+	return scan, nil
+}
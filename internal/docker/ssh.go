@@ -0,0 +1,167 @@
+package docker
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/client"
+)
+
+// sshDummyHost is the HTTP host used for requests tunneled over SSH; the
+// address is never resolved because the dialer ignores it
+const sshDummyHost = "http://docker.example.com"
+
+// sshKeepAlive is how often ssh checks that the remote end is still there
+const sshKeepAlive = 15 * time.Second
+
+// sshTunnelError is the error ssh printed when the tunnel failed or dropped
+type sshTunnelError struct {
+	msg string
+}
+
+func (e *sshTunnelError) Error() string { return e.msg }
+
+// IsSSHHost returns true for ssh://[user@]host[:port] daemon addresses
+func IsSSHHost(host string) bool {
+	return strings.HasPrefix(host, "ssh://")
+}
+
+// sshClientOpts returns client options that reach the daemon through
+// `ssh ... docker system dial-stdio` on the remote host, the same connection
+// helper the docker CLI uses. Keys, agents and ~/.ssh/config are handled by ssh.
+func sshClientOpts(host string) ([]client.Opt, error) {
+	args, err := sshArgs(host)
+	if err != nil {
+		return nil, err
+	}
+
+	dial := func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return newCommandConn(ctx, "ssh", args...)
+	}
+
+	return []client.Opt{
+		client.WithHost(sshDummyHost),
+		client.WithDialContext(dial),
+	}, nil
+}
+
+// sshArgs builds the ssh command line for an ssh://[user@]host[:port] address
+func sshArgs(host string) ([]string, error) {
+	u, err := url.Parse(host)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ssh host %q: %w", host, err)
+	}
+	if u.Scheme != "ssh" || u.Hostname() == "" {
+		return nil, fmt.Errorf("invalid ssh host %q: expected ssh://[user@]host[:port]", host)
+	}
+	if u.Path != "" && u.Path != "/" {
+		return nil, fmt.Errorf("invalid ssh host %q: paths are not supported", host)
+	}
+
+	// Keepalives make ssh exit when the link silently drops (laptop sleep,
+	// NAT timeout) instead of hanging, so the next request dials a new tunnel
+	args := []string{
+		"-o", "ConnectTimeout=30",
+		"-o", fmt.Sprintf("ServerAliveInterval=%d", int(sshKeepAlive.Seconds())),
+		"-o", "ServerAliveCountMax=3",
+		"-T",
+	}
+	if u.User != nil && u.User.Username() != "" {
+		args = append(args, "-l", u.User.Username())
+	}
+	if port := u.Port(); port != "" {
+		args = append(args, "-p", port)
+	}
+	args = append(args, "--", u.Hostname(), "docker", "system", "dial-stdio")
+	return args, nil
+}
+
+// commandConn is a net.Conn backed by a command's stdin and stdout
+type commandConn struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stdout io.ReadCloser
+	stderr *bytes.Buffer
+
+	closeOnce sync.Once
+	waitOnce  sync.Once
+}
+
+func newCommandConn(ctx context.Context, name string, args ...string) (net.Conn, error) {
+	// Not bound to ctx: the connection outlives the dial call and is reused
+	cmd := exec.Command(name, args...)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	stderr := &bytes.Buffer{}
+	cmd.Stderr = stderr
+
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to run %s: %w", name, err)
+	}
+	return &commandConn{cmd: cmd, stdin: stdin, stdout: stdout, stderr: stderr}, nil
+}
+
+func (c *commandConn) Read(p []byte) (int, error) {
+	n, err := c.stdout.Read(p)
+	if err == io.EOF && n == 0 {
+		// Surface ssh's own error (auth failure, docker missing on the host),
+		// which is only fully captured once the process has exited
+		c.wait()
+		if msg := strings.TrimSpace(c.stderr.String()); msg != "" {
+			if !strings.HasPrefix(msg, "ssh:") {
+				msg = "ssh: " + msg
+			}
+			return 0, &sshTunnelError{msg: msg}
+		}
+	}
+	return n, err
+}
+
+func (c *commandConn) Write(p []byte) (int, error) {
+	return c.stdin.Write(p)
+}
+
+func (c *commandConn) Close() error {
+	c.closeOnce.Do(func() {
+		c.stdin.Close()
+		if c.cmd.Process != nil {
+			c.cmd.Process.Kill()
+		}
+		c.wait()
+	})
+	return nil
+}
+
+// wait reaps the process, safe to call more than once
+func (c *commandConn) wait() {
+	c.waitOnce.Do(func() {
+		c.cmd.Wait()
+	})
+}
+
+func (c *commandConn) LocalAddr() net.Addr  { return dummyAddr{} }
+func (c *commandConn) RemoteAddr() net.Addr { return dummyAddr{} }
+
+// Deadlines are not supported on pipes; request contexts still apply
+func (c *commandConn) SetDeadline(t time.Time) error      { return nil }
+func (c *commandConn) SetReadDeadline(t time.Time) error  { return nil }
+func (c *commandConn) SetWriteDeadline(t time.Time) error { return nil }
+
+type dummyAddr struct{}
+
+func (dummyAddr) Network() string { return "ssh" }
+func (dummyAddr) String() string  { return "ssh" }
@@ -0,0 +1,104 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/rizface/doui/internal/models"
+)
+
+// GetHostInfo returns information about the Docker host
+func (c *Client) GetHostInfo(ctx context.Context) (*models.HostInfo, error) {
+	info, err := c.cli.Info(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get host info: %w", err)
+	}
+
+	return &models.HostInfo{
+		Name:            info.Name,
+		ServerVersion:   info.ServerVersion,
+		OperatingSystem: info.OperatingSystem,
+		Architecture:    info.Architecture,
+		NCPU:            info.NCPU,
+		MemTotal:        info.MemTotal,
+	}, nil
+}
+
+// archWarning returns a warning if the image can't run natively on the host
+// Lookups are cached, so this is cheap to call on every refresh
+func (c *Client) archWarning(ctx context.Context, imageID string) string {
+	c.platformMu.Lock()
+	defer c.platformMu.Unlock()
+
+	if c.hostArch == "" {
+		info, err := c.cli.Info(ctx)
+		if err != nil {
+			return ""
+		}
+		c.hostArch = info.Architecture
+	}
+
+	platform := c.imagePlatform(ctx, imageID)
+	if platform == "" {
+		return ""
+	}
+	imageOS, imageArch, _ := strings.Cut(platform, "/")
+	return models.ArchMismatchWarning(imageOS, imageArch, c.hostArch)
+}
+
+// imagePlatform returns the cached "os/arch" of an image, or "" if it can't
+// be inspected. The caller holds platformMu.
+func (c *Client) imagePlatform(ctx context.Context, imageID string) string {
+	if c.imageArch == nil {
+		c.imageArch = make(map[string]string)
+	}
+	platform, ok := c.imageArch[imageID]
+	if !ok {
+		inspect, _, err := c.cli.ImageInspectWithRaw(ctx, imageID)
+		if err != nil {
+			return ""
+		}
+		platform = inspect.Os + "/" + inspect.Architecture
+		c.imageArch[imageID] = platform
+	}
+	return platform
+}
+
+// containerRuntime returns the runtime, image platform and privileged flag
+// of a container. Containers are inspected once and cached, since none of
+// these can change without recreating the container.
+func (c *Client) containerRuntime(ctx context.Context, containerID, imageID string) models.RuntimeInfo {
+	c.platformMu.Lock()
+	defer c.platformMu.Unlock()
+
+	if info, ok := c.runtimes[containerID]; ok {
+		return info
+	}
+	inspect, err := c.cli.ContainerInspect(ctx, containerID)
+	if err != nil || inspect.ContainerJSONBase == nil {
+		return models.RuntimeInfo{}
+	}
+
+	info := models.RuntimeInfo{Platform: c.imagePlatform(ctx, imageID)}
+	if inspect.HostConfig != nil {
+		info.Runtime = inspect.HostConfig.Runtime
+		info.Privileged = inspect.HostConfig.Privileged
+	}
+	if c.runtimes == nil {
+		c.runtimes = make(map[string]models.RuntimeInfo)
+	}
+	c.runtimes[containerID] = info
+	return info
+}
+
+// forgetRuntimes drops the cached runtimes of containers not in ids
+func (c *Client) forgetRuntimes(ids map[string]bool) {
+	c.platformMu.Lock()
+	defer c.platformMu.Unlock()
+	for id := range c.runtimes {
+		if !ids[id] {
+			delete(c.runtimes, id)
+		}
+	}
+}
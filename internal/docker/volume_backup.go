@@ -0,0 +1,191 @@
+package docker
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/client"
+)
+
+// volumeHelperImage is the image of the helper container a volume is
+// mounted in to be backed up or restored. It never runs, so any small image would do.
+const volumeHelperImage = "busybox:latest"
+
+// volumeMountPath is where the helper container mounts the volume
+const volumeMountPath = "/volume"
+
+// ensureHelperImage pulls the helper image unless the daemon already has it
+func (c *Client) ensureHelperImage(ctx context.Context) error {
+	if _, _, err := c.cli.ImageInspectWithRaw(ctx, volumeHelperImage); err == nil {
+		return nil
+	} else if !client.IsErrNotFound(err) {
+		return fmt.Errorf("failed to inspect %s: %w", volumeHelperImage, err)
+	}
+
+	out, err := c.cli.ImagePull(ctx, volumeHelperImage, image.PullOptions{RegistryAuth: c.registryAuth(volumeHelperImage)})
+	if err != nil {
+		return fmt.Errorf("failed to pull %s: %w", volumeHelperImage, err)
+	}
+	defer out.Close()
+
+	scanner := bufio.NewScanner(out)
+	for scanner.Scan() {
+		var event pullEvent
+		if json.Unmarshal(scanner.Bytes(), &event) == nil && event.Error != "" {
+			return fmt.Errorf("failed to pull %s: %s", volumeHelperImage, event.Error)
+		}
+	}
+	return scanner.Err()
+}
+
+// createVolumeHelper creates (without starting) a container with the volume
+// mounted at volumeMountPath, and returns a func removing it
+func (c *Client) createVolumeHelper(ctx context.Context, volumeName string, readOnly bool) (string, func(), error) {
+	if err := c.ensureHelperImage(ctx); err != nil {
+		return "", nil, err
+	}
+
+	resp, err := c.cli.ContainerCreate(ctx,
+		&container.Config{Image: volumeHelperImage, Cmd: []string{"true"}, Labels: map[string]string{"doui.helper": "volume"}},
+		&container.HostConfig{Mounts: []mount.Mount{{
+			Type:     mount.TypeVolume,
+			Source:   volumeName,
+			Target:   volumeMountPath,
+			ReadOnly: readOnly,
+		}}},
+		nil, nil, "")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create helper container: %w", err)
+	}
+
+	// Remove it whatever happens, even once ctx is done
+	remove := func() {
+		rmCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		_ = c.cli.ContainerRemove(rmCtx, resp.ID, container.RemoveOptions{Force: true})
+	}
+	return resp.ID, remove, nil
+}
+
+// BackupVolume writes the contents of a volume to a tar archive on this
+// machine, gzipped if path ends in .gz or .tgz, adding the bytes written so
+// far to written. The daemon archives the volume through a helper container
+// that mounts it, so this works with remote daemons too. Like SaveImage, a
+// failed backup leaves no file behind and an existing file is never
+// overwritten. Returns the size of the archive.
+func (c *Client) BackupVolume(ctx context.Context, volumeName, path string, written *atomic.Int64) (size int64, err error) {
+	defer func() { c.logAction("volume.backup", volumeName, path, err) }()
+
+	path, err = expandHome(path)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := os.Stat(path); err == nil {
+		return 0, fmt.Errorf("%s already exists", path)
+	}
+
+	id, remove, err := c.createVolumeHelper(ctx, volumeName, true)
+	if err != nil {
+		return 0, err
+	}
+	defer remove()
+
+	// "/volume/." archives the contents without the directory itself, like
+	// `tar -C /volume .`
+	archive, _, err := c.cli.CopyFromContainer(ctx, id, volumeMountPath+"/.")
+	if err != nil {
+		return 0, fmt.Errorf("failed to archive volume %s: %w", volumeName, err)
+	}
+	defer archive.Close()
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return 0, fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	defer func() {
+		if err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	var w io.Writer = countingWriter{w: tmp, n: written}
+	var gz *gzip.Writer
+	if strings.HasSuffix(path, ".gz") || strings.HasSuffix(path, ".tgz") {
+		gz = gzip.NewWriter(w)
+		w = gz
+	}
+	if _, err = io.Copy(w, archive); err != nil {
+		return 0, fmt.Errorf("failed to back up volume %s: %w", volumeName, err)
+	}
+	if gz != nil {
+		if err = gz.Close(); err != nil {
+			return 0, fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+	if err = tmp.Chmod(0644); err != nil {
+		return 0, fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err = tmp.Close(); err != nil {
+		return 0, fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return 0, fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return written.Load(), nil
+}
+
+// RestoreVolume extracts a tar archive on this machine (plain or
+// compressed) into a volume, creating the volume if it doesn't exist.
+// Files of the archive overwrite those already in the volume, others are
+// kept. The archive's size is stored in total and the bytes sent so far
+// added to read. Returns true if the volume was created.
+func (c *Client) RestoreVolume(ctx context.Context, volumeName, path string, read, total *atomic.Int64) (created bool, err error) {
+	defer func() { c.logAction("volume.restore", volumeName, path, err) }()
+
+	path, err = expandHome(path)
+	if err != nil {
+		return false, err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return false, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer file.Close()
+	if info, err := file.Stat(); err == nil {
+		total.Store(info.Size())
+	}
+
+	if _, err := c.cli.VolumeInspect(ctx, volumeName); client.IsErrNotFound(err) {
+		if _, err := c.cli.VolumeCreate(ctx, volume.CreateOptions{Name: volumeName}); err != nil {
+			return false, fmt.Errorf("failed to create volume %s: %w", volumeName, err)
+		}
+		created = true
+	} else if err != nil {
+		return false, fmt.Errorf("failed to inspect volume %s: %w", volumeName, err)
+	}
+
+	id, remove, err := c.createVolumeHelper(ctx, volumeName, false)
+	if err != nil {
+		return created, err
+	}
+	defer remove()
+
+	if err := c.cli.CopyToContainer(ctx, id, volumeMountPath, countingReader{r: file, n: read}, container.CopyToContainerOptions{}); err != nil {
+		return created, fmt.Errorf("failed to restore %s into volume %s: %w", path, volumeName, err)
+	}
+	return created, nil
+}
@@ -2,13 +2,16 @@ package docker
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sort"
 	"time"
 
+	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/volume"
 	"github.com/rizface/doui/internal/models"
+	"github.com/rizface/doui/pkg/utils"
 )
 
 // ListVolumes returns all Docker volumes
@@ -57,18 +60,79 @@ func (c *Client) ListVolumes(ctx context.Context) ([]models.Volume, error) {
 	return result, nil
 }
 
+// CreateVolume creates a volume and returns its name, which the daemon
+// generates when name is empty
+func (c *Client) CreateVolume(ctx context.Context, name, driver string, labels, opts map[string]string) (created string, err error) {
+	defer func() { c.logAction("volume.create", created, driver, err) }()
+
+	vol, err := c.cli.VolumeCreate(ctx, volume.CreateOptions{
+		Name:       name,
+		Driver:     driver,
+		DriverOpts: opts,
+		Labels:     labels,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create volume %s: %w", name, err)
+	}
+	return vol.Name, nil
+}
+
+// VolumeSizes returns the disk space used by each volume, by name. The
+// daemon walks every volume for this (like `docker system df -v`), so it can
+// take a while. Volumes whose driver can't tell are left out.
+func (c *Client) VolumeSizes(ctx context.Context) (map[string]int64, error) {
+	usage, err := c.cli.DiskUsage(ctx, types.DiskUsageOptions{Types: []types.DiskUsageObject{types.VolumeObject}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get volume sizes: %w", err)
+	}
+
+	sizes := make(map[string]int64, len(usage.Volumes))
+	for _, vol := range usage.Volumes {
+		if vol.UsageData != nil && vol.UsageData.Size >= 0 {
+			sizes[vol.Name] = vol.UsageData.Size
+		}
+	}
+	return sizes, nil
+}
+
 // RemoveVolume removes a volume by name
-func (c *Client) RemoveVolume(ctx context.Context, volumeName string, force bool) error {
-	err := c.cli.VolumeRemove(ctx, volumeName, force)
+func (c *Client) RemoveVolume(ctx context.Context, volumeName string, force bool) (err error) {
+	defer c.audit("volume.remove", volumeName, &err)
+
+	err = c.cli.VolumeRemove(ctx, volumeName, force)
 	if err != nil {
 		return fmt.Errorf("failed to remove volume %s: %w", volumeName, err)
 	}
 	return nil
 }
 
-// PruneUnusedVolumes removes all unused volumes
-func (c *Client) PruneUnusedVolumes(ctx context.Context) (uint64, error) {
-	report, err := c.cli.VolumesPrune(ctx, filters.Args{})
+// RemoveVolumes removes the given volumes one by one and returns the names
+// of those removed. Volumes in use are refused by the daemon and reported in
+// the error, the others are still removed.
+func (c *Client) RemoveVolumes(ctx context.Context, names []string) (removed []string, err error) {
+	var errs []error
+	for _, name := range names {
+		if err := c.RemoveVolume(ctx, name, false); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		removed = append(removed, name)
+	}
+	return removed, errors.Join(errs...)
+}
+
+// PruneUnusedVolumes removes all unused volumes. Since API 1.42 the daemon
+// only prunes anonymous volumes unless asked for all of them.
+func (c *Client) PruneUnusedVolumes(ctx context.Context) (reclaimed uint64, err error) {
+	defer func() {
+		c.logAction("volume.prune", "unused volumes", utils.FormatBytes(int64(reclaimed))+" reclaimed", err)
+	}()
+
+	pruneFilters := filters.NewArgs()
+	if c.Supports(models.FeaturePruneNamedVolumes) {
+		pruneFilters.Add("all", "true")
+	}
+	report, err := c.cli.VolumesPrune(ctx, pruneFilters)
 	if err != nil {
 		return 0, fmt.Errorf("failed to prune volumes: %w", err)
 	}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// AuditEntry is one mutating action doui sent to a Docker daemon, kept in the
+// audit log to trace what changed a container
+type AuditEntry struct {
+	Time   time.Time `json:"time"`
+	Action string    `json:"action"`           // e.g. container.stop
+	Target string    `json:"target"`           // ID or name the action was applied to
+	Detail string    `json:"detail,omitempty"` // e.g. the new ID of a recreated container
+	Host   string    `json:"host"`             // Daemon address
+	User   string    `json:"user,omitempty"`   // OS user running doui
+	Error  string    `json:"error,omitempty"`  // Empty if the action succeeded
+}
+
+// Succeeded returns true if the action didn't fail
+func (e AuditEntry) Succeeded() bool {
+	return e.Error == ""
+}
+
+// Concerns returns true if the entry is about the container with the given
+// ID or name, including its recreation under a new ID
+func (e AuditEntry) Concerns(id, name string) bool {
+	if e.Target == id || (name != "" && e.Target == name) {
+		return true
+	}
+	return len(id) >= 12 && strings.Contains(e.Detail, id[:12])
+}
+
+// String renders the entry as one line of the audit log viewer
+func (e AuditEntry) String() string {
+	target := e.Target
+	if len(target) == 64 && !strings.ContainsAny(target, ":/") {
+		target = target[:12] // Full container/network ID
+	}
+	line := fmt.Sprintf("%s  %-18s %s", e.Time.Local().Format("2006-01-02 15:04:05"), e.Action, target)
+	if e.Detail != "" {
+		line += " (" + e.Detail + ")"
+	}
+	if e.User != "" {
+		line += " by " + e.User
+	}
+	if !e.Succeeded() {
+		line += ": FAILED " + e.Error
+	}
+	return line
+}
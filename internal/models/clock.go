@@ -0,0 +1,51 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// ClockSkewTolerance is the drift tolerated before a clock check warns; JWT
+// and TLS validation usually allow a few seconds at most
+const ClockSkewTolerance = 2 * time.Second
+
+// ClockCheck compares a container's clock with the daemon host and with the
+// machine doui runs on
+type ClockCheck struct {
+	Container     string
+	ContainerTime time.Time     // As read in the container, to the second
+	Skew          time.Duration // Container clock minus daemon host clock
+	HostOffset    time.Duration // Daemon host clock minus doui's clock
+	Uncertainty   time.Duration // Round trips plus the 1s resolution of date
+}
+
+// Drifted returns true if the container's clock is off from the daemon host
+// by more than the tolerance and the measurement error
+func (c *ClockCheck) Drifted() bool {
+	return absDuration(c.Skew) > ClockSkewTolerance+c.Uncertainty
+}
+
+// HostDrifted returns true if the daemon host's clock is off from doui's, as
+// happens with Docker Desktop's VM after the laptop slept
+func (c *ClockCheck) HostDrifted() bool {
+	return absDuration(c.HostOffset) > ClockSkewTolerance+c.Uncertainty
+}
+
+// FormatSkew formats a clock difference like "+3.2s ahead" or "-1m4s behind"
+func FormatSkew(d time.Duration) string {
+	d = d.Round(100 * time.Millisecond)
+	switch {
+	case d > 0:
+		return fmt.Sprintf("+%s ahead", d)
+	case d < 0:
+		return fmt.Sprintf("%s behind", d)
+	}
+	return "in sync"
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
@@ -1,11 +1,21 @@
 package models
 
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
 // ComposeProject represents a Docker Compose project
 type ComposeProject struct {
 	Name         string
 	Services     []ComposeService
 	ConfigHash   string
 	WorkingDir   string
+	ConfigFiles  []string // Compose files the project was started from
 	ContainerIDs []string // All container IDs in this project
 }
 
@@ -45,3 +55,326 @@ func (p *ComposeProject) AllRunning() bool {
 	}
 	return p.GetRunningCount() == len(p.ContainerIDs)
 }
+
+// PublishedPorts returns the ports the service's containers publish on the
+// host, e.g. "8080:80/tcp", each once
+func (s ComposeService) PublishedPorts() []string {
+	var ports []string
+	seen := make(map[string]bool)
+	for _, ctr := range s.Containers {
+		for _, port := range ctr.Ports {
+			if port.PublicPort == 0 {
+				continue
+			}
+			p := fmt.Sprintf("%d:%d/%s", port.PublicPort, port.PrivatePort, port.Type)
+			if !seen[p] {
+				seen[p] = true
+				ports = append(ports, p)
+			}
+		}
+	}
+	return ports
+}
+
+// DependsOn returns the services this one depends on, from the
+// com.docker.compose.depends_on label (`db:service_started:false,...`)
+// compose sets since v2.20
+func (s ComposeService) DependsOn() []string {
+	if len(s.Containers) == 0 {
+		return nil
+	}
+	var deps []string
+	for _, dep := range strings.Split(s.Containers[0].Labels["com.docker.compose.depends_on"], ",") {
+		if name, _, _ := strings.Cut(strings.TrimSpace(dep), ":"); name != "" {
+			deps = append(deps, name)
+		}
+	}
+	return deps
+}
+
+// Drifted returns true if a container of the service was created from
+// another config than hash, the service's current config hash
+func (s ComposeService) Drifted(hash string) bool {
+	for _, ctr := range s.Containers {
+		if ctr.Labels["com.docker.compose.config-hash"] != hash {
+			return true
+		}
+	}
+	return false
+}
+
+// GetPullableImages returns the images of the project's services, each once.
+// Images referenced by ID and those compose built for a service (named
+// <project>-<service>) can't be pulled and are left out.
+func (p *ComposeProject) GetPullableImages() []string {
+	var images []string
+	seen := make(map[string]bool)
+	for _, service := range p.Services {
+		for _, container := range service.Containers {
+			image := container.Image
+			if image == "" || seen[image] || strings.HasPrefix(image, "sha256:") {
+				continue
+			}
+			seen[image] = true
+			name := strings.TrimSuffix(image, ":latest")
+			if name == p.Name+"-"+service.Name || name == p.Name+"_"+service.Name {
+				continue
+			}
+			images = append(images, image)
+		}
+	}
+	return images
+}
+
+// EnvMatrix holds environment variables of several services side by side
+type EnvMatrix struct {
+	Services []string
+	Keys     []string                     // Sorted union of all keys
+	Values   map[string]map[string]string // Service -> key -> value
+}
+
+// NewEnvMatrix builds a matrix from each service's env ("KEY=value" strings)
+func NewEnvMatrix(serviceEnv map[string][]string) *EnvMatrix {
+	m := &EnvMatrix{
+		Values: make(map[string]map[string]string, len(serviceEnv)),
+	}
+
+	keySet := make(map[string]bool)
+	for service, env := range serviceEnv {
+		m.Services = append(m.Services, service)
+		values := make(map[string]string, len(env))
+		for _, ev := range ParseEnvVars(env) {
+			values[ev.Key] = ev.Value
+			keySet[ev.Key] = true
+		}
+		m.Values[service] = values
+	}
+
+	for key := range keySet {
+		m.Keys = append(m.Keys, key)
+	}
+	sort.Strings(m.Services)
+	sort.Strings(m.Keys)
+
+	return m
+}
+
+// Get returns a service's value for key and whether it is set
+func (m *EnvMatrix) Get(service, key string) (string, bool) {
+	value, ok := m.Values[service][key]
+	return value, ok
+}
+
+// Differs returns true if the key is missing in some services or has
+// different values across services
+func (m *EnvMatrix) Differs(key string) bool {
+	first, firstOK := "", false
+	for i, service := range m.Services {
+		value, ok := m.Get(service, key)
+		if i == 0 {
+			first, firstOK = value, ok
+			continue
+		}
+		if ok != firstOK || value != first {
+			return true
+		}
+	}
+	return false
+}
+
+// ComposeFile is a compose file of a project and its content
+type ComposeFile struct {
+	Path     string
+	Content  string
+	Override bool // Override compose picks up from the working dir, not in the project's labels
+}
+
+// ComposeConfigCheck holds the result of validating a project's compose files
+type ComposeConfigCheck struct {
+	Files       []string // Files in effect, including a detected override
+	Override    string   // Override file not in the config_files label, if any
+	Profiles    []string // Active profiles
+	AllProfiles []string // Profiles defined in the files
+	Errors      []string
+	Warnings    []string
+}
+
+// Valid returns true if the compose files have no errors
+func (c *ComposeConfigCheck) Valid() bool {
+	return len(c.Errors) == 0
+}
+
+// composeWarningPattern matches compose CLI warnings in both log formats:
+// `WARN[0000] msg` and `time="..." level=warning msg="..."`
+var composeWarningPattern = regexp.MustCompile(`^(?:WARN(?:ING)?\[\d+\]\s*|time="[^"]*"\s+level=warning\s+msg=)(.*)$`)
+
+// ParseComposeConfigOutput splits `docker compose config` stderr into
+// warnings and errors. Lines that aren't warnings only count as errors when
+// the command failed.
+func ParseComposeConfigOutput(output string, failed bool) (warnings, errors []string) {
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if m := composeWarningPattern.FindStringSubmatch(line); m != nil {
+			msg := strings.ReplaceAll(strings.Trim(m[1], `"`), `\"`, `"`)
+			warnings = append(warnings, msg)
+			continue
+		}
+		if failed {
+			errors = append(errors, line)
+		}
+	}
+	return warnings, errors
+}
+
+// ComposeServiceChange is a service whose containers don't match the current
+// compose files and that `up` would recreate
+type ComposeServiceChange struct {
+	Service string
+	New     bool // Not created yet (service added to the files)
+}
+
+// ParseComposeConfigHashes reads the `service hash` lines printed by
+// `docker compose config --hash "*"`
+func ParseComposeConfigHashes(output string) map[string]string {
+	hashes := make(map[string]string)
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 {
+			hashes[fields[0]] = fields[1]
+		}
+	}
+	return hashes
+}
+
+// ChangedComposeServices compares the config hash label of every service
+// container to the hash of the service in the current compose files and
+// returns the services to recreate, sorted by name. Services that are no
+// longer in the files are left alone.
+func ChangedComposeServices(project ComposeProject, hashes map[string]string) []ComposeServiceChange {
+	names := make([]string, 0, len(hashes))
+	for name := range hashes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var changes []ComposeServiceChange
+	for _, name := range names {
+		var service *ComposeService
+		for i := range project.Services {
+			if project.Services[i].Name == name {
+				service = &project.Services[i]
+				break
+			}
+		}
+		if service == nil || len(service.Containers) == 0 {
+			changes = append(changes, ComposeServiceChange{Service: name, New: true})
+			continue
+		}
+		if service.Drifted(hashes[name]) {
+			changes = append(changes, ComposeServiceChange{Service: name})
+		}
+	}
+	return changes
+}
+
+// composeFileNames are the files compose looks for in the working dir when
+// it isn't given -f, and composeOverrideFileNames the overrides it then merges
+var (
+	composeFileNames         = []string{"compose.yaml", "compose.yml", "docker-compose.yml", "docker-compose.yaml"}
+	composeOverrideFileNames = []string{"compose.override.yml", "compose.override.yaml", "docker-compose.override.yml", "docker-compose.override.yaml"}
+)
+
+// ComposeOverrideFile returns the override file compose would merge into a
+// project started from a default compose file in its working dir, when it's
+// missing from the project's config files (e.g. created since the last up).
+// exists reports whether a path exists.
+func ComposeOverrideFile(workingDir string, files []string, exists func(string) bool) string {
+	if workingDir == "" || len(files) == 0 {
+		return ""
+	}
+	for _, file := range files {
+		for _, name := range composeOverrideFileNames {
+			if filepath.Base(file) == name {
+				return ""
+			}
+		}
+	}
+
+	base := files[0]
+	if filepath.Clean(filepath.Dir(base)) != filepath.Clean(workingDir) {
+		return ""
+	}
+	isDefault := false
+	for _, name := range composeFileNames {
+		if filepath.Base(base) == name {
+			isDefault = true
+			break
+		}
+	}
+	if !isDefault {
+		return ""
+	}
+
+	for _, name := range composeOverrideFileNames {
+		if path := filepath.Join(workingDir, name); exists(path) {
+			return path
+		}
+	}
+	return ""
+}
+
+// DefaultComposeFile returns the compose file `docker compose` picks up in
+// dir when it isn't given -f, "" if there is none. exists reports whether a
+// path exists.
+func DefaultComposeFile(dir string, exists func(string) bool) string {
+	for _, name := range composeFileNames {
+		if path := filepath.Join(dir, name); exists(path) {
+			return path
+		}
+	}
+	return ""
+}
+
+// ComposeProfiles reads the profiles of every service from the JSON printed
+// by `docker compose --profile "*" config --format json`. A profile is active
+// when one of its services has containers in the project. Both lists are sorted.
+func ComposeProfiles(configJSON []byte, project ComposeProject) (active, all []string, err error) {
+	var config struct {
+		Services map[string]struct {
+			Profiles []string `json:"profiles"`
+		} `json:"services"`
+	}
+	if err := json.Unmarshal(configJSON, &config); err != nil {
+		return nil, nil, err
+	}
+
+	running := make(map[string]bool, len(project.Services))
+	for _, service := range project.Services {
+		if len(service.Containers) > 0 {
+			running[service.Name] = true
+		}
+	}
+
+	activeSet := make(map[string]bool)
+	allSet := make(map[string]bool)
+	for name, service := range config.Services {
+		for _, profile := range service.Profiles {
+			allSet[profile] = true
+			if running[name] {
+				activeSet[profile] = true
+			}
+		}
+	}
+	for profile := range allSet {
+		all = append(all, profile)
+		if activeSet[profile] {
+			active = append(active, profile)
+		}
+	}
+	sort.Strings(active)
+	sort.Strings(all)
+	return active, all, nil
+}
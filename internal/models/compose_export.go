@@ -0,0 +1,251 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ImageDefaults is the config a container inherits from its image, left out
+// of an exported compose service
+type ImageDefaults struct {
+	Env        []string
+	Cmd        []string
+	Entrypoint []string
+	WorkingDir string
+	User       string
+	Labels     map[string]string
+}
+
+// anonymousVolumePattern matches the generated names of anonymous volumes
+var anonymousVolumePattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// serviceNamePattern matches the characters compose doesn't allow in
+// service names
+var serviceNamePattern = regexp.MustCompile(`[^a-z0-9_.-]+`)
+
+// composeString quotes a value for a compose file, escaping the $ compose
+// would otherwise interpolate
+func composeString(value string) string {
+	return strconv.Quote(strings.ReplaceAll(value, "$", "$$"))
+}
+
+// composeList renders a list in flow style: ["a", "b"]
+func composeList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = composeString(v)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+// composeServiceName turns a container name into a valid service name
+func composeServiceName(name string) string {
+	name = strings.Trim(serviceNamePattern.ReplaceAllString(strings.ToLower(name), "-"), "-.")
+	if name == "" {
+		return "service"
+	}
+	return name
+}
+
+// composePort renders a port binding the short way compose writes it:
+// [ip:]host:container[/proto], tcp being the default protocol
+func composePort(port string, binding HostPortBinding) string {
+	port = strings.TrimSuffix(port, "/tcp")
+	if binding.HostPort == "" {
+		return port
+	}
+	mapping := binding.HostPort + ":" + port
+	if ip := binding.HostIP; ip != "" && ip != "0.0.0.0" && ip != "::" {
+		if strings.Contains(ip, ":") {
+			ip = "[" + ip + "]"
+		}
+		mapping = ip + ":" + mapping
+	}
+	return mapping
+}
+
+// GenerateComposeFile writes containers as the services of a compose file:
+// image, command, env, ports, volumes, networks and restart policy. Settings
+// equal to the image's (defaults, by container name) are left out, as are
+// compose's own labels. Named volumes and user networks are declared
+// external so the project reuses the existing ones and their data.
+func GenerateComposeFile(header string, containers []ContainerFullConfig, defaults map[string]ImageDefaults) string {
+	var b strings.Builder
+	for _, line := range strings.Split(header, "\n") {
+		b.WriteString("# " + line + "\n")
+	}
+	b.WriteString("services:\n")
+
+	volumes := make(map[string]bool)
+	networks := make(map[string]bool)
+	used := make(map[string]bool)
+	for _, c := range containers {
+		image := defaults[c.Name]
+
+		service := composeServiceName(c.Name)
+		for i := 2; used[service]; i++ {
+			service = fmt.Sprintf("%s-%d", composeServiceName(c.Name), i)
+		}
+		used[service] = true
+
+		fmt.Fprintf(&b, "  %s:\n", service)
+		fmt.Fprintf(&b, "    image: %s\n", composeString(c.Image))
+		fmt.Fprintf(&b, "    container_name: %s\n", composeString(c.Name))
+		if len(c.Entrypoint) > 0 && !slices.Equal(c.Entrypoint, image.Entrypoint) {
+			fmt.Fprintf(&b, "    entrypoint: %s\n", composeList(c.Entrypoint))
+		}
+		if len(c.Cmd) > 0 && !slices.Equal(c.Cmd, image.Cmd) {
+			fmt.Fprintf(&b, "    command: %s\n", composeList(c.Cmd))
+		}
+		if c.WorkingDir != "" && c.WorkingDir != image.WorkingDir {
+			fmt.Fprintf(&b, "    working_dir: %s\n", composeString(c.WorkingDir))
+		}
+		if c.User != "" && c.User != image.User {
+			fmt.Fprintf(&b, "    user: %s\n", composeString(c.User))
+		}
+
+		var env []string
+		for _, e := range c.Env {
+			if !slices.Contains(image.Env, e) {
+				env = append(env, e)
+			}
+		}
+		if len(env) > 0 {
+			b.WriteString("    environment:\n")
+			for _, e := range env {
+				key, value, _ := strings.Cut(e, "=")
+				fmt.Fprintf(&b, "      %s: %s\n", composeString(key), composeString(value))
+			}
+		}
+
+		var ports []string
+		for port, bindings := range c.PortBindings {
+			for _, binding := range bindings {
+				ports = append(ports, composePort(port, binding))
+			}
+		}
+		sort.Strings(ports)
+		if len(ports) > 0 {
+			b.WriteString("    ports:\n")
+			for _, p := range ports {
+				fmt.Fprintf(&b, "      - %s\n", composeString(p))
+			}
+		}
+
+		var mounts, tmpfs []string
+		for _, bind := range c.Binds {
+			mounts = append(mounts, bind)
+			if source, _, _ := strings.Cut(bind, ":"); !strings.HasPrefix(source, "/") {
+				volumes[source] = true
+			}
+		}
+		for _, m := range c.Mounts {
+			mount := m.Source + ":" + m.Target
+			switch {
+			case m.Type == "tmpfs":
+				tmpfs = append(tmpfs, m.Target)
+				continue
+			case m.Type == "volume" && anonymousVolumePattern.MatchString(m.Source):
+				mount = m.Target
+			case m.Type == "volume":
+				volumes[m.Source] = true
+			}
+			if m.ReadOnly {
+				mount += ":ro"
+			}
+			mounts = append(mounts, mount)
+		}
+		if len(mounts) > 0 {
+			b.WriteString("    volumes:\n")
+			for _, m := range mounts {
+				fmt.Fprintf(&b, "      - %s\n", composeString(m))
+			}
+		}
+		if len(tmpfs) > 0 {
+			fmt.Fprintf(&b, "    tmpfs: %s\n", composeList(tmpfs))
+		}
+
+		switch mode := c.NetworkMode; {
+		case mode == "host" || mode == "none" || strings.HasPrefix(mode, "container:"):
+			fmt.Fprintf(&b, "    network_mode: %s\n", composeString(mode))
+		default:
+			var names []string
+			for name := range c.Networks {
+				if name != "bridge" {
+					names = append(names, name)
+				}
+			}
+			sort.Strings(names)
+			if len(names) > 0 {
+				b.WriteString("    networks:\n")
+				for _, name := range names {
+					fmt.Fprintf(&b, "      - %s\n", composeString(name))
+					networks[name] = true
+				}
+			}
+		}
+
+		switch policy := c.RestartPolicy; policy.Name {
+		case "", "no":
+		case "on-failure":
+			if policy.MaximumRetryCount > 0 {
+				fmt.Fprintf(&b, "    restart: \"on-failure:%d\"\n", policy.MaximumRetryCount)
+			} else {
+				b.WriteString("    restart: on-failure\n")
+			}
+		default:
+			fmt.Fprintf(&b, "    restart: %s\n", policy.Name)
+		}
+		if c.Privileged {
+			b.WriteString("    privileged: true\n")
+		}
+		if len(c.CapAdd) > 0 {
+			fmt.Fprintf(&b, "    cap_add: %s\n", composeList(c.CapAdd))
+		}
+		if len(c.CapDrop) > 0 {
+			fmt.Fprintf(&b, "    cap_drop: %s\n", composeList(c.CapDrop))
+		}
+		if c.CpusetCpus != "" {
+			fmt.Fprintf(&b, "    cpuset: %s\n", composeString(c.CpusetCpus))
+		}
+
+		var labels []string
+		for key, value := range c.Labels {
+			if strings.HasPrefix(key, "com.docker.compose.") {
+				continue
+			}
+			if imageValue, ok := image.Labels[key]; ok && imageValue == value {
+				continue
+			}
+			labels = append(labels, fmt.Sprintf("      %s: %s\n", composeString(key), composeString(value)))
+		}
+		sort.Strings(labels)
+		if len(labels) > 0 {
+			b.WriteString("    labels:\n")
+			b.WriteString(strings.Join(labels, ""))
+		}
+	}
+
+	writeExternal := func(section string, names map[string]bool) {
+		if len(names) == 0 {
+			return
+		}
+		sorted := make([]string, 0, len(names))
+		for name := range names {
+			sorted = append(sorted, name)
+		}
+		sort.Strings(sorted)
+		fmt.Fprintf(&b, "\n%s:\n", section)
+		for _, name := range sorted {
+			fmt.Fprintf(&b, "  %s:\n    external: true\n", composeString(name))
+		}
+	}
+	writeExternal("volumes", volumes)
+	writeExternal("networks", networks)
+
+	return b.String()
+}
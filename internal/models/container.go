@@ -2,25 +2,31 @@ package models
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 )
 
 // Container represents a Docker container with UI-relevant fields
 type Container struct {
-	ID         string
-	ShortID    string // First 12 chars
-	Name       string
-	Image      string
-	Status     string
-	State      string // running, paused, exited, etc.
-	Created    time.Time
-	Ports      []PortMapping
-	Networks   []string
-	Mounts     []MountPoint // Volume/bind mounts
-	Labels     map[string]string
-	SizeRw     int64
-	SizeRootFs int64
+	ID          string
+	ShortID     string // First 12 chars
+	Name        string
+	Image       string
+	ImageID     string
+	Status      string
+	State       string // running, paused, exited, etc.
+	Created     time.Time
+	Ports       []PortMapping
+	Networks    []string
+	IPAddresses map[string]string   // Network name -> IPv4 address
+	Aliases     map[string][]string // Network name -> DNS aliases
+	Mounts      []MountPoint        // Volume/bind mounts
+	Labels      map[string]string
+	SizeRw      int64
+	SizeRootFs  int64
+	ArchWarning string // Set if the image's architecture doesn't match the host
+	Runtime     RuntimeInfo
 }
 
 // MountPoint represents a container mount (volume or bind)
@@ -55,6 +61,56 @@ type ContainerStats struct {
 	Timestamp     time.Time
 }
 
+// ContainerEvent represents a lifecycle event (OOM kill, exit, failed health
+// check) for a container
+type ContainerEvent struct {
+	ContainerID string
+	Name        string // Only set by the events of all containers
+	Action      string // "oom", "die" or "unhealthy"
+	ExitCode    string
+	Timestamp   time.Time
+}
+
+// IsOOM returns true if the event is an out-of-memory kill
+func (e *ContainerEvent) IsOOM() bool {
+	return e.Action == "oom"
+}
+
+// Description returns a human-readable description of the event
+func (e *ContainerEvent) Description() string {
+	at := e.Timestamp.Format("15:04:05")
+	switch {
+	case e.IsOOM():
+		return fmt.Sprintf("OOM killed at %s", at)
+	case e.Action == "unhealthy":
+		return fmt.Sprintf("Unhealthy at %s", at)
+	case e.ExitCode != "":
+		return fmt.Sprintf("Died at %s (exit code %s)", at, e.ExitCode)
+	default:
+		return fmt.Sprintf("Died at %s", at)
+	}
+}
+
+// FindContainer returns the container with the given name, or whose ID
+// starts with ref, or nil
+func FindContainer(containers []Container, ref string) *Container {
+	ref = strings.TrimPrefix(ref, "/")
+	if ref == "" {
+		return nil
+	}
+	for i := range containers {
+		if containers[i].Name == ref {
+			return &containers[i]
+		}
+	}
+	for i := range containers {
+		if strings.HasPrefix(containers[i].ID, ref) {
+			return &containers[i]
+		}
+	}
+	return nil
+}
+
 // ShortID returns the first 12 characters of the container ID
 func (c *Container) GetShortID() string {
 	if len(c.ID) >= 12 {
@@ -68,6 +124,20 @@ func (c *Container) IsRunning() bool {
 	return c.State == "running"
 }
 
+// GetIPAddress returns the container's IPv4 address on the given network
+// If network is empty, the first known address is returned
+func (c *Container) GetIPAddress(network string) string {
+	if network != "" {
+		return c.IPAddresses[network]
+	}
+	for _, netName := range c.Networks {
+		if ip := c.IPAddresses[netName]; ip != "" {
+			return ip
+		}
+	}
+	return ""
+}
+
 // GetPortsString returns a formatted string of port mappings
 func (c *Container) GetPortsString() string {
 	if len(c.Ports) == 0 {
@@ -99,12 +169,15 @@ type ContainerFullConfig struct {
 
 	// Host Config
 	Binds         []string // Volume binds ["/host/path:/container/path:ro", ...]
+	Mounts        []ContainerMount
 	PortBindings  map[string][]HostPortBinding
 	RestartPolicy ContainerRestartPolicy
 	NetworkMode   string
 	Privileged    bool
 	CapAdd        []string
 	CapDrop       []string
+	CpusetCpus    string // CPUs the container may run on (e.g. "0-3")
+	CpusetMems    string // Memory nodes the container may use (NUMA)
 
 	// Network Config
 	Networks map[string]NetworkEndpointConfig
@@ -129,6 +202,15 @@ type ContainerRestartPolicy struct {
 	MaximumRetryCount int
 }
 
+// ContainerMount is a mount given with --mount, as compose does for its
+// volumes, rather than as a bind
+type ContainerMount struct {
+	Type     string // "volume", "bind" or "tmpfs"
+	Source   string // Volume name or host path, empty for tmpfs and anonymous volumes
+	Target   string
+	ReadOnly bool
+}
+
 // NetworkEndpointConfig represents network endpoint configuration
 type NetworkEndpointConfig struct {
 	IPAddress string
@@ -165,3 +247,133 @@ func EnvVarsToStrings(vars []EnvVar) []string {
 	}
 	return result
 }
+
+// ParseLabels converts a label map to []EnvVar sorted by key for display/editing
+func ParseLabels(labels map[string]string) []EnvVar {
+	result := make([]EnvVar, 0, len(labels))
+	for k, v := range labels {
+		result = append(result, EnvVar{Key: k, Value: v})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Key < result[j].Key
+	})
+	return result
+}
+
+// EnvVarsToLabels converts []EnvVar back to a label map
+func EnvVarsToLabels(vars []EnvVar) map[string]string {
+	result := make(map[string]string, len(vars))
+	for _, v := range vars {
+		result[v.Key] = v.Value
+	}
+	return result
+}
+
+// PortBindingsToEnvVars converts port bindings to key/value pairs for editing
+// Key is the container port ("80/tcp"), value the host binding ("8080" or
+// "127.0.0.1:8080", comma-separated when published more than once)
+func PortBindingsToEnvVars(bindings map[string][]HostPortBinding) []EnvVar {
+	result := make([]EnvVar, 0, len(bindings))
+	for port, hostBindings := range bindings {
+		values := make([]string, 0, len(hostBindings))
+		for _, hb := range hostBindings {
+			if hb.HostIP != "" {
+				values = append(values, hb.HostIP+":"+hb.HostPort)
+			} else {
+				values = append(values, hb.HostPort)
+			}
+		}
+		result = append(result, EnvVar{Key: port, Value: strings.Join(values, ",")})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Key < result[j].Key
+	})
+	return result
+}
+
+// EnvVarsToPortBindings converts edited key/value pairs back to port bindings
+// Container ports without a protocol default to tcp
+func EnvVarsToPortBindings(vars []EnvVar) map[string][]HostPortBinding {
+	result := make(map[string][]HostPortBinding, len(vars))
+	for _, v := range vars {
+		port := strings.TrimSpace(v.Key)
+		if !strings.Contains(port, "/") {
+			port += "/tcp"
+		}
+
+		var hostBindings []HostPortBinding
+		for _, value := range strings.Split(v.Value, ",") {
+			value = strings.TrimSpace(value)
+			if value == "" {
+				continue
+			}
+			// Split on the last colon so IPv6 host IPs keep their colons
+			if idx := strings.LastIndex(value, ":"); idx >= 0 {
+				hostBindings = append(hostBindings, HostPortBinding{HostIP: value[:idx], HostPort: value[idx+1:]})
+			} else {
+				hostBindings = append(hostBindings, HostPortBinding{HostPort: value})
+			}
+		}
+		// Exposed but not published: Docker picks a random host port
+		if len(hostBindings) == 0 {
+			hostBindings = []HostPortBinding{{}}
+		}
+		result[port] = hostBindings
+	}
+	return result
+}
+
+// EnvVarChange is a single env var edit applied to many containers at once
+type EnvVarChange struct {
+	Key    string
+	Value  string
+	Remove bool
+}
+
+// Apply returns env with the change applied and whether anything changed
+func (c EnvVarChange) Apply(env []string) ([]string, bool) {
+	vars := ParseEnvVars(env)
+	result := make([]EnvVar, 0, len(vars)+1)
+	found := false
+	changed := false
+
+	for _, v := range vars {
+		if v.Key != c.Key {
+			result = append(result, v)
+			continue
+		}
+		found = true
+		if c.Remove {
+			changed = true
+			continue
+		}
+		if v.Value != c.Value {
+			changed = true
+		}
+		result = append(result, EnvVar{Key: c.Key, Value: c.Value})
+	}
+
+	if !found && !c.Remove {
+		result = append(result, EnvVar{Key: c.Key, Value: c.Value})
+		changed = true
+	}
+
+	return EnvVarsToStrings(result), changed
+}
+
+// Description returns a short summary of the change (e.g. "LOG_LEVEL=debug")
+func (c EnvVarChange) Description() string {
+	if c.Remove {
+		return "remove " + c.Key
+	}
+	return c.Key + "=" + c.Value
+}
+
+// BulkEnvResult is the outcome of applying an EnvVarChange to one container
+type BulkEnvResult struct {
+	ContainerID   string
+	ContainerName string
+	NewID         string // Set when the container was recreated
+	Skipped       bool   // Env already matched, nothing to do
+	Err           error
+}
@@ -0,0 +1,19 @@
+package models
+
+// DefaultContextName is the docker context that uses DOCKER_HOST or the
+// platform default socket
+const DefaultContextName = "default"
+
+// DockerContext is a named Docker daemon endpoint (see `docker context ls`)
+type DockerContext struct {
+	Name        string
+	Description string
+	Host        string // e.g. unix:///var/run/docker.sock, tcp://10.0.0.5:2376
+	TLSDir      string // Directory with ca.pem, cert.pem and key.pem, if any
+	Current     bool   // Selected in the docker CLI config
+}
+
+// IsDefault returns true for the built-in default context
+func (c *DockerContext) IsDefault() bool {
+	return c.Name == DefaultContextName
+}
@@ -0,0 +1,117 @@
+package models
+
+import (
+	"strings"
+)
+
+// DotEnvFile is the .env file in a compose project's working dir, which
+// compose reads to interpolate the variables of the compose files
+type DotEnvFile struct {
+	Path    string
+	Content string
+}
+
+// parseDotEnvLine returns the key and value a .env line sets, ok false for
+// blank lines and comments. `export ` prefixes and the quotes around a value
+// are removed, and so is a comment after an unquoted value.
+func parseDotEnvLine(line string) (key, value string, ok bool) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return "", "", false
+	}
+	line = strings.TrimPrefix(line, "export ")
+	key, value, _ = strings.Cut(line, "=")
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return "", "", false
+	}
+
+	value = strings.TrimSpace(value)
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') {
+		if end := strings.LastIndexByte(value, value[0]); end > 0 {
+			unquoted := value[1:end]
+			if value[0] == '"' {
+				unquoted = strings.NewReplacer(`\"`, `"`, `\\`, `\`, `\n`, "\n").Replace(unquoted)
+			}
+			return key, unquoted, true
+		}
+	}
+	if i := strings.Index(value, " #"); i >= 0 {
+		value = strings.TrimSpace(value[:i])
+	}
+	return key, value, true
+}
+
+// formatDotEnvValue quotes a value if compose would otherwise read it
+// differently
+func formatDotEnvValue(value string) string {
+	if !strings.ContainsAny(value, " \t\n#\"'\\$") {
+		return value
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value) + `"`
+}
+
+// Vars returns the variables the file sets, in file order. A key set twice
+// is listed once, with its last value, as compose reads it.
+func (f DotEnvFile) Vars() []EnvVar {
+	var vars []EnvVar
+	index := make(map[string]int)
+	for _, line := range strings.Split(f.Content, "\n") {
+		key, value, ok := parseDotEnvLine(line)
+		if !ok {
+			continue
+		}
+		if i, seen := index[key]; seen {
+			vars[i].Value = value
+			continue
+		}
+		index[key] = len(vars)
+		vars = append(vars, EnvVar{Key: key, Value: value})
+	}
+	return vars
+}
+
+// WithVars returns the content of the file setting vars instead. Comments,
+// blank lines and the order of the file are kept: lines whose value didn't
+// change are left as they are, changed values are rewritten in place,
+// removed keys are dropped and new ones appended.
+func (f DotEnvFile) WithVars(vars []EnvVar) string {
+	values := make(map[string]string, len(vars))
+	for _, v := range vars {
+		values[v.Key] = v.Value
+	}
+
+	lines := strings.Split(strings.TrimRight(f.Content, "\n"), "\n")
+	if f.Content == "" {
+		lines = nil
+	}
+	written := make(map[string]bool)
+	result := make([]string, 0, len(lines)+len(vars))
+	for _, line := range lines {
+		key, value, ok := parseDotEnvLine(line)
+		if !ok {
+			result = append(result, line)
+			continue
+		}
+		newValue, keep := values[key]
+		if !keep || written[key] {
+			continue
+		}
+		written[key] = true
+		if newValue != value {
+			line = key + "=" + formatDotEnvValue(newValue)
+		}
+		result = append(result, line)
+	}
+	for _, v := range vars {
+		if !written[v.Key] {
+			written[v.Key] = true
+			result = append(result, v.Key+"="+formatDotEnvValue(v.Value))
+		}
+	}
+
+	if len(result) == 0 {
+		return ""
+	}
+	return strings.Join(result, "\n") + "\n"
+}
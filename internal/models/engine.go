@@ -0,0 +1,58 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Feature is something doui does that needs a minimum Docker API version
+type Feature struct {
+	Name   string
+	MinAPI string
+}
+
+// Features gated on the API version negotiated with the daemon
+var (
+	FeaturePlugins           = Feature{Name: "Plugins", MinAPI: "1.25"}
+	FeatureIpvlan            = Feature{Name: "ipvlan networks", MinAPI: "1.30"}
+	FeatureRegistryLookup    = Feature{Name: "Registry lookups (cosign signatures)", MinAPI: "1.30"}
+	FeaturePruneNamedVolumes = Feature{Name: "Pruning named volumes", MinAPI: "1.42"}
+)
+
+// APIVersionLess returns true if Docker API version a is older than b
+// (e.g. "1.41" < "1.42"). Unparsable parts count as 0.
+func APIVersionLess(a, b string) bool {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < max(len(as), len(bs)); i++ {
+		var x, y int
+		if i < len(as) {
+			x, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			y, _ = strconv.Atoi(bs[i])
+		}
+		if x != y {
+			return x < y
+		}
+	}
+	return false
+}
+
+// SupportsFeature returns true if a daemon speaking apiVersion has the
+// feature. An unknown version is assumed to support everything.
+func SupportsFeature(apiVersion string, f Feature) bool {
+	return apiVersion == "" || !APIVersionLess(apiVersion, f.MinAPI)
+}
+
+// UnsupportedFeatureError explains that the daemon is too old for a feature,
+// instead of the bare 404 the API would return
+type UnsupportedFeatureError struct {
+	Feature    Feature
+	APIVersion string
+}
+
+func (e *UnsupportedFeatureError) Error() string {
+	return fmt.Sprintf("%s need Docker API %s or newer, this daemon supports API %s (upgrade Docker Engine to use them)",
+		e.Feature.Name, e.Feature.MinAPI, e.APIVersion)
+}
@@ -1,6 +1,11 @@
 package models
 
-import "time"
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
 
 // Group represents a collection of containers
 type Group struct {
@@ -11,6 +16,289 @@ type Group struct {
 	Created      time.Time `json:"created"`
 	Modified     time.Time `json:"modified"`
 	Color        string    `json:"color"`
+	// Letter or digit starting/stopping the group from any view, after the
+	// group hotkey prefix (g)
+	Hotkey string `json:"hotkey,omitempty"`
+
+	// Groups included in this one, e.g. "all dev stacks" including the group
+	// of each project. They are started, stopped and restarted with it.
+	GroupIDs []string `json:"group_ids,omitempty"`
+
+	// Startup ordering: container ID -> IDs it must start after
+	DependsOn map[string][]string `json:"depends_on,omitempty"`
+	// Containers that must be healthy before their dependents start
+	WaitHealthy map[string]bool `json:"wait_healthy,omitempty"`
+	// Seconds dependents wait after the container started (and became
+	// healthy, if waited on) before they start
+	StartDelay map[string]int `json:"start_delay,omitempty"`
+
+	// Stop in reverse start order (dependents first) instead of all at once
+	OrderedStop bool `json:"ordered_stop,omitempty"`
+	// Seconds to wait for a graceful stop before the container is killed
+	StopGracePeriod map[string]int `json:"stop_grace_period,omitempty"`
+
+	// Cron schedules starting and stopping the group while doui runs,
+	// e.g. "0 8 * * 1-5" and "0 19 * * *"
+	StartSchedule string `json:"start_schedule,omitempty"`
+	StopSchedule  string `json:"stop_schedule,omitempty"`
+
+	// Container ID -> name, to find members again once they are recreated
+	// outside doui (e.g. by `docker compose up`) and get a new ID
+	ContainerNames map[string]string `json:"container_names,omitempty"`
+}
+
+// GroupColors are the colors a group can be marked with, in the order new
+// groups get them
+var GroupColors = []string{"blue", "green", "yellow", "magenta", "cyan", "red"}
+
+// DefaultStopGracePeriod is used for containers without a configured grace period
+const DefaultStopGracePeriod = 10
+
+// GetStopGracePeriod returns how many seconds containerID may take to stop
+func (g *Group) GetStopGracePeriod(containerID string) int {
+	if seconds, ok := g.StopGracePeriod[containerID]; ok {
+		return seconds
+	}
+	return DefaultStopGracePeriod
+}
+
+// ValidHotkey returns true if key can be a group hotkey: one letter or digit
+func ValidHotkey(key string) bool {
+	if len(key) != 1 {
+		return false
+	}
+	c := key[0]
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+// ValidateSchedules returns an error if a schedule of the group isn't a
+// valid cron expression
+func (g *Group) ValidateSchedules() error {
+	for _, expr := range []string{g.StartSchedule, g.StopSchedule} {
+		if expr == "" {
+			continue
+		}
+		if _, err := ParseCronSchedule(expr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ScheduledAt returns whether the group's schedules start or stop it in the
+// minute of t. Invalid schedules never fire.
+func (g *Group) ScheduledAt(t time.Time) (start, stop bool) {
+	if s, err := ParseCronSchedule(g.StartSchedule); g.StartSchedule != "" && err == nil {
+		start = s.Matches(t)
+	}
+	if s, err := ParseCronSchedule(g.StopSchedule); g.StopSchedule != "" && err == nil {
+		stop = s.Matches(t)
+	}
+	return start, stop
+}
+
+// GetStartDelay returns how many seconds containerID's dependents wait after
+// it started
+func (g *Group) GetStartDelay(containerID string) int {
+	return g.StartDelay[containerID]
+}
+
+// StopOrder returns the steps in which the group's containers are stopped:
+// the reverse of StartOrder if OrderedStop is set, otherwise a single step
+func (g *Group) StopOrder() ([][]string, error) {
+	if !g.OrderedStop {
+		if len(g.ContainerIDs) == 0 {
+			return nil, nil
+		}
+		return [][]string{g.ContainerIDs}, nil
+	}
+
+	steps, err := g.StartOrder()
+	if err != nil {
+		return nil, err
+	}
+	for i, j := 0, len(steps)-1; i < j; i, j = i+1, j-1 {
+		steps[i], steps[j] = steps[j], steps[i]
+	}
+	return steps, nil
+}
+
+// StopTimeout returns how long stopping the whole group may take: each step
+// waits for its slowest container's grace period
+func (g *Group) StopTimeout() time.Duration {
+	steps, err := g.StopOrder()
+	if err != nil {
+		return 30 * time.Second
+	}
+
+	total := 0
+	for _, step := range steps {
+		longest := 0
+		for _, id := range step {
+			if grace := g.GetStopGracePeriod(id); grace > longest {
+				longest = grace
+			}
+		}
+		total += longest + 10 // Slack for the API calls
+	}
+	if total < 30 {
+		total = 30
+	}
+	return time.Duration(total) * time.Second
+}
+
+// StartOrder returns the group's containers in dependency order, grouped into
+// steps that can be started in parallel. Dependencies on containers outside the
+// group are ignored. Returns an error if the dependencies form a cycle.
+func (g *Group) StartOrder() ([][]string, error) {
+	inGroup := make(map[string]bool, len(g.ContainerIDs))
+	for _, id := range g.ContainerIDs {
+		inGroup[id] = true
+	}
+
+	// Count unmet dependencies per container
+	pending := make(map[string]int, len(g.ContainerIDs))
+	dependents := make(map[string][]string)
+	for _, id := range g.ContainerIDs {
+		for _, dep := range g.DependsOn[id] {
+			if inGroup[dep] && dep != id {
+				pending[id]++
+				dependents[dep] = append(dependents[dep], id)
+			}
+		}
+	}
+
+	var steps [][]string
+	done := 0
+	var current []string
+	for _, id := range g.ContainerIDs {
+		if pending[id] == 0 {
+			current = append(current, id)
+		}
+	}
+
+	for len(current) > 0 {
+		steps = append(steps, current)
+		done += len(current)
+
+		var next []string
+		for _, id := range current {
+			for _, dependent := range dependents[id] {
+				pending[dependent]--
+				if pending[dependent] == 0 {
+					next = append(next, dependent)
+				}
+			}
+		}
+		current = next
+	}
+
+	if done != len(g.ContainerIDs) {
+		return nil, fmt.Errorf("dependency cycle in group %s", g.Name)
+	}
+
+	return steps, nil
+}
+
+// ReplaceDependencyID replaces oldID with newID in the group's dependency
+// data and member names
+func (g *Group) ReplaceDependencyID(oldID, newID string) {
+	if deps, ok := g.DependsOn[oldID]; ok {
+		delete(g.DependsOn, oldID)
+		g.DependsOn[newID] = deps
+	}
+	for id, deps := range g.DependsOn {
+		for i, dep := range deps {
+			if dep == oldID {
+				g.DependsOn[id][i] = newID
+			}
+		}
+	}
+	if g.WaitHealthy[oldID] {
+		delete(g.WaitHealthy, oldID)
+		g.WaitHealthy[newID] = true
+	}
+	if grace, ok := g.StopGracePeriod[oldID]; ok {
+		delete(g.StopGracePeriod, oldID)
+		g.StopGracePeriod[newID] = grace
+	}
+	if delay, ok := g.StartDelay[oldID]; ok {
+		delete(g.StartDelay, oldID)
+		g.StartDelay[newID] = delay
+	}
+	if name, ok := g.ContainerNames[oldID]; ok {
+		delete(g.ContainerNames, oldID)
+		g.ContainerNames[newID] = name
+	}
+}
+
+// RemoveDependencyID removes a container from the group's dependency data
+// and member names
+func (g *Group) RemoveDependencyID(containerID string) {
+	delete(g.ContainerNames, containerID)
+	delete(g.DependsOn, containerID)
+	delete(g.WaitHealthy, containerID)
+	delete(g.StartDelay, containerID)
+	delete(g.StopGracePeriod, containerID)
+	for id, deps := range g.DependsOn {
+		filtered := deps[:0]
+		for _, dep := range deps {
+			if dep != containerID {
+				filtered = append(filtered, dep)
+			}
+		}
+		if len(filtered) == 0 {
+			delete(g.DependsOn, id)
+		} else {
+			g.DependsOn[id] = filtered
+		}
+	}
+}
+
+// ResolveContainers matches the group's members with the existing
+// containers: the name of each member found by ID is recorded, and a member
+// whose ID is gone is replaced by the container that now has its name, i.e.
+// the same container recreated with a new ID. Returns true if the group
+// changed.
+func (g *Group) ResolveContainers(containers []Container) bool {
+	nameByID := make(map[string]string, len(containers))
+	idByName := make(map[string]string, len(containers))
+	for _, c := range containers {
+		nameByID[c.ID] = c.Name
+		idByName[c.Name] = c.ID
+	}
+	if g.ContainerNames == nil {
+		g.ContainerNames = make(map[string]string)
+	}
+
+	changed := false
+	for i, id := range g.ContainerIDs {
+		if name, ok := nameByID[id]; ok {
+			if g.ContainerNames[id] != name {
+				g.ContainerNames[id] = name
+				changed = true
+			}
+			continue
+		}
+
+		name := g.ContainerNames[id]
+		newID, ok := idByName[name]
+		if name == "" || !ok || slices.Contains(g.ContainerIDs, newID) {
+			continue
+		}
+		g.ContainerIDs[i] = newID
+		g.ReplaceDependencyID(id, newID)
+		changed = true
+	}
+	return changed
+}
+
+// GroupLogPane is a member container's tile in the group logs dashboard
+type GroupLogPane struct {
+	Name  string
+	State string // running, exited...
+	Lines []string
+	Err   string // Why the logs couldn't be read
 }
 
 // GroupConfig represents the persisted configuration
@@ -18,6 +306,26 @@ type GroupConfig struct {
 	Version      string    `json:"version"`
 	Groups       []Group   `json:"groups"`
 	LastModified time.Time `json:"last_modified"`
+
+	// Key remaps by action name, e.g. {"delete": ["D"]}
+	Keybindings map[string][]string `json:"keybindings,omitempty"`
+	// Actions pinned to the quick-action bar by view name,
+	// e.g. {"containers": ["logs", "shell", "restart"]}
+	QuickActions map[string][]string `json:"quick_actions,omitempty"`
+
+	// Color theme name, built-in or one of Themes
+	Theme string `json:"theme,omitempty"`
+	// Custom themes by name, each mapping color names to values,
+	// e.g. {"mine": {"base": "nord", "primary": "#FF79C6"}}
+	Themes map[string]map[string]string `json:"themes,omitempty"`
+
+	// Containers (by name) to notify about when they exit or become unhealthy
+	Watched []string `json:"watched,omitempty"`
+	// How to notify: auto, notify-send, osc777, bell or off (default auto)
+	Notify string `json:"notify,omitempty"`
+
+	// Images (by tag, or ID when untagged) doui refuses to remove or prune
+	Protected []string `json:"protected,omitempty"`
 }
 
 // NewGroupConfig creates a new empty group configuration
@@ -39,17 +347,31 @@ func (gc *GroupConfig) FindGroup(id string) *Group {
 	return nil
 }
 
+// FindGroupByName finds a group by name (case insensitive) or ID, returns nil
+// if not found
+func (gc *GroupConfig) FindGroupByName(name string) *Group {
+	for i := range gc.Groups {
+		if strings.EqualFold(gc.Groups[i].Name, name) {
+			return &gc.Groups[i]
+		}
+	}
+	return gc.FindGroup(name)
+}
+
 // AddGroup adds a new group to the configuration
 func (gc *GroupConfig) AddGroup(group Group) {
 	gc.Groups = append(gc.Groups, group)
 	gc.LastModified = time.Now()
 }
 
-// RemoveGroup removes a group by ID
+// RemoveGroup removes a group by ID, and from the groups including it
 func (gc *GroupConfig) RemoveGroup(id string) bool {
 	for i, group := range gc.Groups {
 		if group.ID == id {
 			gc.Groups = append(gc.Groups[:i], gc.Groups[i+1:]...)
+			for j := range gc.Groups {
+				gc.Groups[j].GroupIDs = slices.DeleteFunc(gc.Groups[j].GroupIDs, func(included string) bool { return included == id })
+			}
 			gc.LastModified = time.Now()
 			return true
 		}
@@ -57,6 +379,59 @@ func (gc *GroupConfig) RemoveGroup(id string) bool {
 	return false
 }
 
+// CheckIncludes returns an error if a group includes itself, directly or
+// through the groups it includes
+func (gc *GroupConfig) CheckIncludes(groupID string) error {
+	var visit func(id string, path []*Group) error
+	visit = func(id string, path []*Group) error {
+		group := gc.FindGroup(id)
+		if group == nil {
+			return nil
+		}
+		cycle := slices.ContainsFunc(path, func(g *Group) bool { return g.ID == id })
+		path = append(path, group)
+		if cycle {
+			names := make([]string, len(path))
+			for i, g := range path {
+				names[i] = g.Name
+			}
+			return fmt.Errorf("group cycle: %s", strings.Join(names, " -> "))
+		}
+		for _, included := range group.GroupIDs {
+			if err := visit(included, path); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return visit(groupID, nil)
+}
+
+// AllContainerIDs returns the containers of a group and of the groups it
+// includes, recursively, each once. The includes must be free of cycles.
+func (gc *GroupConfig) AllContainerIDs(groupID string) []string {
+	var ids []string
+	seen := make(map[string]bool)
+	var collect func(id string)
+	collect = func(id string) {
+		group := gc.FindGroup(id)
+		if group == nil {
+			return
+		}
+		for _, containerID := range group.ContainerIDs {
+			if !seen[containerID] {
+				seen[containerID] = true
+				ids = append(ids, containerID)
+			}
+		}
+		for _, included := range group.GroupIDs {
+			collect(included)
+		}
+	}
+	collect(groupID)
+	return ids
+}
+
 // UpdateGroup updates an existing group
 func (gc *GroupConfig) UpdateGroup(updated Group) bool {
 	for i := range gc.Groups {
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// ImageLayer is one step of an image's build history, as `docker history`
+// lists it, newest first
+type ImageLayer struct {
+	ID        string // "<missing>" for steps built on another machine
+	Created   time.Time
+	CreatedBy string
+	Size      int64 // 0 for steps that only change metadata (ENV, CMD...)
+	Comment   string
+}
+
+// Instruction splits CreatedBy into the Dockerfile instruction and its
+// arguments, e.g. "RUN" and "apt-get install -y curl". Handles both the
+// classic builder ("/bin/sh -c #(nop)  CMD [...]") and BuildKit
+// ("RUN /bin/sh -c ... # buildkit") formats.
+func (l ImageLayer) Instruction() (instruction, args string) {
+	s := strings.TrimSpace(l.CreatedBy)
+	s = strings.TrimSpace(strings.TrimSuffix(s, "# buildkit"))
+
+	// Build args of the classic builder: "|2 A=1 B=2 /bin/sh -c ..."
+	if strings.HasPrefix(s, "|") {
+		if i := strings.Index(s, "/bin/sh -c "); i >= 0 {
+			s = s[i:]
+		}
+	}
+
+	if rest, ok := strings.CutPrefix(s, "/bin/sh -c "); ok {
+		rest = strings.TrimSpace(rest)
+		if nop, ok := strings.CutPrefix(rest, "#(nop)"); ok {
+			instruction, args, _ = strings.Cut(strings.TrimSpace(nop), " ")
+			return instruction, strings.TrimSpace(args)
+		}
+		return "RUN", rest
+	}
+
+	first, rest, _ := strings.Cut(s, " ")
+	if first == "" || strings.ToUpper(first) != first {
+		return "", s
+	}
+	rest = strings.TrimSpace(rest)
+	if first == "RUN" {
+		rest = strings.TrimSpace(strings.TrimPrefix(rest, "/bin/sh -c "))
+	}
+	return first, rest
+}
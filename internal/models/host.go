@@ -0,0 +1,71 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// HostInfo represents information about the Docker host
+type HostInfo struct {
+	Name            string
+	ServerVersion   string
+	OperatingSystem string
+	Architecture    string
+	NCPU            int
+	MemTotal        int64
+}
+
+// ParseCPUSet parses a cpuset list (e.g. "0-3,5") into sorted CPU/node indexes
+func ParseCPUSet(spec string) ([]int, error) {
+	seen := make(map[int]bool)
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			return nil, fmt.Errorf("invalid cpuset %q: empty entry", spec)
+		}
+
+		start, end := part, part
+		if idx := strings.Index(part, "-"); idx >= 0 {
+			start, end = part[:idx], part[idx+1:]
+		}
+
+		first, err := strconv.Atoi(start)
+		if err != nil || first < 0 {
+			return nil, fmt.Errorf("invalid cpuset %q: bad value %q", spec, start)
+		}
+		last, err := strconv.Atoi(end)
+		if err != nil || last < first {
+			return nil, fmt.Errorf("invalid cpuset %q: bad range %q", spec, part)
+		}
+
+		for i := first; i <= last; i++ {
+			seen[i] = true
+		}
+	}
+
+	result := make([]int, 0, len(seen))
+	for i := range seen {
+		result = append(result, i)
+	}
+	sort.Ints(result)
+	return result, nil
+}
+
+// ValidateCPUSet checks that a cpuset list only references CPUs available on the host
+func ValidateCPUSet(spec string, hostCPUs int) error {
+	cpus, err := ParseCPUSet(spec)
+	if err != nil {
+		return err
+	}
+	if hostCPUs <= 0 {
+		return nil
+	}
+	for _, cpu := range cpus {
+		if cpu >= hostCPUs {
+			return fmt.Errorf("CPU %d out of range (host has %d CPUs: 0-%d)", cpu, hostCPUs, hostCPUs-1)
+		}
+	}
+	return nil
+}
@@ -1,6 +1,10 @@
 package models
 
-import "time"
+import (
+	"slices"
+	"strings"
+	"time"
+)
 
 // Image represents a Docker image
 type Image struct {
@@ -12,7 +16,8 @@ type Image struct {
 	Size         int64
 	VirtualSize  int64
 	Labels       map[string]string
-	Containers   int // Number of containers using this image
+	Containers   int    // Number of containers using this image
+	ArchWarning  string // Set if the image's architecture doesn't match the host
 }
 
 // GetShortID returns the first 12 characters of the image ID
@@ -81,3 +86,33 @@ func (i *Image) IsDangling() bool {
 func (i *Image) IsUnused() bool {
 	return i.Containers == 0
 }
+
+// ArchiveName suggests a file name for saving an image, e.g.
+// "nginx_1.27.tar" for nginx:1.27 or the short ID for untagged images
+func (i *Image) ArchiveName() string {
+	if i.IsDangling() {
+		return i.ShortID + ".tar"
+	}
+	name := i.GetPrimaryTag()
+	name = name[strings.LastIndex(name, "/")+1:]
+	return strings.ReplaceAll(name, ":", "_") + ".tar"
+}
+
+// ProtectionRef is what protecting an image stores: its tag, which stays
+// protected when a newer version is pulled, or the ID of an untagged image
+func (i *Image) ProtectionRef() string {
+	if i.IsDangling() {
+		return i.ID
+	}
+	return i.GetPrimaryTag()
+}
+
+// IsProtected returns true if the ID or any tag of the image is in refs
+func (i *Image) IsProtected(refs []string) bool {
+	for _, ref := range refs {
+		if ref == i.ID || slices.Contains(i.RepoTags, ref) {
+			return true
+		}
+	}
+	return false
+}
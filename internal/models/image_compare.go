@@ -0,0 +1,80 @@
+package models
+
+import (
+	"sort"
+)
+
+// ImageSpec is what an image is made of, as far as comparing two images goes
+type ImageSpec struct {
+	Ref    string // Tag, or short ID of an untagged image
+	ID     string
+	Size   int64
+	Layers []string          // Layer diff IDs, base first
+	Config map[string]string // Setting -> value, e.g. "env PATH", "cmd", "port 80/tcp"
+}
+
+// ConfigDiff is a setting that differs between two images, "" where unset
+type ConfigDiff struct {
+	Key string
+	A   string
+	B   string
+}
+
+// ImageComparison is how image B differs from image A
+type ImageComparison struct {
+	A, B         ImageSpec
+	CommonBase   int      // Leading layers both images share
+	Shared       int      // Layers both images have, anywhere in the stack
+	OnlyA, OnlyB []string // Layers of one image only, base first
+	SizeDelta    int64    // B's size minus A's
+	ConfigDiffs  []ConfigDiff
+}
+
+// CompareImages compares the layers and config of two images
+func CompareImages(a, b ImageSpec) ImageComparison {
+	cmp := ImageComparison{A: a, B: b, SizeDelta: b.Size - a.Size}
+
+	for cmp.CommonBase < min(len(a.Layers), len(b.Layers)) && a.Layers[cmp.CommonBase] == b.Layers[cmp.CommonBase] {
+		cmp.CommonBase++
+	}
+
+	inA := make(map[string]bool, len(a.Layers))
+	for _, layer := range a.Layers {
+		inA[layer] = true
+	}
+	inB := make(map[string]bool, len(b.Layers))
+	for _, layer := range b.Layers {
+		inB[layer] = true
+		if inA[layer] {
+			cmp.Shared++
+		} else {
+			cmp.OnlyB = append(cmp.OnlyB, layer)
+		}
+	}
+	for _, layer := range a.Layers {
+		if !inB[layer] {
+			cmp.OnlyA = append(cmp.OnlyA, layer)
+		}
+	}
+
+	keys := make(map[string]bool)
+	for key := range a.Config {
+		keys[key] = true
+	}
+	for key := range b.Config {
+		keys[key] = true
+	}
+	for key := range keys {
+		if a.Config[key] != b.Config[key] {
+			cmp.ConfigDiffs = append(cmp.ConfigDiffs, ConfigDiff{Key: key, A: a.Config[key], B: b.Config[key]})
+		}
+	}
+	sort.Slice(cmp.ConfigDiffs, func(i, j int) bool { return cmp.ConfigDiffs[i].Key < cmp.ConfigDiffs[j].Key })
+	return cmp
+}
+
+// SameContent returns true if both images have the same layers and config,
+// i.e. a rebuild changed nothing that matters at runtime
+func (c ImageComparison) SameContent() bool {
+	return len(c.OnlyA) == 0 && len(c.OnlyB) == 0 && len(c.A.Layers) == len(c.B.Layers) && len(c.ConfigDiffs) == 0
+}
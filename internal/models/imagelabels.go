@@ -0,0 +1,82 @@
+package models
+
+import (
+	"sort"
+	"strings"
+)
+
+// ImageLabel is a label of an image, with a web link when its value points
+// somewhere (source repo, commit, docs)
+type ImageLabel struct {
+	Key   string
+	Value string
+	Link  string
+	OCI   bool // One of the org.opencontainers.image.* annotations
+}
+
+// ImageLabels returns the labels of an image, OCI annotations first, each
+// group sorted by key. The revision links to its commit when the source is
+// a known forge.
+func ImageLabels(img Image) []ImageLabel {
+	labels := make([]ImageLabel, 0, len(img.Labels))
+	for key, value := range img.Labels {
+		label := ImageLabel{Key: key, Value: value, OCI: strings.HasPrefix(key, OCILabelPrefix)}
+		switch strings.TrimPrefix(key, OCILabelPrefix) {
+		case "source", "url", "documentation":
+			if label.OCI {
+				label.Link = WebURL(value)
+			}
+		case "revision":
+			if label.OCI {
+				label.Link = CommitURL(img.Labels[OCILabelPrefix+"source"], value)
+			}
+		}
+		labels = append(labels, label)
+	}
+	sort.Slice(labels, func(i, j int) bool {
+		if labels[i].OCI != labels[j].OCI {
+			return labels[i].OCI
+		}
+		return labels[i].Key < labels[j].Key
+	})
+	return labels
+}
+
+// WebURL returns the browsable URL of a repository or page address, e.g.
+// https://github.com/org/repo for git@github.com:org/repo.git, or "" if the
+// value isn't an address
+func WebURL(value string) string {
+	value = strings.TrimSpace(value)
+	if rest, ok := strings.CutPrefix(value, "git@"); ok {
+		host, path, found := strings.Cut(rest, ":")
+		if !found {
+			return ""
+		}
+		value = "https://" + host + "/" + path
+	} else if rest, ok := strings.CutPrefix(value, "git+"); ok {
+		value = rest
+	}
+	if !strings.HasPrefix(value, "https://") && !strings.HasPrefix(value, "http://") {
+		return ""
+	}
+	return strings.TrimSuffix(value, ".git")
+}
+
+// CommitURL returns the web page of a revision of a source repository on
+// GitHub, GitLab, Bitbucket or Codeberg, or "" for other hosts
+func CommitURL(source, revision string) string {
+	repo := strings.TrimSuffix(WebURL(source), "/")
+	revision = strings.TrimSpace(revision)
+	if repo == "" || revision == "" || strings.ContainsAny(revision, " /") {
+		return ""
+	}
+	switch {
+	case strings.Contains(repo, "://github.com/"), strings.Contains(repo, "://codeberg.org/"):
+		return repo + "/commit/" + revision
+	case strings.Contains(repo, "://gitlab."):
+		return repo + "/-/commit/" + revision
+	case strings.Contains(repo, "://bitbucket.org/"):
+		return repo + "/commits/" + revision
+	}
+	return ""
+}
@@ -0,0 +1,78 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// IsComposeFile reports whether a pull list path looks like a compose file
+// (its images are then read from the services instead of line by line)
+func IsComposeFile(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yml", ".yaml":
+		return true
+	}
+	return false
+}
+
+// ParseImageList reads image references from a text or lock file: one per
+// line, the first word of the line, skipping blank lines and # comments.
+// Duplicates are dropped, keeping the first occurrence.
+func ParseImageList(text string) []string {
+	var images []string
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(text, "\n") {
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 || seen[fields[0]] {
+			continue
+		}
+		seen[fields[0]] = true
+		images = append(images, fields[0])
+	}
+	return images
+}
+
+// ComposeImages returns the images of a compose project's services from the
+// output of `docker compose config --format json`, sorted by service name.
+// Services that are built locally are skipped, there's nothing to pull.
+func ComposeImages(configJSON []byte) ([]string, error) {
+	var config struct {
+		Services map[string]struct {
+			Image string          `json:"image"`
+			Build json.RawMessage `json:"build"`
+		} `json:"services"`
+	}
+	if err := json.Unmarshal(configJSON, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse compose config: %w", err)
+	}
+
+	names := make([]string, 0, len(config.Services))
+	for name := range config.Services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var images []string
+	seen := make(map[string]bool)
+	for _, name := range names {
+		service := config.Services[name]
+		if service.Image == "" || len(service.Build) > 0 || seen[service.Image] {
+			continue
+		}
+		seen[service.Image] = true
+		images = append(images, service.Image)
+	}
+	return images, nil
+}
+
+// BatchPullResult is the outcome of pulling one image of a pull list
+type BatchPullResult struct {
+	Image string
+	Err   error
+}
@@ -0,0 +1,73 @@
+package models
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// logRateWindow is the rolling window log rates are computed over
+const logRateWindow = 60
+
+// errorKeywordPattern matches log lines that look like errors
+var errorKeywordPattern = regexp.MustCompile(`(?i)\b(error|err|fatal|panic|exception|critical|crit)\b`)
+
+// IsErrorLine returns true if a log line contains an error keyword
+func IsErrorLine(line string) bool {
+	return errorKeywordPattern.MatchString(line)
+}
+
+// LogRate keeps a rolling per-minute count of log lines and error lines,
+// bucketed per second so floods don't grow memory
+type LogRate struct {
+	seconds [logRateWindow]int64 // Unix second each bucket holds
+	lines   [logRateWindow]int
+	errors  [logRateWindow]int
+}
+
+// Add records a log line seen at t
+func (r *LogRate) Add(t time.Time, isError bool) {
+	sec := t.Unix()
+	i := int(sec % logRateWindow)
+	if r.seconds[i] > sec {
+		return // Older than the window the bucket already holds
+	}
+	if r.seconds[i] != sec {
+		r.seconds[i] = sec
+		r.lines[i] = 0
+		r.errors[i] = 0
+	}
+	r.lines[i]++
+	if isError {
+		r.errors[i]++
+	}
+}
+
+// PerMinute returns the lines and error lines seen in the minute before now
+func (r *LogRate) PerMinute(now time.Time) (lines, errors int) {
+	cutoff := now.Unix() - logRateWindow
+	for i := range r.seconds {
+		if r.seconds[i] > cutoff {
+			lines += r.lines[i]
+			errors += r.errors[i]
+		}
+	}
+	return lines, errors
+}
+
+// Reset clears all counts
+func (r *LogRate) Reset() {
+	*r = LogRate{}
+}
+
+// LogLineTime returns the timestamp docker prefixes log lines with (when
+// requested), skipping the stream header bytes that may precede it
+func LogLineTime(line string) (time.Time, bool) {
+	for i := 0; i < 9 && i < len(line); i++ {
+		token, _, _ := strings.Cut(line[i:], " ")
+		if t, err := time.Parse(time.RFC3339Nano, token); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
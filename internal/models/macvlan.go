@@ -0,0 +1,153 @@
+package models
+
+import (
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+
+	"github.com/rizface/doui/pkg/utils"
+)
+
+// macvlanModes lists the modes of each driver, the first one is the default
+var macvlanModes = map[string][]string{
+	"macvlan": {"bridge", "private", "vepa", "passthru"},
+	"ipvlan":  {"l2", "l3", "l3s"},
+}
+
+// MacvlanNetwork describes a macvlan or ipvlan network to create, whose
+// containers get addresses directly on the parent interface's network
+type MacvlanNetwork struct {
+	Name    string
+	Driver  string // macvlan or ipvlan
+	Mode    string // Empty for the driver's default
+	Parent  string // Host interface, e.g. eth0 or eth0.10 for a VLAN
+	Subnet  string
+	Gateway string
+	IPRange string // Part of the subnet Docker assigns container addresses from
+}
+
+// GetMode returns the mode, or the driver's default mode
+func (n *MacvlanNetwork) GetMode() string {
+	if n.Mode != "" {
+		return n.Mode
+	}
+	if modes := macvlanModes[n.Driver]; len(modes) > 0 {
+		return modes[0]
+	}
+	return ""
+}
+
+// IsL3 returns true for ipvlan L3 modes, where the host routes traffic and
+// containers aren't on the parent's L2 segment
+func (n *MacvlanNetwork) IsL3() bool {
+	return n.Driver == "ipvlan" && strings.HasPrefix(n.GetMode(), "l3")
+}
+
+// Validate checks the settings and returns warnings for settings that work
+// but are likely mistakes. When the parent interface is known (local daemon)
+// the subnet and gateway are compared to its addresses.
+func (n *MacvlanNetwork) Validate(parent *utils.HostInterface) (warnings []string, err error) {
+	if n.Name == "" {
+		return nil, fmt.Errorf("network name is required")
+	}
+	modes, ok := macvlanModes[n.Driver]
+	if !ok {
+		return nil, fmt.Errorf("driver must be macvlan or ipvlan, got %q", n.Driver)
+	}
+	if n.Mode != "" && !slices.Contains(modes, n.Mode) {
+		return nil, fmt.Errorf("%s mode must be one of %s, got %q", n.Driver, strings.Join(modes, ", "), n.Mode)
+	}
+	if n.Parent == "" {
+		return nil, fmt.Errorf("parent interface is required")
+	}
+
+	_, subnet, err := net.ParseCIDR(n.Subnet)
+	if err != nil {
+		return nil, fmt.Errorf("invalid subnet %q: expected CIDR like 192.168.1.0/24", n.Subnet)
+	}
+	if n.Gateway != "" {
+		gateway := net.ParseIP(n.Gateway)
+		if gateway == nil {
+			return nil, fmt.Errorf("invalid gateway %q", n.Gateway)
+		}
+		if !subnet.Contains(gateway) {
+			return nil, fmt.Errorf("gateway %s is not in subnet %s", n.Gateway, subnet)
+		}
+		if gateway.Equal(subnet.IP) {
+			return nil, fmt.Errorf("gateway %s is the subnet's network address", n.Gateway)
+		}
+		if n.IsL3() {
+			warnings = append(warnings, "ipvlan L3 mode ignores the gateway, the parent interface routes the traffic")
+		}
+	} else if !n.IsL3() {
+		warnings = append(warnings, "No gateway: containers can only reach hosts on the local network")
+	}
+
+	var ipRange *net.IPNet
+	if n.IPRange != "" {
+		if _, ipRange, err = net.ParseCIDR(n.IPRange); err != nil {
+			return nil, fmt.Errorf("invalid IP range %q: expected CIDR like 192.168.1.192/27", n.IPRange)
+		}
+		rangeOnes, _ := ipRange.Mask.Size()
+		subnetOnes, _ := subnet.Mask.Size()
+		if !subnet.Contains(ipRange.IP) || rangeOnes < subnetOnes {
+			return nil, fmt.Errorf("IP range %s is not inside subnet %s", n.IPRange, subnet)
+		}
+	}
+
+	// VLAN sub-interfaces (eth0.10) are created by Docker on another segment,
+	// the parent's own addresses say nothing about them
+	if parent == nil || strings.Contains(n.Parent, ".") || n.IsL3() {
+		return warnings, nil
+	}
+
+	onParentNetwork := false
+	for _, addr := range parent.Addrs {
+		ip, ifaceNet, err := net.ParseCIDR(addr)
+		if err != nil || !ifaceNet.Contains(subnet.IP) {
+			continue
+		}
+		onParentNetwork = true
+		pool := subnet
+		if ipRange != nil {
+			pool = ipRange
+		}
+		if pool.Contains(ip) {
+			warnings = append(warnings, fmt.Sprintf("The host's own address %s is in the range Docker assigns from: set an IP range that excludes it", ip))
+		}
+		if n.Gateway != "" && parent.Gateway != "" && n.Gateway != parent.Gateway && ip.To4() != nil {
+			warnings = append(warnings, fmt.Sprintf("Gateway %s differs from the host's default gateway %s on %s", n.Gateway, parent.Gateway, parent.Name))
+		}
+	}
+	if !onParentNetwork {
+		warnings = append(warnings, fmt.Sprintf("Subnet %s is not the network of %s (%s): containers won't reach the LAN unless it is routed there",
+			subnet, parent.Name, strings.Join(parent.Addrs, ", ")))
+	}
+	if ipRange == nil {
+		warnings = append(warnings, "No IP range: Docker may hand out addresses your DHCP server also leases, reserve a range outside its pool")
+	}
+	return warnings, nil
+}
+
+// Caveats explains the reachability limits of the network
+func (n *MacvlanNetwork) Caveats(parent *utils.HostInterface) []string {
+	var caveats []string
+	if n.IsL3() {
+		caveats = append(caveats, fmt.Sprintf("Other machines need a route to %s via this host", n.Subnet))
+	} else {
+		caveats = append(caveats, fmt.Sprintf("The host can't reach containers on this network through %s (and they can't reach the host): add a %s shim interface on the host if it needs to", n.Parent, n.Driver))
+	}
+	if n.Driver == "macvlan" {
+		caveats = append(caveats, "Each container gets its own MAC address, switches with port security may drop them")
+		if parent != nil && parent.Wireless {
+			caveats = append(caveats, fmt.Sprintf("%s is a wireless interface: Wi-Fi access points usually drop extra MAC addresses, use ipvlan instead", parent.Name))
+		}
+		if n.GetMode() == "bridge" {
+			caveats = append(caveats, "The parent interface may need promiscuous mode (VMs and cloud networks often block it)")
+		}
+	} else if !n.IsL3() {
+		caveats = append(caveats, "Containers share the parent's MAC address, so DHCP servers see one MAC for several IPs")
+	}
+	return caveats
+}
@@ -1,6 +1,9 @@
 package models
 
 import (
+	"fmt"
+	"net"
+	"strings"
 	"time"
 )
 
@@ -42,3 +45,41 @@ func (n *Network) GetContainerCount() int {
 func (n *Network) IsSystemNetwork() bool {
 	return n.Name == "bridge" || n.Name == "host" || n.Name == "none"
 }
+
+// ValidateStaticIP checks that ip can be given to a container on the
+// network: an IPv4 address inside its subnet, other than the gateway
+func (n *Network) ValidateStaticIP(ip string) error {
+	addr := net.ParseIP(ip)
+	if addr == nil || addr.To4() == nil {
+		return fmt.Errorf("invalid IPv4 address %q", ip)
+	}
+	if n.IPAM.Subnet == "" {
+		return fmt.Errorf("network %s has no configured subnet, Docker only accepts static IPs on networks created with one", n.Name)
+	}
+	if _, subnet, err := net.ParseCIDR(n.IPAM.Subnet); err == nil && subnet.IP.To4() != nil {
+		if !subnet.Contains(addr) {
+			return fmt.Errorf("%s is not in subnet %s of network %s", ip, n.IPAM.Subnet, n.Name)
+		}
+		if addr.Equal(subnet.IP) {
+			return fmt.Errorf("%s is the subnet's network address", ip)
+		}
+	}
+	if n.IPAM.Gateway != "" && addr.Equal(net.ParseIP(n.IPAM.Gateway)) {
+		return fmt.Errorf("%s is the gateway of network %s", ip, n.Name)
+	}
+	return nil
+}
+
+// ParseAliases splits a comma or space separated list of DNS aliases,
+// dropping duplicates
+func ParseAliases(text string) []string {
+	var aliases []string
+	seen := make(map[string]bool)
+	for _, alias := range strings.FieldsFunc(text, func(r rune) bool { return r == ',' || r == ' ' }) {
+		if !seen[alias] {
+			seen[alias] = true
+			aliases = append(aliases, alias)
+		}
+	}
+	return aliases
+}
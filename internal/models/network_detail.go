@@ -0,0 +1,52 @@
+package models
+
+import (
+	"sort"
+	"strings"
+)
+
+// NetworkDetail is everything `docker network inspect` tells about a network
+type NetworkDetail struct {
+	Network
+	EnableIPv6  bool
+	ConfigOnly  bool
+	ConfigFrom  string // Config-only network this one was created from
+	Subnets     []NetworkSubnet
+	IPAMOptions map[string]string
+	Options     map[string]string // Driver options, e.g. com.docker.network.bridge.name
+	Endpoints   []NetworkEndpoint
+}
+
+// NetworkSubnet is one IPAM pool of a network
+type NetworkSubnet struct {
+	Subnet       string
+	Gateway      string
+	IPRange      string
+	AuxAddresses map[string]string // Host name -> reserved address
+}
+
+// NetworkEndpoint is a container's attachment to a network
+type NetworkEndpoint struct {
+	ContainerID string
+	Name        string // Container name
+	IPv4        string // With prefix length, e.g. 172.18.0.2/16
+	IPv6        string
+	MAC         string
+}
+
+// GetIPv4 returns the IPv4 address without the prefix length
+func (e NetworkEndpoint) GetIPv4() string {
+	ip, _, _ := strings.Cut(e.IPv4, "/")
+	return ip
+}
+
+// GetIPv6 returns the IPv6 address without the prefix length
+func (e NetworkEndpoint) GetIPv6() string {
+	ip, _, _ := strings.Cut(e.IPv6, "/")
+	return ip
+}
+
+// SortEndpoints orders the endpoints by container name
+func (d *NetworkDetail) SortEndpoints() {
+	sort.Slice(d.Endpoints, func(i, j int) bool { return d.Endpoints[i].Name < d.Endpoints[j].Name })
+}
@@ -0,0 +1,73 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ExecFormatHint explains the usual cause of "exec format error"
+const ExecFormatHint = "\"exec format error\" means the image was built for another CPU architecture " +
+	"than this host (e.g. an amd64-only image on an ARM Mac). Use a multi-arch image or one built for " +
+	"this host, or run it with --platform and emulation enabled."
+
+// NormalizeArch maps kernel architecture names (uname -m) to the names used
+// in image platforms, e.g. "x86_64" -> "amd64", "aarch64" -> "arm64"
+func NormalizeArch(arch string) string {
+	switch strings.ToLower(arch) {
+	case "x86_64", "x86-64", "amd64":
+		return "amd64"
+	case "aarch64", "arm64":
+		return "arm64"
+	case "armv7l", "armv6l", "armhf", "arm":
+		return "arm"
+	case "i386", "i686", "386":
+		return "386"
+	default:
+		return strings.ToLower(arch)
+	}
+}
+
+// ArchMismatchWarning returns a warning if an image built for imageArch can't
+// run natively on a host with hostArch, or "" if it can (or either is unknown)
+func ArchMismatchWarning(imageOS, imageArch, hostArch string) string {
+	if imageArch == "" || hostArch == "" {
+		return ""
+	}
+	if NormalizeArch(imageArch) == NormalizeArch(hostArch) {
+		return ""
+	}
+	platform := imageArch
+	if imageOS != "" {
+		platform = imageOS + "/" + imageArch
+	}
+	return fmt.Sprintf("image is %s, host is %s (emulated, or fails with exec format error)", platform, NormalizeArch(hostArch))
+}
+
+// FormatPlatform joins a platform's parts like "linux/arm/v7"
+func FormatPlatform(os, arch, variant string) string {
+	platform := os + "/" + arch
+	if variant != "" {
+		platform += "/" + variant
+	}
+	return platform
+}
+
+// ValidPlatform returns true for a platform like "linux/arm64" or
+// "linux/arm/v7", as accepted by `docker pull --platform`
+func ValidPlatform(platform string) bool {
+	parts := strings.Split(platform, "/")
+	if len(parts) < 2 || len(parts) > 3 {
+		return false
+	}
+	for _, part := range parts {
+		if part == "" || strings.ContainsAny(part, " \t:@") {
+			return false
+		}
+	}
+	return true
+}
+
+// IsExecFormatError returns true if text contains an "exec format error"
+func IsExecFormatError(text string) bool {
+	return strings.Contains(strings.ToLower(text), "exec format error")
+}
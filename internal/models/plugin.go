@@ -0,0 +1,29 @@
+package models
+
+import "strings"
+
+// Plugin represents an installed Docker engine plugin
+type Plugin struct {
+	ID           string
+	Name         string
+	Description  string
+	Enabled      bool
+	Capabilities []string // e.g. volumedriver, networkdriver, logdriver
+	Reference    string   // Remote reference the plugin was pulled from
+}
+
+// GetShortID returns the first 12 characters of the plugin ID
+func (p *Plugin) GetShortID() string {
+	if len(p.ID) > 12 {
+		return p.ID[:12]
+	}
+	return p.ID
+}
+
+// GetKind returns the plugin capabilities as a readable list
+func (p *Plugin) GetKind() string {
+	if len(p.Capabilities) == 0 {
+		return "unknown"
+	}
+	return strings.Join(p.Capabilities, ", ")
+}
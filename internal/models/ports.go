@@ -0,0 +1,135 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rizface/doui/pkg/utils"
+)
+
+// PortBinding is a host port published (or to be published) by a container
+type PortBinding struct {
+	ContainerID   string
+	ContainerName string
+	Running       bool
+	HostIP        string // Empty, 0.0.0.0 or :: means all interfaces
+	HostPort      int
+	Proto         string // tcp, udp
+}
+
+// PortConflict describes a host port that is or will be double-booked
+type PortConflict struct {
+	Port     int
+	Proto    string
+	Severity string // "error" (cannot start now) or "warning" (will conflict if started)
+	Message  string
+}
+
+// isWildcardIP returns true if the address binds all interfaces
+func isWildcardIP(ip string) bool {
+	return ip == "" || ip == "0.0.0.0" || ip == "::"
+}
+
+// ipsOverlap returns true if two bind addresses would collide
+func ipsOverlap(a, b string) bool {
+	return isWildcardIP(a) || isWildcardIP(b) || a == b
+}
+
+// DetectPortConflicts finds stopped containers that cannot start because their
+// host port is taken (by a running container or a host process), and stopped
+// containers that share a host port and so cannot run at the same time
+func DetectPortConflicts(bindings []PortBinding, listeners []utils.HostListener) []PortConflict {
+	var conflicts []PortConflict
+
+	// Group bindings by proto/port, one entry per container
+	byPort := make(map[string][]PortBinding)
+	seen := make(map[string]bool)
+	for _, b := range bindings {
+		key := fmt.Sprintf("%d/%s", b.HostPort, b.Proto)
+		if seen[key+b.ContainerID] {
+			continue
+		}
+		seen[key+b.ContainerID] = true
+		byPort[key] = append(byPort[key], b)
+	}
+
+	for _, group := range byPort {
+		var running, stopped []PortBinding
+		for _, b := range group {
+			if b.Running {
+				running = append(running, b)
+			} else {
+				stopped = append(stopped, b)
+			}
+		}
+
+		// Stopped containers whose port is held by a running container
+		var free []PortBinding
+		for _, s := range stopped {
+			holder := ""
+			for _, r := range running {
+				if ipsOverlap(s.HostIP, r.HostIP) {
+					holder = r.ContainerName
+					break
+				}
+			}
+			if holder != "" {
+				conflicts = append(conflicts, PortConflict{
+					Port:     s.HostPort,
+					Proto:    s.Proto,
+					Severity: "error",
+					Message:  fmt.Sprintf("%s cannot start: port is used by running container %s", s.ContainerName, holder),
+				})
+				continue
+			}
+			free = append(free, s)
+		}
+
+		// Stopped containers whose port is held by a host process
+		var unclaimed []PortBinding
+		for _, s := range free {
+			taken := false
+			for _, l := range listeners {
+				if l.Proto == s.Proto && l.Port == s.HostPort && ipsOverlap(s.HostIP, l.IP) {
+					taken = true
+					break
+				}
+			}
+			if taken {
+				conflicts = append(conflicts, PortConflict{
+					Port:     s.HostPort,
+					Proto:    s.Proto,
+					Severity: "error",
+					Message:  fmt.Sprintf("%s cannot start: port is already in use by a host process", s.ContainerName),
+				})
+				continue
+			}
+			unclaimed = append(unclaimed, s)
+		}
+
+		// Several stopped containers competing for the same free port
+		if len(unclaimed) > 1 {
+			names := make([]string, len(unclaimed))
+			for i, s := range unclaimed {
+				names[i] = s.ContainerName
+			}
+			sort.Strings(names)
+			conflicts = append(conflicts, PortConflict{
+				Port:     unclaimed[0].HostPort,
+				Proto:    unclaimed[0].Proto,
+				Severity: "warning",
+				Message:  fmt.Sprintf("%s all bind this port, only one can run at a time", strings.Join(names, ", ")),
+			})
+		}
+	}
+
+	sort.Slice(conflicts, func(i, j int) bool {
+		if conflicts[i].Port != conflicts[j].Port {
+			return conflicts[i].Port < conflicts[j].Port
+		}
+		return conflicts[i].Message < conflicts[j].Message
+	})
+
+	return conflicts
+}
@@ -0,0 +1,75 @@
+package models
+
+import (
+	"sort"
+	"strings"
+)
+
+// OCILabelPrefix is the prefix of the standard OCI image annotations, which
+// build tools also set as labels (source repo, revision, base image...)
+const OCILabelPrefix = "org.opencontainers.image."
+
+// ImageProvenance holds what can be verified about where an image came from
+type ImageProvenance struct {
+	// OCI labels without the prefix, e.g. "source", "revision"
+	OCILabels map[string]string
+	// Registry digest the image was pulled by, empty for local builds
+	Digest string
+
+	// Platform of the local image ("linux/amd64") and those the registry
+	// has for it, more than one for multi-arch images
+	Platform     string
+	Platforms    []string
+	PlatformsErr string // Why the registry couldn't be asked
+
+	// Build attestations (SLSA provenance, SBOM) stored with the image.
+	// Only the containerd image store keeps them, AttestationsKnown is false
+	// when the daemon can't tell.
+	Attestations      int
+	AttestationsKnown bool
+
+	// Cosign signature and attestation tags found in the registry
+	CosignChecked     bool
+	CosignSignature   string
+	CosignAttestation string
+	CosignErr         string // Why the registry couldn't be checked
+}
+
+// NewImageProvenance collects the OCI labels and pull digest of an image
+func NewImageProvenance(img Image) *ImageProvenance {
+	p := &ImageProvenance{OCILabels: make(map[string]string)}
+	for key, value := range img.Labels {
+		if name, ok := strings.CutPrefix(key, OCILabelPrefix); ok && value != "" {
+			p.OCILabels[name] = value
+		}
+	}
+	if len(img.RepoDigests) > 0 {
+		p.Digest = img.RepoDigests[0]
+	}
+	return p
+}
+
+// SortedOCILabels returns the OCI label names in alphabetical order
+func (p *ImageProvenance) SortedOCILabels() []string {
+	names := make([]string, 0, len(p.OCILabels))
+	for name := range p.OCILabels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// CosignTag returns the tag cosign stores a signature (".sig") or
+// attestation (".att") under for a repo digest like "nginx@sha256:abc",
+// e.g. "nginx:sha256-abc.sig"
+func CosignTag(repoDigest, suffix string) string {
+	repo, digest, ok := strings.Cut(repoDigest, "@")
+	if !ok {
+		return ""
+	}
+	algo, hex, ok := strings.Cut(digest, ":")
+	if !ok {
+		return ""
+	}
+	return repo + ":" + algo + "-" + hex + suffix
+}
@@ -0,0 +1,95 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DockerHub is the registry of image references without a registry host
+const DockerHub = "docker.io"
+
+// ImageRegistry returns the registry host of an image reference, DockerHub
+// for references like nginx or library/nginx:latest
+func ImageRegistry(ref string) string {
+	first, _, found := strings.Cut(ref, "/")
+	if !found || (!strings.ContainsAny(first, ".:") && first != "localhost") {
+		return DockerHub
+	}
+	return NormalizeRegistry(first)
+}
+
+// NormalizeRegistry maps the aliases of Docker Hub to DockerHub and strips a
+// scheme or path from a registry address
+func NormalizeRegistry(registry string) string {
+	registry = strings.TrimSpace(registry)
+	if rest, ok := strings.CutPrefix(registry, "https://"); ok {
+		registry = rest
+	} else if rest, ok := strings.CutPrefix(registry, "http://"); ok {
+		registry = rest
+	}
+	registry, _, _ = strings.Cut(registry, "/")
+	switch registry {
+	case "", DockerHub, "index.docker.io", "registry-1.docker.io", "registry.hub.docker.com":
+		return DockerHub
+	}
+	return registry
+}
+
+// RateLimit is the Docker Hub pull limit as reported by its ratelimit headers
+type RateLimit struct {
+	Limit      int           // Pulls allowed per window, 0 if the account is unlimited
+	Remaining  int           // Pulls left in the current window
+	Window     time.Duration // e.g. 6h
+	RetryAfter time.Duration // Only sent by the registry while limited
+	CheckedAt  time.Time
+}
+
+// ParseRateLimitHeader parses a ratelimit-limit or ratelimit-remaining header
+// value like "100;w=21600"
+func ParseRateLimitHeader(value string) (count int, window time.Duration, ok bool) {
+	parts := strings.Split(value, ";")
+	count, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return 0, 0, false
+	}
+	for _, part := range parts[1:] {
+		if seconds, found := strings.CutPrefix(strings.TrimSpace(part), "w="); found {
+			if s, err := strconv.Atoi(seconds); err == nil {
+				window = time.Duration(s) * time.Second
+			}
+		}
+	}
+	return count, window, true
+}
+
+// ResetAt returns when pulls are allowed again. Docker Hub counts pulls over
+// a rolling window, so without a Retry-After this is the latest it can be.
+func (r *RateLimit) ResetAt() time.Time {
+	if r.RetryAfter > 0 {
+		return r.CheckedAt.Add(r.RetryAfter)
+	}
+	return r.CheckedAt.Add(r.Window)
+}
+
+// RateLimitMessage explains a rate-limited pull. limit may be nil when the
+// limit couldn't be looked up; user is the logged in Docker Hub user, if any.
+func RateLimitMessage(limit *RateLimit, user, loginKey string) string {
+	msg := "Docker Hub pull rate limit reached"
+	if limit != nil && limit.Limit > 0 {
+		msg = fmt.Sprintf("Docker Hub rate limited (%d pulls per %s), resets by %s",
+			limit.Limit, formatWindow(limit.Window), limit.ResetAt().Format("15:04"))
+	}
+	if user != "" {
+		return fmt.Sprintf("%s for %s", msg, user)
+	}
+	return fmt.Sprintf("%s; press %s to log in to increase limits", msg, loginKey)
+}
+
+func formatWindow(d time.Duration) string {
+	if d >= time.Hour && d%time.Hour == 0 {
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	}
+	return d.String()
+}
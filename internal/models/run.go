@@ -0,0 +1,97 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// RunResult is the outcome of a one-shot container run, kept after the
+// container is removed
+type RunResult struct {
+	Image       string
+	Command     string // Empty for the image's default command
+	ContainerID string
+	Started     time.Time
+	Finished    time.Time
+	ExitCode    int
+	Output      string // stdout and stderr, interleaved
+	OutputFile  string // Where the full output was saved, if it could be
+	Err         string // Why the run failed, as opposed to a non-zero exit
+}
+
+// Duration returns how long the container ran
+func (r *RunResult) Duration() time.Duration {
+	if r.Started.IsZero() || r.Finished.Before(r.Started) {
+		return 0
+	}
+	return r.Finished.Sub(r.Started)
+}
+
+// Succeeded returns true if the container ran and exited with 0
+func (r *RunResult) Succeeded() bool {
+	return r.Err == "" && r.ExitCode == 0
+}
+
+// Summary describes the run in one line
+func (r *RunResult) Summary() string {
+	what := r.Image
+	if r.Command != "" {
+		what += " " + r.Command
+	}
+	if r.Err != "" {
+		return fmt.Sprintf("%s  %s: %s", r.Started.Format("15:04:05"), what, r.Err)
+	}
+	return fmt.Sprintf("%s  %s: exit %d in %s", r.Started.Format("15:04:05"), what, r.ExitCode, r.Duration().Round(10*time.Millisecond))
+}
+
+// OutputTail returns the last n lines of the output
+func (r *RunResult) OutputTail(n int) []string {
+	output := strings.TrimRight(r.Output, "\n")
+	if output == "" {
+		return nil
+	}
+	lines := strings.Split(output, "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return lines
+}
+
+// SplitCommand splits a command line into arguments like a shell would for
+// simple cases: whitespace separated, with single or double quoted parts
+func SplitCommand(line string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	inArg := false
+	var quote rune
+	for _, r := range line {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote in command", quote)
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
@@ -0,0 +1,44 @@
+package models
+
+import "strings"
+
+// RuntimeInfo is how a container is isolated from its host, fixed when the
+// container is created
+type RuntimeInfo struct {
+	Runtime    string // OCI runtime, e.g. runc, runsc (gVisor), kata-runtime
+	Platform   string // os/arch of its image
+	Privileged bool
+}
+
+// RuntimeName returns a readable name for an OCI runtime, e.g. "gVisor" for
+// runsc or "Kata" for kata-runtime and io.containerd.kata.v2
+func RuntimeName(runtime string) string {
+	lower := strings.ToLower(runtime)
+	switch {
+	case lower == "":
+		return ""
+	case strings.Contains(lower, "runsc") || strings.Contains(lower, "gvisor"):
+		return "gVisor"
+	case strings.Contains(lower, "kata"):
+		return "Kata"
+	case lower == "runc" || lower == "io.containerd.runc.v2":
+		return "runc"
+	default:
+		return runtime
+	}
+}
+
+// Sandboxed returns true if the runtime isolates the container with its own
+// kernel (gVisor, Kata) instead of sharing the host's
+func (r RuntimeInfo) Sandboxed() bool {
+	switch RuntimeName(r.Runtime) {
+	case "gVisor", "Kata":
+		return true
+	}
+	return false
+}
+
+// Known returns true if the runtime info was looked up
+func (r RuntimeInfo) Known() bool {
+	return r.Runtime != "" || r.Platform != ""
+}
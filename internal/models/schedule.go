@@ -0,0 +1,98 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CronSchedule is a cron expression of five fields: minute, hour, day of
+// month, month and day of week (0 or 7 is Sunday). Each field is `*`, a
+// value, a range `1-5`, a step `*/15` or `8-18/2`, or a comma-separated list
+// of those.
+type CronSchedule struct {
+	fields [5][]bool
+	// Day of month and day of week restricted: either one matching is enough
+	eitherDay bool
+}
+
+// cronFieldRanges are the allowed values of each field
+var cronFieldRanges = [5][2]int{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 7}}
+
+var cronFieldNames = [5]string{"minute", "hour", "day of month", "month", "day of week"}
+
+// ParseCronSchedule parses a cron expression like "0 8 * * 1-5"
+func ParseCronSchedule(expr string) (*CronSchedule, error) {
+	parts := strings.Fields(expr)
+	if len(parts) != 5 {
+		return nil, fmt.Errorf("invalid schedule %q: want 5 fields (minute hour day month weekday)", expr)
+	}
+
+	var s CronSchedule
+	for i, part := range parts {
+		field, err := parseCronField(part, cronFieldRanges[i][0], cronFieldRanges[i][1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule %q: %s: %w", expr, cronFieldNames[i], err)
+		}
+		s.fields[i] = field
+	}
+	// Sunday is 0 or 7
+	if s.fields[4][7] {
+		s.fields[4][0] = true
+	}
+	s.eitherDay = !strings.HasPrefix(parts[2], "*") && !strings.HasPrefix(parts[4], "*")
+	return &s, nil
+}
+
+// parseCronField returns which values between low and high a field allows
+func parseCronField(field string, low, high int) ([]bool, error) {
+	allowed := make([]bool, high+1)
+	for _, item := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(item, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepPart)
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("invalid step %q", stepPart)
+			}
+			step = n
+		}
+
+		from, to := low, high
+		if rangePart != "*" {
+			start, end, isRange := strings.Cut(rangePart, "-")
+			var err error
+			if from, err = strconv.Atoi(start); err != nil {
+				return nil, fmt.Errorf("invalid value %q", start)
+			}
+			to = from
+			if isRange {
+				if to, err = strconv.Atoi(end); err != nil {
+					return nil, fmt.Errorf("invalid value %q", end)
+				}
+			} else if hasStep {
+				to = high
+			}
+		}
+		if from < low || to > high || from > to {
+			return nil, fmt.Errorf("%q out of range %d-%d", item, low, high)
+		}
+		for v := from; v <= to; v += step {
+			allowed[v] = true
+		}
+	}
+	return allowed, nil
+}
+
+// Matches returns true if the schedule fires in the minute of t
+func (s *CronSchedule) Matches(t time.Time) bool {
+	if !s.fields[0][t.Minute()] || !s.fields[1][t.Hour()] || !s.fields[3][int(t.Month())] {
+		return false
+	}
+	dom, dow := s.fields[2][t.Day()], s.fields[4][int(t.Weekday())]
+	if s.eitherDay {
+		return dom || dow
+	}
+	return dom && dow
+}
@@ -0,0 +1,12 @@
+package models
+
+// Session is what doui was showing when it last exited, restored on the
+// next launch
+type Session struct {
+	View          string `json:"view"`                // Main view name, e.g. "images"
+	Container     string `json:"container,omitempty"` // Selected container name
+	StateFilter   string `json:"state_filter,omitempty"`
+	ProjectFilter string `json:"project_filter,omitempty"`
+	LabelFilter   string `json:"label_filter,omitempty"`
+	RuntimeColumn bool   `json:"runtime_column,omitempty"`
+}
@@ -0,0 +1,79 @@
+package models
+
+import (
+	"sort"
+	"time"
+)
+
+// ContainerSnapshot is a point-in-time capture of the container list,
+// used to compare against the current state (e.g. after a deployment)
+type ContainerSnapshot struct {
+	Taken      time.Time
+	Containers map[string]Container // Keyed by container name
+}
+
+// ContainerChange describes a container whose state, image or ID changed
+type ContainerChange struct {
+	Before Container
+	After  Container
+}
+
+// Recreated returns true if the container was replaced (same name, new ID)
+func (c ContainerChange) Recreated() bool {
+	return c.Before.ID != c.After.ID
+}
+
+// ContainerDiff is the difference between a snapshot and the current containers
+type ContainerDiff struct {
+	Added   []Container
+	Removed []Container
+	Changed []ContainerChange
+}
+
+// IsEmpty returns true if nothing changed
+func (d ContainerDiff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// NewContainerSnapshot captures the given containers
+func NewContainerSnapshot(containers []Container) *ContainerSnapshot {
+	snapshot := &ContainerSnapshot{
+		Taken:      time.Now(),
+		Containers: make(map[string]Container, len(containers)),
+	}
+	for _, c := range containers {
+		snapshot.Containers[c.Name] = c
+	}
+	return snapshot
+}
+
+// Diff compares the snapshot with the current containers
+// Containers are matched by name so a redeployed container shows as changed
+func (s *ContainerSnapshot) Diff(current []Container) ContainerDiff {
+	var diff ContainerDiff
+
+	seen := make(map[string]bool, len(current))
+	for _, c := range current {
+		seen[c.Name] = true
+		before, ok := s.Containers[c.Name]
+		if !ok {
+			diff.Added = append(diff.Added, c)
+			continue
+		}
+		if before.ID != c.ID || before.State != c.State || before.Image != c.Image {
+			diff.Changed = append(diff.Changed, ContainerChange{Before: before, After: c})
+		}
+	}
+
+	for name, c := range s.Containers {
+		if !seen[name] {
+			diff.Removed = append(diff.Removed, c)
+		}
+	}
+
+	sort.Slice(diff.Added, func(i, j int) bool { return diff.Added[i].Name < diff.Added[j].Name })
+	sort.Slice(diff.Removed, func(i, j int) bool { return diff.Removed[i].Name < diff.Removed[j].Name })
+	sort.Slice(diff.Changed, func(i, j int) bool { return diff.Changed[i].After.Name < diff.Changed[j].After.Name })
+
+	return diff
+}
@@ -1,5 +1,10 @@
 package models
 
+import (
+	"fmt"
+	"strings"
+)
+
 // ViewType represents different screens in the application
 type ViewType int
 
@@ -10,10 +15,18 @@ const (
 	ViewVolumes
 	ViewCompose
 	ViewNetworks
+	ViewPlugins
 	ViewLogs
 	ViewStats
 	ViewEnvVars
+	ViewComposeEnv
 	ViewAbout
+	ViewImageLabels
+	ViewGroupLogs
+	ViewVulnerabilities
+	ViewImageHistory
+	ViewComposeFile
+	ViewComposeDotEnv
 )
 
 // String returns the string representation of ViewType
@@ -31,19 +44,52 @@ func (v ViewType) String() string {
 		return "Compose"
 	case ViewNetworks:
 		return "Networks"
+	case ViewPlugins:
+		return "Plugins"
 	case ViewLogs:
 		return "Logs"
 	case ViewStats:
 		return "Stats"
 	case ViewEnvVars:
 		return "Environment Variables"
+	case ViewComposeEnv:
+		return "Compose Env"
 	case ViewAbout:
 		return "About"
+	case ViewImageLabels:
+		return "Image Labels"
+	case ViewGroupLogs:
+		return "Group Logs"
+	case ViewVulnerabilities:
+		return "Vulnerabilities"
+	case ViewImageHistory:
+		return "Image History"
+	case ViewComposeFile:
+		return "Compose File"
+	case ViewComposeDotEnv:
+		return "Compose .env"
 	default:
 		return "Unknown"
 	}
 }
 
+// mainViews are the views that can be opened from the sidebar, by name
+var mainViews = []ViewType{
+	ViewContainers, ViewImages, ViewGroups, ViewVolumes, ViewCompose, ViewNetworks, ViewPlugins, ViewAbout,
+}
+
+// ParseView returns the main view with the given name (e.g. "images")
+func ParseView(name string) (ViewType, error) {
+	names := make([]string, len(mainViews))
+	for i, v := range mainViews {
+		names[i] = strings.ToLower(v.String())
+		if strings.EqualFold(strings.TrimSpace(name), names[i]) {
+			return v, nil
+		}
+	}
+	return ViewContainers, fmt.Errorf("unknown view %q (expected one of %s)", name, strings.Join(names, ", "))
+}
+
 // GroupsTabType represents tabs within the Groups view
 type GroupsTabType int
 
@@ -62,6 +108,23 @@ const (
 	NetworksAvailableTab                         // Tab 3: Containers available to attach
 )
 
+// VolumesTabType represents tabs within the Volumes view
+type VolumesTabType int
+
+const (
+	VolumesListTab   VolumesTabType = iota // Tab 1: List of volumes
+	VolumesUsedByTab                       // Tab 2: Containers mounting the selected volume
+)
+
+// EnvVarsTabType represents tabs within the container config editor
+type EnvVarsTabType int
+
+const (
+	EnvVarsEnvTab    EnvVarsTabType = iota // Tab 1: Environment variables
+	EnvVarsLabelsTab                       // Tab 2: Container labels
+	EnvVarsPortsTab                        // Tab 3: Published ports
+)
+
 // AppState represents the global application state
 type AppState struct {
 	CurrentView       ViewType
@@ -1,6 +1,10 @@
 package models
 
-import "time"
+import (
+	"fmt"
+	"strings"
+	"time"
+)
 
 // Volume represents a Docker volume
 type Volume struct {
@@ -20,8 +24,33 @@ type VolumeUsageData struct {
 	Size     int64 // Size in bytes (-1 if unavailable)
 }
 
-// GetShortName returns the volume name truncated if too long
+// anonymousVolumeLabel marks volumes the daemon created for a container
+// (Engine 23+), e.g. for a VOLUME of its image
+const anonymousVolumeLabel = "com.docker.volume.anonymous"
+
+// IsAnonymous returns true for volumes created without a name, which get a
+// 64 character hex hash as name
+func (v *Volume) IsAnonymous() bool {
+	if _, ok := v.Labels[anonymousVolumeLabel]; ok {
+		return true
+	}
+	if len(v.Name) != 64 {
+		return false
+	}
+	for _, r := range v.Name {
+		if !strings.ContainsRune("0123456789abcdef", r) {
+			return false
+		}
+	}
+	return true
+}
+
+// GetShortName returns the volume name truncated if too long, or the first
+// 12 characters of an anonymous volume's hash, like short IDs
 func (v *Volume) GetShortName() string {
+	if v.IsAnonymous() && len(v.Name) > 12 {
+		return v.Name[:12]
+	}
 	if len(v.Name) > 40 {
 		return v.Name[:37] + "..."
 	}
@@ -40,3 +69,31 @@ func (v *Volume) GetDriver() string {
 	}
 	return v.Driver
 }
+
+// ArchiveName suggests a file name for backing up the volume, e.g.
+// "pgdata-20261016.tar.gz"
+func (v *Volume) ArchiveName(now time.Time) string {
+	return fmt.Sprintf("%s-%s.tar.gz", v.Name, now.Format("20060102"))
+}
+
+// ParseKeyValues parses whitespace separated key=value pairs, as typed for
+// labels or driver options. Values may be quoted and contain '=' or ','
+// (e.g. o=addr=10.0.0.2,rw).
+func ParseKeyValues(text string) (map[string]string, error) {
+	items, err := SplitCommand(text)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, nil
+	}
+	result := make(map[string]string, len(items))
+	for _, item := range items {
+		k, v, ok := strings.Cut(item, "=")
+		if !ok || strings.TrimSpace(k) == "" {
+			return nil, fmt.Errorf("%q is not key=value", item)
+		}
+		result[k] = v
+	}
+	return result, nil
+}
@@ -0,0 +1,190 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Severities are the severity levels of findings, most severe first
+var Severities = []string{"CRITICAL", "HIGH", "MEDIUM", "LOW", "UNKNOWN"}
+
+// Vulnerability is a finding of an image scanner in one package
+type Vulnerability struct {
+	ID        string // e.g. CVE-2024-1234 or GHSA-...
+	Severity  string // One of Severities
+	Package   string
+	Installed string
+	Fix       string // Fixed version (or the scanner's advice), "" if there is none yet
+	Title     string
+}
+
+// VulnScan is the result of scanning an image for known vulnerabilities
+type VulnScan struct {
+	Image     string // Reference that was scanned
+	Scanner   string // "trivy" or "docker scout"
+	Findings  []Vulnerability
+	ScannedAt time.Time
+}
+
+// NormalizeSeverity maps a scanner's severity (e.g. "High", "negligible") to
+// one of Severities
+func NormalizeSeverity(severity string) string {
+	severity = strings.ToUpper(strings.TrimSpace(severity))
+	switch severity {
+	case "NEGLIGIBLE", "INFO":
+		return "LOW"
+	}
+	if slices.Contains(Severities, severity) {
+		return severity
+	}
+	return "UNKNOWN"
+}
+
+// Counts returns the number of findings per severity
+func (s *VulnScan) Counts() map[string]int {
+	counts := make(map[string]int, len(Severities))
+	for _, v := range s.Findings {
+		counts[v.Severity]++
+	}
+	return counts
+}
+
+// Fixable returns how many findings have a fix available
+func (s *VulnScan) Fixable() int {
+	n := 0
+	for _, v := range s.Findings {
+		if v.Fix != "" {
+			n++
+		}
+	}
+	return n
+}
+
+// Summary is a one line count of the findings, e.g. "2 critical, 5 high"
+func (s *VulnScan) Summary() string {
+	if len(s.Findings) == 0 {
+		return "no known vulnerabilities"
+	}
+	counts := s.Counts()
+	var parts []string
+	for _, severity := range Severities {
+		if counts[severity] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[severity], strings.ToLower(severity)))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// sortFindings orders findings by severity, then ID and package, dropping
+// duplicates (scanners report a package once per file it was found in)
+func sortFindings(findings []Vulnerability) []Vulnerability {
+	slices.SortStableFunc(findings, func(a, b Vulnerability) int {
+		if c := slices.Index(Severities, a.Severity) - slices.Index(Severities, b.Severity); c != 0 {
+			return c
+		}
+		if c := strings.Compare(a.ID, b.ID); c != 0 {
+			return c
+		}
+		if c := strings.Compare(a.Package, b.Package); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Installed, b.Installed)
+	})
+	return slices.CompactFunc(findings, func(a, b Vulnerability) bool {
+		return a.ID == b.ID && a.Package == b.Package && a.Installed == b.Installed
+	})
+}
+
+// trivyReport is the part of `trivy image --format json` about vulnerabilities
+type trivyReport struct {
+	Results []struct {
+		Vulnerabilities []struct {
+			VulnerabilityID  string `json:"VulnerabilityID"`
+			PkgName          string `json:"PkgName"`
+			InstalledVersion string `json:"InstalledVersion"`
+			FixedVersion     string `json:"FixedVersion"`
+			Severity         string `json:"Severity"`
+			Title            string `json:"Title"`
+		} `json:"Vulnerabilities"`
+	} `json:"Results"`
+}
+
+// ParseTrivyReport parses the JSON report of `trivy image --format json`
+func ParseTrivyReport(data []byte) ([]Vulnerability, error) {
+	var report trivyReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("failed to parse trivy report: %w", err)
+	}
+	var findings []Vulnerability
+	for _, result := range report.Results {
+		for _, v := range result.Vulnerabilities {
+			findings = append(findings, Vulnerability{
+				ID:        v.VulnerabilityID,
+				Severity:  NormalizeSeverity(v.Severity),
+				Package:   v.PkgName,
+				Installed: v.InstalledVersion,
+				Fix:       v.FixedVersion,
+				Title:     v.Title,
+			})
+		}
+	}
+	return sortFindings(findings), nil
+}
+
+// scoutReport is the part of `docker scout cves --format gitlab` (GitLab's
+// container scanning report) about vulnerabilities
+type scoutReport struct {
+	Vulnerabilities []struct {
+		ID          string `json:"id"`
+		Name        string `json:"name"`
+		Description string `json:"description"`
+		Severity    string `json:"severity"`
+		Solution    string `json:"solution"`
+		Identifiers []struct {
+			Value string `json:"value"`
+		} `json:"identifiers"`
+		Location struct {
+			Dependency struct {
+				Package struct {
+					Name string `json:"name"`
+				} `json:"package"`
+				Version string `json:"version"`
+			} `json:"dependency"`
+		} `json:"location"`
+	} `json:"vulnerabilities"`
+}
+
+// ParseScoutReport parses the report of `docker scout cves --format gitlab`
+func ParseScoutReport(data []byte) ([]Vulnerability, error) {
+	var report scoutReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("failed to parse docker scout report: %w", err)
+	}
+	var findings []Vulnerability
+	for _, v := range report.Vulnerabilities {
+		id := v.ID
+		if len(v.Identifiers) > 0 && v.Identifiers[0].Value != "" {
+			id = v.Identifiers[0].Value
+		}
+		fix := v.Solution
+		if strings.Contains(strings.ToLower(fix), "no solution") {
+			fix = ""
+		}
+		title := v.Name
+		if title == "" || title == id {
+			title, _, _ = strings.Cut(v.Description, "\n")
+		}
+		findings = append(findings, Vulnerability{
+			ID:        id,
+			Severity:  NormalizeSeverity(v.Severity),
+			Package:   v.Location.Dependency.Package.Name,
+			Installed: v.Location.Dependency.Version,
+			Fix:       fix,
+			Title:     title,
+		})
+	}
+	return sortFindings(findings), nil
+}
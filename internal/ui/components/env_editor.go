@@ -1,13 +1,16 @@
 package components
 
 import (
+	"fmt"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/rizface/doui/internal/models"
+	"github.com/rizface/doui/internal/ui/keys"
 	"github.com/rizface/doui/internal/ui/styles"
 )
 
@@ -54,6 +57,15 @@ type EnvEditor struct {
 
 	// State
 	modified bool
+
+	// Display names (env vars, labels or ports)
+	title    string
+	itemName string
+
+	// Optional helper that fills the value field (Ctrl+F in add/edit mode)
+	suggestValue func() (string, error)
+	suggestHint  string
+	suggestErr   string
 }
 
 // NewEnvEditor creates a new environment variable editor
@@ -88,6 +100,8 @@ func NewEnvEditor(envVars []models.EnvVar) *EnvEditor {
 		keyInput:   keyInput,
 		valueInput: valueInput,
 		editIndex:  -1,
+		title:      "Environment Variables",
+		itemName:   "Environment Variable",
 	}
 
 	copy(editor.envVars, envVars)
@@ -97,6 +111,35 @@ func NewEnvEditor(envVars []models.EnvVar) *EnvEditor {
 	return editor
 }
 
+// NewLabelEditor creates an editor for container labels
+func NewLabelEditor(labels []models.EnvVar) *EnvEditor {
+	editor := NewEnvEditor(labels)
+	editor.title = "Labels"
+	editor.itemName = "Label"
+	editor.list.Title = "Labels"
+	editor.keyInput.Placeholder = "com.example.label"
+	editor.keyInput.CharLimit = 200
+	return editor
+}
+
+// NewPortEditor creates an editor for published ports
+// Keys are container ports ("80/tcp"), values host bindings ("8080" or "127.0.0.1:8080")
+func NewPortEditor(ports []models.EnvVar) *EnvEditor {
+	editor := NewEnvEditor(ports)
+	editor.title = "Ports"
+	editor.itemName = "Port Binding"
+	editor.list.Title = "Ports"
+	editor.keyInput.Placeholder = "80/tcp"
+	editor.valueInput.Placeholder = "8080 or 127.0.0.1:8080"
+	return editor
+}
+
+// SetValueSuggester sets a helper that fills the value field on Ctrl+F
+func (e *EnvEditor) SetValueSuggester(hint string, suggest func() (string, error)) {
+	e.suggestHint = hint
+	e.suggestValue = suggest
+}
+
 func (e *EnvEditor) updateList() {
 	items := make([]list.Item, len(e.envVars))
 	for i, ev := range e.envVars {
@@ -136,28 +179,30 @@ func (e *EnvEditor) Update(msg tea.Msg) (*EnvEditor, tea.Cmd) {
 func (e *EnvEditor) updateListMode(msg tea.Msg) (*EnvEditor, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "a", "n":
+		switch {
+		case key.Matches(msg, keys.Map.EditorAdd):
 			// Add new env var
 			e.mode = EnvModeAdd
 			e.editIndex = -1
+			e.suggestErr = ""
 			e.keyInput.SetValue("")
 			e.valueInput.SetValue("")
 			e.keyInput.Focus()
 			return e, nil
 
-		case "e", "enter":
+		case key.Matches(msg, keys.Map.EditorEdit):
 			// Edit selected
 			if len(e.envVars) > 0 && e.list.Index() < len(e.envVars) {
 				e.mode = EnvModeEdit
 				e.editIndex = e.list.Index()
+				e.suggestErr = ""
 				e.keyInput.SetValue(e.envVars[e.editIndex].Key)
 				e.valueInput.SetValue(e.envVars[e.editIndex].Value)
 				e.keyInput.Focus()
 			}
 			return e, nil
 
-		case "d", "delete":
+		case key.Matches(msg, keys.Map.EditorDelete):
 			// Delete selected
 			if len(e.envVars) > 0 && e.list.Index() < len(e.envVars) {
 				idx := e.list.Index()
@@ -204,6 +249,19 @@ func (e *EnvEditor) updateEditMode(msg tea.Msg) (*EnvEditor, tea.Cmd) {
 			e.mode = EnvModeList
 			return e, nil
 
+		case "ctrl+f":
+			// Fill the value using the suggester (e.g. a free host port)
+			if e.suggestValue != nil {
+				value, err := e.suggestValue()
+				if err != nil {
+					e.suggestErr = err.Error()
+				} else {
+					e.suggestErr = ""
+					e.valueInput.SetValue(value)
+				}
+			}
+			return e, nil
+
 		case "tab":
 			// Switch between key and value inputs
 			if e.keyInput.Focused() {
@@ -234,19 +292,19 @@ func (e *EnvEditor) View() string {
 	switch e.mode {
 	case EnvModeList:
 		if len(e.envVars) == 0 {
-			b.WriteString(styles.TitleStyle.Render("Environment Variables"))
+			b.WriteString(styles.TitleStyle.Render(e.title))
 			b.WriteString("\n\n")
-			b.WriteString(styles.SubtitleStyle.Render("No environment variables defined."))
+			b.WriteString(styles.SubtitleStyle.Render(fmt.Sprintf("No %s defined.", strings.ToLower(e.title))))
 			b.WriteString("\n\n")
-			b.WriteString(styles.DescStyle.Render("Press 'a' to add a new variable."))
+			b.WriteString(styles.DescStyle.Render(fmt.Sprintf("Press '%s' to add a new %s.", keys.Label(keys.Map.EditorAdd), strings.ToLower(e.itemName))))
 		} else {
 			b.WriteString(e.list.View())
 		}
 
 	case EnvModeAdd, EnvModeEdit:
-		title := "Add Environment Variable"
+		title := "Add " + e.itemName
 		if e.mode == EnvModeEdit {
-			title = "Edit Environment Variable"
+			title = "Edit " + e.itemName
 		}
 		b.WriteString(styles.TitleStyle.Render(title))
 		b.WriteString("\n\n")
@@ -269,7 +327,16 @@ func (e *EnvEditor) View() string {
 		b.WriteString(e.valueInput.View())
 		b.WriteString("\n\n")
 
-		b.WriteString(styles.DescStyle.Render("Tab: Switch field • Enter: Save • Esc: Cancel"))
+		if e.suggestErr != "" {
+			b.WriteString(styles.ErrorStyle.Render(e.suggestErr))
+			b.WriteString("\n\n")
+		}
+
+		hint := "Tab: Switch field • Enter: Save • Esc: Cancel"
+		if e.suggestValue != nil {
+			hint += " • Ctrl+F: " + e.suggestHint
+		}
+		b.WriteString(styles.DescStyle.Render(hint))
 	}
 
 	// Wrap in a container
@@ -287,9 +354,9 @@ func (e *EnvEditor) GetHelpText() string {
 
 	helps := []string{
 		styles.KeyStyle.Render("↑/↓") + " navigate",
-		styles.KeyStyle.Render("a") + " add",
-		styles.KeyStyle.Render("e") + " edit",
-		styles.KeyStyle.Render("d") + " delete",
+		styles.KeyStyle.Render(keys.Label(keys.Map.EditorAdd)) + " add",
+		styles.KeyStyle.Render(keys.Label(keys.Map.EditorEdit)) + " edit",
+		styles.KeyStyle.Render(keys.Label(keys.Map.EditorDelete)) + " delete",
 		styles.KeyStyle.Render("/") + " filter",
 	}
 
@@ -1,9 +1,6 @@
 package components
 
-import (
-	"github.com/charmbracelet/lipgloss"
-	"github.com/rizface/doui/internal/ui/styles"
-)
+import "github.com/rizface/doui/internal/ui/styles"
 
 // Header represents the application header
 type Header struct {
@@ -22,11 +19,9 @@ func (h *Header) SetSize(width int) {
 
 // View renders the header
 func (h *Header) View(title string) string {
-	headerStyle := lipgloss.NewStyle().
+	headerStyle := styles.Highlight(styles.ColorPrimary).
 		Width(h.width).
 		Bold(true).
-		Foreground(lipgloss.Color("#FFFFFF")).
-		Background(styles.ColorPrimary).
 		Padding(0, 2)
 
 	return headerStyle.Render("🐳 " + title)
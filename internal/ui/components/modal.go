@@ -15,6 +15,8 @@ type ModalType int
 const (
 	ModalConfirm ModalType = iota
 	ModalForm
+	ModalMenu
+	ModalInfo
 )
 
 // Modal represents a modal dialog
@@ -33,6 +35,10 @@ type Modal struct {
 	inputs         []textinput.Model
 	focusIndex     int
 	requiredFields []bool // true if field is required
+
+	// For menu modals
+	options        []string
+	selectedOption int
 }
 
 // NewConfirmModal creates a new confirmation modal
@@ -94,6 +100,29 @@ func NewFormModalWithOptional(title string, fieldLabels []string, optionalFields
 	}
 }
 
+// NewMenuModal creates a new modal for picking one of several options
+func NewMenuModal(title string, options []string) *Modal {
+	return &Modal{
+		visible:     true,
+		modalType:   ModalMenu,
+		title:       title,
+		confirmText: "Select",
+		cancelText:  "Cancel",
+		options:     options,
+	}
+}
+
+// NewInfoModal creates a read-only modal that just displays a message
+func NewInfoModal(title, message string) *Modal {
+	return &Modal{
+		visible:     true,
+		modalType:   ModalInfo,
+		title:       title,
+		message:     message,
+		confirmText: "Close",
+	}
+}
+
 // Show shows the modal
 func (m *Modal) Show() {
 	m.visible = true
@@ -123,6 +152,58 @@ func (m *Modal) GetInputValues() []string {
 	return values
 }
 
+// GetSelectedOption returns the option picked in a menu modal
+func (m *Modal) GetSelectedOption() string {
+	if m.selectedOption < len(m.options) {
+		return m.options[m.selectedOption]
+	}
+	return ""
+}
+
+// GetSelectedIndex returns the index of the option picked in a menu modal
+func (m *Modal) GetSelectedIndex() int {
+	return m.selectedOption
+}
+
+// SetSelectedIndex moves the cursor of a menu modal to the option at index
+func (m *Modal) SetSelectedIndex(index int) {
+	if index >= 0 && index < len(m.options) {
+		m.selectedOption = index
+	}
+}
+
+// SetInputValues pre-fills form inputs (e.g. with current values when editing)
+func (m *Modal) SetInputValues(values []string) {
+	for i := range m.inputs {
+		if i < len(values) {
+			m.inputs[i].SetValue(values[i])
+		}
+	}
+}
+
+// SetPasswordField hides what is typed in the index-th field of a form
+func (m *Modal) SetPasswordField(index int) {
+	if index >= 0 && index < len(m.inputs) {
+		m.inputs[index].EchoMode = textinput.EchoPassword
+		m.inputs[index].EchoCharacter = '•'
+	}
+}
+
+// SetMessage replaces the modal message (e.g. to update a progress list)
+func (m *Modal) SetMessage(message string) {
+	m.message = message
+}
+
+// SetConfirmText sets the label of the confirm button
+func (m *Modal) SetConfirmText(text string) {
+	m.confirmText = text
+}
+
+// SetCancelText sets the text of the cancel button of a confirm modal
+func (m *Modal) SetCancelText(text string) {
+	m.cancelText = text
+}
+
 // Update handles messages
 func (m *Modal) Update(msg tea.Msg) (*Modal, tea.Cmd) {
 	if !m.visible {
@@ -133,10 +214,13 @@ func (m *Modal) Update(msg tea.Msg) (*Modal, tea.Cmd) {
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "enter":
-			if m.modalType == ModalConfirm {
+			if m.modalType == ModalConfirm || m.modalType == ModalMenu {
 				m.confirmed = true
 				m.visible = false
 				return m, nil
+			} else if m.modalType == ModalInfo {
+				m.visible = false
+				return m, nil
 			} else if m.modalType == ModalForm {
 				// Only confirm if all required fields are filled
 				allFilled := true
@@ -178,6 +262,14 @@ func (m *Modal) Update(msg tea.Msg) (*Modal, tea.Cmd) {
 			// For form modals, let 'n' pass through to the text input
 
 		case "tab", "shift+tab", "up", "down":
+			if m.modalType == ModalMenu {
+				// Move the menu cursor
+				if msg.String() == "tab" || msg.String() == "down" {
+					m.selectedOption = (m.selectedOption + 1) % len(m.options)
+				} else {
+					m.selectedOption = (m.selectedOption - 1 + len(m.options)) % len(m.options)
+				}
+			}
 			if m.modalType == ModalForm {
 				// Navigate between inputs
 				if msg.String() == "tab" || msg.String() == "down" {
@@ -233,15 +325,11 @@ func (m *Modal) View() string {
 		content.WriteString("\n\n")
 
 		// Buttons
-		confirmBtn := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFFFFF")).
-			Background(styles.ColorSuccess).
+		confirmBtn := styles.Highlight(styles.ColorSuccess).
 			Padding(0, 2).
 			Render(m.confirmText)
 
-		cancelBtn := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFFFFF")).
-			Background(styles.ColorMuted).
+		cancelBtn := styles.Highlight(styles.ColorMuted).
 			Padding(0, 2).
 			Render(m.cancelText)
 
@@ -258,21 +346,42 @@ func (m *Modal) View() string {
 		content.WriteString("\n\n")
 
 		// Buttons
-		confirmBtn := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFFFFF")).
-			Background(styles.ColorPrimary).
+		confirmBtn := styles.Highlight(styles.ColorPrimary).
 			Padding(0, 2).
 			Render(m.confirmText)
 
-		cancelBtn := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFFFFF")).
-			Background(styles.ColorMuted).
+		cancelBtn := styles.Highlight(styles.ColorMuted).
 			Padding(0, 2).
 			Render(m.cancelText)
 
 		content.WriteString(confirmBtn + "  " + cancelBtn)
 		content.WriteString("\n\n")
 		content.WriteString(styles.DescStyle.Render("Tab: Next field • Enter: Submit • Esc: Cancel"))
+
+	case ModalInfo:
+		content.WriteString(m.message)
+		content.WriteString("\n\n")
+
+		closeBtn := styles.Highlight(styles.ColorPrimary).
+			Padding(0, 2).
+			Render(m.confirmText)
+
+		content.WriteString(closeBtn)
+
+	case ModalMenu:
+		// Render options with a cursor on the selected one
+		for i, option := range m.options {
+			if i == m.selectedOption {
+				content.WriteString(styles.KeyStyle.Render("> " + option))
+			} else {
+				content.WriteString("  " + option)
+			}
+			if i < len(m.options)-1 {
+				content.WriteString("\n")
+			}
+		}
+		content.WriteString("\n\n")
+		content.WriteString(styles.DescStyle.Render("↑/↓: Select • Enter: " + m.confirmText + " • Esc: Cancel"))
 	}
 
 	// Wrap in modal style
@@ -0,0 +1,175 @@
+package components
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rizface/doui/internal/ui/styles"
+	"github.com/sahilm/fuzzy"
+)
+
+// PaletteItem is one entry of the command palette
+type PaletteItem struct {
+	Title string // Matched against the query
+	Hint  string // Shown dimmed next to the title (e.g. the key)
+}
+
+// Palette is a fuzzy-searchable list of commands
+type Palette struct {
+	visible bool
+	input   textinput.Model
+	items   []PaletteItem
+	matches []int // Indices into items, best match first
+	cursor  int
+	chosen  int
+	width   int
+	height  int
+}
+
+// NewPalette creates a visible command palette listing items
+func NewPalette(items []PaletteItem) *Palette {
+	ti := textinput.New()
+	ti.Placeholder = "Type a command..."
+	ti.Prompt = "> "
+	ti.CharLimit = 100
+	ti.Width = 50
+	ti.Focus()
+
+	p := &Palette{
+		visible: true,
+		input:   ti,
+		items:   items,
+		chosen:  -1,
+	}
+	p.filter()
+	return p
+}
+
+// IsVisible returns whether the palette is open
+func (p *Palette) IsVisible() bool {
+	return p.visible
+}
+
+// Chosen returns the index of the item picked with enter, or -1 when the
+// palette was closed without picking one
+func (p *Palette) Chosen() int {
+	return p.chosen
+}
+
+// SetSize sets the palette dimensions for centering
+func (p *Palette) SetSize(width, height int) {
+	p.width = width
+	p.height = height
+}
+
+// filter updates the matches for the current query
+func (p *Palette) filter() {
+	p.cursor = 0
+	query := strings.TrimSpace(p.input.Value())
+	p.matches = p.matches[:0]
+	if query == "" {
+		for i := range p.items {
+			p.matches = append(p.matches, i)
+		}
+		return
+	}
+
+	titles := make([]string, len(p.items))
+	for i, item := range p.items {
+		titles[i] = item.Title
+	}
+	for _, m := range fuzzy.Find(query, titles) {
+		p.matches = append(p.matches, m.Index)
+	}
+}
+
+// Update handles messages
+func (p *Palette) Update(msg tea.Msg) (*Palette, tea.Cmd) {
+	if !p.visible {
+		return p, nil
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "esc", "ctrl+c":
+			p.visible = false
+			return p, nil
+		case "enter":
+			if len(p.matches) > 0 {
+				p.chosen = p.matches[p.cursor]
+			}
+			p.visible = false
+			return p, nil
+		case "up", "ctrl+k", "shift+tab":
+			if p.cursor > 0 {
+				p.cursor--
+			}
+			return p, nil
+		case "down", "ctrl+j", "tab":
+			if p.cursor < len(p.matches)-1 {
+				p.cursor++
+			}
+			return p, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	before := p.input.Value()
+	p.input, cmd = p.input.Update(msg)
+	if p.input.Value() != before {
+		p.filter()
+	}
+	return p, cmd
+}
+
+// View renders the palette
+func (p *Palette) View() string {
+	if !p.visible {
+		return ""
+	}
+
+	var content strings.Builder
+	content.WriteString(styles.TitleStyle.Render("Command Palette"))
+	content.WriteString("\n")
+	content.WriteString(p.input.View())
+	content.WriteString("\n\n")
+
+	// Keep the cursor in the window of visible results
+	maxResults := max(3, p.height-14)
+	start := 0
+	if p.cursor >= maxResults {
+		start = p.cursor - maxResults + 1
+	}
+	end := min(len(p.matches), start+maxResults)
+
+	if len(p.matches) == 0 {
+		content.WriteString(styles.DescStyle.Render("No matching command"))
+	}
+	maxWidth := max(20, p.width-14)
+	for i := start; i < end; i++ {
+		item := p.items[p.matches[i]]
+		line := "  " + item.Title
+		if i == p.cursor {
+			line = styles.KeyStyle.Render("> " + item.Title)
+		}
+		if item.Hint != "" {
+			line += "  " + styles.DescStyle.Render(item.Hint)
+		}
+		content.WriteString(lipgloss.NewStyle().MaxWidth(maxWidth).Render(line))
+		if i < end-1 {
+			content.WriteString("\n")
+		}
+	}
+	content.WriteString("\n\n")
+	content.WriteString(styles.DescStyle.Render("↑/↓: Select • Enter: Run • Esc: Cancel"))
+
+	return lipgloss.Place(
+		p.width,
+		p.height,
+		lipgloss.Center,
+		lipgloss.Center,
+		styles.ModalStyle.Render(content.String()),
+	)
+}
@@ -1,18 +1,27 @@
 package components
 
 import (
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/rizface/doui/internal/models"
 	"github.com/rizface/doui/internal/ui/styles"
 )
 
+// slowLatency is the round trip from which the latency shows as a warning
+const slowLatency = 500 * time.Millisecond
+
 // Sidebar represents the left sidebar with tabs
 type Sidebar struct {
 	width        int
 	height       int
 	currentView  models.ViewType
+	disconnected bool
+	contextName  string
+	idle         bool
+	latency      time.Duration // Round trip to a remote daemon, 0 if unknown
 }
 
 // NewSidebar creates a new sidebar
@@ -34,6 +43,26 @@ func (s *Sidebar) SetCurrentView(view models.ViewType) {
 	s.currentView = view
 }
 
+// SetContext sets the name of the docker context doui is connected to
+func (s *Sidebar) SetContext(name string) {
+	s.contextName = name
+}
+
+// SetDisconnected marks the Docker daemon as unreachable
+func (s *Sidebar) SetDisconnected(disconnected bool) {
+	s.disconnected = disconnected
+}
+
+// SetLatency sets the measured round trip to a remote daemon, 0 hides it
+func (s *Sidebar) SetLatency(latency time.Duration) {
+	s.latency = latency
+}
+
+// SetIdle marks auto-refresh as slowed down because there's been no input
+func (s *Sidebar) SetIdle(idle bool) {
+	s.idle = idle
+}
+
 // View renders the sidebar
 func (s *Sidebar) View() string {
 	var b strings.Builder
@@ -43,10 +72,10 @@ func (s *Sidebar) View() string {
 		text  string
 		color lipgloss.Color
 	}{
-		{"    _            _", lipgloss.Color("#06B6D4")},  // Cyan
-		{" __| | ___  _  _(_)", lipgloss.Color("#3B82F6")}, // Blue
-		{"/ _` |/ _ \\| || | |", lipgloss.Color("#8B5CF6")}, // Purple
-		{"\\__,_|\\___/ \\_,_|_|", lipgloss.Color("#EC4899")}, // Pink
+		{"    _            _", styles.Current.Logo[0]},
+		{" __| | ___  _  _(_)", styles.Current.Logo[1]},
+		{"/ _` |/ _ \\| || | |", styles.Current.Logo[2]},
+		{"\\__,_|\\___/ \\_,_|_|", styles.Current.Logo[3]},
 	}
 
 	for _, line := range logoLines {
@@ -58,6 +87,29 @@ func (s *Sidebar) View() string {
 	}
 	b.WriteString("\n")
 
+	// Docker context
+	if s.contextName != "" {
+		b.WriteString(styles.DescStyle.Render("ctx: " + s.contextName))
+		b.WriteString("\n")
+	}
+	if s.latency > 0 && !s.disconnected {
+		style := styles.SuccessStyle
+		if s.latency >= slowLatency {
+			style = styles.WarningStyle
+		}
+		b.WriteString(style.Render(fmt.Sprintf("● %s", s.latency.Round(time.Millisecond))))
+		b.WriteString("\n")
+	}
+
+	// Connection state
+	if s.disconnected {
+		b.WriteString(styles.ErrorStyle.Render("● disconnected"))
+		b.WriteString("\n\n")
+	} else if s.idle {
+		b.WriteString(styles.DescStyle.Render("◌ idle, refresh slowed"))
+		b.WriteString("\n\n")
+	}
+
 	// Tabs
 	tabs := []struct {
 		view  models.ViewType
@@ -69,6 +121,7 @@ func (s *Sidebar) View() string {
 		{models.ViewVolumes, "Volumes"},
 		{models.ViewCompose, "Compose"},
 		{models.ViewNetworks, "Networks"},
+		{models.ViewPlugins, "Plugins"},
 	}
 
 	for _, tab := range tabs {
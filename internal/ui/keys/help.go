@@ -0,0 +1,243 @@
+package keys
+
+import "strings"
+
+// HelpEntry is one line of the help overlay
+type HelpEntry struct {
+	Keys   string // All keys bound to the action, e.g. "q/ctrl+c"
+	Action string // Action name used in the config file
+	Desc   string
+}
+
+// HelpSection groups the help entries of one view
+type HelpSection struct {
+	Title   string
+	Entries []HelpEntry
+}
+
+// helpSections lists the actions shown in the help overlay by view, with
+// what they do there. Keys are looked up in the key map when rendering.
+var helpSections = []struct {
+	title   string
+	actions [][2]string // action name, description
+}{
+	{"Global", [][2]string{
+		{"help", "show this help"},
+		{"command_palette", "command palette"},
+		{"quit", "quit (back from logs, stats and about)"},
+		{"back", "back / close"},
+		{"refresh", "refresh now"},
+		{"next_view", "next view"},
+		{"prev_view", "previous view"},
+		{"view_containers", "go to containers"},
+		{"view_images", "go to images"},
+		{"view_groups", "go to groups"},
+		{"view_volumes", "go to volumes"},
+		{"view_compose", "go to compose projects"},
+		{"view_networks", "go to networks"},
+		{"view_plugins", "go to plugins"},
+		{"view_about", "go to about"},
+		{"switch_context", "switch docker context"},
+		{"run_results", "results of one-shot runs"},
+		{"quick_action", "run the quick-action bar's 1st...5th action"},
+		{"group_hotkey", "then a group's hotkey: start it, or stop it if running"},
+		{"audit_log", "audit log of changes made with doui (selected container's only in containers)"},
+		{"retry", "retry connecting (when the daemon is unreachable at start)"},
+	}},
+	{"Containers", [][2]string{
+		{"start", "start"},
+		{"stop", "stop"},
+		{"restart", "restart"},
+		{"delete", "remove"},
+		{"logs", "logs"},
+		{"tail_file", "tail a file inside the container"},
+		{"stats", "stats"},
+		{"shell", "shell"},
+		{"edit_config", "edit env vars, labels and ports"},
+		{"edit_cpuset", "edit cpuset"},
+		{"copy_id", "copy ID"},
+		{"copy_command", "copy docker run command"},
+		{"filter_running", "show running only"},
+		{"filter_exited", "show exited only"},
+		{"filter_project", "filter by compose project"},
+		{"filter_label", "filter by label"},
+		{"clear_filters", "clear filters"},
+		{"snapshot", "snapshot container states"},
+		{"snapshot_diff", "diff since snapshot"},
+		{"port_check", "check published ports"},
+		{"clock_check", "check the container's clock for drift"},
+		{"watch", "watch: notify when it exits or becomes unhealthy"},
+		{"inspect", "runtime, platform and privileges"},
+		{"runtime_column", "show runtime/platform/privileged in the list"},
+	}},
+	{"Images", [][2]string{
+		{"select", "containers created from the image"},
+		{"toggle_select", "select for bulk remove or compare"},
+		{"compare_images", "compare the two selected images (layers, size, config)"},
+		{"delete", "remove"},
+		{"pull_image", "pull (optionally another platform)"},
+		{"pull_list", "pull every image listed in a file"},
+		{"inspect", "platforms, provenance and signatures"},
+		{"image_labels", "labels and OCI annotations"},
+		{"image_history", "layer history: the size of each build step"},
+		{"tag_image", "tag or rename (retag)"},
+		{"prune_images", "prune dangling or all unused images"},
+		{"protect_image", "protect from remove and prune (or unprotect)"},
+		{"quick_run", "run a container in the background (name, ports)"},
+		{"run_once", "run once (like docker run --rm) and show the results"},
+		{"save_image", "save to a tar archive (docker save)"},
+		{"load_image", "load images from a tar archive (docker load)"},
+		{"scan_image", "scan for vulnerabilities (trivy or docker scout)"},
+		{"registry_login", "log in to a registry (higher Docker Hub pull limits)"},
+		{"copy_id", "copy tag"},
+		{"filter_usage", "filter: only dangling / unused / in use (cycles)"},
+		{"clear_filters", "clear filter"},
+	}},
+	{"Groups", [][2]string{
+		{"select", "open group / add container"},
+		{"logs", "logs dashboard of the group / container logs"},
+		{"new", "new group"},
+		{"edit_config", "edit group name, description, hotkey, included groups, schedules and color"},
+		{"start", "start all (ordered) / start container"},
+		{"stop", "stop all / stop container"},
+		{"restart", "restart all / restart container"},
+		{"ordered_stop", "ordered stop"},
+		{"start_order", "edit start order"},
+		{"group_env", "set env var on all containers"},
+		{"export_compose", "export as a compose file"},
+		{"unlink", "remove container from group"},
+		{"delete", "delete group / container"},
+		{"prev_tab", "previous tab"},
+		{"next_tab", "next tab"},
+	}},
+	{"Volumes", [][2]string{
+		{"select", "containers using the volume"},
+		{"new", "create a volume"},
+		{"delete", "remove volume / container"},
+		{"prune_volumes", "prune unused volumes"},
+		{"prune_anonymous", "remove unused anonymous volumes"},
+		{"backup_volume", "back up to a tar archive"},
+		{"restore_volume", "restore from a tar archive"},
+		{"volume_sizes", "measure sizes, biggest first"},
+		{"copy_id", "copy name / container ID"},
+		{"prev_tab", "previous tab"},
+		{"next_tab", "next tab"},
+	}},
+	{"Compose", [][2]string{
+		{"select", "view services"},
+		{"new", "compose up from a compose file"},
+		{"delete", "compose down (optionally volumes and images)"},
+		{"start", "start all"},
+		{"stop", "stop all"},
+		{"restart", "restart all"},
+		{"logs", "merged logs of all services"},
+		{"pull_image", "pull the images of all services"},
+		{"env_matrix", "env var matrix"},
+		{"check_config", "check project config"},
+		{"compose_file", "view the compose files"},
+		{"edit_config", "edit the .env file"},
+		{"edit_compose_file", "edit the compose file in $EDITOR, then apply"},
+		{"recreate_changed", "recreate services whose config changed"},
+		{"recreate_project", "force-recreate every container"},
+		{"copy_id", "copy project name"},
+	}},
+	{"Networks", [][2]string{
+		{"select", "open network / connect container"},
+		{"inspect", "network details: IPAM, options, endpoints"},
+		{"new", "new network"},
+		{"new_macvlan", "macvlan/ipvlan network wizard"},
+		{"prune_networks", "prune unused networks"},
+		{"delete", "delete network"},
+		{"unlink", "disconnect container"},
+		{"edit_aliases", "edit the container's DNS aliases on the network"},
+		{"copy_id", "copy ID / IP"},
+		{"prev_tab", "previous tab"},
+		{"next_tab", "next tab"},
+	}},
+	{"Plugins", [][2]string{
+		{"start", "enable"},
+		{"stop", "disable"},
+		{"delete", "remove"},
+		{"copy_id", "copy name"},
+	}},
+	{"Logs and stats", [][2]string{
+		{"follow", "toggle follow"},
+		{"top", "top"},
+		{"bottom", "bottom"},
+		{"search", "search"},
+		{"next_match", "next match"},
+		{"prev_match", "previous match"},
+		{"copy_id", "copy container ID"},
+		{"copy_command", "copy docker logs command"},
+	}},
+	{"Env matrix", [][2]string{
+		{"only_differing", "only differing keys"},
+		{"top", "top"},
+		{"bottom", "bottom"},
+	}},
+	{"Image labels", [][2]string{
+		{"copy_id", "copy value"},
+		{"copy_command", "copy link (source repo, commit, docs)"},
+		{"top", "top"},
+		{"bottom", "bottom"},
+	}},
+	{"Image history", [][2]string{
+		{"copy_id", "copy the step's command"},
+		{"top", "top"},
+		{"bottom", "bottom"},
+	}},
+	{"Compose file", [][2]string{
+		{"prev_tab", "previous file"},
+		{"next_tab", "next file"},
+		{"edit_compose_file", "edit the file in $EDITOR, then apply"},
+		{"copy_id", "copy the file's path"},
+		{"top", "top"},
+		{"bottom", "bottom"},
+	}},
+	{"Vulnerabilities", [][2]string{
+		{"copy_id", "copy vulnerability ID"},
+		{"scan_image", "rescan"},
+		{"top", "top"},
+		{"bottom", "bottom"},
+	}},
+	{"Env/labels/ports editor", [][2]string{
+		{"editor_add", "add"},
+		{"editor_edit", "edit"},
+		{"editor_delete", "delete"},
+		{"save", "save and recreate the container (or apply the .env)"},
+		{"back", "discard changes"},
+	}},
+}
+
+// HelpSections returns every action with its current keys, grouped by view
+func (m *KeyMap) HelpSections() []HelpSection {
+	actions := m.actions()
+	sections := make([]HelpSection, 0, len(helpSections))
+	for _, s := range helpSections {
+		section := HelpSection{Title: s.title}
+		for _, a := range s.actions {
+			b, ok := actions[a[0]]
+			if !ok {
+				continue
+			}
+			section.Entries = append(section.Entries, HelpEntry{
+				Keys:   keyNames(b.Keys()),
+				Action: a[0],
+				Desc:   a[1],
+			})
+		}
+		sections = append(sections, section)
+	}
+	return sections
+}
+
+func keyNames(keys []string) string {
+	names := make([]string, len(keys))
+	for i, k := range keys {
+		if k == " " {
+			k = "space"
+		}
+		names[i] = k
+	}
+	return strings.Join(names, "/")
+}
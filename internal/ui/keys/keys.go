@@ -0,0 +1,475 @@
+package keys
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// KeyMap holds the key bindings of every remappable action
+type KeyMap struct {
+	// Global
+	Quit          key.Binding
+	Back          key.Binding
+	Refresh       key.Binding
+	Help          key.Binding
+	Palette       key.Binding
+	NextView      key.Binding
+	PrevView      key.Binding
+	Containers    key.Binding
+	Images        key.Binding
+	Groups        key.Binding
+	Volumes       key.Binding
+	Compose       key.Binding
+	Networks      key.Binding
+	Plugins       key.Binding
+	AboutView     key.Binding
+	SwitchContext key.Binding
+	Retry         key.Binding
+	AuditLog      key.Binding
+	RunResults    key.Binding
+	QuickAction   key.Binding // One key per quick-action bar slot
+	GroupHotkey   key.Binding // Prefix of the group hotkeys, e.g. g then 1
+
+	// Resources (meaning depends on the current view)
+	Select       key.Binding
+	ToggleSelect key.Binding
+	New          key.Binding
+	Start        key.Binding
+	Stop         key.Binding
+	Restart      key.Binding
+	Delete       key.Binding
+	Logs         key.Binding
+	Stats        key.Binding
+	Shell        key.Binding
+	EditConfig   key.Binding
+	CopyID       key.Binding
+	CopyCommand  key.Binding
+	Save         key.Binding
+
+	// Containers view
+	TailFile      key.Binding
+	EditCpuset    key.Binding
+	FilterRunning key.Binding
+	FilterExited  key.Binding
+	FilterProject key.Binding
+	FilterLabel   key.Binding
+	ClearFilters  key.Binding
+	Snapshot      key.Binding
+	SnapshotDiff  key.Binding
+	PortCheck     key.Binding
+	ClockCheck    key.Binding
+	Watch         key.Binding
+	RuntimeColumn key.Binding
+
+	// Images and volumes views
+	PullImage      key.Binding
+	PullList       key.Binding
+	PruneImages    key.Binding
+	PruneVolumes   key.Binding
+	Inspect        key.Binding
+	ImageLabels    key.Binding
+	ImageHistory   key.Binding
+	TagImage       key.Binding
+	RegistryLogin  key.Binding
+	RunOnce        key.Binding
+	SaveImage      key.Binding
+	LoadImage      key.Binding
+	QuickRun       key.Binding
+	ScanImage      key.Binding
+	FilterUsage    key.Binding
+	CompareImages  key.Binding
+	ProtectImage   key.Binding
+	BackupVolume   key.Binding
+	RestoreVolume  key.Binding
+	VolumeSizes    key.Binding
+	PruneAnonymous key.Binding
+	PruneNetworks  key.Binding
+
+	// Groups and networks views
+	PrevTab       key.Binding
+	NextTab       key.Binding
+	StartOrder    key.Binding
+	OrderedStop   key.Binding
+	GroupEnv      key.Binding
+	ExportCompose key.Binding
+	Unlink        key.Binding
+	EditAliases   key.Binding
+	NewMacvlan    key.Binding
+
+	// Compose view
+	EnvMatrix       key.Binding
+	CheckConfig     key.Binding
+	RecreateChanged key.Binding
+	RecreateProject key.Binding
+	ComposeFile     key.Binding
+	EditComposeFile key.Binding
+
+	// Logs and matrix viewers
+	Follow        key.Binding
+	Top           key.Binding
+	Bottom        key.Binding
+	OnlyDiffering key.Binding
+	Search        key.Binding
+	NextMatch     key.Binding
+	PrevMatch     key.Binding
+
+	// Env/labels/ports editor
+	EditorAdd    key.Binding
+	EditorEdit   key.Binding
+	EditorDelete key.Binding
+}
+
+// Map is the active key map, set up once at startup
+var Map = DefaultKeyMap()
+
+func binding(keys ...string) key.Binding {
+	help := keys[0]
+	if help == " " {
+		help = "space"
+	}
+	return key.NewBinding(key.WithKeys(keys...), key.WithHelp(help, ""))
+}
+
+// DefaultKeyMap returns the built-in key bindings
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		Quit:          binding("q", "ctrl+c"),
+		Back:          binding("esc"),
+		Refresh:       binding("ctrl+r"),
+		Help:          binding("?"),
+		Palette:       binding("ctrl+p"),
+		NextView:      binding("tab", "right"),
+		PrevView:      binding("shift+tab", "left"),
+		Containers:    binding("1"),
+		Images:        binding("2"),
+		Groups:        binding("3"),
+		Volumes:       binding("4"),
+		Compose:       binding("5"),
+		Networks:      binding("6"),
+		Plugins:       binding("7"),
+		AboutView:     binding("8"),
+		SwitchContext: binding("K"),
+		Retry:         binding("r"),
+		AuditLog:      binding("J"),
+		RunResults:    binding("W"),
+		QuickAction:   binding("alt+1", "alt+2", "alt+3", "alt+4", "alt+5"),
+		GroupHotkey:   binding("ctrl+g"),
+
+		Select:       binding("enter"),
+		ToggleSelect: binding(" "),
+		New:          binding("n"),
+		Start:        binding("s"),
+		Stop:         binding("x"),
+		Restart:      binding("r"),
+		Delete:       binding("d"),
+		Logs:         binding("l"),
+		Stats:        binding("t"),
+		Shell:        binding("e"),
+		EditConfig:   binding("v"),
+		CopyID:       binding("y"),
+		CopyCommand:  binding("Y"),
+		Save:         binding("ctrl+s"),
+
+		TailFile:      binding("T"),
+		EditCpuset:    binding("c"),
+		FilterRunning: binding("R"),
+		FilterExited:  binding("X"),
+		FilterProject: binding("C"),
+		FilterLabel:   binding("L"),
+		ClearFilters:  binding("F"),
+		Snapshot:      binding("S"),
+		SnapshotDiff:  binding("D"),
+		PortCheck:     binding("H"),
+		ClockCheck:    binding("Z"),
+		Watch:         binding("w"),
+		RuntimeColumn: binding("b"),
+
+		PullImage:      binding("p"),
+		PullList:       binding("B"),
+		PruneImages:    binding("P"),
+		PruneVolumes:   binding("p"),
+		Inspect:        binding("i"),
+		ImageLabels:    binding("I"),
+		ImageHistory:   binding("H"),
+		TagImage:       binding("t"),
+		RegistryLogin:  binding("L"),
+		RunOnce:        binding("E"),
+		SaveImage:      binding("S"),
+		LoadImage:      binding("O"),
+		QuickRun:       binding("R"),
+		ScanImage:      binding("V"),
+		FilterUsage:    binding("u"),
+		CompareImages:  binding("="),
+		ProtectImage:   binding("X"),
+		BackupVolume:   binding("S"),
+		RestoreVolume:  binding("O"),
+		VolumeSizes:    binding("u"),
+		PruneAnonymous: binding("P"),
+		PruneNetworks:  binding("p"),
+
+		PrevTab:       binding("[", "left"),
+		NextTab:       binding("]", "right"),
+		StartOrder:    binding("o"),
+		OrderedStop:   binding("O"),
+		GroupEnv:      binding("V"),
+		ExportCompose: binding("S"),
+		Unlink:        binding("u"),
+		EditAliases:   binding("A"),
+		NewMacvlan:    binding("M"),
+
+		EnvMatrix:       binding("m"),
+		CheckConfig:     binding("c"),
+		RecreateChanged: binding("U"),
+		RecreateProject: binding("R"),
+		ComposeFile:     binding("f"),
+		EditComposeFile: binding("e"),
+
+		Follow:        binding("f"),
+		Top:           binding("g"),
+		Bottom:        binding("G"),
+		OnlyDiffering: binding("d"),
+		Search:        binding("/"),
+		NextMatch:     binding("n"),
+		PrevMatch:     binding("N"),
+
+		EditorAdd:    binding("a", "n"),
+		EditorEdit:   binding("e", "enter"),
+		EditorDelete: binding("d", "delete"),
+	}
+}
+
+// actions maps the action names used in the settings file to their bindings
+func (m *KeyMap) actions() map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"quit":            &m.Quit,
+		"back":            &m.Back,
+		"refresh":         &m.Refresh,
+		"help":            &m.Help,
+		"command_palette": &m.Palette,
+		"next_view":       &m.NextView,
+		"prev_view":       &m.PrevView,
+		"view_containers": &m.Containers,
+		"view_images":     &m.Images,
+		"view_groups":     &m.Groups,
+		"view_volumes":    &m.Volumes,
+		"view_compose":    &m.Compose,
+		"view_networks":   &m.Networks,
+		"view_plugins":    &m.Plugins,
+		"view_about":      &m.AboutView,
+		"switch_context":  &m.SwitchContext,
+		"retry":           &m.Retry,
+		"audit_log":       &m.AuditLog,
+		"run_results":     &m.RunResults,
+		"quick_action":    &m.QuickAction,
+		"group_hotkey":    &m.GroupHotkey,
+
+		"select":        &m.Select,
+		"toggle_select": &m.ToggleSelect,
+		"new":           &m.New,
+		"start":         &m.Start,
+		"stop":          &m.Stop,
+		"restart":       &m.Restart,
+		"delete":        &m.Delete,
+		"logs":          &m.Logs,
+		"stats":         &m.Stats,
+		"shell":         &m.Shell,
+		"edit_config":   &m.EditConfig,
+		"copy_id":       &m.CopyID,
+		"copy_command":  &m.CopyCommand,
+		"save":          &m.Save,
+
+		"tail_file":      &m.TailFile,
+		"edit_cpuset":    &m.EditCpuset,
+		"filter_running": &m.FilterRunning,
+		"filter_exited":  &m.FilterExited,
+		"filter_project": &m.FilterProject,
+		"filter_label":   &m.FilterLabel,
+		"clear_filters":  &m.ClearFilters,
+		"snapshot":       &m.Snapshot,
+		"snapshot_diff":  &m.SnapshotDiff,
+		"port_check":     &m.PortCheck,
+		"clock_check":    &m.ClockCheck,
+		"watch":          &m.Watch,
+		"runtime_column": &m.RuntimeColumn,
+
+		"pull_image":      &m.PullImage,
+		"pull_list":       &m.PullList,
+		"prune_images":    &m.PruneImages,
+		"prune_volumes":   &m.PruneVolumes,
+		"inspect":         &m.Inspect,
+		"image_labels":    &m.ImageLabels,
+		"image_history":   &m.ImageHistory,
+		"tag_image":       &m.TagImage,
+		"registry_login":  &m.RegistryLogin,
+		"run_once":        &m.RunOnce,
+		"save_image":      &m.SaveImage,
+		"load_image":      &m.LoadImage,
+		"quick_run":       &m.QuickRun,
+		"scan_image":      &m.ScanImage,
+		"filter_usage":    &m.FilterUsage,
+		"compare_images":  &m.CompareImages,
+		"protect_image":   &m.ProtectImage,
+		"backup_volume":   &m.BackupVolume,
+		"restore_volume":  &m.RestoreVolume,
+		"volume_sizes":    &m.VolumeSizes,
+		"prune_anonymous": &m.PruneAnonymous,
+		"prune_networks":  &m.PruneNetworks,
+
+		"prev_tab":       &m.PrevTab,
+		"next_tab":       &m.NextTab,
+		"start_order":    &m.StartOrder,
+		"ordered_stop":   &m.OrderedStop,
+		"group_env":      &m.GroupEnv,
+		"export_compose": &m.ExportCompose,
+		"unlink":         &m.Unlink,
+		"edit_aliases":   &m.EditAliases,
+		"new_macvlan":    &m.NewMacvlan,
+
+		"env_matrix":        &m.EnvMatrix,
+		"check_config":      &m.CheckConfig,
+		"recreate_changed":  &m.RecreateChanged,
+		"recreate_project":  &m.RecreateProject,
+		"compose_file":      &m.ComposeFile,
+		"edit_compose_file": &m.EditComposeFile,
+
+		"follow":         &m.Follow,
+		"top":            &m.Top,
+		"bottom":         &m.Bottom,
+		"only_differing": &m.OnlyDiffering,
+		"search":         &m.Search,
+		"next_match":     &m.NextMatch,
+		"prev_match":     &m.PrevMatch,
+
+		"editor_add":    &m.EditorAdd,
+		"editor_edit":   &m.EditorEdit,
+		"editor_delete": &m.EditorDelete,
+	}
+}
+
+// Apply rebinds actions by name (e.g. {"delete": ["D"]}). A remapped key
+// must not already be used by another action, otherwise which of the two
+// runs would depend on the view; remap both to swap keys.
+func (m *KeyMap) Apply(overrides map[string][]string) error {
+	if len(overrides) == 0 {
+		return nil
+	}
+
+	actions := m.actions()
+	defaults := DefaultKeyMap()
+	defaultActions := defaults.actions()
+
+	// Validate everything before changing any binding
+	names := make([]string, 0, len(overrides))
+	for name, keys := range overrides {
+		if _, ok := actions[name]; !ok {
+			return fmt.Errorf("unknown keybinding action %q", name)
+		}
+		if len(keys) == 0 {
+			return fmt.Errorf("keybinding %q has no keys", name)
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// Keys each action ends up with, in a stable order for error messages
+	all := make([]string, 0, len(actions))
+	final := make(map[string][]string, len(actions))
+	for name, b := range actions {
+		all = append(all, name)
+		final[name] = b.Keys()
+	}
+	for name, keys := range overrides {
+		final[name] = keys
+	}
+	sort.Strings(all)
+
+	for _, name := range names {
+		for _, k := range overrides[name] {
+			// Keeping a default key is fine even if other actions share it
+			if containsKey(defaultActions[name].Keys(), k) {
+				continue
+			}
+			for _, other := range all {
+				if other != name && containsKey(final[other], k) {
+					return fmt.Errorf("key %q of %q is already bound to %q", k, name, other)
+				}
+			}
+		}
+	}
+
+	for _, name := range names {
+		keys := overrides[name]
+		help := keys[0]
+		if help == " " {
+			help = "space"
+		}
+		actions[name].SetKeys(keys...)
+		actions[name].SetHelp(help, "")
+	}
+	return nil
+}
+
+// Binding returns the binding of an action by its config file name
+func (m *KeyMap) Binding(action string) (key.Binding, bool) {
+	b, ok := m.actions()[action]
+	if !ok {
+		return key.Binding{}, false
+	}
+	return *b, true
+}
+
+// Label returns the key shown in help texts for a binding
+func Label(b key.Binding) string {
+	return b.Help().Key
+}
+
+// Labels joins the help keys of several bindings (e.g. "g/G")
+func Labels(bindings ...key.Binding) string {
+	labels := make([]string, len(bindings))
+	for i, b := range bindings {
+		labels[i] = Label(b)
+	}
+	return strings.Join(labels, "/")
+}
+
+func containsKey(keys []string, k string) bool {
+	for _, existing := range keys {
+		if existing == k {
+			return true
+		}
+	}
+	return false
+}
+
+// keyTypes maps key names ("enter", "ctrl+r", " ") to bubbletea key types
+var keyTypes = func() map[string]tea.KeyType {
+	types := make(map[string]tea.KeyType)
+	for t := tea.KeyType(-128); t < 128; t++ {
+		if name := t.String(); name != "" {
+			types[name] = t
+		}
+	}
+	return types
+}()
+
+// Press returns a key message that matches the binding, used to run an
+// action as if its key was pressed
+func Press(b key.Binding) tea.KeyMsg {
+	name := b.Keys()[0]
+	msg := tea.KeyMsg{}
+	if rest, ok := strings.CutPrefix(name, "alt+"); ok && rest != "" {
+		msg.Alt = true
+		name = rest
+	}
+	if t, ok := keyTypes[name]; ok {
+		msg.Type = t
+		return msg
+	}
+	msg.Type = tea.KeyRunes
+	msg.Runes = []rune(name)
+	return msg
+}
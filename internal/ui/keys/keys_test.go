@@ -0,0 +1,29 @@
+package keys
+
+import "testing"
+
+// The help overlay is kept by hand: every action must be listed, only known
+// actions, and each at most once per section
+func TestHelpSectionsMatchKeyMap(t *testing.T) {
+	keyMap := DefaultKeyMap()
+	actions := keyMap.actions()
+	listed := make(map[string]bool, len(actions))
+	for _, s := range helpSections {
+		inSection := make(map[string]bool, len(s.actions))
+		for _, a := range s.actions {
+			if _, ok := actions[a[0]]; !ok {
+				t.Errorf("help section %q lists unknown action %q", s.title, a[0])
+			}
+			if inSection[a[0]] {
+				t.Errorf("help section %q lists %q twice", s.title, a[0])
+			}
+			inSection[a[0]] = true
+			listed[a[0]] = true
+		}
+	}
+	for name := range actions {
+		if !listed[name] {
+			t.Errorf("action %q is missing from the help sections", name)
+		}
+	}
+}
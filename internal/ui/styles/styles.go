@@ -3,107 +3,161 @@ package styles
 import "github.com/charmbracelet/lipgloss"
 
 var (
-	// Color palette
-	ColorPrimary   = lipgloss.Color("#7C3AED") // Purple
-	ColorSecondary = lipgloss.Color("#10B981") // Green
-	ColorAccent    = lipgloss.Color("#F59E0B") // Orange
-	ColorDanger    = lipgloss.Color("#EF4444") // Red
-	ColorMuted     = lipgloss.Color("#6B7280") // Gray
-	ColorSuccess   = lipgloss.Color("#10B981") // Green
-	ColorWarning   = lipgloss.Color("#F59E0B") // Orange
-	ColorInfo      = lipgloss.Color("#3B82F6") // Blue
+	// Color palette of the current theme
+	ColorPrimary    lipgloss.Color
+	ColorSecondary  lipgloss.Color
+	ColorAccent     lipgloss.Color
+	ColorDanger     lipgloss.Color
+	ColorMuted      lipgloss.Color
+	ColorSuccess    lipgloss.Color
+	ColorWarning    lipgloss.Color
+	ColorInfo       lipgloss.Color
+	ColorText       lipgloss.Color
+	ColorOnPrimary  lipgloss.Color
+	ColorBackground lipgloss.Color
 
 	// Text styles
+	TitleStyle    lipgloss.Style
+	SubtitleStyle lipgloss.Style
+	ErrorStyle    lipgloss.Style
+	SuccessStyle  lipgloss.Style
+	StatusStyle   lipgloss.Style
+
+	// Component styles
+	HeaderStyle      lipgloss.Style
+	FooterStyle      lipgloss.Style
+	TabActiveStyle   lipgloss.Style
+	TabInactiveStyle lipgloss.Style
+
+	// List/Table styles
+	SelectedItemStyle lipgloss.Style
+	NormalItemStyle   lipgloss.Style
+
+	// Container status colors
+	RunningStyle lipgloss.Style
+	StoppedStyle lipgloss.Style
+	PausedStyle  lipgloss.Style
+
+	// Borders and containers
+	BorderStyle lipgloss.Style
+	ModalStyle  lipgloss.Style
+
+	// Key binding hints
+	KeyStyle       lipgloss.Style
+	DescStyle      lipgloss.Style
+	SeparatorStyle lipgloss.Style
+	WarningStyle   lipgloss.Style
+	NewBadgeStyle  lipgloss.Style
+)
+
+func init() {
+	SetTheme(DefaultTheme)
+}
+
+// SetTheme makes t the current theme and rebuilds all styles from it.
+// Call it before creating views, some of them copy styles when created.
+func SetTheme(t Theme) {
+	Current = t
+
+	ColorPrimary = t.Primary
+	ColorSecondary = t.Secondary
+	ColorAccent = t.Accent
+	ColorDanger = t.Danger
+	ColorMuted = t.Muted
+	ColorSuccess = t.Success
+	ColorWarning = t.Warning
+	ColorInfo = t.Info
+	ColorText = t.Text
+	ColorOnPrimary = t.OnPrimary
+	ColorBackground = t.Background
+
 	TitleStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(ColorPrimary).
-			MarginBottom(1)
+		Bold(true).
+		Foreground(ColorPrimary).
+		MarginBottom(1)
 
 	SubtitleStyle = lipgloss.NewStyle().
-			Foreground(ColorMuted)
+		Foreground(ColorMuted)
 
 	ErrorStyle = lipgloss.NewStyle().
-			Foreground(ColorDanger).
-			Bold(true)
+		Foreground(ColorDanger).
+		Bold(true)
 
 	SuccessStyle = lipgloss.NewStyle().
-			Foreground(ColorSuccess).
-			Bold(true)
+		Foreground(ColorSuccess).
+		Bold(true)
 
 	StatusStyle = lipgloss.NewStyle().
-			Foreground(ColorInfo)
+		Foreground(ColorInfo)
 
-	// Component styles
-	HeaderStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(lipgloss.Color("#FFFFFF")).
-			Background(ColorPrimary).
-			Padding(0, 1)
+	HeaderStyle = Highlight(ColorPrimary).
+		Bold(true).
+		Padding(0, 1)
 
 	FooterStyle = lipgloss.NewStyle().
-			Foreground(ColorMuted).
-			BorderTop(true).
-			BorderStyle(lipgloss.NormalBorder()).
-			Padding(0, 1)
+		Foreground(ColorMuted).
+		BorderTop(true).
+		BorderStyle(lipgloss.NormalBorder()).
+		Padding(0, 1)
 
-	TabActiveStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(lipgloss.Color("#FFFFFF")).
-			Background(ColorPrimary).
-			Padding(0, 2)
+	TabActiveStyle = Highlight(ColorPrimary).
+		Bold(true).
+		Padding(0, 2)
 
 	TabInactiveStyle = lipgloss.NewStyle().
-			Foreground(ColorMuted).
-			Padding(0, 2)
+		Foreground(ColorMuted).
+		Padding(0, 2)
 
-	// List/Table styles
 	SelectedItemStyle = lipgloss.NewStyle().
-				Foreground(ColorPrimary).
-				Bold(true).
-				PaddingLeft(2)
+		Foreground(ColorPrimary).
+		Bold(true).
+		PaddingLeft(2)
 
 	NormalItemStyle = lipgloss.NewStyle().
-			PaddingLeft(4)
+		PaddingLeft(4)
 
-	// Container status colors
 	RunningStyle = lipgloss.NewStyle().
-			Foreground(ColorSuccess).
-			Bold(true)
+		Foreground(ColorSuccess).
+		Bold(true)
 
 	StoppedStyle = lipgloss.NewStyle().
-			Foreground(ColorMuted)
+		Foreground(ColorMuted)
 
 	PausedStyle = lipgloss.NewStyle().
-			Foreground(ColorWarning)
+		Foreground(ColorWarning)
 
-	// Borders and containers
 	BorderStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(ColorMuted).
-			Padding(1, 2)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorMuted).
+		Padding(1, 2)
 
 	ModalStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(ColorPrimary).
-			Padding(1, 2).
-			Background(lipgloss.Color("#1F2937"))
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorPrimary).
+		Padding(1, 2)
+	if !Plain {
+		ModalStyle = ModalStyle.Background(ColorBackground)
+	}
 
-	// Key binding hints
 	KeyStyle = lipgloss.NewStyle().
-			Foreground(ColorPrimary).
-			Bold(true)
+		Foreground(ColorPrimary).
+		Bold(true)
 
 	DescStyle = lipgloss.NewStyle().
-			Foreground(ColorMuted)
+		Foreground(ColorMuted)
 
 	SeparatorStyle = lipgloss.NewStyle().
-			Foreground(ColorMuted).
-			SetString(" • ")
+		Foreground(ColorMuted).
+		SetString(" • ")
 
 	WarningStyle = lipgloss.NewStyle().
-			Foreground(ColorWarning).
-			Bold(true)
-)
+		Foreground(ColorWarning).
+		Bold(true)
+
+	NewBadgeStyle = lipgloss.NewStyle().
+		Foreground(ColorInfo).
+		Bold(true)
+}
 
 // GetStatusStyle returns appropriate style for container status
 func GetStatusStyle(status string) lipgloss.Style {
@@ -118,3 +172,23 @@ func GetStatusStyle(status string) lipgloss.Style {
 		return NormalItemStyle
 	}
 }
+
+// groupColors maps the colors of groups to ANSI colors, which the terminal
+// adapts to its background
+var groupColors = map[string]lipgloss.Color{
+	"blue":    "12",
+	"green":   "10",
+	"yellow":  "11",
+	"magenta": "13",
+	"cyan":    "14",
+	"red":     "9",
+}
+
+// GroupMarker renders the dot marking a group in its color
+func GroupMarker(color string) string {
+	c, ok := groupColors[color]
+	if !ok {
+		c = ColorMuted
+	}
+	return lipgloss.NewStyle().Foreground(c).Render("●")
+}
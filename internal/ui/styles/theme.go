@@ -0,0 +1,225 @@
+package styles
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Theme is a color scheme the UI styles are built from
+type Theme struct {
+	Name       string
+	Primary    lipgloss.Color
+	Secondary  lipgloss.Color
+	Accent     lipgloss.Color
+	Danger     lipgloss.Color
+	Muted      lipgloss.Color
+	Success    lipgloss.Color
+	Warning    lipgloss.Color
+	Info       lipgloss.Color
+	Text       lipgloss.Color // Regular text drawn on the background (e.g. about page)
+	OnPrimary  lipgloss.Color // Text drawn on top of primary/success/muted backgrounds
+	Background lipgloss.Color // Modal background
+	Logo       [4]lipgloss.Color
+}
+
+// DefaultTheme is the original purple palette
+var DefaultTheme = Theme{
+	Name:       "default",
+	Primary:    "#7C3AED",
+	Secondary:  "#10B981",
+	Accent:     "#F59E0B",
+	Danger:     "#EF4444",
+	Muted:      "#6B7280",
+	Success:    "#10B981",
+	Warning:    "#F59E0B",
+	Info:       "#3B82F6",
+	Text:       "#E5E7EB",
+	OnPrimary:  "#FFFFFF",
+	Background: "#1F2937",
+	Logo:       [4]lipgloss.Color{"#06B6D4", "#3B82F6", "#8B5CF6", "#EC4899"},
+}
+
+// LightTheme uses darker colors that stay readable on light terminals
+var LightTheme = Theme{
+	Name:       "light",
+	Primary:    "#6D28D9",
+	Secondary:  "#047857",
+	Accent:     "#B45309",
+	Danger:     "#B91C1C",
+	Muted:      "#4B5563",
+	Success:    "#047857",
+	Warning:    "#B45309",
+	Info:       "#1D4ED8",
+	Text:       "#1F2937",
+	OnPrimary:  "#FFFFFF",
+	Background: "#F3F4F6",
+	Logo:       [4]lipgloss.Color{"#0891B2", "#1D4ED8", "#6D28D9", "#BE185D"},
+}
+
+// Themes holds the built-in themes by name
+var Themes = map[string]Theme{
+	"default": DefaultTheme,
+	"light":   LightTheme,
+	"nord": {
+		Name:       "nord",
+		Primary:    "#88C0D0",
+		Secondary:  "#A3BE8C",
+		Accent:     "#EBCB8B",
+		Danger:     "#BF616A",
+		Muted:      "#616E88",
+		Success:    "#A3BE8C",
+		Warning:    "#EBCB8B",
+		Info:       "#81A1C1",
+		Text:       "#ECEFF4",
+		OnPrimary:  "#2E3440",
+		Background: "#3B4252",
+		Logo:       [4]lipgloss.Color{"#8FBCBB", "#88C0D0", "#81A1C1", "#5E81AC"},
+	},
+	"gruvbox": {
+		Name:       "gruvbox",
+		Primary:    "#FE8019",
+		Secondary:  "#B8BB26",
+		Accent:     "#FABD2F",
+		Danger:     "#FB4934",
+		Muted:      "#928374",
+		Success:    "#B8BB26",
+		Warning:    "#FABD2F",
+		Info:       "#83A598",
+		Text:       "#EBDBB2",
+		OnPrimary:  "#282828",
+		Background: "#3C3836",
+		Logo:       [4]lipgloss.Color{"#8EC07C", "#83A598", "#D3869B", "#FE8019"},
+	},
+}
+
+// Current is the theme the styles were last built from
+var Current Theme
+
+// Plain disables colors (NO_COLOR): highlighted text is shown in reverse
+// video instead of on a colored background. Set it before SetTheme.
+var Plain bool
+
+var hexColorPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// ThemeNames returns the names of the built-in themes, sorted
+func ThemeNames() []string {
+	names := make([]string, 0, len(Themes))
+	for name := range Themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// colorFields maps the color names used in the settings file to theme fields
+func (t *Theme) colorFields() map[string]*lipgloss.Color {
+	return map[string]*lipgloss.Color{
+		"primary":    &t.Primary,
+		"secondary":  &t.Secondary,
+		"accent":     &t.Accent,
+		"danger":     &t.Danger,
+		"muted":      &t.Muted,
+		"success":    &t.Success,
+		"warning":    &t.Warning,
+		"info":       &t.Info,
+		"text":       &t.Text,
+		"on_primary": &t.OnPrimary,
+		"background": &t.Background,
+		"logo_1":     &t.Logo[0],
+		"logo_2":     &t.Logo[1],
+		"logo_3":     &t.Logo[2],
+		"logo_4":     &t.Logo[3],
+	}
+}
+
+// NewTheme builds a theme named name from a base theme with some colors
+// replaced (e.g. {"base": "nord", "primary": "#FF79C6"}). The base defaults
+// to the default theme. Colors are hex (#RGB or #RRGGBB) or ANSI numbers.
+func NewTheme(name string, colors map[string]string) (Theme, error) {
+	baseName := colors["base"]
+	if baseName == "" {
+		baseName = "default"
+	}
+	base, ok := Themes[baseName]
+	if !ok {
+		return Theme{}, fmt.Errorf("theme %q: unknown base theme %q", name, baseName)
+	}
+
+	t := base
+	t.Name = name
+	fields := t.colorFields()
+
+	keys := make([]string, 0, len(colors))
+	for k := range colors {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if k == "base" {
+			continue
+		}
+		field, ok := fields[k]
+		if !ok {
+			return Theme{}, fmt.Errorf("theme %q: unknown color %q", name, k)
+		}
+		value := strings.TrimSpace(colors[k])
+		if !validColor(value) {
+			return Theme{}, fmt.Errorf("theme %q: invalid color %q for %q", name, colors[k], k)
+		}
+		*field = lipgloss.Color(value)
+	}
+	return t, nil
+}
+
+// ResolveTheme looks a theme up by name, custom themes taking precedence
+// over the built-in ones. An empty name or "auto" picks the default or light
+// theme depending on darkBackground.
+func ResolveTheme(name string, custom map[string]map[string]string, darkBackground bool) (Theme, error) {
+	if name == "" || name == "auto" {
+		if darkBackground {
+			return DefaultTheme, nil
+		}
+		return LightTheme, nil
+	}
+	if colors, ok := custom[name]; ok {
+		return NewTheme(name, colors)
+	}
+	if t, ok := Themes[name]; ok {
+		return t, nil
+	}
+	return Theme{}, fmt.Errorf("unknown theme %q (built-in: auto, %s)", name, strings.Join(ThemeNames(), ", "))
+}
+
+// Highlight returns a style for text on a bg colored background, such as
+// buttons and the header
+func Highlight(bg lipgloss.Color) lipgloss.Style {
+	if Plain {
+		return lipgloss.NewStyle().Reverse(true)
+	}
+	return lipgloss.NewStyle().
+		Foreground(ColorOnPrimary).
+		Background(bg)
+}
+
+func validColor(value string) bool {
+	if hexColorPattern.MatchString(value) {
+		return true
+	}
+	// ANSI 0-255
+	if value == "" || len(value) > 3 {
+		return false
+	}
+	n := 0
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+		n = n*10 + int(r-'0')
+	}
+	return n <= 255
+}
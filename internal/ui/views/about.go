@@ -5,6 +5,7 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/rizface/doui/internal/ui/keys"
 	"github.com/rizface/doui/internal/ui/styles"
 )
 
@@ -39,10 +40,10 @@ func (v *AboutView) View() string {
 		text  string
 		color lipgloss.Color
 	}{
-		{"    _            _", lipgloss.Color("#06B6D4")},  // Cyan
-		{" __| | ___  _  _(_)", lipgloss.Color("#3B82F6")}, // Blue
-		{"/ _` |/ _ \\| || | |", lipgloss.Color("#8B5CF6")}, // Purple
-		{"\\__,_|\\___/ \\_,_|_|", lipgloss.Color("#EC4899")}, // Pink
+		{"    _            _", styles.Current.Logo[0]},
+		{" __| | ___  _  _(_)", styles.Current.Logo[1]},
+		{"/ _` |/ _ \\| || | |", styles.Current.Logo[2]},
+		{"\\__,_|\\___/ \\_,_|_|", styles.Current.Logo[3]},
 	}
 
 	// Build the logo
@@ -102,7 +103,7 @@ an intuitive way to interact with your Docker environment.`
 			Bold(true).
 			Render(f.icon)
 		text := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#E5E7EB")).
+			Foreground(styles.ColorText).
 			Render(" " + f.text)
 		featuresBuilder.WriteString("  " + icon + text + "\n")
 	}
@@ -147,8 +148,8 @@ an intuitive way to interact with your Docker environment.`
 // GetHelpText returns help text for the about view
 func (v *AboutView) GetHelpText() string {
 	helps := []string{
-		styles.KeyStyle.Render("esc") + " back",
-		styles.KeyStyle.Render("q") + " quit",
+		styles.KeyStyle.Render(keys.Label(keys.Map.Back)) + " back",
+		styles.KeyStyle.Render(keys.Label(keys.Map.Quit)) + " quit",
 	}
 	return strings.Join(helps, styles.SeparatorStyle.String())
 }
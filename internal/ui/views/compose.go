@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/rizface/doui/internal/models"
+	"github.com/rizface/doui/internal/ui/keys"
 	"github.com/rizface/doui/internal/ui/styles"
 )
 
@@ -19,6 +21,10 @@ func (i ComposeProjectItem) FilterValue() string {
 	return i.project.Name
 }
 
+func (i ComposeProjectItem) itemID() string {
+	return i.project.Name
+}
+
 func (i ComposeProjectItem) Title() string {
 	status := ""
 	if i.project.AllRunning() {
@@ -37,13 +43,18 @@ func (i ComposeProjectItem) Description() string {
 
 // ComposeServiceItem implements list.Item for services within a project
 type ComposeServiceItem struct {
-	service models.ComposeService
+	service    models.ComposeService
+	configHash string // Current config hash of the service, "" if unknown
 }
 
 func (i ComposeServiceItem) FilterValue() string {
 	return i.service.Name
 }
 
+func (i ComposeServiceItem) itemID() string {
+	return i.service.Name
+}
+
 func (i ComposeServiceItem) Title() string {
 	runningCount := 0
 	for _, c := range i.service.Containers {
@@ -62,15 +73,26 @@ func (i ComposeServiceItem) Title() string {
 		status = styles.StoppedStyle.Render("stopped")
 	}
 
-	return fmt.Sprintf("%s  %s", i.service.Name, status)
+	title := fmt.Sprintf("%s  %s", i.service.Name, status)
+	if i.configHash != "" && i.service.Drifted(i.configHash) {
+		title += "  " + styles.WarningStyle.Render("⚠ config changed")
+	}
+	return title
 }
 
 func (i ComposeServiceItem) Description() string {
+	desc := fmt.Sprintf("%d containers (scaled)", len(i.service.Containers))
 	if len(i.service.Containers) == 1 {
 		c := i.service.Containers[0]
-		return fmt.Sprintf("ID: %s | Image: %s", c.ShortID, c.Image)
+		desc = fmt.Sprintf("ID: %s | Image: %s", c.ShortID, c.Image)
+	}
+	if ports := i.service.PublishedPorts(); len(ports) > 0 {
+		desc += " | Ports: " + strings.Join(ports, ", ")
+	}
+	if deps := i.service.DependsOn(); len(deps) > 0 {
+		desc += " | Depends on: " + strings.Join(deps, ", ")
 	}
-	return fmt.Sprintf("%d containers (scaled)", len(i.service.Containers))
+	return desc
 }
 
 // ComposeContainerItem implements list.Item for containers within a service
@@ -82,6 +104,10 @@ func (i ComposeContainerItem) FilterValue() string {
 	return i.container.Name
 }
 
+func (i ComposeContainerItem) itemID() string {
+	return i.container.ID
+}
+
 func (i ComposeContainerItem) Title() string {
 	status := styles.GetStatusStyle(i.container.State).Render(i.container.State)
 	return fmt.Sprintf("%s  %s", i.container.Name, status)
@@ -103,6 +129,9 @@ type ComposeView struct {
 	viewingServices   bool
 	viewingContainers bool
 
+	// Current config hash of each service of the selected project
+	configHashes map[string]string
+
 	width  int
 	height int
 }
@@ -160,7 +189,7 @@ func (v *ComposeView) SetProjects(projects []models.ComposeProject) {
 		items[i] = ComposeProjectItem{project: p}
 	}
 
-	v.projectsList.SetItems(items)
+	setItemsKeepSelection(&v.projectsList, items)
 
 	// Update selectedProject to point to new data (if still exists)
 	if v.selectedProject != nil {
@@ -226,6 +255,11 @@ func (v *ComposeView) GetSelectedProject() *models.ComposeProject {
 	return nil
 }
 
+// GetProjects returns all compose projects
+func (v *ComposeView) GetProjects() []models.ComposeProject {
+	return v.projects
+}
+
 // GetSelectedService returns the currently selected service
 func (v *ComposeView) GetSelectedService() *models.ComposeService {
 	if v.selectedProject == nil {
@@ -278,7 +312,7 @@ func (v *ComposeView) updateContainersList() {
 		items[i] = ComposeContainerItem{container: c}
 	}
 
-	v.containersList.SetItems(items)
+	setItemsKeepSelection(&v.containersList, items)
 	v.containersList.Title = fmt.Sprintf("Containers in '%s'", v.selectedService.Name)
 }
 
@@ -300,8 +334,8 @@ func (v *ComposeView) Update(msg tea.Msg) (*ComposeView, tea.Cmd) {
 	// Handle key messages
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "enter":
+		switch {
+		case key.Matches(msg, keys.Map.Select):
 			if v.viewingContainers {
 				// Already at container level, enter does nothing
 				return v, nil
@@ -320,12 +354,13 @@ func (v *ComposeView) Update(msg tea.Msg) (*ComposeView, tea.Cmd) {
 				v.selectedProject = v.GetSelectedProject()
 				if v.selectedProject != nil {
 					v.viewingServices = true
+					v.configHashes = nil
 					v.updateServicesList()
 				}
 				return v, nil
 			}
 
-		case "esc":
+		case key.Matches(msg, keys.Map.Back):
 			if v.viewingContainers {
 				// Return to services list
 				v.viewingContainers = false
@@ -353,6 +388,17 @@ func (v *ComposeView) Update(msg tea.Msg) (*ComposeView, tea.Cmd) {
 	return v, cmd
 }
 
+// SetConfigHashes sets the current config hash of each service of a
+// project, to flag the services created from an older config. Ignored
+// unless it is the selected project.
+func (v *ComposeView) SetConfigHashes(projectName string, hashes map[string]string) {
+	if v.selectedProject == nil || v.selectedProject.Name != projectName {
+		return
+	}
+	v.configHashes = hashes
+	v.updateServicesList()
+}
+
 // updateServicesList updates the services list based on selected project
 func (v *ComposeView) updateServicesList() {
 	if v.selectedProject == nil {
@@ -362,10 +408,10 @@ func (v *ComposeView) updateServicesList() {
 
 	items := make([]list.Item, len(v.selectedProject.Services))
 	for i, s := range v.selectedProject.Services {
-		items[i] = ComposeServiceItem{service: s}
+		items[i] = ComposeServiceItem{service: s, configHash: v.configHashes[s.Name]}
 	}
 
-	v.servicesList.SetItems(items)
+	setItemsKeepSelection(&v.servicesList, items)
 	v.servicesList.Title = fmt.Sprintf("Services in '%s'", v.selectedProject.Name)
 }
 
@@ -392,7 +438,7 @@ func (v *ComposeView) View() string {
 	}
 
 	if len(v.projects) == 0 {
-		return v.renderEmpty("No Docker Compose projects found.\nCompose projects are detected automatically from running containers.")
+		return v.renderEmpty(fmt.Sprintf("No Docker Compose projects found.\nCompose projects are detected automatically from their containers.\nPress '%s' to bring one up from a compose file.", keys.Label(keys.Map.New)))
 	}
 
 	return v.projectsList.View()
@@ -437,44 +483,62 @@ func (v *ComposeView) GetHelpText() string {
 		// Viewing containers in a scaled service - full container operations
 		helps = []string{
 			styles.KeyStyle.Render("↑/↓") + " navigate",
-			styles.KeyStyle.Render("s") + " start",
-			styles.KeyStyle.Render("x") + " stop",
-			styles.KeyStyle.Render("r") + " restart",
-			styles.KeyStyle.Render("l") + " logs",
-			styles.KeyStyle.Render("t") + " stats",
-			styles.KeyStyle.Render("e") + " shell",
-			styles.KeyStyle.Render("v") + " env",
-			styles.KeyStyle.Render("d") + " remove",
-			styles.KeyStyle.Render("esc") + " back",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Start)) + " start",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Stop)) + " stop",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Restart)) + " restart",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Logs)) + " logs",
+			styles.KeyStyle.Render(keys.Label(keys.Map.TailFile)) + " tail file",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Stats)) + " stats",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Shell)) + " shell",
+			styles.KeyStyle.Render(keys.Label(keys.Map.EditConfig)) + " env/labels/ports",
+			styles.KeyStyle.Render(keys.Label(keys.Map.CopyID)) + " copy ID",
+			styles.KeyStyle.Render(keys.Label(keys.Map.CopyCommand)) + " copy cmd",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Delete)) + " remove",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Back)) + " back",
 			styles.KeyStyle.Render("/") + " filter",
 		}
 	} else if v.viewingServices {
 		// Viewing services - show container ops for single-container services
 		helps = []string{
 			styles.KeyStyle.Render("↑/↓") + " navigate",
-			styles.KeyStyle.Render("enter") + " containers",
-			styles.KeyStyle.Render("s") + " start",
-			styles.KeyStyle.Render("x") + " stop",
-			styles.KeyStyle.Render("r") + " restart",
-			styles.KeyStyle.Render("l") + " logs",
-			styles.KeyStyle.Render("t") + " stats",
-			styles.KeyStyle.Render("e") + " shell",
-			styles.KeyStyle.Render("v") + " env",
-			styles.KeyStyle.Render("esc") + " back",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Select)) + " containers",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Start)) + " start",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Stop)) + " stop",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Restart)) + " restart",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Logs)) + " logs",
+			styles.KeyStyle.Render(keys.Label(keys.Map.TailFile)) + " tail file",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Stats)) + " stats",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Shell)) + " shell",
+			styles.KeyStyle.Render(keys.Label(keys.Map.EditConfig)) + " env/labels/ports",
+			styles.KeyStyle.Render(keys.Label(keys.Map.CopyID)) + " copy ID",
+			styles.KeyStyle.Render(keys.Label(keys.Map.CopyCommand)) + " copy cmd",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Back)) + " back",
 			styles.KeyStyle.Render("/") + " filter",
 		}
 	} else {
 		// Viewing projects
 		helps = []string{
 			styles.KeyStyle.Render("↑/↓") + " navigate",
-			styles.KeyStyle.Render("enter") + " view services",
-			styles.KeyStyle.Render("s") + " start all",
-			styles.KeyStyle.Render("x") + " stop all",
-			styles.KeyStyle.Render("r") + " restart all",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Select)) + " view services",
+			styles.KeyStyle.Render(keys.Label(keys.Map.New)) + " up from file",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Delete)) + " down",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Start)) + " start all",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Stop)) + " stop all",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Restart)) + " restart all",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Logs)) + " logs",
+			styles.KeyStyle.Render(keys.Label(keys.Map.PullImage)) + " pull images",
+			styles.KeyStyle.Render(keys.Label(keys.Map.EnvMatrix)) + " env matrix",
+			styles.KeyStyle.Render(keys.Label(keys.Map.CheckConfig)) + " check config",
+			styles.KeyStyle.Render(keys.Label(keys.Map.ComposeFile)) + " compose file",
+			styles.KeyStyle.Render(keys.Label(keys.Map.EditComposeFile)) + " edit file",
+			styles.KeyStyle.Render(keys.Label(keys.Map.EditConfig)) + " .env",
+			styles.KeyStyle.Render(keys.Label(keys.Map.RecreateChanged)) + " up changed",
+			styles.KeyStyle.Render(keys.Label(keys.Map.RecreateProject)) + " recreate all",
+			styles.KeyStyle.Render(keys.Label(keys.Map.CopyID)) + " copy name",
 			styles.KeyStyle.Render("/") + " filter",
 		}
 	}
 
-	helps = append(helps, styles.KeyStyle.Render("q")+" quit")
+	helps = append(helps, styles.KeyStyle.Render(keys.Label(keys.Map.Quit))+" quit")
 	return strings.Join(helps, styles.SeparatorStyle.String())
 }
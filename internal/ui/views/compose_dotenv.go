@@ -0,0 +1,122 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/rizface/doui/internal/models"
+	"github.com/rizface/doui/internal/ui/components"
+	"github.com/rizface/doui/internal/ui/keys"
+	"github.com/rizface/doui/internal/ui/styles"
+)
+
+// ComposeDotEnvView is a full-screen editor for the .env file of a compose
+// project
+type ComposeDotEnvView struct {
+	editor  *components.EnvEditor
+	project models.ComposeProject
+	file    models.DotEnvFile
+	width   int
+	height  int
+}
+
+// NewComposeDotEnvView creates a new compose .env view
+func NewComposeDotEnvView() *ComposeDotEnvView {
+	return &ComposeDotEnvView{}
+}
+
+// SetFile sets the project and its .env file to edit
+func (v *ComposeDotEnvView) SetFile(project models.ComposeProject, file models.DotEnvFile) {
+	v.project = project
+	v.file = file
+	v.editor = components.NewEnvEditor(file.Vars())
+	v.editor.SetSize(v.width, v.height-6)
+}
+
+// SetSize updates the view dimensions
+func (v *ComposeDotEnvView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+	if v.editor != nil {
+		v.editor.SetSize(width, height-6)
+	}
+}
+
+// GetProject returns the project whose .env file is edited
+func (v *ComposeDotEnvView) GetProject() models.ComposeProject {
+	return v.project
+}
+
+// GetFile returns the .env file with the edited variables
+func (v *ComposeDotEnvView) GetFile() models.DotEnvFile {
+	file := v.file
+	if v.editor != nil {
+		file.Content = v.file.WithVars(v.editor.GetEnvVars())
+	}
+	return file
+}
+
+// IsModified returns true if changes were made
+func (v *ComposeDotEnvView) IsModified() bool {
+	return v.editor != nil && v.editor.IsModified()
+}
+
+// Update handles messages
+func (v *ComposeDotEnvView) Update(msg tea.Msg) (*ComposeDotEnvView, tea.Cmd) {
+	if v.editor == nil {
+		return v, nil
+	}
+	var cmd tea.Cmd
+	v.editor, cmd = v.editor.Update(msg)
+	return v, cmd
+}
+
+// View renders the view
+func (v *ComposeDotEnvView) View() string {
+	if v.editor == nil {
+		return "Loading .env file..."
+	}
+
+	var b strings.Builder
+	b.WriteString(styles.TitleStyle.Render(fmt.Sprintf("Compose .env: %s", v.project.Name)))
+	b.WriteString("\n")
+	b.WriteString(styles.SubtitleStyle.Render(v.file.Path))
+	b.WriteString("\n")
+	if v.IsModified() {
+		b.WriteString(styles.WarningStyle.Render("[Modified] "))
+	}
+	b.WriteString(styles.DescStyle.Render(fmt.Sprintf("Press %s to save; comments and the order of the file are kept", keys.Label(keys.Map.Save))))
+	b.WriteString("\n\n")
+	b.WriteString(v.editor.View())
+
+	return b.String()
+}
+
+// GetHelpText returns help text
+func (v *ComposeDotEnvView) GetHelpText() string {
+	if v.editor == nil {
+		return ""
+	}
+
+	var helps []string
+	if editorHelp := v.editor.GetHelpText(); editorHelp != "" {
+		helps = append(helps, editorHelp)
+	}
+	if v.IsModified() {
+		helps = append(helps, styles.KeyStyle.Render(keys.Label(keys.Map.Save))+" save & apply")
+	}
+	helps = append(helps, styles.KeyStyle.Render(keys.Label(keys.Map.Back))+" back (discard)")
+
+	return strings.Join(helps, styles.SeparatorStyle.String())
+}
+
+// IsFiltering returns true if editor is filtering
+func (v *ComposeDotEnvView) IsFiltering() bool {
+	return v.editor != nil && v.editor.IsFiltering()
+}
+
+// IsEditing returns true if editor is in add/edit mode
+func (v *ComposeDotEnvView) IsEditing() bool {
+	return v.editor != nil && v.editor.IsEditing()
+}
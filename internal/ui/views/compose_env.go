@@ -0,0 +1,193 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/rizface/doui/internal/models"
+	"github.com/rizface/doui/internal/ui/keys"
+	"github.com/rizface/doui/internal/ui/styles"
+)
+
+// ComposeEnvView shows env vars of a compose project's services side by side
+// (rows = keys, columns = services), highlighting keys that differ
+type ComposeEnvView struct {
+	viewport      viewport.Model
+	matrix        *models.EnvMatrix
+	projectName   string
+	onlyDiffering bool
+	ready         bool
+	width         int
+	height        int
+}
+
+// NewComposeEnvView creates a new compose env matrix view
+func NewComposeEnvView() *ComposeEnvView {
+	vp := viewport.New(0, 0)
+	vp.Style = styles.BorderStyle
+
+	return &ComposeEnvView{
+		viewport: vp,
+	}
+}
+
+// SetMatrix sets the project and its env matrix to display
+func (v *ComposeEnvView) SetMatrix(projectName string, matrix *models.EnvMatrix) {
+	v.projectName = projectName
+	v.matrix = matrix
+	v.ready = true
+	v.viewport.GotoTop()
+	v.updateContent()
+}
+
+// SetSize updates the view dimensions
+func (v *ComposeEnvView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+	headerHeight := 3
+	v.viewport.Width = width - 4
+	v.viewport.Height = height - headerHeight - 4
+	v.updateContent()
+}
+
+// Update handles messages
+func (v *ComposeEnvView) Update(msg tea.Msg) (*ComposeEnvView, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, keys.Map.OnlyDiffering):
+			v.onlyDiffering = !v.onlyDiffering
+			v.updateContent()
+			return v, nil
+		case key.Matches(msg, keys.Map.Top):
+			v.viewport.GotoTop()
+			return v, nil
+		case key.Matches(msg, keys.Map.Bottom):
+			v.viewport.GotoBottom()
+			return v, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	v.viewport, cmd = v.viewport.Update(msg)
+	return v, cmd
+}
+
+// updateContent renders the matrix into the viewport
+func (v *ComposeEnvView) updateContent() {
+	if v.matrix == nil || v.viewport.Width <= 0 {
+		return
+	}
+
+	// Column widths: key column up to 30, services share the rest
+	keyWidth := 3
+	for _, key := range v.matrix.Keys {
+		if len(key) > keyWidth {
+			keyWidth = len(key)
+		}
+	}
+	if keyWidth > 30 {
+		keyWidth = 30
+	}
+
+	colWidth := 24
+	if n := len(v.matrix.Services); n > 0 {
+		available := (v.viewport.Width - 4 - keyWidth - 2) / n
+		if available < colWidth {
+			colWidth = available
+		}
+	}
+	if colWidth < 6 {
+		colWidth = 6
+	}
+
+	var b strings.Builder
+
+	// Header row
+	b.WriteString(styles.KeyStyle.Render(padCell("KEY", keyWidth)))
+	for _, service := range v.matrix.Services {
+		b.WriteString("  ")
+		b.WriteString(styles.KeyStyle.Render(padCell(service, colWidth)))
+	}
+	b.WriteString("\n")
+
+	rows := 0
+	for _, key := range v.matrix.Keys {
+		differs := v.matrix.Differs(key)
+		if v.onlyDiffering && !differs {
+			continue
+		}
+		rows++
+
+		keyCell := padCell(key, keyWidth)
+		if differs {
+			keyCell = styles.WarningStyle.Render(keyCell)
+		}
+		b.WriteString(keyCell)
+
+		for _, service := range v.matrix.Services {
+			b.WriteString("  ")
+			value, ok := v.matrix.Get(service, key)
+			if !ok {
+				b.WriteString(styles.SubtitleStyle.Render(padCell("—", colWidth)))
+				continue
+			}
+			b.WriteString(padCell(value, colWidth))
+		}
+		b.WriteString("\n")
+	}
+
+	if rows == 0 {
+		b.WriteString(styles.SubtitleStyle.Render("All services share the same environment."))
+	}
+
+	v.viewport.SetContent(b.String())
+}
+
+// padCell truncates or pads s to exactly width characters
+func padCell(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) > width {
+		return string(runes[:width-1]) + "…"
+	}
+	return s + strings.Repeat(" ", width-len(runes))
+}
+
+// View renders the view
+func (v *ComposeEnvView) View() string {
+	if !v.ready || v.matrix == nil {
+		return "Loading environment..."
+	}
+
+	var b strings.Builder
+
+	title := fmt.Sprintf("Environment Matrix: %s (%d services, %d keys)", v.projectName, len(v.matrix.Services), len(v.matrix.Keys))
+	b.WriteString(styles.TitleStyle.Render(title))
+	b.WriteString("\n")
+
+	subtitle := "Highlighted keys differ between services or are missing in some"
+	if v.onlyDiffering {
+		subtitle = "Showing only keys that differ"
+	}
+	b.WriteString(styles.SubtitleStyle.Render(subtitle))
+	b.WriteString("\n")
+
+	b.WriteString(v.viewport.View())
+
+	return b.String()
+}
+
+// GetHelpText returns help text for the compose env view
+func (v *ComposeEnvView) GetHelpText() string {
+	helps := []string{
+		styles.KeyStyle.Render("↑/↓") + " scroll",
+		styles.KeyStyle.Render(keys.Label(keys.Map.OnlyDiffering)) + " only differing",
+		styles.KeyStyle.Render(keys.Labels(keys.Map.Top, keys.Map.Bottom)) + " top/bottom",
+		styles.KeyStyle.Render(keys.Label(keys.Map.Back)) + " back",
+	}
+
+	return strings.Join(helps, styles.SeparatorStyle.String())
+}
@@ -0,0 +1,253 @@
+package views
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rizface/doui/internal/models"
+	"github.com/rizface/doui/internal/ui/keys"
+	"github.com/rizface/doui/internal/ui/styles"
+)
+
+// ComposeFileView shows the compose files of a project read-only, with YAML
+// syntax highlighting, one file at a time
+type ComposeFileView struct {
+	viewport viewport.Model
+	project  models.ComposeProject
+	files    []models.ComposeFile
+	current  int
+	width    int
+	height   int
+}
+
+// NewComposeFileView creates a new compose file view
+func NewComposeFileView() *ComposeFileView {
+	vp := viewport.New(0, 0)
+	vp.Style = styles.BorderStyle
+	return &ComposeFileView{viewport: vp}
+}
+
+// SetFiles sets the project and its compose files, the first one shown.
+// Reloading the same project keeps the file shown and the scroll position.
+func (v *ComposeFileView) SetFiles(project models.ComposeProject, files []models.ComposeFile) {
+	if project.Name == v.project.Name && v.current < len(files) {
+		offset := v.viewport.YOffset
+		v.project = project
+		v.files = files
+		v.showCurrent()
+		v.viewport.SetYOffset(offset)
+		return
+	}
+	v.project = project
+	v.files = files
+	v.current = 0
+	v.showCurrent()
+}
+
+// GetProject returns the project whose files are shown
+func (v *ComposeFileView) GetProject() models.ComposeProject {
+	return v.project
+}
+
+// SetSize updates the view dimensions
+func (v *ComposeFileView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+	v.viewport.Width = width - 4
+	v.viewport.Height = max(height-7, 1)
+}
+
+// GetCurrentFile returns the file shown
+func (v *ComposeFileView) GetCurrentFile() *models.ComposeFile {
+	if v.current < 0 || v.current >= len(v.files) {
+		return nil
+	}
+	return &v.files[v.current]
+}
+
+// showCurrent renders the current file into the viewport, with line numbers
+func (v *ComposeFileView) showCurrent() {
+	file := v.GetCurrentFile()
+	if file == nil {
+		v.viewport.SetContent("")
+		return
+	}
+
+	lines := strings.Split(strings.TrimRight(strings.ReplaceAll(file.Content, "\t", "  "), "\n"), "\n")
+	numberWidth := len(fmt.Sprint(len(lines)))
+	numberStyle := lipgloss.NewStyle().Foreground(styles.ColorMuted)
+	for i, line := range lines {
+		lines[i] = numberStyle.Render(fmt.Sprintf("%*d ", numberWidth, i+1)) + highlightYAML(line)
+	}
+	v.viewport.SetContent(strings.Join(lines, "\n"))
+	v.viewport.GotoTop()
+}
+
+// Update handles messages
+func (v *ComposeFileView) Update(msg tea.Msg) (*ComposeFileView, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case key.Matches(keyMsg, keys.Map.PrevTab):
+			if len(v.files) > 1 {
+				v.current = (v.current - 1 + len(v.files)) % len(v.files)
+				v.showCurrent()
+			}
+			return v, nil
+		case key.Matches(keyMsg, keys.Map.NextTab):
+			if len(v.files) > 1 {
+				v.current = (v.current + 1) % len(v.files)
+				v.showCurrent()
+			}
+			return v, nil
+		case key.Matches(keyMsg, keys.Map.Top):
+			v.viewport.GotoTop()
+			return v, nil
+		case key.Matches(keyMsg, keys.Map.Bottom):
+			v.viewport.GotoBottom()
+			return v, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	v.viewport, cmd = v.viewport.Update(msg)
+	return v, cmd
+}
+
+// View renders the view
+func (v *ComposeFileView) View() string {
+	var b strings.Builder
+
+	file := v.GetCurrentFile()
+	if file == nil {
+		b.WriteString(styles.TitleStyle.Render(fmt.Sprintf("Compose File: %s", v.project.Name)))
+		b.WriteString("\n\n")
+		b.WriteString(styles.SubtitleStyle.Render("No compose file found for this project."))
+		return b.String()
+	}
+
+	title := fmt.Sprintf("Compose File: %s", v.project.Name)
+	if len(v.files) > 1 {
+		title += fmt.Sprintf(" (%d/%d)", v.current+1, len(v.files))
+	}
+	b.WriteString(styles.TitleStyle.Render(title))
+	b.WriteString("\n")
+	subtitle := file.Path
+	if file.Override {
+		subtitle += "  (override picked up from the working dir)"
+	}
+	b.WriteString(styles.SubtitleStyle.Render(subtitle))
+	b.WriteString(styles.SeparatorStyle.String())
+	b.WriteString(styles.DescStyle.Render(fmt.Sprintf("%d%%", int(v.viewport.ScrollPercent()*100))))
+	b.WriteString("\n\n")
+	b.WriteString(v.viewport.View())
+
+	return b.String()
+}
+
+// GetHelpText returns help text for the compose file view
+func (v *ComposeFileView) GetHelpText() string {
+	helps := []string{
+		styles.KeyStyle.Render("↑/↓") + " scroll",
+	}
+	if len(v.files) > 1 {
+		helps = append(helps, styles.KeyStyle.Render(keys.Labels(keys.Map.PrevTab, keys.Map.NextTab))+" other file")
+	}
+	helps = append(helps,
+		styles.KeyStyle.Render(keys.Labels(keys.Map.Top, keys.Map.Bottom))+" top/bottom",
+		styles.KeyStyle.Render(keys.Label(keys.Map.EditComposeFile))+" edit",
+		styles.KeyStyle.Render(keys.Label(keys.Map.CopyID))+" copy path",
+		styles.KeyStyle.Render(keys.Label(keys.Map.Back))+" back",
+	)
+
+	return strings.Join(helps, styles.SeparatorStyle.String())
+}
+
+// yamlKeyPattern matches the key of a mapping entry, after the indent and
+// list dashes: `image:`, `"8080":`, `x-common: &common`
+var yamlKeyPattern = regexp.MustCompile(`^("[^"]*"|'[^']*'|[^\s#'"{\[][^:#]*?)(:)(\s|$)`)
+
+// yamlScalarPattern matches plain scalars that aren't strings
+var yamlScalarPattern = regexp.MustCompile(`^(?:true|false|yes|no|on|off|null|~|-?\d+(?:\.\d+)?)$`)
+
+// highlightYAML colors a line of YAML: keys, strings, numbers and booleans,
+// anchors and aliases, and comments. Line based, good enough for compose files.
+func highlightYAML(line string) string {
+	keyStyle := lipgloss.NewStyle().Foreground(styles.ColorInfo)
+	stringStyle := lipgloss.NewStyle().Foreground(styles.ColorSuccess)
+	scalarStyle := lipgloss.NewStyle().Foreground(styles.ColorAccent)
+	refStyle := lipgloss.NewStyle().Foreground(styles.ColorPrimary)
+	mutedStyle := lipgloss.NewStyle().Foreground(styles.ColorMuted)
+
+	code, comment := splitYAMLComment(line)
+	trimmed := strings.TrimLeft(code, " ")
+	var b strings.Builder
+	b.WriteString(code[:len(code)-len(trimmed)])
+
+	if t := strings.TrimSpace(trimmed); t == "---" || t == "..." {
+		return b.String() + mutedStyle.Render(trimmed) + mutedStyle.Render(comment)
+	}
+
+	// List dashes, possibly several (`- - a`)
+	for strings.HasPrefix(trimmed, "- ") || trimmed == "-" {
+		b.WriteString(mutedStyle.Render("-"))
+		trimmed = strings.TrimPrefix(trimmed, "-")
+		spaces := len(trimmed) - len(strings.TrimLeft(trimmed, " "))
+		b.WriteString(trimmed[:spaces])
+		trimmed = trimmed[spaces:]
+	}
+
+	if m := yamlKeyPattern.FindStringSubmatch(trimmed); m != nil {
+		b.WriteString(keyStyle.Render(m[1]))
+		b.WriteString(mutedStyle.Render(m[2]))
+		b.WriteString(m[3])
+		trimmed = trimmed[len(m[0]):]
+	}
+
+	value := strings.TrimRight(trimmed, " ")
+	spaces := len(value) - len(strings.TrimLeft(value, " "))
+	b.WriteString(value[:spaces])
+	value = value[spaces:]
+	switch {
+	case value == "":
+	case strings.HasPrefix(value, "&") || strings.HasPrefix(value, "*") || strings.HasPrefix(value, "<<"):
+		b.WriteString(refStyle.Render(value))
+	case strings.HasPrefix(value, `"`) || strings.HasPrefix(value, "'"):
+		b.WriteString(stringStyle.Render(value))
+	case value == "|" || value == ">" || strings.HasPrefix(value, "|-") || strings.HasPrefix(value, ">-"):
+		b.WriteString(mutedStyle.Render(value))
+	case yamlScalarPattern.MatchString(strings.ToLower(value)):
+		b.WriteString(scalarStyle.Render(value))
+	default:
+		b.WriteString(value)
+	}
+	b.WriteString(trimmed[len(strings.TrimRight(trimmed, " ")):])
+
+	if comment != "" {
+		b.WriteString(mutedStyle.Render(comment))
+	}
+	return b.String()
+}
+
+// splitYAMLComment splits a line at the # starting a comment, which is at
+// the start of the line or after a space and not inside quotes
+func splitYAMLComment(line string) (code, comment string) {
+	var quote rune
+	for i, r := range line {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return line[:i], line[i:]
+		}
+	}
+	return line, ""
+}
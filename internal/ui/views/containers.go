@@ -2,11 +2,14 @@ package views
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/rizface/doui/internal/models"
+	"github.com/rizface/doui/internal/ui/keys"
 	"github.com/rizface/doui/internal/ui/styles"
 )
 
@@ -14,38 +17,123 @@ import (
 type ContainerItem struct {
 	container  models.Container
 	rebuilding bool
+	isNew      bool
+	watched    bool
+	runtime    bool     // Show the runtime column
+	groups     []string // Colors of the groups the container is in
 }
 
 func (i ContainerItem) FilterValue() string {
 	return i.container.Name
 }
 
+func (i ContainerItem) itemID() string {
+	return i.container.ID
+}
+
 func (i ContainerItem) Title() string {
 	if i.rebuilding {
 		status := styles.WarningStyle.Render("rebuilding...")
 		return fmt.Sprintf("%s  %s", i.container.Name, status)
 	}
-	status := styles.GetStatusStyle(i.container.State).Render(i.container.State)
-	return fmt.Sprintf("%s  %s", i.container.Name, status)
+	name := i.container.Name
+	if len(i.groups) > 0 {
+		markers := make([]string, len(i.groups))
+		for j, color := range i.groups {
+			markers[j] = styles.GroupMarker(color)
+		}
+		name += " " + strings.Join(markers, "")
+	}
+	title := fmt.Sprintf("%s  %s", name, styles.GetStatusStyle(i.container.State).Render(i.container.State))
+	if i.isNew {
+		title += " " + styles.NewBadgeStyle.Render("[new]")
+	}
+	if i.container.ArchWarning != "" {
+		title += " " + styles.WarningStyle.Render("[arch]")
+	}
+	if i.watched {
+		title += " " + styles.DescStyle.Render("[watched]")
+	}
+	return title
 }
 
 func (i ContainerItem) Description() string {
 	if i.rebuilding {
 		return styles.SubtitleStyle.Render("Container is being rebuilt, please wait...")
 	}
-	return fmt.Sprintf("ID: %s | Image: %s | %s",
+	if i.container.ArchWarning != "" {
+		return fmt.Sprintf("ID: %s | %s", i.container.ShortID, styles.WarningStyle.Render("⚠ "+i.container.ArchWarning))
+	}
+	desc := fmt.Sprintf("ID: %s | Image: %s | %s",
 		i.container.ShortID,
 		i.container.Image,
 		i.container.Status)
+	if i.runtime && i.container.Runtime.Known() {
+		desc += " | " + RuntimeBadges(i.container.Runtime)
+	}
+	return desc
+}
+
+// RuntimeBadges renders a container's runtime, platform and privileged flag,
+// highlighting sandboxed runtimes and privileged containers
+func RuntimeBadges(info models.RuntimeInfo) string {
+	var badges []string
+	if name := models.RuntimeName(info.Runtime); name != "" {
+		style := styles.DescStyle
+		if info.Sandboxed() {
+			style = styles.SuccessStyle
+		}
+		badges = append(badges, style.Render("["+name+"]"))
+	}
+	if info.Platform != "" {
+		badges = append(badges, styles.DescStyle.Render("["+info.Platform+"]"))
+	}
+	if info.Privileged {
+		badges = append(badges, styles.ErrorStyle.Render("[privileged]"))
+	}
+	return strings.Join(badges, " ")
 }
 
 // ContainersView displays the list of containers
 type ContainersView struct {
-	list            list.Model
-	containers      []models.Container
-	width           int
-	height          int
-	rebuildingName  string // Name of container currently being rebuilt
+	list           list.Model
+	containers     []models.Container
+	width          int
+	height         int
+	rebuildingName string // Name of container currently being rebuilt
+	newTracker     *newItemTracker
+
+	// Quick filters applied on top of the list (independent of "/" filtering)
+	stateFilter   string // "running", "exited" or "" for all
+	projectFilter string // Compose project name
+	labelFilter   string // "key" or "key=value"
+	imageFilter   string // Image ID, when drilled in from the images view
+	imageName     string // Tag of imageFilter, for the title
+
+	// Snapshot for comparing the container list over time
+	snapshot *models.ContainerSnapshot
+
+	// Names of the containers watched for exits
+	watched map[string]bool
+
+	// Container ID -> colors of the groups it is in
+	groupColors map[string][]string
+
+	// Show each container's runtime, platform and privileged flag
+	runtimeColumn bool
+
+	// Per docker context state, restored when switching back to a context
+	contextName   string
+	contextStates map[string]*containersContextState
+}
+
+// containersContextState is the daemon specific part of the view
+type containersContextState struct {
+	newTracker    *newItemTracker
+	stateFilter   string
+	projectFilter string
+	labelFilter   string
+	snapshot      *models.ContainerSnapshot
 }
 
 // NewContainersView creates a new containers view
@@ -61,21 +149,104 @@ func NewContainersView() *ContainersView {
 	l.Styles.Title = styles.TitleStyle
 
 	return &ContainersView{
-		list: l,
+		list:          l,
+		newTracker:    newNewItemTracker(),
+		contextName:   models.DefaultContextName,
+		contextStates: make(map[string]*containersContextState),
+	}
+}
+
+// SwitchContext saves the filters, snapshot and badges of the current docker
+// context and restores those of name (or starts fresh), clearing the list
+// until the new daemon's containers are loaded
+func (v *ContainersView) SwitchContext(name string) {
+	if name == v.contextName {
+		return
+	}
+
+	v.contextStates[v.contextName] = &containersContextState{
+		newTracker:    v.newTracker,
+		stateFilter:   v.stateFilter,
+		projectFilter: v.projectFilter,
+		labelFilter:   v.labelFilter,
+		snapshot:      v.snapshot,
 	}
+
+	state, ok := v.contextStates[name]
+	if !ok {
+		state = &containersContextState{newTracker: newNewItemTracker()}
+	}
+	v.newTracker = state.newTracker
+	v.stateFilter = state.stateFilter
+	v.projectFilter = state.projectFilter
+	v.labelFilter = state.labelFilter
+	v.snapshot = state.snapshot
+
+	// Image IDs are per daemon, the drill-in doesn't carry over
+	v.imageFilter, v.imageName = "", ""
+	v.contextName = name
+	v.containers = nil
+	v.rebuildingName = ""
+	v.rebuildList()
 }
 
 // SetContainers updates the list of containers
 func (v *ContainersView) SetContainers(containers []models.Container) {
 	v.containers = containers
 
-	items := make([]list.Item, len(containers))
+	ids := make([]string, len(containers))
 	for i, c := range containers {
-		rebuilding := v.rebuildingName != "" && c.Name == v.rebuildingName
-		items[i] = ContainerItem{container: c, rebuilding: rebuilding}
+		ids[i] = c.ID
 	}
+	v.newTracker.Update(ids)
 
-	v.list.SetItems(items)
+	v.rebuildList()
+}
+
+// TakeSnapshot captures the current containers for later comparison
+func (v *ContainersView) TakeSnapshot() int {
+	v.snapshot = models.NewContainerSnapshot(v.containers)
+	return len(v.containers)
+}
+
+// GetSnapshot returns the last snapshot, or nil if none was taken
+func (v *ContainersView) GetSnapshot() *models.ContainerSnapshot {
+	return v.snapshot
+}
+
+// RenderSnapshotDiff renders what was added, removed or changed since the snapshot
+func (v *ContainersView) RenderSnapshotDiff() string {
+	if v.snapshot == nil {
+		return ""
+	}
+
+	diff := v.snapshot.Diff(v.containers)
+	if diff.IsEmpty() {
+		return styles.SubtitleStyle.Render("No changes since snapshot.")
+	}
+
+	var lines []string
+	for _, c := range diff.Added {
+		lines = append(lines, styles.SuccessStyle.Render(fmt.Sprintf("+ %s (%s, %s)", c.Name, c.Image, c.State)))
+	}
+	for _, c := range diff.Removed {
+		lines = append(lines, styles.ErrorStyle.Render(fmt.Sprintf("- %s (%s)", c.Name, c.Image)))
+	}
+	for _, change := range diff.Changed {
+		var details []string
+		if change.Before.State != change.After.State {
+			details = append(details, fmt.Sprintf("%s → %s", change.Before.State, change.After.State))
+		}
+		if change.Before.Image != change.After.Image {
+			details = append(details, fmt.Sprintf("image %s → %s", change.Before.Image, change.After.Image))
+		}
+		if change.Recreated() {
+			details = append(details, "recreated")
+		}
+		lines = append(lines, styles.WarningStyle.Render(fmt.Sprintf("~ %s: %s", change.After.Name, strings.Join(details, ", "))))
+	}
+
+	return strings.Join(lines, "\n")
 }
 
 // SetRebuilding marks a container as being rebuilt
@@ -103,12 +274,198 @@ func (v *ContainersView) IsAnyRebuilding() bool {
 
 // rebuildList rebuilds the list items with current state
 func (v *ContainersView) rebuildList() {
-	items := make([]list.Item, len(v.containers))
-	for i, c := range v.containers {
+	items := make([]list.Item, 0, len(v.containers))
+	for _, c := range v.containers {
+		if !v.matchesQuickFilters(c) {
+			continue
+		}
 		rebuilding := v.rebuildingName != "" && c.Name == v.rebuildingName
-		items[i] = ContainerItem{container: c, rebuilding: rebuilding}
+		items = append(items, ContainerItem{
+			container:  c,
+			rebuilding: rebuilding,
+			isNew:      v.newTracker.IsNew(c.ID),
+			watched:    v.watched[c.Name],
+			runtime:    v.runtimeColumn,
+			groups:     v.groupColors[c.ID],
+		})
+	}
+	setItemsKeepSelection(&v.list, items)
+	v.updateTitle()
+}
+
+// matchesQuickFilters returns true if the container passes all active quick filters
+func (v *ContainersView) matchesQuickFilters(c models.Container) bool {
+	if v.stateFilter != "" && c.State != v.stateFilter {
+		return false
+	}
+	if v.projectFilter != "" && c.Labels["com.docker.compose.project"] != v.projectFilter {
+		return false
+	}
+	if v.imageFilter != "" && c.ImageID != v.imageFilter {
+		return false
+	}
+	if v.labelFilter != "" {
+		key, value, hasValue := strings.Cut(v.labelFilter, "=")
+		labelValue, ok := c.Labels[key]
+		if !ok || (hasValue && labelValue != value) {
+			return false
+		}
+	}
+	return true
+}
+
+// updateTitle shows the active quick filters in the list title
+func (v *ContainersView) updateTitle() {
+	var filters []string
+	if v.stateFilter != "" {
+		filters = append(filters, v.stateFilter)
+	}
+	if v.projectFilter != "" {
+		filters = append(filters, "project="+v.projectFilter)
 	}
-	v.list.SetItems(items)
+	if v.labelFilter != "" {
+		filters = append(filters, "label="+v.labelFilter)
+	}
+	if v.imageFilter != "" {
+		filters = append(filters, "image="+v.imageName)
+	}
+
+	if len(filters) == 0 {
+		v.list.Title = "Docker Containers"
+		return
+	}
+	v.list.Title = fmt.Sprintf("Docker Containers [%s]", strings.Join(filters, " • "))
+}
+
+// ToggleStateFilter shows only containers in the given state, or all if already active
+func (v *ContainersView) ToggleStateFilter(state string) {
+	if v.stateFilter == state {
+		v.stateFilter = ""
+	} else {
+		v.stateFilter = state
+	}
+	v.rebuildList()
+}
+
+// CycleProjectFilter steps through the compose projects of the listed containers
+// and returns the active project ("" once the cycle wraps back to all)
+func (v *ContainersView) CycleProjectFilter() string {
+	var projects []string
+	seen := make(map[string]bool)
+	for _, c := range v.containers {
+		project := c.Labels["com.docker.compose.project"]
+		if project != "" && !seen[project] {
+			seen[project] = true
+			projects = append(projects, project)
+		}
+	}
+	sort.Strings(projects)
+
+	next := ""
+	for i, project := range projects {
+		if v.projectFilter == "" {
+			next = project
+			break
+		}
+		if project == v.projectFilter {
+			if i+1 < len(projects) {
+				next = projects[i+1]
+			}
+			break
+		}
+	}
+
+	v.projectFilter = next
+	v.rebuildList()
+	return next
+}
+
+// SetLabelFilter shows only containers with the label ("key" or "key=value")
+func (v *ContainersView) SetLabelFilter(filter string) {
+	v.labelFilter = strings.TrimSpace(filter)
+	v.rebuildList()
+}
+
+// GetLabelFilter returns the active label filter
+func (v *ContainersView) GetLabelFilter() string {
+	return v.labelFilter
+}
+
+// ClearQuickFilters removes all quick filters
+func (v *ContainersView) ClearQuickFilters() {
+	v.stateFilter = ""
+	v.projectFilter = ""
+	v.labelFilter = ""
+	v.imageFilter, v.imageName = "", ""
+	v.rebuildList()
+}
+
+// SetImageFilter shows only the containers created from an image, name being
+// its tag for the title. An empty id removes the filter.
+func (v *ContainersView) SetImageFilter(id, name string) {
+	v.imageFilter, v.imageName = id, name
+	v.rebuildList()
+}
+
+// ImageFilter returns the ID of the image whose containers are shown, or ""
+func (v *ContainersView) ImageFilter() string {
+	return v.imageFilter
+}
+
+// SetWatched marks the containers watched for exits, by name
+func (v *ContainersView) SetWatched(names []string) {
+	v.watched = make(map[string]bool, len(names))
+	for _, name := range names {
+		v.watched[name] = true
+	}
+	v.rebuildList()
+}
+
+// SetGroups marks the containers of each group with the group's color
+func (v *ContainersView) SetGroups(groups []models.Group) {
+	v.groupColors = make(map[string][]string)
+	for _, g := range groups {
+		for _, id := range g.ContainerIDs {
+			v.groupColors[id] = append(v.groupColors[id], g.Color)
+		}
+	}
+	v.rebuildList()
+}
+
+// ToggleRuntimeColumn shows or hides the runtime column and returns whether
+// it is now shown
+func (v *ContainersView) ToggleRuntimeColumn() bool {
+	v.SetRuntimeColumn(!v.runtimeColumn)
+	return v.runtimeColumn
+}
+
+// SetRuntimeColumn shows or hides the runtime column
+func (v *ContainersView) SetRuntimeColumn(show bool) {
+	v.runtimeColumn = show
+	v.rebuildList()
+}
+
+// RuntimeColumn returns true if the runtime column is shown
+func (v *ContainersView) RuntimeColumn() bool {
+	return v.runtimeColumn
+}
+
+// QuickFilters returns the active state, project and label filters
+func (v *ContainersView) QuickFilters() (state, project, label string) {
+	return v.stateFilter, v.projectFilter, v.labelFilter
+}
+
+// SetQuickFilters replaces all quick filters (e.g. when restoring a session)
+func (v *ContainersView) SetQuickFilters(state, project, label string) {
+	v.stateFilter = state
+	v.projectFilter = project
+	v.labelFilter = strings.TrimSpace(label)
+	v.rebuildList()
+}
+
+// HasQuickFilters returns true if any quick filter is active
+func (v *ContainersView) HasQuickFilters() bool {
+	return v.stateFilter != "" || v.projectFilter != "" || v.labelFilter != "" || v.imageFilter != ""
 }
 
 // SetSize updates the view dimensions
@@ -129,20 +486,20 @@ func (v *ContainersView) Update(msg tea.Msg) (*ContainersView, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "s":
+		switch {
+		case key.Matches(msg, keys.Map.Start):
 			// Start container
 			if len(v.containers) > 0 && v.list.Index() < len(v.containers) {
 				// Will be handled by parent app
 				return v, nil
 			}
-		case "x":
+		case key.Matches(msg, keys.Map.Stop):
 			// Stop container
 			if len(v.containers) > 0 && v.list.Index() < len(v.containers) {
 				// Will be handled by parent app
 				return v, nil
 			}
-		case "r":
+		case key.Matches(msg, keys.Map.Restart):
 			// Restart container
 			if len(v.containers) > 0 && v.list.Index() < len(v.containers) {
 				// Will be handled by parent app
@@ -180,8 +537,8 @@ func (v *ContainersView) GetSelectedContainer() *models.Container {
 // SelectByID selects a container by its ID
 // Returns true if the container was found and selected
 func (v *ContainersView) SelectByID(containerID string) bool {
-	for i, c := range v.containers {
-		if c.ID == containerID {
+	for i, item := range v.list.Items() {
+		if containerItem, ok := item.(ContainerItem); ok && containerItem.container.ID == containerID {
 			v.list.Select(i)
 			return true
 		}
@@ -208,17 +565,34 @@ func (v *ContainersView) IsFiltering() bool {
 func (v *ContainersView) GetHelpText() string {
 	helps := []string{
 		styles.KeyStyle.Render("↑/↓") + " navigate",
-		styles.KeyStyle.Render("s") + " start",
-		styles.KeyStyle.Render("x") + " stop",
-		styles.KeyStyle.Render("r") + " restart",
-		styles.KeyStyle.Render("d") + " remove",
-		styles.KeyStyle.Render("e") + " shell",
-		styles.KeyStyle.Render("v") + " env",
-		styles.KeyStyle.Render("l") + " logs",
-		styles.KeyStyle.Render("t") + " stats",
+		styles.KeyStyle.Render(keys.Label(keys.Map.Start)) + " start",
+		styles.KeyStyle.Render(keys.Label(keys.Map.Stop)) + " stop",
+		styles.KeyStyle.Render(keys.Label(keys.Map.Restart)) + " restart",
+		styles.KeyStyle.Render(keys.Label(keys.Map.Delete)) + " remove",
+		styles.KeyStyle.Render(keys.Label(keys.Map.Shell)) + " shell",
+		styles.KeyStyle.Render(keys.Label(keys.Map.EditConfig)) + " env/labels/ports",
+		styles.KeyStyle.Render(keys.Label(keys.Map.EditCpuset)) + " cpuset",
+		styles.KeyStyle.Render(keys.Label(keys.Map.Logs)) + " logs",
+		styles.KeyStyle.Render(keys.Label(keys.Map.TailFile)) + " tail file",
+		styles.KeyStyle.Render(keys.Label(keys.Map.Stats)) + " stats",
+		styles.KeyStyle.Render(keys.Label(keys.Map.CopyID)) + " copy ID",
+		styles.KeyStyle.Render(keys.Label(keys.Map.CopyCommand)) + " copy cmd",
 		styles.KeyStyle.Render("/") + " filter",
-		styles.KeyStyle.Render("q") + " quit",
+		styles.KeyStyle.Render(keys.Labels(keys.Map.FilterRunning, keys.Map.FilterExited, keys.Map.FilterProject, keys.Map.FilterLabel)) + " running/exited/project/label",
+		styles.KeyStyle.Render(keys.Label(keys.Map.Snapshot)) + " snapshot",
+		styles.KeyStyle.Render(keys.Label(keys.Map.PortCheck)) + " port check",
+		styles.KeyStyle.Render(keys.Label(keys.Map.ClockCheck)) + " clock check",
+		styles.KeyStyle.Render(keys.Label(keys.Map.Watch)) + " watch",
+		styles.KeyStyle.Render(keys.Label(keys.Map.Inspect)) + " inspect",
+		styles.KeyStyle.Render(keys.Label(keys.Map.RuntimeColumn)) + " runtime column",
+	}
+	if v.snapshot != nil {
+		helps = append(helps, styles.KeyStyle.Render(keys.Label(keys.Map.SnapshotDiff))+" diff since snapshot")
+	}
+	if v.HasQuickFilters() {
+		helps = append(helps, styles.KeyStyle.Render(keys.Label(keys.Map.ClearFilters))+" clear filters")
 	}
+	helps = append(helps, styles.KeyStyle.Render(keys.Label(keys.Map.Quit))+" quit")
 
 	return strings.Join(helps, styles.SeparatorStyle.String())
 }
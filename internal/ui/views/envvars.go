@@ -2,17 +2,28 @@ package views
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/rizface/doui/internal/models"
 	"github.com/rizface/doui/internal/ui/components"
+	"github.com/rizface/doui/internal/ui/keys"
 	"github.com/rizface/doui/internal/ui/styles"
+	"github.com/rizface/doui/pkg/utils"
 )
 
-// EnvVarsView is a full-screen view for editing container env vars
+// EnvVarsView is a full-screen view for editing container env vars, labels and ports
 type EnvVarsView struct {
 	editor        *components.EnvEditor
+	labelEditor   *components.EnvEditor
+	portEditor    *components.EnvEditor
+	currentTab    models.EnvVarsTabType
+	freePortMin   int
+	freePortMax   int
+	suggestPorts  bool
+	published     map[int]bool // Host ports published by other containers
 	containerID   string
 	containerName string
 	originalEnv   []models.EnvVar
@@ -28,22 +39,97 @@ func NewEnvVarsView() *EnvVarsView {
 	}
 }
 
+// SetFreePortRange sets the host port range used for free port suggestions
+func (v *EnvVarsView) SetFreePortRange(min, max int) {
+	v.freePortMin = min
+	v.freePortMax = max
+}
+
+// SetPortSuggestions enables free host port suggestions (only when the
+// daemon runs on this machine) and sets the host ports already published,
+// which are never suggested
+func (v *EnvVarsView) SetPortSuggestions(enabled bool, published []models.PortBinding) {
+	v.suggestPorts = enabled
+	v.published = make(map[int]bool, len(published))
+	for _, p := range published {
+		v.published[p.HostPort] = true
+	}
+}
+
 // SetContainer initializes the view with container data
-func (v *EnvVarsView) SetContainer(containerID, containerName string, env []string) {
+func (v *EnvVarsView) SetContainer(containerID, containerName string, env []string, labels map[string]string, ports map[string][]models.HostPortBinding) {
 	v.containerID = containerID
 	v.containerName = containerName
 	v.originalEnv = models.ParseEnvVars(env)
 	v.editor = components.NewEnvEditor(v.originalEnv)
-	v.editor.SetSize(v.width, v.height-6)
+	v.editor.SetSize(v.width, v.height-7)
+	v.labelEditor = components.NewLabelEditor(models.ParseLabels(labels))
+	v.labelEditor.SetSize(v.width, v.height-7)
+	v.portEditor = components.NewPortEditor(models.PortBindingsToEnvVars(ports))
+	if v.suggestPorts {
+		v.portEditor.SetValueSuggester("Suggest free host port", v.suggestFreePort)
+	}
+	v.portEditor.SetSize(v.width, v.height-7)
+	v.currentTab = models.EnvVarsEnvTab
 	v.ready = true
 }
 
+// suggestFreePort probes the host for an unused port, skipping ports already
+// assigned in the editor or published by other containers
+func (v *EnvVarsView) suggestFreePort() (string, error) {
+	exclude := make(map[int]bool, len(v.published))
+	for port := range v.published {
+		exclude[port] = true
+	}
+	for _, hb := range v.GetPortBindings() {
+		for _, b := range hb {
+			if port, err := strconv.Atoi(b.HostPort); err == nil {
+				exclude[port] = true
+			}
+		}
+	}
+
+	port, err := utils.FindFreePort(v.freePortMin, v.freePortMax, exclude)
+	if err != nil {
+		return "", err
+	}
+	return strconv.Itoa(port), nil
+}
+
+// activeEditor returns the editor for the current tab
+func (v *EnvVarsView) activeEditor() *components.EnvEditor {
+	switch v.currentTab {
+	case models.EnvVarsLabelsTab:
+		return v.labelEditor
+	case models.EnvVarsPortsTab:
+		return v.portEditor
+	}
+	return v.editor
+}
+
+// GetCurrentTab returns the current tab
+func (v *EnvVarsView) GetCurrentTab() models.EnvVarsTabType {
+	return v.currentTab
+}
+
+// SwitchTab switches to the next or previous tab
+func (v *EnvVarsView) SwitchTab(direction int) {
+	tabCount := 3
+	v.currentTab = models.EnvVarsTabType((int(v.currentTab) + direction + tabCount) % tabCount)
+}
+
 // SetSize updates the view dimensions
 func (v *EnvVarsView) SetSize(width, height int) {
 	v.width = width
 	v.height = height
 	if v.editor != nil {
-		v.editor.SetSize(width, height-6)
+		v.editor.SetSize(width, height-7)
+	}
+	if v.labelEditor != nil {
+		v.labelEditor.SetSize(width, height-7)
+	}
+	if v.portEditor != nil {
+		v.portEditor.SetSize(width, height-7)
 	}
 }
 
@@ -55,26 +141,83 @@ func (v *EnvVarsView) GetEnvVars() []string {
 	return models.EnvVarsToStrings(v.editor.GetEnvVars())
 }
 
+// GetLabels returns the current labels
+func (v *EnvVarsView) GetLabels() map[string]string {
+	if v.labelEditor == nil {
+		return nil
+	}
+	return models.EnvVarsToLabels(v.labelEditor.GetEnvVars())
+}
+
+// GetPortBindings returns the current port bindings
+func (v *EnvVarsView) GetPortBindings() map[string][]models.HostPortBinding {
+	if v.portEditor == nil {
+		return nil
+	}
+	return models.EnvVarsToPortBindings(v.portEditor.GetEnvVars())
+}
+
 // IsModified returns true if changes were made
 func (v *EnvVarsView) IsModified() bool {
-	return v.editor != nil && v.editor.IsModified()
+	return (v.editor != nil && v.editor.IsModified()) ||
+		(v.labelEditor != nil && v.labelEditor.IsModified()) ||
+		(v.portEditor != nil && v.portEditor.IsModified())
 }
 
 // Update handles messages
 func (v *EnvVarsView) Update(msg tea.Msg) (*EnvVarsView, tea.Cmd) {
-	if v.editor == nil {
+	editor := v.activeEditor()
+	if editor == nil {
 		return v, nil
 	}
 
+	// Switch tabs when not editing or filtering
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && !editor.IsEditing() && !editor.IsFiltering() {
+		switch keyMsg.String() {
+		case "tab":
+			v.SwitchTab(1)
+			return v, nil
+		case "shift+tab":
+			v.SwitchTab(-1)
+			return v, nil
+		}
+	}
+
 	var cmd tea.Cmd
-	v.editor, cmd = v.editor.Update(msg)
+	switch v.currentTab {
+	case models.EnvVarsLabelsTab:
+		v.labelEditor, cmd = v.labelEditor.Update(msg)
+	case models.EnvVarsPortsTab:
+		v.portEditor, cmd = v.portEditor.Update(msg)
+	default:
+		v.editor, cmd = v.editor.Update(msg)
+	}
 	return v, cmd
 }
 
+// RenderTabBar renders the tab bar
+func (v *EnvVarsView) RenderTabBar() string {
+	tabs := []string{
+		v.renderTab("Environment", models.EnvVarsEnvTab),
+		v.renderTab("Labels", models.EnvVarsLabelsTab),
+		v.renderTab("Ports", models.EnvVarsPortsTab),
+	}
+
+	return lipgloss.JoinHorizontal(lipgloss.Top, tabs...) + "\n"
+}
+
+// renderTab renders a single tab
+func (v *EnvVarsView) renderTab(label string, tab models.EnvVarsTabType) string {
+	if v.currentTab == tab {
+		return styles.TabActiveStyle.Render(" " + label + " ")
+	}
+	return styles.TabInactiveStyle.Render(" " + label + " ")
+}
+
 // View renders the view
 func (v *EnvVarsView) View() string {
 	if !v.ready || v.editor == nil {
-		return "Loading environment variables..."
+		return "Loading container configuration..."
 	}
 
 	var b strings.Builder
@@ -84,19 +227,20 @@ func (v *EnvVarsView) View() string {
 	if len(shortID) > 12 {
 		shortID = shortID[:12]
 	}
-	title := fmt.Sprintf("Environment Variables: %s (%s)", v.containerName, shortID)
+	title := fmt.Sprintf("Container Config: %s (%s)", v.containerName, shortID)
 	b.WriteString(styles.TitleStyle.Render(title))
 	b.WriteString("\n")
+	b.WriteString(v.RenderTabBar())
 
 	// Modified indicator and save hint
-	if v.editor.IsModified() {
+	if v.IsModified() {
 		b.WriteString(styles.WarningStyle.Render("[Modified] "))
 	}
-	b.WriteString(styles.DescStyle.Render("Press Ctrl+S to save and rebuild container"))
+	b.WriteString(styles.DescStyle.Render(fmt.Sprintf("Press %s to save and rebuild container", keys.Label(keys.Map.Save))))
 	b.WriteString("\n\n")
 
 	// Editor
-	b.WriteString(v.editor.View())
+	b.WriteString(v.activeEditor().View())
 
 	return b.String()
 }
@@ -109,25 +253,29 @@ func (v *EnvVarsView) GetHelpText() string {
 
 	var helps []string
 
-	editorHelp := v.editor.GetHelpText()
+	editor := v.activeEditor()
+	editorHelp := editor.GetHelpText()
 	if editorHelp != "" {
 		helps = append(helps, editorHelp)
+		helps = append(helps, styles.KeyStyle.Render("tab")+" env/labels/ports")
 	}
 
-	if v.editor.IsModified() {
-		helps = append(helps, styles.KeyStyle.Render("ctrl+s")+" save & rebuild")
+	if v.IsModified() {
+		helps = append(helps, styles.KeyStyle.Render(keys.Label(keys.Map.Save))+" save & rebuild")
 	}
-	helps = append(helps, styles.KeyStyle.Render("esc")+" back (discard)")
+	helps = append(helps, styles.KeyStyle.Render(keys.Label(keys.Map.Back))+" back (discard)")
 
 	return strings.Join(helps, styles.SeparatorStyle.String())
 }
 
 // IsFiltering returns true if editor is filtering
 func (v *EnvVarsView) IsFiltering() bool {
-	return v.editor != nil && v.editor.IsFiltering()
+	editor := v.activeEditor()
+	return editor != nil && editor.IsFiltering()
 }
 
 // IsEditing returns true if editor is in add/edit mode
 func (v *EnvVarsView) IsEditing() bool {
-	return v.editor != nil && v.editor.IsEditing()
+	editor := v.activeEditor()
+	return editor != nil && editor.IsEditing()
 }
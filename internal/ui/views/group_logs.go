@@ -0,0 +1,140 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rizface/doui/internal/models"
+	"github.com/rizface/doui/internal/ui/keys"
+	"github.com/rizface/doui/internal/ui/styles"
+)
+
+// GroupLogsView tiles the last log lines of every container of a group in a
+// grid, refreshed with the auto-refresh of the app
+type GroupLogsView struct {
+	groupName string
+	panes     []models.GroupLogPane
+	loaded    bool
+	width     int
+	height    int
+}
+
+// NewGroupLogsView creates a new group logs dashboard
+func NewGroupLogsView() *GroupLogsView {
+	return &GroupLogsView{}
+}
+
+// SetGroup starts showing a group, panes arrive with SetPanes
+func (v *GroupLogsView) SetGroup(name string) {
+	v.groupName = name
+	v.panes = nil
+	v.loaded = false
+}
+
+// SetPanes replaces the panes with freshly read logs
+func (v *GroupLogsView) SetPanes(panes []models.GroupLogPane) {
+	v.panes = panes
+	v.loaded = true
+}
+
+// SetSize updates the view dimensions
+func (v *GroupLogsView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+}
+
+// columns returns how many panes fit side by side
+func (v *GroupLogsView) columns() int {
+	cols := 1
+	switch {
+	case v.width >= 180:
+		cols = 3
+	case v.width >= 100:
+		cols = 2
+	}
+	return max(min(cols, len(v.panes)), 1)
+}
+
+// View renders the view
+func (v *GroupLogsView) View() string {
+	var b strings.Builder
+
+	b.WriteString(styles.TitleStyle.Render(fmt.Sprintf("Group Logs: %s (%d containers)", v.groupName, len(v.panes))))
+	b.WriteString("\n")
+
+	switch {
+	case !v.loaded:
+		b.WriteString(styles.SubtitleStyle.Render("Reading logs..."))
+		return b.String()
+	case len(v.panes) == 0:
+		b.WriteString(styles.SubtitleStyle.Render("This group has no containers."))
+		return b.String()
+	}
+
+	cols := v.columns()
+	rows := (len(v.panes) + cols - 1) / cols
+	paneWidth := max(v.width/cols-2, 20)
+	paneHeight := max((v.height-2)/rows-2, 3)
+
+	var grid []string
+	for r := 0; r < rows; r++ {
+		var row []string
+		for c := 0; c < cols && r*cols+c < len(v.panes); c++ {
+			row = append(row, v.renderPane(v.panes[r*cols+c], paneWidth, paneHeight))
+		}
+		grid = append(grid, lipgloss.JoinHorizontal(lipgloss.Top, row...))
+	}
+	b.WriteString(lipgloss.JoinVertical(lipgloss.Left, grid...))
+	return b.String()
+}
+
+// renderPane renders a container's title and as many of its last log lines
+// as fit in height rows
+func (v *GroupLogsView) renderPane(pane models.GroupLogPane, width, height int) string {
+	stateStyle := styles.StoppedStyle
+	switch pane.State {
+	case "running":
+		stateStyle = styles.RunningStyle
+	case "paused", "restarting":
+		stateStyle = styles.PausedStyle
+	}
+	title := styles.KeyStyle.Render(pane.Name) + " " + stateStyle.Render(pane.State)
+
+	var body []string
+	switch {
+	case pane.Err != "":
+		body = []string{styles.ErrorStyle.Render(pane.Err)}
+	case len(pane.Lines) == 0:
+		body = []string{styles.DescStyle.Render("no output")}
+	default:
+		lines := pane.Lines
+		if len(lines) > height-1 {
+			lines = lines[len(lines)-(height-1):]
+		}
+		lineStyle := lipgloss.NewStyle().MaxWidth(width - 4)
+		for _, line := range lines {
+			body = append(body, lineStyle.Render(strings.ReplaceAll(line, "\t", "    ")))
+		}
+	}
+
+	content := lipgloss.NewStyle().MaxWidth(width-4).Render(title) + "\n" + strings.Join(body, "\n")
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.ColorMuted).
+		Padding(0, 1).
+		Width(width - 2).
+		Height(height).
+		MaxHeight(height + 2).
+		Render(content)
+}
+
+// GetHelpText returns help text for the group logs view
+func (v *GroupLogsView) GetHelpText() string {
+	helps := []string{
+		styles.KeyStyle.Render(keys.Label(keys.Map.Refresh)) + " refresh now",
+		styles.KeyStyle.Render(keys.Label(keys.Map.Back)) + " back",
+	}
+
+	return strings.Join(helps, styles.SeparatorStyle.String())
+}
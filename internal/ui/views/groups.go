@@ -4,49 +4,105 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/rizface/doui/internal/models"
+	"github.com/rizface/doui/internal/ui/keys"
 	"github.com/rizface/doui/internal/ui/styles"
 )
 
 // GroupItem implements list.Item for groups
 type GroupItem struct {
-	group models.Group
+	group    models.Group
+	includes []string // Names of the groups it includes
+	running  int      // Containers of the group running
 }
 
 func (i GroupItem) FilterValue() string {
 	return i.group.Name
 }
 
+func (i GroupItem) itemID() string {
+	return i.group.ID
+}
+
 func (i GroupItem) Title() string {
-	return fmt.Sprintf("%s (%d containers)", i.group.Name, len(i.group.ContainerIDs))
+	title := fmt.Sprintf("%s %s (%d containers)", styles.GroupMarker(i.group.Color), i.group.Name, len(i.group.ContainerIDs))
+	total := len(i.group.ContainerIDs)
+	switch {
+	case total == 0:
+		return title
+	case i.running == total:
+		return title + "  " + styles.RunningStyle.Render("all running")
+	case i.running > 0:
+		return title + "  " + styles.PausedStyle.Render(fmt.Sprintf("%d/%d running", i.running, total))
+	default:
+		return title + "  " + styles.StoppedStyle.Render("stopped")
+	}
 }
 
 func (i GroupItem) Description() string {
+	desc := "No description"
 	if i.group.Description != "" {
-		return i.group.Description
+		desc = i.group.Description
+	}
+	if i.group.Hotkey != "" {
+		desc += fmt.Sprintf(" | Hotkey %s %s", keys.Label(keys.Map.GroupHotkey), i.group.Hotkey)
+	}
+	if len(i.includes) > 0 {
+		desc += " | Includes: " + strings.Join(i.includes, ", ")
+	}
+	if i.group.StartSchedule != "" {
+		desc += " | Starts " + i.group.StartSchedule
 	}
-	return "No description"
+	if i.group.StopSchedule != "" {
+		desc += " | Stops " + i.group.StopSchedule
+	}
+	if i.group.OrderedStop {
+		desc += " | Ordered stop"
+	}
+	return desc
 }
 
 // ContainerItemForGroup implements list.Item for containers in groups view
 type ContainerItemForGroup struct {
-	container models.Container
+	container   models.Container
+	dependsOn   []string // Names of containers this one starts after
+	waitHealthy bool     // Dependents wait for this container to be healthy
+	startDelay  int      // Seconds dependents wait after it started
+	stopGrace   int      // Seconds to stop gracefully, 0 if default
 }
 
 func (i ContainerItemForGroup) FilterValue() string {
 	return i.container.Name
 }
 
+func (i ContainerItemForGroup) itemID() string {
+	return i.container.ID
+}
+
 func (i ContainerItemForGroup) Title() string {
 	status := styles.GetStatusStyle(i.container.State).Render(i.container.State)
 	return fmt.Sprintf("%s  %s", i.container.Name, status)
 }
 
 func (i ContainerItemForGroup) Description() string {
-	return fmt.Sprintf("ID: %s | Image: %s", i.container.ShortID, i.container.Image)
+	desc := fmt.Sprintf("ID: %s | Image: %s", i.container.ShortID, i.container.Image)
+	if len(i.dependsOn) > 0 {
+		desc += " | After: " + strings.Join(i.dependsOn, ", ")
+	}
+	if i.waitHealthy {
+		desc += " | Wait healthy"
+	}
+	if i.startDelay > 0 {
+		desc += fmt.Sprintf(" | Delay %ds", i.startDelay)
+	}
+	if i.stopGrace > 0 {
+		desc += fmt.Sprintf(" | Stop grace %ds", i.stopGrace)
+	}
+	return desc
 }
 
 // GroupsView displays the tabbed groups management interface
@@ -115,13 +171,7 @@ func NewGroupsView() *GroupsView {
 // SetGroups updates the list of groups
 func (v *GroupsView) SetGroups(groups []models.Group) {
 	v.groups = groups
-
-	items := make([]list.Item, len(groups))
-	for i, g := range groups {
-		items[i] = GroupItem{group: g}
-	}
-
-	v.groupsList.SetItems(items)
+	v.updateGroupList()
 
 	// Refresh selectedGroup if one is selected (to get updated ContainerIDs)
 	if v.selectedGroup != nil {
@@ -147,9 +197,33 @@ func (v *GroupsView) SetGroups(groups []models.Group) {
 // SetAllContainers updates the list of all containers
 func (v *GroupsView) SetAllContainers(containers []models.Container) {
 	v.allContainers = containers
+	v.updateGroupList()
 	v.updateContainerLists()
 }
 
+// updateGroupList rebuilds the group items, counting the running
+// containers of each group
+func (v *GroupsView) updateGroupList() {
+	running := make(map[string]bool, len(v.allContainers))
+	for _, c := range v.allContainers {
+		if c.State == "running" {
+			running[c.ID] = true
+		}
+	}
+
+	items := make([]list.Item, len(v.groups))
+	for i, g := range v.groups {
+		item := GroupItem{group: g, includes: v.GetIncludedNames(&g)}
+		for _, id := range g.ContainerIDs {
+			if running[id] {
+				item.running++
+			}
+		}
+		items[i] = item
+	}
+	setItemsKeepSelection(&v.groupsList, items)
+}
+
 // SetSize updates the view dimensions
 func (v *GroupsView) SetSize(width, height int) {
 	v.width = width
@@ -162,6 +236,20 @@ func (v *GroupsView) SetSize(width, height int) {
 	v.availableContainersList.SetSize(width, listHeight)
 }
 
+// GetIncludedNames returns the names of the groups a group includes
+func (v *GroupsView) GetIncludedNames(group *models.Group) []string {
+	var names []string
+	for _, id := range group.GroupIDs {
+		for _, g := range v.groups {
+			if g.ID == id {
+				names = append(names, g.Name)
+				break
+			}
+		}
+	}
+	return names
+}
+
 // GetSelectedGroup returns the currently selected group
 func (v *GroupsView) GetSelectedGroup() *models.Group {
 	item := v.groupsList.SelectedItem()
@@ -203,10 +291,14 @@ func (v *GroupsView) GetContainersInGroup() []models.Container {
 	if v.selectedGroup == nil {
 		return []models.Container{}
 	}
+	return v.ContainersForGroup(v.selectedGroup)
+}
 
+// ContainersForGroup returns the existing containers that belong to group
+func (v *GroupsView) ContainersForGroup(group *models.Group) []models.Container {
 	// Build set of container IDs in group
 	inGroup := make(map[string]bool)
-	for _, id := range v.selectedGroup.ContainerIDs {
+	for _, id := range group.ContainerIDs {
 		inGroup[id] = true
 	}
 
@@ -242,15 +334,48 @@ func (v *GroupsView) GetAvailableContainers() []models.Container {
 	return result
 }
 
+// GetDependencyNames returns the names of the containers that containerID
+// starts after in the selected group
+func (v *GroupsView) GetDependencyNames(containerID string) []string {
+	if v.selectedGroup == nil {
+		return nil
+	}
+
+	var names []string
+	for _, depID := range v.selectedGroup.DependsOn[containerID] {
+		name := depID
+		if len(name) > 12 {
+			name = name[:12]
+		}
+		for _, c := range v.allContainers {
+			if c.ID == depID {
+				name = c.Name
+				break
+			}
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
 // updateContainerLists updates the container lists based on selected group
 func (v *GroupsView) updateContainerLists() {
 	// Update containers in group
 	inGroupContainers := v.GetContainersInGroup()
 	inGroupItems := make([]list.Item, len(inGroupContainers))
 	for i, c := range inGroupContainers {
-		inGroupItems[i] = ContainerItemForGroup{container: c}
+		item := ContainerItemForGroup{container: c}
+		if v.selectedGroup != nil {
+			item.dependsOn = v.GetDependencyNames(c.ID)
+			item.waitHealthy = v.selectedGroup.WaitHealthy[c.ID]
+			item.startDelay = v.selectedGroup.GetStartDelay(c.ID)
+			if grace := v.selectedGroup.GetStopGracePeriod(c.ID); grace != models.DefaultStopGracePeriod {
+				item.stopGrace = grace
+			}
+		}
+		inGroupItems[i] = item
 	}
-	v.containersInGroupList.SetItems(inGroupItems)
+	setItemsKeepSelection(&v.containersInGroupList, inGroupItems)
 
 	// Update available containers
 	availableContainers := v.GetAvailableContainers()
@@ -258,7 +383,7 @@ func (v *GroupsView) updateContainerLists() {
 	for i, c := range availableContainers {
 		availableItems[i] = ContainerItemForGroup{container: c}
 	}
-	v.availableContainersList.SetItems(availableItems)
+	setItemsKeepSelection(&v.availableContainersList, availableItems)
 }
 
 // SwitchTab switches to the next or previous tab
@@ -308,18 +433,18 @@ func (v *GroupsView) Update(msg tea.Msg) (*GroupsView, tea.Cmd) {
 	// Handle key messages
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "[", "left":
+		switch {
+		case key.Matches(msg, keys.Map.PrevTab):
 			// Switch to previous tab
 			v.SwitchTab(-1)
 			return v, nil
 
-		case "]", "right":
+		case key.Matches(msg, keys.Map.NextTab):
 			// Switch to next tab
 			v.SwitchTab(+1)
 			return v, nil
 
-		case "enter":
+		case key.Matches(msg, keys.Map.Select):
 			if v.currentTab == models.GroupsListTab {
 				// Select group and switch to "In Group" tab
 				v.selectedGroup = v.GetSelectedGroup()
@@ -331,7 +456,7 @@ func (v *GroupsView) Update(msg tea.Msg) (*GroupsView, tea.Cmd) {
 			}
 			// enter key in Available tab is handled in app.go for adding container
 
-		case "esc":
+		case key.Matches(msg, keys.Map.Back):
 			// Return to Groups list tab
 			if v.currentTab != models.GroupsListTab {
 				v.currentTab = models.GroupsListTab
@@ -419,7 +544,7 @@ func (v *GroupsView) renderEmpty() string {
 	b.WriteString("\n\n")
 	b.WriteString(styles.SubtitleStyle.Render("No groups found. Create a group to manage multiple containers together."))
 	b.WriteString("\n\n")
-	b.WriteString(styles.DescStyle.Render("Press 'n' to create a new group"))
+	b.WriteString(styles.DescStyle.Render(fmt.Sprintf("Press '%s' to create a new group", keys.Label(keys.Map.New))))
 
 	return b.String()
 }
@@ -442,42 +567,53 @@ func (v *GroupsView) GetHelpText() string {
 	case models.GroupsListTab:
 		helps = []string{
 			styles.KeyStyle.Render("↑/↓") + " navigate",
-			styles.KeyStyle.Render("enter") + " select",
-			styles.KeyStyle.Render("n") + " new",
-			styles.KeyStyle.Render("s") + " start all",
-			styles.KeyStyle.Render("x") + " stop all",
-			styles.KeyStyle.Render("d") + " delete",
-			styles.KeyStyle.Render("[/]") + " tabs",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Select)) + " select",
+			styles.KeyStyle.Render(keys.Label(keys.Map.New)) + " new",
+			styles.KeyStyle.Render(keys.Label(keys.Map.EditConfig)) + " edit",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Start)) + " start all (ordered)",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Stop)) + " stop all",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Restart)) + " restart all",
+			styles.KeyStyle.Render(keys.Label(keys.Map.OrderedStop)) + " ordered stop",
+			styles.KeyStyle.Render(keys.Label(keys.Map.GroupEnv)) + " set env on all",
+			styles.KeyStyle.Render(keys.Label(keys.Map.ExportCompose)) + " export compose",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Delete)) + " delete",
+			styles.KeyStyle.Render(keys.Labels(keys.Map.PrevTab, keys.Map.NextTab)) + " tabs",
 			styles.KeyStyle.Render("/") + " filter",
 		}
 
 	case models.GroupsContainersTab:
 		helps = []string{
 			styles.KeyStyle.Render("↑/↓") + " navigate",
-			styles.KeyStyle.Render("s") + " start",
-			styles.KeyStyle.Render("x") + " stop",
-			styles.KeyStyle.Render("r") + " restart",
-			styles.KeyStyle.Render("d") + " delete",
-			styles.KeyStyle.Render("e") + " shell",
-			styles.KeyStyle.Render("l") + " logs",
-			styles.KeyStyle.Render("t") + " stats",
-			styles.KeyStyle.Render("v") + " env",
-			styles.KeyStyle.Render("u") + " unlink",
-			styles.KeyStyle.Render("[/]") + " tabs",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Start)) + " start",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Stop)) + " stop",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Restart)) + " restart",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Delete)) + " delete",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Shell)) + " shell",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Logs)) + " logs",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Stats)) + " stats",
+			styles.KeyStyle.Render(keys.Label(keys.Map.EditConfig)) + " env/labels/ports",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Unlink)) + " unlink",
+			styles.KeyStyle.Render(keys.Label(keys.Map.StartOrder)) + " start order",
+			styles.KeyStyle.Render(keys.Label(keys.Map.GroupEnv)) + " set env on all",
+			styles.KeyStyle.Render(keys.Label(keys.Map.CopyID)) + " copy ID",
+			styles.KeyStyle.Render(keys.Label(keys.Map.CopyCommand)) + " copy cmd",
+			styles.KeyStyle.Render(keys.Labels(keys.Map.PrevTab, keys.Map.NextTab)) + " tabs",
 			styles.KeyStyle.Render("/") + " filter",
 		}
 
 	case models.GroupsAvailableTab:
 		helps = []string{
 			styles.KeyStyle.Render("↑/↓") + " navigate",
-			styles.KeyStyle.Render("enter") + " add",
-			styles.KeyStyle.Render("[/]") + " tabs",
-			styles.KeyStyle.Render("esc") + " back",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Select)) + " add",
+			styles.KeyStyle.Render(keys.Label(keys.Map.CopyID)) + " copy ID",
+			styles.KeyStyle.Render(keys.Label(keys.Map.CopyCommand)) + " copy cmd",
+			styles.KeyStyle.Render(keys.Labels(keys.Map.PrevTab, keys.Map.NextTab)) + " tabs",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Back)) + " back",
 			styles.KeyStyle.Render("/") + " filter",
 		}
 	}
 
-	helps = append(helps, styles.KeyStyle.Render("q") + " quit")
+	helps = append(helps, styles.KeyStyle.Render(keys.Label(keys.Map.Quit)) + " quit")
 	return strings.Join(helps, styles.SeparatorStyle.String())
 }
 
@@ -486,6 +622,21 @@ func (v *GroupsView) GetCurrentTab() models.GroupsTabType {
 	return v.currentTab
 }
 
+// OpenGroup selects a group and shows its containers, like pressing enter on
+// it. Returns false if there is no such group.
+func (v *GroupsView) OpenGroup(id string) bool {
+	for i, item := range v.groupsList.Items() {
+		if groupItem, ok := item.(GroupItem); ok && groupItem.group.ID == id {
+			v.groupsList.Select(i)
+			v.selectedGroup = &groupItem.group
+			v.currentTab = models.GroupsContainersTab
+			v.updateContainerLists()
+			return true
+		}
+	}
+	return false
+}
+
 // GetSelectedGroupForApp returns the selected group for app operations
 func (v *GroupsView) GetSelectedGroupForApp() *models.Group {
 	return v.selectedGroup
@@ -0,0 +1,116 @@
+package views
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rizface/doui/internal/ui/keys"
+	"github.com/rizface/doui/internal/ui/styles"
+)
+
+// HelpView is a full-screen overlay listing every keybinding by view,
+// generated from the active key map
+type HelpView struct {
+	viewport viewport.Model
+	width    int
+	height   int
+}
+
+// NewHelpView creates a new help overlay
+func NewHelpView() *HelpView {
+	vp := viewport.New(0, 0)
+	vp.Style = styles.BorderStyle
+
+	return &HelpView{
+		viewport: vp,
+	}
+}
+
+// SetSize updates the view dimensions
+func (v *HelpView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+	headerHeight := 3
+	v.viewport.Width = width - 4
+	v.viewport.Height = height - headerHeight - 4
+	v.updateContent()
+}
+
+// Reset scrolls back to the top, called when the overlay is opened
+func (v *HelpView) Reset() {
+	v.updateContent()
+	v.viewport.GotoTop()
+}
+
+// Update handles messages
+func (v *HelpView) Update(msg tea.Msg) (*HelpView, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, keys.Map.Top):
+			v.viewport.GotoTop()
+			return v, nil
+		case key.Matches(msg, keys.Map.Bottom):
+			v.viewport.GotoBottom()
+			return v, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	v.viewport, cmd = v.viewport.Update(msg)
+	return v, cmd
+}
+
+// updateContent renders the key map into the viewport
+func (v *HelpView) updateContent() {
+	sections := keys.Map.HelpSections()
+
+	keyWidth := 0
+	for _, s := range sections {
+		for _, e := range s.Entries {
+			keyWidth = max(keyWidth, lipgloss.Width(e.Keys))
+		}
+	}
+
+	var b strings.Builder
+	for i, s := range sections {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(styles.TitleStyle.UnsetMarginBottom().Render(s.Title))
+		b.WriteString("\n")
+		for _, e := range s.Entries {
+			b.WriteString("  " + styles.KeyStyle.Render(padCell(e.Keys, keyWidth)) + "  " + e.Desc)
+			b.WriteString(styles.DescStyle.Render("  (" + e.Action + ")"))
+			b.WriteString("\n")
+		}
+	}
+
+	v.viewport.SetContent(b.String())
+}
+
+// View renders the help overlay
+func (v *HelpView) View() string {
+	header := styles.TitleStyle.Render("Keybindings")
+	subtitle := styles.SubtitleStyle.Render("Action names in parentheses can be remapped in config.json")
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		header,
+		subtitle,
+		v.viewport.View(),
+	)
+}
+
+// GetHelpText returns help text for the help overlay
+func (v *HelpView) GetHelpText() string {
+	helps := []string{
+		styles.KeyStyle.Render("↑/↓") + " scroll",
+		styles.KeyStyle.Render(keys.Labels(keys.Map.Top, keys.Map.Bottom)) + " top/bottom",
+		styles.KeyStyle.Render(keys.Labels(keys.Map.Back, keys.Map.Help)) + " close",
+	}
+	return strings.Join(helps, styles.SeparatorStyle.String())
+}
@@ -0,0 +1,195 @@
+package views
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rizface/doui/internal/models"
+	"github.com/rizface/doui/internal/ui/keys"
+	"github.com/rizface/doui/internal/ui/styles"
+	"github.com/rizface/doui/pkg/utils"
+)
+
+// historyDetailLines is how many lines the full created-by of the selected
+// layer may take below the list
+const historyDetailLines = 4
+
+// ImageHistoryView lists the build steps of an image with their size, to
+// find the instruction that bloats it
+type ImageHistoryView struct {
+	imageName string
+	layers    []models.ImageLayer
+	total     int64
+	largest   map[int]bool // The biggest layers, highlighted
+	cursor    int
+	offset    int
+	width     int
+	height    int
+}
+
+// NewImageHistoryView creates a new image history view
+func NewImageHistoryView() *ImageHistoryView {
+	return &ImageHistoryView{}
+}
+
+// SetHistory sets the image and its layers, newest first
+func (v *ImageHistoryView) SetHistory(img models.Image, layers []models.ImageLayer) {
+	v.imageName = img.GetPrimaryTag()
+	if img.IsDangling() {
+		v.imageName = img.ShortID
+	}
+	v.layers = layers
+	v.cursor = 0
+	v.offset = 0
+
+	v.total = 0
+	bySize := make([]int, 0, len(layers))
+	for i, layer := range layers {
+		v.total += layer.Size
+		if layer.Size > 0 {
+			bySize = append(bySize, i)
+		}
+	}
+	sort.SliceStable(bySize, func(i, j int) bool { return layers[bySize[i]].Size > layers[bySize[j]].Size })
+	v.largest = make(map[int]bool)
+	for _, i := range bySize[:min(3, len(bySize))] {
+		v.largest[i] = true
+	}
+}
+
+// SetSize updates the view dimensions
+func (v *ImageHistoryView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+}
+
+// GetSelectedLayer returns the layer under the cursor
+func (v *ImageHistoryView) GetSelectedLayer() *models.ImageLayer {
+	if v.cursor < 0 || v.cursor >= len(v.layers) {
+		return nil
+	}
+	return &v.layers[v.cursor]
+}
+
+// visibleRows is how many layers fit between the title and the details
+func (v *ImageHistoryView) visibleRows() int {
+	return max(v.height-8-historyDetailLines, 1)
+}
+
+// Update handles messages
+func (v *ImageHistoryView) Update(msg tea.Msg) (*ImageHistoryView, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || len(v.layers) == 0 {
+		return v, nil
+	}
+
+	switch {
+	case keyMsg.String() == "up" || keyMsg.String() == "k":
+		v.cursor--
+	case keyMsg.String() == "down" || keyMsg.String() == "j":
+		v.cursor++
+	case keyMsg.String() == "pgup":
+		v.cursor -= v.visibleRows()
+	case keyMsg.String() == "pgdown":
+		v.cursor += v.visibleRows()
+	case key.Matches(keyMsg, keys.Map.Top):
+		v.cursor = 0
+	case key.Matches(keyMsg, keys.Map.Bottom):
+		v.cursor = len(v.layers) - 1
+	}
+	v.cursor = min(max(v.cursor, 0), len(v.layers)-1)
+
+	// Keep the cursor on screen
+	if v.cursor < v.offset {
+		v.offset = v.cursor
+	} else if rows := v.visibleRows(); v.cursor >= v.offset+rows {
+		v.offset = v.cursor - rows + 1
+	}
+	return v, nil
+}
+
+// View renders the view
+func (v *ImageHistoryView) View() string {
+	var b strings.Builder
+
+	b.WriteString(styles.TitleStyle.Render(fmt.Sprintf("History: %s (%d steps, %s)", v.imageName, len(v.layers), utils.FormatBytes(v.total))))
+	b.WriteString("\n")
+	b.WriteString(styles.SubtitleStyle.Render("Newest first; the largest layers are highlighted"))
+	b.WriteString("\n\n")
+
+	if len(v.layers) == 0 {
+		b.WriteString(styles.DescStyle.Render("This image has no history."))
+		return styles.BorderStyle.Width(max(v.width-4, 0)).Render(b.String())
+	}
+
+	const instrWidth, sizeWidth, shareWidth, ageWidth = 10, 10, 5, 5
+	argsWidth := max(v.width-instrWidth-sizeWidth-shareWidth-ageWidth-16, 10)
+
+	end := min(v.offset+v.visibleRows(), len(v.layers))
+	for i := v.offset; i < end; i++ {
+		layer := v.layers[i]
+		instruction, args := layer.Instruction()
+		if instruction == "" {
+			instruction = "?"
+		}
+
+		share := "-"
+		if v.total > 0 && layer.Size > 0 {
+			share = fmt.Sprintf("%d%%", layer.Size*100/v.total)
+		}
+		size := fmt.Sprintf("%*s", sizeWidth, utils.FormatBytes(layer.Size))
+		sizeStyle := styles.DescStyle
+		if v.largest[i] {
+			sizeStyle = styles.WarningStyle
+		}
+
+		prefix := "  "
+		instrStyle := styles.KeyStyle
+		if i == v.cursor {
+			prefix = "> "
+			instrStyle = styles.SelectedItemStyle
+		}
+		b.WriteString(prefix + instrStyle.Render(padCell(instruction, instrWidth)) + "  " +
+			padCell(args, argsWidth) + "  " +
+			sizeStyle.Render(size) + " " +
+			sizeStyle.Render(fmt.Sprintf("%*s", shareWidth, share)) + "  " +
+			styles.DescStyle.Render(padCell(utils.FormatTimeSince(layer.Created), ageWidth)))
+		b.WriteString("\n")
+	}
+	if end < len(v.layers) || v.offset > 0 {
+		b.WriteString(styles.DescStyle.Render(fmt.Sprintf("  %d-%d of %d", v.offset+1, end, len(v.layers))))
+		b.WriteString("\n")
+	}
+
+	// Full created-by of the selected step, which the list truncates
+	if layer := v.GetSelectedLayer(); layer != nil {
+		b.WriteString("\n")
+		detail := layer.CreatedBy
+		if layer.Comment != "" {
+			detail += "  (" + layer.Comment + ")"
+		}
+		wrapped := strings.Split(lipgloss.NewStyle().Width(max(v.width-8, 10)).Render(detail), "\n")
+		if len(wrapped) > historyDetailLines {
+			wrapped = append(wrapped[:historyDetailLines-1], "…")
+		}
+		b.WriteString(styles.SubtitleStyle.Render(strings.Join(wrapped, "\n")))
+	}
+
+	return styles.BorderStyle.Width(max(v.width-4, 0)).Render(b.String())
+}
+
+// GetHelpText returns help text for the image history view
+func (v *ImageHistoryView) GetHelpText() string {
+	helps := []string{
+		styles.KeyStyle.Render("↑/↓") + " navigate",
+		styles.KeyStyle.Render(keys.Label(keys.Map.CopyID)) + " copy command",
+		styles.KeyStyle.Render(keys.Labels(keys.Map.Top, keys.Map.Bottom)) + " top/bottom",
+		styles.KeyStyle.Render(keys.Label(keys.Map.Back)) + " back",
+	}
+
+	return strings.Join(helps, styles.SeparatorStyle.String())
+}
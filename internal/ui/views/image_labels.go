@@ -0,0 +1,156 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/rizface/doui/internal/models"
+	"github.com/rizface/doui/internal/ui/keys"
+	"github.com/rizface/doui/internal/ui/styles"
+)
+
+// ImageLabelsView lists the labels of an image, OCI annotations first, with
+// a cursor to copy a value or its link
+type ImageLabelsView struct {
+	imageName string
+	labels    []models.ImageLabel
+	cursor    int
+	offset    int
+	width     int
+	height    int
+}
+
+// NewImageLabelsView creates a new image labels view
+func NewImageLabelsView() *ImageLabelsView {
+	return &ImageLabelsView{}
+}
+
+// SetImage sets the image whose labels are shown
+func (v *ImageLabelsView) SetImage(img models.Image) {
+	v.imageName = img.GetPrimaryTag()
+	if img.IsDangling() {
+		v.imageName = img.GetShortID()
+	}
+	v.labels = models.ImageLabels(img)
+	v.cursor = 0
+	v.offset = 0
+}
+
+// SetSize updates the view dimensions
+func (v *ImageLabelsView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+}
+
+// GetSelectedLabel returns the label under the cursor
+func (v *ImageLabelsView) GetSelectedLabel() *models.ImageLabel {
+	if v.cursor < 0 || v.cursor >= len(v.labels) {
+		return nil
+	}
+	return &v.labels[v.cursor]
+}
+
+// visibleRows is how many labels fit below the title, with their links
+func (v *ImageLabelsView) visibleRows() int {
+	return max((v.height-6)/2, 1)
+}
+
+// Update handles messages
+func (v *ImageLabelsView) Update(msg tea.Msg) (*ImageLabelsView, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || len(v.labels) == 0 {
+		return v, nil
+	}
+
+	switch {
+	case keyMsg.String() == "up" || keyMsg.String() == "k":
+		v.cursor--
+	case keyMsg.String() == "down" || keyMsg.String() == "j":
+		v.cursor++
+	case keyMsg.String() == "pgup":
+		v.cursor -= v.visibleRows()
+	case keyMsg.String() == "pgdown":
+		v.cursor += v.visibleRows()
+	case key.Matches(keyMsg, keys.Map.Top):
+		v.cursor = 0
+	case key.Matches(keyMsg, keys.Map.Bottom):
+		v.cursor = len(v.labels) - 1
+	}
+	v.cursor = min(max(v.cursor, 0), len(v.labels)-1)
+
+	// Keep the cursor on screen
+	if v.cursor < v.offset {
+		v.offset = v.cursor
+	} else if rows := v.visibleRows(); v.cursor >= v.offset+rows {
+		v.offset = v.cursor - rows + 1
+	}
+	return v, nil
+}
+
+// View renders the view
+func (v *ImageLabelsView) View() string {
+	var b strings.Builder
+
+	oci := 0
+	for _, label := range v.labels {
+		if label.OCI {
+			oci++
+		}
+	}
+	b.WriteString(styles.TitleStyle.Render(fmt.Sprintf("Labels: %s (%d, %d OCI)", v.imageName, len(v.labels), oci)))
+	b.WriteString("\n")
+	b.WriteString(styles.SubtitleStyle.Render("OCI annotations set by CI (org.opencontainers.image.*) first, then the other labels"))
+	b.WriteString("\n\n")
+
+	if len(v.labels) == 0 {
+		b.WriteString(styles.DescStyle.Render("This image has no labels."))
+		return styles.BorderStyle.Width(max(v.width-4, 0)).Render(b.String())
+	}
+
+	keyWidth := 0
+	for _, label := range v.labels {
+		keyWidth = max(keyWidth, len(label.Key))
+	}
+	keyWidth = min(keyWidth, 45)
+	valueWidth := max(v.width-keyWidth-12, 10)
+
+	end := min(v.offset+v.visibleRows(), len(v.labels))
+	for i := v.offset; i < end; i++ {
+		label := v.labels[i]
+		prefix := "  "
+		keyStyle := styles.DescStyle
+		if label.OCI {
+			keyStyle = styles.KeyStyle
+		}
+		if i == v.cursor {
+			prefix = "> "
+			keyStyle = styles.SelectedItemStyle
+		}
+		b.WriteString(prefix + keyStyle.Render(padCell(label.Key, keyWidth)) + "  " + padCell(label.Value, valueWidth))
+		b.WriteString("\n")
+		if label.Link != "" {
+			b.WriteString(strings.Repeat(" ", keyWidth+4) + styles.SubtitleStyle.Render(padCell("↳ "+label.Link, valueWidth)))
+			b.WriteString("\n")
+		}
+	}
+	if end < len(v.labels) || v.offset > 0 {
+		b.WriteString(styles.DescStyle.Render(fmt.Sprintf("  %d-%d of %d", v.offset+1, end, len(v.labels))))
+	}
+
+	return styles.BorderStyle.Width(max(v.width-4, 0)).Render(b.String())
+}
+
+// GetHelpText returns help text for the image labels view
+func (v *ImageLabelsView) GetHelpText() string {
+	helps := []string{
+		styles.KeyStyle.Render("↑/↓") + " navigate",
+		styles.KeyStyle.Render(keys.Label(keys.Map.CopyID)) + " copy value",
+		styles.KeyStyle.Render(keys.Label(keys.Map.CopyCommand)) + " copy link",
+		styles.KeyStyle.Render(keys.Labels(keys.Map.Top, keys.Map.Bottom)) + " top/bottom",
+		styles.KeyStyle.Render(keys.Label(keys.Map.Back)) + " back",
+	}
+
+	return strings.Join(helps, styles.SeparatorStyle.String())
+}
@@ -2,35 +2,52 @@ package views
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/rizface/doui/internal/models"
+	"github.com/rizface/doui/internal/ui/keys"
 	"github.com/rizface/doui/internal/ui/styles"
 )
 
 // ImageItem implements list.Item for images
 type ImageItem struct {
-	image    models.Image
-	selected bool
+	image     models.Image
+	selected  bool
+	isNew     bool
+	protected bool
 }
 
 func (i ImageItem) FilterValue() string {
 	return i.image.GetPrimaryTag()
 }
 
+func (i ImageItem) itemID() string {
+	return i.image.ID
+}
+
 func (i ImageItem) Title() string {
 	title := i.image.GetPrimaryTag()
 
 	// Add status markers
 	var markers []string
+	if i.isNew {
+		markers = append(markers, styles.NewBadgeStyle.Render("[new]"))
+	}
 	if i.image.IsDangling() {
 		markers = append(markers, styles.WarningStyle.Render("[dangling]"))
 	}
 	if i.image.IsUnused() {
 		markers = append(markers, styles.SubtitleStyle.Render("[unused]"))
 	}
+	if i.image.ArchWarning != "" {
+		markers = append(markers, styles.WarningStyle.Render("[arch]"))
+	}
+	if i.protected {
+		markers = append(markers, styles.KeyStyle.Render("[protected]"))
+	}
 
 	// Add selection marker
 	selectMark := "  "
@@ -50,16 +67,27 @@ func (i ImageItem) Description() string {
 	if i.image.Containers > 0 {
 		containers = fmt.Sprintf(" • %d container(s)", i.image.Containers)
 	}
+	if i.image.ArchWarning != "" {
+		return fmt.Sprintf("   ID: %s • %s", i.image.ShortID, styles.WarningStyle.Render("⚠ "+i.image.ArchWarning))
+	}
 	return fmt.Sprintf("   ID: %s • Size: %s%s", i.image.ShortID, size, containers)
 }
 
+// imageUsageFilters are the usage filters in the order the filter key
+// cycles through them, "" showing all images
+var imageUsageFilters = []string{"", "dangling", "unused", "in use"}
+
 // ImagesView displays the list of images
 type ImagesView struct {
-	list     list.Model
-	images   []models.Image
-	selected map[string]bool // Map of image ID to selection state
-	width    int
-	height   int
+	list        list.Model
+	images      []models.Image
+	selected    map[string]bool // Map of image ID to selection state
+	usageFilter string          // One of imageUsageFilters
+	protected   []string        // Tags or IDs of the images protected from removal
+	width       int
+	height      int
+
+	newTracker *newItemTracker
 }
 
 // NewImagesView creates a new images view
@@ -75,8 +103,9 @@ func NewImagesView() *ImagesView {
 	l.Styles.Title = styles.TitleStyle
 
 	return &ImagesView{
-		list:     l,
-		selected: make(map[string]bool),
+		list:       l,
+		selected:   make(map[string]bool),
+		newTracker: newNewItemTracker(),
 	}
 }
 
@@ -86,9 +115,12 @@ func (v *ImagesView) SetImages(images []models.Image) {
 
 	// Clean up selected map - remove IDs that no longer exist
 	existingIDs := make(map[string]bool)
+	ids := make([]string, 0, len(images))
 	for _, img := range images {
 		existingIDs[img.ID] = true
+		ids = append(ids, img.ID)
 	}
+	v.newTracker.Update(ids)
 	for id := range v.selected {
 		if !existingIDs[id] {
 			delete(v.selected, id)
@@ -100,14 +132,57 @@ func (v *ImagesView) SetImages(images []models.Image) {
 
 // rebuildList rebuilds the list items with current selection state
 func (v *ImagesView) rebuildList() {
-	items := make([]list.Item, len(v.images))
-	for i, img := range v.images {
-		items[i] = ImageItem{
-			image:    img,
-			selected: v.selected[img.ID],
+	items := make([]list.Item, 0, len(v.images))
+	for _, img := range v.images {
+		if !v.matchesUsageFilter(img) {
+			continue
 		}
+		items = append(items, ImageItem{
+			image:     img,
+			selected:  v.selected[img.ID],
+			isNew:     v.newTracker.IsNew(img.ID),
+			protected: img.IsProtected(v.protected),
+		})
 	}
-	v.list.SetItems(items)
+	setItemsKeepSelection(&v.list, items)
+
+	v.list.Title = "Docker Images"
+	if v.usageFilter != "" {
+		v.list.Title = fmt.Sprintf("Docker Images [%s]", v.usageFilter)
+	}
+}
+
+// matchesUsageFilter returns true if the image passes the usage filter
+func (v *ImagesView) matchesUsageFilter(img models.Image) bool {
+	switch v.usageFilter {
+	case "dangling":
+		return img.IsDangling()
+	case "unused":
+		return img.IsUnused()
+	case "in use":
+		return !img.IsUnused()
+	}
+	return true
+}
+
+// CycleUsageFilter steps through showing only dangling, only unused and only
+// in-use images, then all again, and returns the active filter
+func (v *ImagesView) CycleUsageFilter() string {
+	i := slices.Index(imageUsageFilters, v.usageFilter)
+	v.usageFilter = imageUsageFilters[(i+1)%len(imageUsageFilters)]
+	v.rebuildList()
+	return v.usageFilter
+}
+
+// ClearUsageFilter shows all images again
+func (v *ImagesView) ClearUsageFilter() {
+	v.usageFilter = ""
+	v.rebuildList()
+}
+
+// UsageFilter returns the active usage filter, "" if none
+func (v *ImagesView) UsageFilter() string {
+	return v.usageFilter
 }
 
 // SetSize updates the view dimensions
@@ -176,6 +251,49 @@ func (v *ImagesView) HasSelection() bool {
 	return len(v.selected) > 0
 }
 
+// Reset forgets images, selection and "new" badges, e.g. after switching to
+// another Docker daemon
+func (v *ImagesView) Reset() {
+	v.images = nil
+	v.selected = make(map[string]bool)
+	v.newTracker = newNewItemTracker()
+	v.rebuildList()
+}
+
+// SetProtected marks the images protected from removal, by tag or ID
+func (v *ImagesView) SetProtected(refs []string) {
+	v.protected = refs
+	v.rebuildList()
+}
+
+// IsProtected returns true if the image is protected from removal
+func (v *ImagesView) IsProtected(img models.Image) bool {
+	return img.IsProtected(v.protected)
+}
+
+// ProtectedIDs returns the IDs of the listed images that are protected
+func (v *ImagesView) ProtectedIDs() map[string]bool {
+	ids := make(map[string]bool)
+	for _, img := range v.images {
+		if img.IsProtected(v.protected) {
+			ids[img.ID] = true
+		}
+	}
+	return ids
+}
+
+// Unused counts the images no container uses, only the untagged ones with
+// danglingOnly, and adds up their sizes. Protected images aren't counted.
+func (v *ImagesView) Unused(danglingOnly bool) (count int, size int64) {
+	for _, img := range v.images {
+		if img.IsUnused() && (!danglingOnly || img.IsDangling()) && !img.IsProtected(v.protected) {
+			count++
+			size += img.Size
+		}
+	}
+	return count, size
+}
+
 // ClearSelection clears all selections
 func (v *ImagesView) ClearSelection() {
 	v.selected = make(map[string]bool)
@@ -206,12 +324,32 @@ func (v *ImagesView) IsFiltering() bool {
 func (v *ImagesView) GetHelpText() string {
 	helps := []string{
 		styles.KeyStyle.Render("↑/↓") + " navigate",
-		styles.KeyStyle.Render("space") + " select",
-		styles.KeyStyle.Render("d") + " remove",
-		styles.KeyStyle.Render("p") + " pull",
-		styles.KeyStyle.Render("P") + " prune",
+		styles.KeyStyle.Render(keys.Label(keys.Map.Select)) + " containers",
+		styles.KeyStyle.Render(keys.Label(keys.Map.ToggleSelect)) + " select",
+		styles.KeyStyle.Render(keys.Label(keys.Map.Delete)) + " remove",
+		styles.KeyStyle.Render(keys.Label(keys.Map.PullImage)) + " pull",
+		styles.KeyStyle.Render(keys.Label(keys.Map.PullList)) + " pull list",
+		styles.KeyStyle.Render(keys.Label(keys.Map.Inspect)) + " inspect",
+		styles.KeyStyle.Render(keys.Label(keys.Map.ImageLabels)) + " labels",
+		styles.KeyStyle.Render(keys.Label(keys.Map.ImageHistory)) + " history",
+		styles.KeyStyle.Render(keys.Label(keys.Map.CompareImages)) + " compare 2",
+		styles.KeyStyle.Render(keys.Label(keys.Map.ProtectImage)) + " protect",
+		styles.KeyStyle.Render(keys.Label(keys.Map.TagImage)) + " tag",
+		styles.KeyStyle.Render(keys.Label(keys.Map.QuickRun)) + " run",
+		styles.KeyStyle.Render(keys.Label(keys.Map.RunOnce)) + " run once",
+		styles.KeyStyle.Render(keys.Label(keys.Map.SaveImage)) + " save",
+		styles.KeyStyle.Render(keys.Label(keys.Map.LoadImage)) + " load",
+		styles.KeyStyle.Render(keys.Label(keys.Map.ScanImage)) + " scan",
+		styles.KeyStyle.Render(keys.Label(keys.Map.PruneImages)) + " prune",
+		styles.KeyStyle.Render(keys.Label(keys.Map.RegistryLogin)) + " login",
+		styles.KeyStyle.Render(keys.Label(keys.Map.CopyID)) + " copy tag",
+		styles.KeyStyle.Render(keys.Label(keys.Map.FilterUsage)) + " dangling/unused/in use",
 		styles.KeyStyle.Render("/") + " filter",
-		styles.KeyStyle.Render("q") + " quit",
+		styles.KeyStyle.Render(keys.Label(keys.Map.Quit)) + " quit",
+	}
+
+	if v.usageFilter != "" {
+		helps = append(helps, styles.KeyStyle.Render(keys.Label(keys.Map.ClearFilters))+" clear filter")
 	}
 
 	// Show selection count if any
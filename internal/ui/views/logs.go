@@ -2,11 +2,19 @@ package views
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
+	"time"
 
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/rizface/doui/internal/docker"
+	"github.com/rizface/doui/internal/models"
+	"github.com/rizface/doui/internal/ui/keys"
 	"github.com/rizface/doui/internal/ui/styles"
 )
 
@@ -18,12 +26,27 @@ type LogsView struct {
 	maxLines      int
 	containerID   string
 	containerName string
+	filePath      string   // Set when tailing a file inside the container
+	projectName   string   // Set when showing the merged logs of a compose project
+	sources       []string // Services seen so far, in order, to color their lines
+	sourceWidth   int
+	rate          models.LogRate
+	lastLineTime  time.Time // Docker timestamp of the newest line, to resume from
+	paused        bool
+	pausedAt      time.Time
 	logsChan      <-chan docker.LogEntry
 	errorChan     <-chan error
 	ready         bool
 	width         int
 	height        int
 	mouseEnabled  bool // Track mouse state for text selection toggle
+
+	// Search within the lines
+	searchInput textinput.Model
+	searching   bool           // Typing the query
+	query       *regexp.Regexp // Case-insensitive literal match of the query, nil without a search
+	matches     []int          // Lines matching the query, in order
+	match       int            // Index in matches of the current match
 }
 
 // NewLogsView creates a new logs view
@@ -31,8 +54,15 @@ func NewLogsView() *LogsView {
 	vp := viewport.New(0, 0)
 	vp.Style = styles.BorderStyle
 
+	searchInput := textinput.New()
+	searchInput.Prompt = "/"
+	searchInput.Placeholder = "search"
+	searchInput.CharLimit = 200
+	searchInput.Width = 30
+
 	return &LogsView{
 		viewport:     vp,
+		searchInput:  searchInput,
 		lines:        []string{},
 		follow:       true,
 		maxLines:     1000,
@@ -45,11 +75,41 @@ func NewLogsView() *LogsView {
 func (v *LogsView) SetContainer(containerID, containerName string) {
 	v.containerID = containerID
 	v.containerName = containerName
+	v.filePath = ""
+	v.projectName = ""
 	v.lines = []string{}
+	v.rate.Reset()
+	v.lastLineTime = time.Time{}
+	v.paused = false
+	v.ClearSearch()
 	v.ready = false        // Reset ready so View() shows loading state until StartStreaming is called
 	v.mouseEnabled = false // Default to select mode for easy text copying
 }
 
+// SetProject shows the merged logs of the containers of a compose project
+func (v *LogsView) SetProject(projectName string) {
+	v.SetContainer("", projectName)
+	v.projectName = projectName
+	v.sources = nil
+	v.sourceWidth = 0
+}
+
+// IsProjectLogs returns whether the view shows the logs of a compose project
+func (v *LogsView) IsProjectLogs() bool {
+	return v.projectName != ""
+}
+
+// SetFile sets a file inside the container to tail instead of its logs
+func (v *LogsView) SetFile(containerID, containerName, path string) {
+	v.SetContainer(containerID, containerName)
+	v.filePath = path
+}
+
+// IsTailingFile returns whether the view shows a file rather than container logs
+func (v *LogsView) IsTailingFile() bool {
+	return v.filePath != ""
+}
+
 // StartStreaming starts streaming logs
 func (v *LogsView) StartStreaming(logsChan <-chan docker.LogEntry, errorChan <-chan error) {
 	v.logsChan = logsChan
@@ -57,6 +117,28 @@ func (v *LogsView) StartStreaming(logsChan <-chan docker.LogEntry, errorChan <-c
 	v.ready = true
 }
 
+// SetPaused marks the stream as stopped while the terminal is unfocused
+func (v *LogsView) SetPaused(paused bool) {
+	v.paused = paused
+	if paused {
+		v.pausedAt = time.Now()
+	}
+}
+
+// IsPaused returns whether the stream is paused
+func (v *LogsView) IsPaused() bool {
+	return v.paused
+}
+
+// ResumePoint returns the container and the time to stream logs from so
+// that no line is missed or repeated after a pause
+func (v *LogsView) ResumePoint() (string, time.Time) {
+	if v.lastLineTime.IsZero() {
+		return v.containerID, v.pausedAt
+	}
+	return v.containerID, v.lastLineTime.Add(time.Nanosecond)
+}
+
 // SetSize updates the view dimensions
 func (v *LogsView) SetSize(width, height int) {
 	v.width = width
@@ -71,36 +153,195 @@ func (v *LogsView) ToggleFollow() {
 	v.follow = !v.follow
 }
 
+// IsSearching returns true while the search query is typed
+func (v *LogsView) IsSearching() bool {
+	return v.searching
+}
+
+// HasSearch returns true if matches of a search are highlighted
+func (v *LogsView) HasSearch() bool {
+	return v.query != nil
+}
+
+// ClearSearch removes the search and its highlighting
+func (v *LogsView) ClearSearch() {
+	v.searching = false
+	v.searchInput.Blur()
+	v.searchInput.SetValue("")
+	v.query = nil
+	v.matches = nil
+	v.match = 0
+	v.refreshContent()
+}
+
+// setQuery searches for text, case-insensitively, and goes to the first
+// match from the top of the viewport
+func (v *LogsView) setQuery(text string) {
+	v.query = nil
+	if text != "" {
+		v.query = regexp.MustCompile("(?i)" + regexp.QuoteMeta(text))
+	}
+	v.findMatches(-1)
+	v.refreshContent()
+
+	if len(v.matches) > 0 {
+		v.match = 0
+		for i, line := range v.matches {
+			if line >= v.viewport.YOffset {
+				v.match = i
+				break
+			}
+		}
+		v.gotoMatch()
+	}
+}
+
+// findMatches collects the lines matching the query, keeping the current
+// match on currentLine if it still matches
+func (v *LogsView) findMatches(currentLine int) {
+	v.matches = v.matches[:0]
+	v.match = 0
+	if v.query == nil {
+		return
+	}
+	for i, line := range v.lines {
+		if v.query.MatchString(ansi.Strip(line)) {
+			if i <= currentLine {
+				v.match = len(v.matches)
+			}
+			v.matches = append(v.matches, i)
+		}
+	}
+}
+
+// gotoMatch stops following and centers the current match in the viewport
+func (v *LogsView) gotoMatch() {
+	if len(v.matches) == 0 {
+		return
+	}
+	v.follow = false
+	v.refreshContent()
+	v.viewport.SetYOffset(v.matches[v.match] - v.viewport.Height/2)
+}
+
+// refreshContent renders the lines into the viewport, with the matches of
+// the search highlighted (the current one in another color)
+func (v *LogsView) refreshContent() {
+	if len(v.matches) == 0 {
+		v.viewport.SetContent(strings.Join(v.lines, "\n"))
+		return
+	}
+
+	matchStyle := styles.Highlight(styles.ColorWarning)
+	currentStyle := styles.Highlight(styles.ColorPrimary)
+	lines := make([]string, len(v.lines))
+	copy(lines, v.lines)
+	for i, idx := range v.matches {
+		style := matchStyle
+		if i == v.match {
+			style = currentStyle
+		}
+		// Matched lines lose their colors so the highlighting stands out
+		line := ansi.Strip(v.lines[idx])
+		var b strings.Builder
+		last := 0
+		for _, loc := range v.query.FindAllStringIndex(line, -1) {
+			b.WriteString(line[last:loc[0]])
+			b.WriteString(style.Render(line[loc[0]:loc[1]]))
+			last = loc[1]
+		}
+		b.WriteString(line[last:])
+		lines[idx] = b.String()
+	}
+	v.viewport.SetContent(strings.Join(lines, "\n"))
+}
+
 // Update handles messages
 func (v *LogsView) Update(msg tea.Msg) (*LogsView, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "f":
+		if v.searching {
+			switch msg.String() {
+			case "enter":
+				v.searching = false
+				v.searchInput.Blur()
+				if v.query == nil {
+					v.ClearSearch()
+				}
+				return v, nil
+			case "esc":
+				v.ClearSearch()
+				return v, nil
+			}
+			var cmd tea.Cmd
+			previous := v.searchInput.Value()
+			v.searchInput, cmd = v.searchInput.Update(msg)
+			if value := v.searchInput.Value(); value != previous {
+				v.setQuery(value)
+			}
+			return v, cmd
+		}
+
+		switch {
+		case key.Matches(msg, keys.Map.Search):
+			v.searching = true
+			return v, v.searchInput.Focus()
+		case key.Matches(msg, keys.Map.NextMatch):
+			if len(v.matches) > 0 {
+				v.match = (v.match + 1) % len(v.matches)
+				v.gotoMatch()
+			}
+			return v, nil
+		case key.Matches(msg, keys.Map.PrevMatch):
+			if len(v.matches) > 0 {
+				v.match = (v.match - 1 + len(v.matches)) % len(v.matches)
+				v.gotoMatch()
+			}
+			return v, nil
+		case key.Matches(msg, keys.Map.Follow):
 			v.ToggleFollow()
 			if v.follow {
 				v.viewport.GotoBottom()
 			}
 			return v, nil
-		case "g":
+		case key.Matches(msg, keys.Map.Top):
 			v.viewport.GotoTop()
 			return v, nil
-		case "G":
+		case key.Matches(msg, keys.Map.Bottom):
 			v.viewport.GotoBottom()
 			return v, nil
 		}
 
 	case docker.LogEntry:
 		// Add new log line
-		v.lines = append(v.lines, msg.Line)
+		v.lines = append(v.lines, v.prefixSource(msg))
+		v.recordRate(msg)
+		if t, ok := models.LogLineTime(msg.Line); ok {
+			v.lastLineTime = t
+		}
 
 		// Limit lines to maxLines (circular buffer)
+		trimmed := 0
 		if len(v.lines) > v.maxLines {
-			v.lines = v.lines[len(v.lines)-v.maxLines:]
+			trimmed = len(v.lines) - v.maxLines
+			v.lines = v.lines[trimmed:]
 		}
 
-		// Update viewport content
-		v.viewport.SetContent(strings.Join(v.lines, "\n"))
+		// Keep the matches and the current one in line with the shifted lines
+		if v.query != nil {
+			currentLine := -1
+			if len(v.matches) > 0 {
+				currentLine = v.matches[v.match] - trimmed
+			}
+			v.findMatches(currentLine)
+		}
+
+		// Update viewport content, keeping the lines shown while not following
+		offset := v.viewport.YOffset - trimmed
+		v.refreshContent()
+		if !v.follow && trimmed > 0 {
+			v.viewport.SetYOffset(offset)
+		}
 
 		// Auto-scroll if follow mode is enabled
 		if v.follow {
@@ -116,6 +357,61 @@ func (v *LogsView) Update(msg tea.Msg) (*LogsView, tea.Cmd) {
 	return v, cmd
 }
 
+// sourceColors returns the colors the services of a project are told apart
+// by, from the current theme
+func sourceColors() []lipgloss.Color {
+	return []lipgloss.Color{
+		styles.ColorInfo, styles.ColorSuccess, styles.ColorAccent, styles.ColorPrimary,
+		styles.Current.Logo[0], styles.Current.Logo[3], styles.ColorDanger,
+	}
+}
+
+// prefixSource prefixes a line of a project's logs with its service, in the
+// service's color, like `docker compose logs`
+func (v *LogsView) prefixSource(entry docker.LogEntry) string {
+	if entry.Source == "" {
+		return entry.Line
+	}
+	idx := -1
+	for i, source := range v.sources {
+		if source == entry.Source {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		idx = len(v.sources)
+		v.sources = append(v.sources, entry.Source)
+		v.sourceWidth = max(v.sourceWidth, len(entry.Source))
+	}
+
+	colors := sourceColors()
+	prefix := lipgloss.NewStyle().Foreground(colors[idx%len(colors)]).Render(fmt.Sprintf("%-*s |", v.sourceWidth, entry.Source))
+	line := entry.Line
+	if entry.IsError {
+		line = styles.ErrorStyle.Render(line)
+	}
+	return prefix + " " + line
+}
+
+// recordRate counts a line towards the per-minute rates, by the time it was
+// logged when docker prefixed it with a timestamp (the initial backlog of
+// old lines then doesn't count as a flood)
+func (v *LogsView) recordRate(entry docker.LogEntry) {
+	now := time.Now()
+	at := entry.Timestamp
+	if t, ok := models.LogLineTime(entry.Line); ok {
+		at = t
+	}
+	if at.After(now) {
+		at = now
+	}
+	if now.Sub(at) >= time.Minute {
+		return
+	}
+	v.rate.Add(at, models.IsErrorLine(entry.Line))
+}
+
 // View renders the view
 func (v *LogsView) View() string {
 	if !v.ready {
@@ -130,6 +426,11 @@ func (v *LogsView) View() string {
 		shortID = shortID[:12]
 	}
 	title := fmt.Sprintf("Logs: %s (%s)", v.containerName, shortID)
+	if v.projectName != "" {
+		title = fmt.Sprintf("Project Logs: %s (%d services)", v.projectName, len(v.sources))
+	} else if v.filePath != "" {
+		title = fmt.Sprintf("File: %s in %s (%s)", v.filePath, v.containerName, shortID)
+	}
 	b.WriteString(styles.TitleStyle.Render(title))
 	b.WriteString("\n")
 
@@ -139,6 +440,37 @@ func (v *LogsView) View() string {
 		followStatus = styles.SuccessStyle.Render("Follow: ON")
 	}
 	b.WriteString(followStatus)
+
+	// Rolling rates to spot floods
+	lines, errors := v.rate.PerMinute(time.Now())
+	b.WriteString(styles.SeparatorStyle.String())
+	b.WriteString(fmt.Sprintf("%d lines/min", lines))
+	errorRate := fmt.Sprintf("%d errors/min", errors)
+	if errors > 0 {
+		errorRate = styles.ErrorStyle.Render(errorRate)
+	}
+	b.WriteString(", " + errorRate)
+	if v.paused {
+		b.WriteString(styles.SeparatorStyle.String())
+		b.WriteString(styles.WarningStyle.Render("Paused (terminal unfocused)"))
+	}
+
+	// Search query and matches
+	if v.searching {
+		b.WriteString(styles.SeparatorStyle.String())
+		b.WriteString(v.searchInput.View())
+	}
+	if v.query != nil {
+		if !v.searching {
+			b.WriteString(styles.SeparatorStyle.String())
+			b.WriteString("Search: " + v.searchInput.Value() + " ")
+		}
+		if len(v.matches) == 0 {
+			b.WriteString(styles.WarningStyle.Render("(no matches)"))
+		} else {
+			b.WriteString(styles.DescStyle.Render(fmt.Sprintf("(%d/%d)", v.match+1, len(v.matches))))
+		}
+	}
 	b.WriteString("\n\n")
 
 	// Viewport with logs
@@ -149,12 +481,40 @@ func (v *LogsView) View() string {
 
 // GetHelpText returns help text for the logs view
 func (v *LogsView) GetHelpText() string {
+	if v.searching {
+		helps := []string{
+			styles.KeyStyle.Render("enter") + " keep search",
+			styles.KeyStyle.Render("esc") + " cancel",
+		}
+		return strings.Join(helps, styles.SeparatorStyle.String())
+	}
+
+	search := styles.KeyStyle.Render(keys.Label(keys.Map.Search)) + " search"
+	if v.query != nil {
+		search = styles.KeyStyle.Render(keys.Labels(keys.Map.NextMatch, keys.Map.PrevMatch)) + " next/prev match"
+	}
+
+	if v.projectName != "" {
+		helps := []string{
+			styles.KeyStyle.Render("↑/↓") + " scroll",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Follow)) + " toggle follow",
+			styles.KeyStyle.Render(keys.Labels(keys.Map.Top, keys.Map.Bottom)) + " top/bottom",
+			search,
+			styles.KeyStyle.Render(keys.Label(keys.Map.Back)) + " back",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Quit)) + " quit",
+		}
+		return strings.Join(helps, styles.SeparatorStyle.String())
+	}
+
 	helps := []string{
 		styles.KeyStyle.Render("↑/↓") + " scroll",
-		styles.KeyStyle.Render("f") + " toggle follow",
-		styles.KeyStyle.Render("g/G") + " top/bottom",
-		styles.KeyStyle.Render("esc") + " back",
-		styles.KeyStyle.Render("q") + " quit",
+		styles.KeyStyle.Render(keys.Label(keys.Map.Follow)) + " toggle follow",
+		styles.KeyStyle.Render(keys.Labels(keys.Map.Top, keys.Map.Bottom)) + " top/bottom",
+		search,
+		styles.KeyStyle.Render(keys.Label(keys.Map.CopyID)) + " copy ID",
+		styles.KeyStyle.Render(keys.Label(keys.Map.CopyCommand)) + " copy cmd",
+		styles.KeyStyle.Render(keys.Label(keys.Map.Back)) + " back",
+		styles.KeyStyle.Render(keys.Label(keys.Map.Quit)) + " quit",
 	}
 
 	return strings.Join(helps, styles.SeparatorStyle.String())
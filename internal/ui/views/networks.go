@@ -4,10 +4,12 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/rizface/doui/internal/models"
+	"github.com/rizface/doui/internal/ui/keys"
 	"github.com/rizface/doui/internal/ui/styles"
 )
 
@@ -20,6 +22,10 @@ func (i NetworkItem) FilterValue() string {
 	return i.network.Name
 }
 
+func (i NetworkItem) itemID() string {
+	return i.network.ID
+}
+
 func (i NetworkItem) Title() string {
 	info := ""
 	if i.network.IsSystemNetwork() {
@@ -42,19 +48,47 @@ func (i NetworkItem) Description() string {
 // ContainerItemForNetwork implements list.Item for containers in networks view
 type ContainerItemForNetwork struct {
 	container models.Container
+	network   string                  // Set in the containers tab to show the aliases
+	endpoint  *models.NetworkEndpoint // The container's attachment, once inspected
 }
 
 func (i ContainerItemForNetwork) FilterValue() string {
 	return i.container.Name
 }
 
+func (i ContainerItemForNetwork) itemID() string {
+	return i.container.ID
+}
+
 func (i ContainerItemForNetwork) Title() string {
 	status := styles.GetStatusStyle(i.container.State).Render(i.container.State)
 	return fmt.Sprintf("%s  %s", i.container.Name, status)
 }
 
 func (i ContainerItemForNetwork) Description() string {
-	return fmt.Sprintf("ID: %s | Image: %s", i.container.ShortID, i.container.Image)
+	desc := fmt.Sprintf("ID: %s | Image: %s", i.container.ShortID, i.container.Image)
+	if i.network != "" {
+		desc = fmt.Sprintf("IP: %s | %s", i.ipAddress(), desc)
+		if i.endpoint != nil && i.endpoint.IPv6 != "" {
+			desc += " | IPv6: " + i.endpoint.GetIPv6()
+		}
+	}
+	if aliases := i.container.Aliases[i.network]; len(aliases) > 0 {
+		desc += " | Aliases: " + strings.Join(aliases, ", ")
+	}
+	return desc
+}
+
+// ipAddress is the container's IPv4 address on the network, from the
+// network's endpoints if loaded, otherwise from the container list
+func (i ContainerItemForNetwork) ipAddress() string {
+	if i.endpoint != nil && i.endpoint.IPv4 != "" {
+		return i.endpoint.GetIPv4()
+	}
+	if ip := i.container.GetIPAddress(i.network); ip != "" {
+		return ip
+	}
+	return "none"
 }
 
 // NetworksView displays the tabbed networks management interface
@@ -66,6 +100,7 @@ type NetworksView struct {
 	networks        []models.Network
 	allContainers   []models.Container
 	selectedNetwork *models.Network
+	endpoints       map[string]models.NetworkEndpoint // Container ID -> attachment to the selected network
 
 	// List models for each tab
 	networksList            list.Model
@@ -133,7 +168,7 @@ func (v *NetworksView) SetNetworks(networks []models.Network) {
 		for i, n := range networks {
 			items[i] = NetworkItem{network: n}
 		}
-		v.networksList.SetItems(items)
+		setItemsKeepSelection(&v.networksList, items)
 	}
 
 	// Update the selected network if it still exists
@@ -189,7 +224,7 @@ func (v *NetworksView) syncNetworkContainerCounts() {
 	for i, n := range v.networks {
 		items[i] = NetworkItem{network: n}
 	}
-	v.networksList.SetItems(items)
+	setItemsKeepSelection(&v.networksList, items)
 
 	// Update selected network reference if it exists
 	if v.selectedNetwork != nil {
@@ -226,6 +261,18 @@ func (v *NetworksView) GetSelectedNetwork() *models.Network {
 	return nil
 }
 
+// Unused returns the user-defined networks no container is connected to,
+// which a prune removes
+func (v *NetworksView) Unused() []models.Network {
+	var result []models.Network
+	for _, n := range v.networks {
+		if !n.IsSystemNetwork() && n.GetContainerCount() == 0 {
+			result = append(result, n)
+		}
+	}
+	return result
+}
+
 // GetSelectedInNetworkContainer returns the selected container from the "In Network" tab
 func (v *NetworksView) GetSelectedInNetworkContainer() *models.Container {
 	item := v.containersInNetworkList.SelectedItem()
@@ -297,10 +344,18 @@ func (v *NetworksView) updateContainerLists() {
 	// Update containers in network
 	inNetworkContainers := v.GetContainersInNetwork()
 	inNetworkItems := make([]list.Item, len(inNetworkContainers))
+	networkName := ""
+	if v.selectedNetwork != nil {
+		networkName = v.selectedNetwork.Name
+	}
 	for i, c := range inNetworkContainers {
-		inNetworkItems[i] = ContainerItemForNetwork{container: c}
+		item := ContainerItemForNetwork{container: c, network: networkName}
+		if endpoint, ok := v.endpoints[c.ID]; ok {
+			item.endpoint = &endpoint
+		}
+		inNetworkItems[i] = item
 	}
-	v.containersInNetworkList.SetItems(inNetworkItems)
+	setItemsKeepSelection(&v.containersInNetworkList, inNetworkItems)
 
 	// Update available containers
 	availableContainers := v.GetAvailableContainers()
@@ -308,7 +363,32 @@ func (v *NetworksView) updateContainerLists() {
 	for i, c := range availableContainers {
 		availableItems[i] = ContainerItemForNetwork{container: c}
 	}
-	v.availableContainersList.SetItems(availableItems)
+	setItemsKeepSelection(&v.availableContainersList, availableItems)
+}
+
+// SetEndpoints sets the containers' attachments to a network, from
+// inspecting it. Ignored unless it is still the selected network.
+func (v *NetworksView) SetEndpoints(networkID string, endpoints []models.NetworkEndpoint) {
+	if v.selectedNetwork == nil || v.selectedNetwork.ID != networkID {
+		return
+	}
+	v.endpoints = make(map[string]models.NetworkEndpoint, len(endpoints))
+	for _, endpoint := range endpoints {
+		v.endpoints[endpoint.ContainerID] = endpoint
+	}
+	v.updateContainerLists()
+}
+
+// GetContainerIP returns the IPv4 address of a container on the selected
+// network, "" if it has none
+func (v *NetworksView) GetContainerIP(container models.Container) string {
+	if v.selectedNetwork == nil {
+		return ""
+	}
+	if endpoint, ok := v.endpoints[container.ID]; ok && endpoint.IPv4 != "" {
+		return endpoint.GetIPv4()
+	}
+	return container.GetIPAddress(v.selectedNetwork.Name)
 }
 
 // SwitchTab switches to the next or previous tab
@@ -358,21 +438,22 @@ func (v *NetworksView) Update(msg tea.Msg) (*NetworksView, tea.Cmd) {
 	// Handle key messages
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "[", "left":
+		switch {
+		case key.Matches(msg, keys.Map.PrevTab):
 			// Switch to previous tab
 			v.SwitchTab(-1)
 			return v, nil
 
-		case "]", "right":
+		case key.Matches(msg, keys.Map.NextTab):
 			// Switch to next tab
 			v.SwitchTab(+1)
 			return v, nil
 
-		case "enter":
+		case key.Matches(msg, keys.Map.Select):
 			if v.currentTab == models.NetworksListTab {
 				// Select network and switch to "In Network" tab
 				v.selectedNetwork = v.GetSelectedNetwork()
+				v.endpoints = nil
 				if v.selectedNetwork != nil {
 					v.currentTab = models.NetworksContainersTab
 					v.updateContainerLists()
@@ -381,7 +462,7 @@ func (v *NetworksView) Update(msg tea.Msg) (*NetworksView, tea.Cmd) {
 			}
 			// enter key in Available tab is handled in app.go for attaching container
 
-		case "esc":
+		case key.Matches(msg, keys.Map.Back):
 			// Return to Networks list tab
 			if v.currentTab != models.NetworksListTab {
 				v.currentTab = models.NetworksListTab
@@ -492,40 +573,49 @@ func (v *NetworksView) GetHelpText() string {
 	case models.NetworksListTab:
 		helps = []string{
 			styles.KeyStyle.Render("↑/↓") + " navigate",
-			styles.KeyStyle.Render("enter") + " select",
-			styles.KeyStyle.Render("n") + " new",
-			styles.KeyStyle.Render("d") + " delete",
-			styles.KeyStyle.Render("[/]") + " tabs",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Select)) + " select",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Inspect)) + " details",
+			styles.KeyStyle.Render(keys.Label(keys.Map.New)) + " new",
+			styles.KeyStyle.Render(keys.Label(keys.Map.NewMacvlan)) + " macvlan",
+			styles.KeyStyle.Render(keys.Label(keys.Map.PruneNetworks)) + " prune",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Delete)) + " delete",
+			styles.KeyStyle.Render(keys.Label(keys.Map.CopyID)) + " copy ID",
+			styles.KeyStyle.Render(keys.Labels(keys.Map.PrevTab, keys.Map.NextTab)) + " tabs",
 			styles.KeyStyle.Render("/") + " filter",
 		}
 
 	case models.NetworksContainersTab:
 		helps = []string{
 			styles.KeyStyle.Render("↑/↓") + " navigate",
-			styles.KeyStyle.Render("s") + " start",
-			styles.KeyStyle.Render("x") + " stop",
-			styles.KeyStyle.Render("r") + " restart",
-			styles.KeyStyle.Render("d") + " delete",
-			styles.KeyStyle.Render("e") + " shell",
-			styles.KeyStyle.Render("l") + " logs",
-			styles.KeyStyle.Render("t") + " stats",
-			styles.KeyStyle.Render("v") + " env",
-			styles.KeyStyle.Render("u") + " disconnect",
-			styles.KeyStyle.Render("[/]") + " tabs",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Start)) + " start",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Stop)) + " stop",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Restart)) + " restart",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Delete)) + " delete",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Shell)) + " shell",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Logs)) + " logs",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Stats)) + " stats",
+			styles.KeyStyle.Render(keys.Label(keys.Map.EditConfig)) + " env/labels/ports",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Unlink)) + " disconnect",
+			styles.KeyStyle.Render(keys.Label(keys.Map.EditAliases)) + " aliases",
+			styles.KeyStyle.Render(keys.Label(keys.Map.CopyID)) + " copy IP",
+			styles.KeyStyle.Render(keys.Label(keys.Map.CopyCommand)) + " copy cmd",
+			styles.KeyStyle.Render(keys.Labels(keys.Map.PrevTab, keys.Map.NextTab)) + " tabs",
 			styles.KeyStyle.Render("/") + " filter",
 		}
 
 	case models.NetworksAvailableTab:
 		helps = []string{
 			styles.KeyStyle.Render("↑/↓") + " navigate",
-			styles.KeyStyle.Render("enter") + " connect",
-			styles.KeyStyle.Render("[/]") + " tabs",
-			styles.KeyStyle.Render("esc") + " back",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Select)) + " connect",
+			styles.KeyStyle.Render(keys.Label(keys.Map.CopyID)) + " copy ID",
+			styles.KeyStyle.Render(keys.Label(keys.Map.CopyCommand)) + " copy cmd",
+			styles.KeyStyle.Render(keys.Labels(keys.Map.PrevTab, keys.Map.NextTab)) + " tabs",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Back)) + " back",
 			styles.KeyStyle.Render("/") + " filter",
 		}
 	}
 
-	helps = append(helps, styles.KeyStyle.Render("q")+" quit")
+	helps = append(helps, styles.KeyStyle.Render(keys.Label(keys.Map.Quit))+" quit")
 	return strings.Join(helps, styles.SeparatorStyle.String())
 }
 
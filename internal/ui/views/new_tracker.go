@@ -0,0 +1,51 @@
+package views
+
+// newBadgeRefreshes is how many list refreshes a "new" badge stays visible
+const newBadgeRefreshes = 5
+
+// newItemTracker remembers which resource IDs have been seen so items that
+// appear between refreshes (created by compose, CI, other users) can be badged
+type newItemTracker struct {
+	seen      map[string]bool
+	remaining map[string]int // ID -> refreshes left to show the badge
+}
+
+func newNewItemTracker() *newItemTracker {
+	return &newItemTracker{
+		remaining: make(map[string]int),
+	}
+}
+
+// Update records the current set of IDs and ages existing badges
+// The first call only establishes the baseline, so nothing is new on startup
+func (t *newItemTracker) Update(ids []string) {
+	for id, left := range t.remaining {
+		if left <= 1 {
+			delete(t.remaining, id)
+		} else {
+			t.remaining[id] = left - 1
+		}
+	}
+
+	current := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		current[id] = true
+		if t.seen != nil && !t.seen[id] {
+			t.remaining[id] = newBadgeRefreshes
+		}
+	}
+
+	// Forget badges for items that are gone
+	for id := range t.remaining {
+		if !current[id] {
+			delete(t.remaining, id)
+		}
+	}
+
+	t.seen = current
+}
+
+// IsNew returns true if the ID appeared within the last few refreshes
+func (t *newItemTracker) IsNew(id string) bool {
+	return t.remaining[id] > 0
+}
@@ -0,0 +1,159 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/rizface/doui/internal/models"
+	"github.com/rizface/doui/internal/ui/keys"
+	"github.com/rizface/doui/internal/ui/styles"
+)
+
+// PluginItem implements list.Item for plugins
+type PluginItem struct {
+	plugin models.Plugin
+}
+
+func (i PluginItem) FilterValue() string {
+	return i.plugin.Name
+}
+
+func (i PluginItem) itemID() string {
+	return i.plugin.ID
+}
+
+func (i PluginItem) Title() string {
+	status := ""
+	if i.plugin.Enabled {
+		status = styles.RunningStyle.Render("enabled")
+	} else {
+		status = styles.StoppedStyle.Render("disabled")
+	}
+	return fmt.Sprintf("%s  %s", i.plugin.Name, status)
+}
+
+func (i PluginItem) Description() string {
+	desc := fmt.Sprintf("ID: %s | Type: %s", i.plugin.GetShortID(), i.plugin.GetKind())
+	if i.plugin.Description != "" {
+		desc += " | " + i.plugin.Description
+	}
+	return desc
+}
+
+// PluginsView displays the list of installed engine plugins
+type PluginsView struct {
+	list        list.Model
+	plugins     []models.Plugin
+	unsupported string // Why the daemon can't list plugins (too old)
+	width       int
+	height      int
+}
+
+// NewPluginsView creates a new plugins view
+func NewPluginsView() *PluginsView {
+	delegate := list.NewDefaultDelegate()
+	delegate.SetHeight(2)
+	delegate.SetSpacing(1)
+
+	l := list.New([]list.Item{}, delegate, 0, 0)
+	l.Title = "Docker Plugins"
+	l.SetShowStatusBar(true)
+	l.SetFilteringEnabled(true)
+	l.Styles.Title = styles.TitleStyle
+
+	return &PluginsView{
+		list: l,
+	}
+}
+
+// SetPlugins updates the list of plugins
+func (v *PluginsView) SetPlugins(plugins []models.Plugin) {
+	v.plugins = plugins
+
+	items := make([]list.Item, len(plugins))
+	for i, plugin := range plugins {
+		items[i] = PluginItem{plugin: plugin}
+	}
+	setItemsKeepSelection(&v.list, items)
+}
+
+// SetUnsupported explains that the daemon doesn't support plugins, shown
+// instead of the list ("" clears it)
+func (v *PluginsView) SetUnsupported(reason string) {
+	v.unsupported = reason
+}
+
+// SetSize updates the view dimensions
+func (v *PluginsView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+	v.list.SetSize(width, height-6) // Reserve space for header and footer
+}
+
+// Update handles messages
+func (v *PluginsView) Update(msg tea.Msg) (*PluginsView, tea.Cmd) {
+	var cmd tea.Cmd
+	v.list, cmd = v.list.Update(msg)
+	return v, cmd
+}
+
+// View renders the view
+func (v *PluginsView) View() string {
+	if len(v.plugins) == 0 {
+		return v.renderEmpty()
+	}
+
+	return v.list.View()
+}
+
+// GetSelectedPlugin returns the currently selected plugin
+func (v *PluginsView) GetSelectedPlugin() *models.Plugin {
+	item := v.list.SelectedItem()
+	if item == nil {
+		return nil
+	}
+	if pluginItem, ok := item.(PluginItem); ok {
+		return &pluginItem.plugin
+	}
+	return nil
+}
+
+func (v *PluginsView) renderEmpty() string {
+	var b strings.Builder
+
+	b.WriteString(styles.TitleStyle.Render("Docker Plugins"))
+	b.WriteString("\n\n")
+	if v.unsupported != "" {
+		b.WriteString(styles.WarningStyle.Render("Not available on this daemon."))
+		b.WriteString("\n")
+		b.WriteString(styles.DescStyle.Width(max(20, v.width-4)).Render(v.unsupported))
+		return b.String()
+	}
+	b.WriteString(styles.SubtitleStyle.Render("No plugins installed."))
+	b.WriteString("\n")
+	b.WriteString(styles.DescStyle.Render("Install one with `docker plugin install <name>`."))
+
+	return b.String()
+}
+
+// IsFiltering returns true if the list is in filtering mode
+func (v *PluginsView) IsFiltering() bool {
+	return v.list.FilterState() == list.Filtering
+}
+
+// GetHelpText returns help text for the plugins view
+func (v *PluginsView) GetHelpText() string {
+	helps := []string{
+		styles.KeyStyle.Render("↑/↓") + " navigate",
+		styles.KeyStyle.Render(keys.Label(keys.Map.Start)) + " enable",
+		styles.KeyStyle.Render(keys.Label(keys.Map.Stop)) + " disable",
+		styles.KeyStyle.Render(keys.Label(keys.Map.Delete)) + " remove",
+		styles.KeyStyle.Render(keys.Label(keys.Map.CopyID)) + " copy name",
+		styles.KeyStyle.Render("/") + " filter",
+		styles.KeyStyle.Render(keys.Label(keys.Map.Quit)) + " quit",
+	}
+
+	return strings.Join(helps, styles.SeparatorStyle.String())
+}
@@ -0,0 +1,88 @@
+package views
+
+import (
+	"reflect"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// identifiableItem is a list item with a stable identity across refreshes
+type identifiableItem interface {
+	itemID() string
+}
+
+// setItemsKeepSelection replaces the list items and keeps the cursor on the
+// previously selected item, even if refreshed data moved it to another index.
+// When the set of items is unchanged (same IDs in the same order) only the
+// entries whose data changed are updated, which avoids rebuilding the whole
+// list on every refresh tick.
+func setItemsKeepSelection(l *list.Model, items []list.Item) {
+	if updateChangedItems(l, items) {
+		return
+	}
+
+	selectedID := ""
+	if item, ok := l.SelectedItem().(identifiableItem); ok {
+		selectedID = item.itemID()
+	}
+
+	applyFilter(l, l.SetItems(items))
+
+	if selectedID == "" {
+		return
+	}
+
+	visible := l.VisibleItems()
+	for i, item := range visible {
+		if identifiable, ok := item.(identifiableItem); ok && identifiable.itemID() == selectedID {
+			l.Select(i)
+			return
+		}
+	}
+
+	// Selected item is gone, keep the cursor within bounds
+	if len(visible) > 0 && l.Index() >= len(visible) {
+		l.Select(len(visible) - 1)
+	}
+}
+
+// updateChangedItems updates only the items whose data changed, in place.
+// Returns false if items were added, removed or reordered, in which case the
+// caller must replace the whole list.
+func updateChangedItems(l *list.Model, items []list.Item) bool {
+	current := l.Items()
+	if len(current) != len(items) || len(items) == 0 {
+		return false
+	}
+
+	for i := range items {
+		oldItem, ok1 := current[i].(identifiableItem)
+		newItem, ok2 := items[i].(identifiableItem)
+		if !ok1 || !ok2 || oldItem.itemID() != newItem.itemID() {
+			return false
+		}
+	}
+
+	var filterCmd tea.Cmd
+	for i := range items {
+		if !reflect.DeepEqual(current[i], items[i]) {
+			if cmd := l.SetItem(i, items[i]); cmd != nil {
+				filterCmd = cmd
+			}
+		}
+	}
+
+	// Re-filter once after all updates rather than per item
+	applyFilter(l, filterCmd)
+	return true
+}
+
+// applyFilter runs a list's re-filter command synchronously so the visible
+// items reflect new data immediately while a "/" filter is applied
+func applyFilter(l *list.Model, cmd tea.Cmd) {
+	if cmd == nil {
+		return
+	}
+	*l, _ = l.Update(cmd())
+}
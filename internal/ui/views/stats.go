@@ -8,6 +8,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/rizface/doui/internal/models"
+	"github.com/rizface/doui/internal/ui/keys"
 	"github.com/rizface/doui/internal/ui/styles"
 )
 
@@ -20,7 +21,12 @@ type StatsView struct {
 	containerName string
 	statsChan     <-chan *models.ContainerStats
 	errorChan     <-chan error
+	events        []models.ContainerEvent
+	maxEvents     int
+	eventChan     <-chan *models.ContainerEvent
+	eventErrChan  <-chan error
 	ready         bool
+	paused        bool
 	width         int
 	height        int
 }
@@ -30,6 +36,7 @@ func NewStatsView() *StatsView {
 	return &StatsView{
 		history:    []models.ContainerStats{},
 		maxHistory: 60, // Keep last 60 data points
+		maxEvents:  5,  // Keep last 5 OOM/die events
 		ready:      false,
 	}
 }
@@ -40,6 +47,8 @@ func (v *StatsView) SetContainer(containerID, containerName string) {
 	v.containerName = containerName
 	v.stats = nil
 	v.history = []models.ContainerStats{}
+	v.events = nil
+	v.paused = false
 	v.ready = false // Reset ready so View() shows loading state until StartStreaming is called
 }
 
@@ -50,6 +59,33 @@ func (v *StatsView) StartStreaming(statsChan <-chan *models.ContainerStats, erro
 	v.ready = true
 }
 
+// ContainerID returns the ID of the monitored container
+func (v *StatsView) ContainerID() string {
+	return v.containerID
+}
+
+// SetPaused marks sampling as stopped while the terminal is unfocused
+func (v *StatsView) SetPaused(paused bool) {
+	v.paused = paused
+}
+
+// IsPaused returns whether sampling is paused
+func (v *StatsView) IsPaused() bool {
+	return v.paused
+}
+
+// StartEventStreaming starts watching OOM/die events for the container
+func (v *StatsView) StartEventStreaming(eventChan <-chan *models.ContainerEvent, errChan <-chan error) {
+	v.eventChan = eventChan
+	v.eventErrChan = errChan
+}
+
+// EventStream returns the channels of the events stream, which the app waits
+// on for the next event
+func (v *StatsView) EventStream() (<-chan *models.ContainerEvent, <-chan error) {
+	return v.eventChan, v.eventErrChan
+}
+
 // SetSize updates the view dimensions
 func (v *StatsView) SetSize(width, height int) {
 	v.width = width
@@ -70,6 +106,17 @@ func (v *StatsView) Update(msg tea.Msg) (*StatsView, tea.Cmd) {
 
 		// Wait for next stats update
 		return v, waitForStats(v.statsChan, v.errorChan)
+
+	case *models.ContainerEvent:
+		// Ignore events from a previously watched container
+		if msg.ContainerID != v.containerID {
+			return v, nil
+		}
+
+		v.events = append(v.events, *msg)
+		if len(v.events) > v.maxEvents {
+			v.events = v.events[1:]
+		}
 	}
 
 	return v, nil
@@ -96,6 +143,10 @@ func (v *StatsView) View() string {
 	b.WriteString(styles.TitleStyle.Render(title))
 	b.WriteString("\n")
 	b.WriteString(styles.SubtitleStyle.Render(fmt.Sprintf("Updated: %s", v.stats.Timestamp.Format(time.RFC3339))))
+	if v.paused {
+		b.WriteString(styles.SeparatorStyle.String())
+		b.WriteString(styles.WarningStyle.Render("Paused (terminal unfocused)"))
+	}
 	b.WriteString("\n\n")
 
 	// CPU Usage
@@ -126,7 +177,75 @@ func (v *StatsView) View() string {
 	// PIDs
 	b.WriteString(styles.KeyStyle.Render("PIDs:        "))
 	b.WriteString(fmt.Sprintf("%d", v.stats.PIDs))
+	b.WriteString("\n\n")
+
+	// Memory history timeline with event markers
+	b.WriteString(v.renderTimeline())
+
+	return b.String()
+}
+
+// renderTimeline renders the memory history sparkline with OOM/die markers
+// below the sample where each event happened
+func (v *StatsView) renderTimeline() string {
+	var b strings.Builder
+
+	sparkChars := []rune("▁▂▃▄▅▆▇█")
+	var spark strings.Builder
+	for _, s := range v.history {
+		idx := int(s.MemoryPercent / 100 * float64(len(sparkChars)-1))
+		if idx < 0 {
+			idx = 0
+		} else if idx >= len(sparkChars) {
+			idx = len(sparkChars) - 1
+		}
+		spark.WriteRune(sparkChars[idx])
+	}
+
+	b.WriteString(styles.KeyStyle.Render("Memory:      "))
+	b.WriteString(spark.String())
+	b.WriteString("\n")
+
+	if len(v.events) == 0 {
+		return b.String()
+	}
+
+	// Place each event marker under the first sample taken at or after it
+	markers := []rune(strings.Repeat(" ", len(v.history)))
+	for _, e := range v.events {
+		pos := len(v.history) - 1
+		for i, s := range v.history {
+			if !s.Timestamp.Before(e.Timestamp) {
+				pos = i
+				break
+			}
+		}
+		if pos < 0 {
+			continue
+		}
+		if e.IsOOM() {
+			markers[pos] = '!'
+		} else if markers[pos] != '!' {
+			markers[pos] = 'x'
+		}
+	}
+
+	b.WriteString("             ")
+	b.WriteString(lipgloss.NewStyle().Foreground(styles.ColorDanger).Render(string(markers)))
+	b.WriteString("\n\n")
+
+	// Event list, most recent first
+	b.WriteString(styles.KeyStyle.Render("Events:"))
 	b.WriteString("\n")
+	for i := len(v.events) - 1; i >= 0; i-- {
+		e := v.events[i]
+		marker := "x"
+		if e.IsOOM() {
+			marker = "!"
+		}
+		b.WriteString(styles.ErrorStyle.Render(fmt.Sprintf("  %s %s", marker, e.Description())))
+		b.WriteString("\n")
+	}
 
 	return b.String()
 }
@@ -169,8 +288,10 @@ func (v *StatsView) renderMetric(label string, value float64, unit string, max f
 // GetHelpText returns help text for the stats view
 func (v *StatsView) GetHelpText() string {
 	helps := []string{
-		styles.KeyStyle.Render("esc") + " back",
-		styles.KeyStyle.Render("q") + " quit",
+		styles.KeyStyle.Render(keys.Label(keys.Map.CopyID)) + " copy ID",
+		styles.KeyStyle.Render(keys.Label(keys.Map.CopyCommand)) + " copy cmd",
+		styles.KeyStyle.Render(keys.Label(keys.Map.Back)) + " back",
+		styles.KeyStyle.Render(keys.Label(keys.Map.Quit)) + " quit",
 	}
 
 	return strings.Join(helps, styles.SeparatorStyle.String())
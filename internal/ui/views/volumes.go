@@ -2,23 +2,34 @@ package views
 
 import (
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/rizface/doui/internal/models"
+	"github.com/rizface/doui/internal/ui/keys"
 	"github.com/rizface/doui/internal/ui/styles"
+	"github.com/rizface/doui/pkg/utils"
 )
 
 // VolumeItem implements list.Item for volumes
 type VolumeItem struct {
 	volume models.Volume
+	usedBy string // First container mounting the volume, the creator of an anonymous one
 }
 
 func (i VolumeItem) FilterValue() string {
 	return i.volume.Name
 }
 
+func (i VolumeItem) itemID() string {
+	return i.volume.Name
+}
+
 func (i VolumeItem) Title() string {
 	status := ""
 	if i.volume.IsInUse() {
@@ -26,6 +37,9 @@ func (i VolumeItem) Title() string {
 	} else {
 		status = styles.StoppedStyle.Render("unused")
 	}
+	if i.volume.IsAnonymous() {
+		status += "  " + styles.PausedStyle.Render("anonymous")
+	}
 	return fmt.Sprintf("%s  %s", i.volume.GetShortName(), status)
 }
 
@@ -35,14 +49,60 @@ func (i VolumeItem) Description() string {
 	if i.volume.UsageData != nil {
 		refCount = i.volume.UsageData.RefCount
 	}
-	return fmt.Sprintf("Driver: %s | Containers: %d | %s", driver, refCount, i.volume.Mountpoint)
+	desc := fmt.Sprintf("Driver: %s | Containers: %d", driver, refCount)
+	if i.volume.UsageData != nil && i.volume.UsageData.Size >= 0 {
+		desc += " | Size: " + utils.FormatBytes(i.volume.UsageData.Size)
+	}
+	// The container an anonymous volume belongs to is its only identity
+	if i.volume.IsAnonymous() {
+		if i.usedBy != "" {
+			desc += " | Created by: " + i.usedBy
+		} else {
+			desc += " | Orphaned"
+		}
+	}
+	return desc + " | " + i.volume.Mountpoint
+}
+
+// ContainerItemForVolume implements list.Item for containers in the Used By tab
+type ContainerItemForVolume struct {
+	container models.Container
+	mount     models.MountPoint
+}
+
+func (i ContainerItemForVolume) FilterValue() string {
+	return i.container.Name
+}
+
+func (i ContainerItemForVolume) itemID() string {
+	return i.container.ID
+}
+
+func (i ContainerItemForVolume) Title() string {
+	status := styles.GetStatusStyle(i.container.State).Render(i.container.State)
+	return fmt.Sprintf("%s  %s", i.container.Name, status)
+}
+
+func (i ContainerItemForVolume) Description() string {
+	mode := "rw"
+	if i.mount.ReadOnly {
+		mode = "ro"
+	}
+	return fmt.Sprintf("ID: %s | Image: %s | Mounted at %s (%s)", i.container.ShortID, i.container.Image, i.mount.Destination, mode)
 }
 
-// VolumesView displays the list of volumes
+// VolumesView displays the tabbed volumes interface: the volumes, and the
+// containers mounting the selected one
 type VolumesView struct {
+	currentTab     models.VolumesTabType
+	selectedVolume string // Name of the volume shown in the Used By tab
+
 	list          list.Model
+	usedByList    list.Model
 	volumes       []models.Volume
 	allContainers []models.Container
+	sizes         map[string]int64 // Volume name -> bytes, once measured
+	sizesAt       time.Time
 	width         int
 	height        int
 }
@@ -59,14 +119,29 @@ func NewVolumesView() *VolumesView {
 	l.SetFilteringEnabled(true)
 	l.Styles.Title = styles.TitleStyle
 
+	usedByDelegate := list.NewDefaultDelegate()
+	usedByDelegate.SetHeight(2)
+	usedByDelegate.SetSpacing(1)
+
+	usedBy := list.New([]list.Item{}, usedByDelegate, 0, 0)
+	usedBy.Title = "Used By"
+	usedBy.SetShowStatusBar(true)
+	usedBy.SetFilteringEnabled(true)
+	usedBy.Styles.Title = styles.TitleStyle
+
 	return &VolumesView{
-		list: l,
+		currentTab: models.VolumesListTab,
+		list:       l,
+		usedByList: usedBy,
 	}
 }
 
 // SetVolumes updates the list of volumes
 func (v *VolumesView) SetVolumes(volumes []models.Volume) {
 	v.volumes = volumes
+	if v.selectedVolume != "" && v.GetVolume(v.selectedVolume) == nil {
+		v.selectedVolume = ""
+	}
 	v.syncVolumeContainerCounts()
 }
 
@@ -76,18 +151,53 @@ func (v *VolumesView) SetAllContainers(containers []models.Container) {
 	v.syncVolumeContainerCounts()
 }
 
+// SetVolumeSizes sets the measured size of each volume, listing the
+// biggest first. Sizes stay shown until measured again.
+func (v *VolumesView) SetVolumeSizes(sizes map[string]int64, at time.Time) {
+	v.sizes = sizes
+	v.sizesAt = at
+	v.syncVolumeContainerCounts()
+}
+
+// Reset forgets volumes and sizes, e.g. after switching to another Docker
+// daemon
+func (v *VolumesView) Reset() {
+	v.volumes = nil
+	v.sizes = nil
+	v.selectedVolume = ""
+	v.currentTab = models.VolumesListTab
+	v.list.Title = "Docker Volumes"
+	v.list.SetItems(nil)
+	v.usedByList.SetItems(nil)
+}
+
+// GetVolume returns the volume with the given name, or nil
+func (v *VolumesView) GetVolume(name string) *models.Volume {
+	for i := range v.volumes {
+		if v.volumes[i].Name == name {
+			return &v.volumes[i]
+		}
+	}
+	return nil
+}
+
 // syncVolumeContainerCounts populates each volume's UsageData from container mount data
 func (v *VolumesView) syncVolumeContainerCounts() {
+	v.updateUsedByList()
 	if len(v.volumes) == 0 {
 		return
 	}
 
-	// Build map of volume name -> container count
+	// Build map of volume name -> container count, and the first container
 	volumeUsage := make(map[string]int)
+	usedBy := make(map[string]string)
 	for _, c := range v.allContainers {
 		for _, m := range c.Mounts {
 			if m.Type == "volume" && m.Name != "" {
 				volumeUsage[m.Name]++
+				if usedBy[m.Name] == "" {
+					usedBy[m.Name] = c.Name
+				}
 			}
 		}
 	}
@@ -103,44 +213,199 @@ func (v *VolumesView) syncVolumeContainerCounts() {
 		} else {
 			v.volumes[i].UsageData.RefCount = refCount
 		}
+		if size, ok := v.sizes[v.volumes[i].Name]; ok {
+			v.volumes[i].UsageData.Size = size
+		}
+	}
+
+	// Once measured, the biggest volumes come first
+	v.list.Title = "Docker Volumes"
+	if v.sizes != nil {
+		var total int64
+		for _, vol := range v.volumes {
+			total += max(vol.UsageData.Size, 0)
+		}
+		sort.SliceStable(v.volumes, func(i, j int) bool {
+			return v.volumes[i].UsageData.Size > v.volumes[j].UsageData.Size
+		})
+		v.list.Title = fmt.Sprintf("Docker Volumes (%s, measured at %s)", utils.FormatBytes(total), v.sizesAt.Format("15:04"))
 	}
 
 	// Rebuild the list items with updated counts
 	items := make([]list.Item, len(v.volumes))
 	for i, vol := range v.volumes {
-		items[i] = VolumeItem{volume: vol}
+		items[i] = VolumeItem{volume: vol, usedBy: usedBy[vol.Name]}
+	}
+	setItemsKeepSelection(&v.list, items)
+}
+
+// AnonymousUnused returns the anonymous volumes no container uses anymore,
+// typically left behind by containers removed without -v
+func (v *VolumesView) AnonymousUnused() []models.Volume {
+	var result []models.Volume
+	for _, vol := range v.volumes {
+		if vol.IsAnonymous() && !vol.IsInUse() {
+			result = append(result, vol)
+		}
 	}
-	v.list.SetItems(items)
+	return result
+}
+
+// GetContainersUsingVolume returns the containers mounting the selected
+// volume, with the mount of each
+func (v *VolumesView) GetContainersUsingVolume() ([]models.Container, []models.MountPoint) {
+	if v.selectedVolume == "" {
+		return nil, nil
+	}
+
+	var containers []models.Container
+	var mounts []models.MountPoint
+	for _, c := range v.allContainers {
+		for _, m := range c.Mounts {
+			if m.Type == "volume" && m.Name == v.selectedVolume {
+				containers = append(containers, c)
+				mounts = append(mounts, m)
+				break
+			}
+		}
+	}
+	return containers, mounts
+}
+
+// updateUsedByList updates the Used By tab from the selected volume
+func (v *VolumesView) updateUsedByList() {
+	containers, mounts := v.GetContainersUsingVolume()
+	items := make([]list.Item, len(containers))
+	for i, c := range containers {
+		items[i] = ContainerItemForVolume{container: c, mount: mounts[i]}
+	}
+	setItemsKeepSelection(&v.usedByList, items)
 }
 
 // SetSize updates the view dimensions
 func (v *VolumesView) SetSize(width, height int) {
 	v.width = width
 	v.height = height
-	v.list.SetSize(width, height-6) // Reserve space for header and footer
+
+	// Account for tab bar (3 lines) and reduce height accordingly
+	listHeight := height - 9
+	v.list.SetSize(width, listHeight)
+	v.usedByList.SetSize(width, listHeight)
+}
+
+// SwitchTab switches to the next or previous tab
+func (v *VolumesView) SwitchTab(direction int) {
+	newTab := int(v.currentTab) + direction
+
+	// Wrap around
+	if newTab < 0 {
+		newTab = int(models.VolumesUsedByTab)
+	} else if newTab > int(models.VolumesUsedByTab) {
+		newTab = int(models.VolumesListTab)
+	}
+
+	v.currentTab = models.VolumesTabType(newTab)
+	if v.currentTab == models.VolumesUsedByTab && v.selectedVolume == "" {
+		v.selectVolume()
+	}
+}
+
+// selectVolume shows the containers of the volume under the cursor in the
+// Used By tab
+func (v *VolumesView) selectVolume() {
+	if vol := v.GetSelectedVolume(); vol != nil {
+		v.selectedVolume = vol.Name
+		v.updateUsedByList()
+	}
 }
 
 // Update handles messages
 func (v *VolumesView) Update(msg tea.Msg) (*VolumesView, tea.Cmd) {
-	// If filtering, pass all input directly to the list
+	// If filtering, pass all input directly to the active list
 	if v.IsFiltering() {
 		var cmd tea.Cmd
-		v.list, cmd = v.list.Update(msg)
+		switch v.currentTab {
+		case models.VolumesListTab:
+			v.list, cmd = v.list.Update(msg)
+		case models.VolumesUsedByTab:
+			v.usedByList, cmd = v.usedByList.Update(msg)
+		}
 		return v, cmd
 	}
 
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case key.Matches(keyMsg, keys.Map.PrevTab):
+			v.SwitchTab(-1)
+			return v, nil
+
+		case key.Matches(keyMsg, keys.Map.NextTab):
+			v.SwitchTab(+1)
+			return v, nil
+
+		case key.Matches(keyMsg, keys.Map.Select):
+			// Select volume and switch to the Used By tab
+			if v.currentTab == models.VolumesListTab {
+				v.selectVolume()
+				if v.selectedVolume != "" {
+					v.currentTab = models.VolumesUsedByTab
+				}
+				return v, nil
+			}
+		}
+	}
+
 	var cmd tea.Cmd
-	v.list, cmd = v.list.Update(msg)
+	switch v.currentTab {
+	case models.VolumesListTab:
+		v.list, cmd = v.list.Update(msg)
+	case models.VolumesUsedByTab:
+		v.usedByList, cmd = v.usedByList.Update(msg)
+	}
 	return v, cmd
 }
 
+// RenderTabBar renders the tab bar
+func (v *VolumesView) RenderTabBar() string {
+	tabs := []string{
+		v.renderTab("Volumes", models.VolumesListTab),
+		v.renderTab("Used By", models.VolumesUsedByTab),
+	}
+
+	return lipgloss.JoinHorizontal(lipgloss.Top, tabs...) + "\n"
+}
+
+// renderTab renders a single tab
+func (v *VolumesView) renderTab(label string, tab models.VolumesTabType) string {
+	if v.currentTab == tab {
+		return styles.TabActiveStyle.Render(" " + label + " ")
+	}
+	return styles.TabInactiveStyle.Render(" " + label + " ")
+}
+
 // View renders the view
 func (v *VolumesView) View() string {
-	if len(v.volumes) == 0 {
-		return v.renderEmpty()
+	var content string
+	switch v.currentTab {
+	case models.VolumesListTab:
+		if len(v.volumes) == 0 {
+			content = v.renderEmpty()
+		} else {
+			content = v.list.View()
+		}
+
+	case models.VolumesUsedByTab:
+		if v.selectedVolume == "" {
+			content = "\n\n" + styles.SubtitleStyle.Render("Select a volume from the Volumes tab") + "\n\n"
+		} else if len(v.usedByList.Items()) == 0 {
+			content = "\n\n" + styles.SubtitleStyle.Render(fmt.Sprintf("No containers use '%s'", v.selectedVolume)) + "\n\n"
+		} else {
+			v.usedByList.Title = fmt.Sprintf("Containers using '%s'", v.selectedVolume)
+			content = v.usedByList.View()
+		}
 	}
 
-	return v.list.View()
+	return lipgloss.JoinVertical(lipgloss.Left, v.RenderTabBar(), content)
 }
 
 // GetSelectedVolume returns the currently selected volume
@@ -165,20 +430,75 @@ func (v *VolumesView) renderEmpty() string {
 	return b.String()
 }
 
-// IsFiltering returns true if the list is in filtering mode
+// IsFiltering returns true if the active list is in filtering mode
 func (v *VolumesView) IsFiltering() bool {
+	if v.currentTab == models.VolumesUsedByTab {
+		return v.usedByList.FilterState() == list.Filtering
+	}
 	return v.list.FilterState() == list.Filtering
 }
 
+// GetCurrentTab returns the current tab type
+func (v *VolumesView) GetCurrentTab() models.VolumesTabType {
+	return v.currentTab
+}
+
+// ShowVolumes returns to the Volumes tab
+func (v *VolumesView) ShowVolumes() {
+	v.currentTab = models.VolumesListTab
+}
+
+// GetSelectedUsedByContainer returns the selected container of the Used By tab
+func (v *VolumesView) GetSelectedUsedByContainer() *models.Container {
+	item := v.usedByList.SelectedItem()
+	if item == nil {
+		return nil
+	}
+	if containerItem, ok := item.(ContainerItemForVolume); ok {
+		return &containerItem.container
+	}
+	return nil
+}
+
 // GetHelpText returns help text for the volumes view
 func (v *VolumesView) GetHelpText() string {
-	helps := []string{
-		styles.KeyStyle.Render("↑/↓") + " navigate",
-		styles.KeyStyle.Render("d") + " remove",
-		styles.KeyStyle.Render("p") + " prune unused",
-		styles.KeyStyle.Render("/") + " filter",
-		styles.KeyStyle.Render("q") + " quit",
+	var helps []string
+
+	switch v.currentTab {
+	case models.VolumesListTab:
+		helps = []string{
+			styles.KeyStyle.Render("↑/↓") + " navigate",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Select)) + " used by",
+			styles.KeyStyle.Render(keys.Label(keys.Map.New)) + " new",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Delete)) + " remove",
+			styles.KeyStyle.Render(keys.Label(keys.Map.PruneVolumes)) + " prune unused",
+			styles.KeyStyle.Render(keys.Label(keys.Map.PruneAnonymous)) + " remove anonymous",
+			styles.KeyStyle.Render(keys.Label(keys.Map.BackupVolume)) + " backup",
+			styles.KeyStyle.Render(keys.Label(keys.Map.RestoreVolume)) + " restore",
+			styles.KeyStyle.Render(keys.Label(keys.Map.VolumeSizes)) + " sizes",
+			styles.KeyStyle.Render(keys.Label(keys.Map.CopyID)) + " copy name",
+			styles.KeyStyle.Render(keys.Labels(keys.Map.PrevTab, keys.Map.NextTab)) + " tabs",
+			styles.KeyStyle.Render("/") + " filter",
+		}
+
+	case models.VolumesUsedByTab:
+		helps = []string{
+			styles.KeyStyle.Render("↑/↓") + " navigate",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Start)) + " start",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Stop)) + " stop",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Restart)) + " restart",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Delete)) + " delete",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Shell)) + " shell",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Logs)) + " logs",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Stats)) + " stats",
+			styles.KeyStyle.Render(keys.Label(keys.Map.EditConfig)) + " env/labels/ports",
+			styles.KeyStyle.Render(keys.Label(keys.Map.CopyID)) + " copy ID",
+			styles.KeyStyle.Render(keys.Labels(keys.Map.PrevTab, keys.Map.NextTab)) + " tabs",
+			styles.KeyStyle.Render(keys.Label(keys.Map.Back)) + " back",
+			styles.KeyStyle.Render("/") + " filter",
+		}
 	}
 
+	helps = append(helps, styles.KeyStyle.Render(keys.Label(keys.Map.Quit))+" quit")
 	return strings.Join(helps, styles.SeparatorStyle.String())
 }
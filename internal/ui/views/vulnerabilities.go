@@ -0,0 +1,172 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rizface/doui/internal/models"
+	"github.com/rizface/doui/internal/ui/keys"
+	"github.com/rizface/doui/internal/ui/styles"
+)
+
+// VulnerabilitiesView lists the findings of an image scan grouped by
+// severity, most severe first
+type VulnerabilitiesView struct {
+	scan   *models.VulnScan
+	cursor int
+	offset int
+	width  int
+	height int
+}
+
+// NewVulnerabilitiesView creates a new vulnerabilities view
+func NewVulnerabilitiesView() *VulnerabilitiesView {
+	return &VulnerabilitiesView{}
+}
+
+// SetScan sets the scan whose findings are shown
+func (v *VulnerabilitiesView) SetScan(scan *models.VulnScan) {
+	v.scan = scan
+	v.cursor = 0
+	v.offset = 0
+}
+
+// SetSize updates the view dimensions
+func (v *VulnerabilitiesView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+}
+
+// GetSelectedFinding returns the finding under the cursor
+func (v *VulnerabilitiesView) GetSelectedFinding() *models.Vulnerability {
+	if v.scan == nil || v.cursor < 0 || v.cursor >= len(v.scan.Findings) {
+		return nil
+	}
+	return &v.scan.Findings[v.cursor]
+}
+
+// visibleRows is how many findings fit below the title, leaving room for
+// the severity headings
+func (v *VulnerabilitiesView) visibleRows() int {
+	return max(v.height-7-len(models.Severities), 1)
+}
+
+// severityStyle colors a severity like the app's error/warning messages
+func severityStyle(severity string) lipgloss.Style {
+	switch severity {
+	case "CRITICAL", "HIGH":
+		return styles.ErrorStyle
+	case "MEDIUM":
+		return styles.WarningStyle
+	}
+	return styles.DescStyle
+}
+
+// Update handles messages
+func (v *VulnerabilitiesView) Update(msg tea.Msg) (*VulnerabilitiesView, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || v.scan == nil || len(v.scan.Findings) == 0 {
+		return v, nil
+	}
+
+	switch {
+	case keyMsg.String() == "up" || keyMsg.String() == "k":
+		v.cursor--
+	case keyMsg.String() == "down" || keyMsg.String() == "j":
+		v.cursor++
+	case keyMsg.String() == "pgup":
+		v.cursor -= v.visibleRows()
+	case keyMsg.String() == "pgdown":
+		v.cursor += v.visibleRows()
+	case key.Matches(keyMsg, keys.Map.Top):
+		v.cursor = 0
+	case key.Matches(keyMsg, keys.Map.Bottom):
+		v.cursor = len(v.scan.Findings) - 1
+	}
+	v.cursor = min(max(v.cursor, 0), len(v.scan.Findings)-1)
+
+	// Keep the cursor on screen
+	if v.cursor < v.offset {
+		v.offset = v.cursor
+	} else if rows := v.visibleRows(); v.cursor >= v.offset+rows {
+		v.offset = v.cursor - rows + 1
+	}
+	return v, nil
+}
+
+// View renders the view
+func (v *VulnerabilitiesView) View() string {
+	var b strings.Builder
+	if v.scan == nil {
+		return styles.BorderStyle.Width(max(v.width-4, 0)).Render("")
+	}
+
+	findings := v.scan.Findings
+	b.WriteString(styles.TitleStyle.Render(fmt.Sprintf("Vulnerabilities: %s (%d, %d fixable)", v.scan.Image, len(findings), v.scan.Fixable())))
+	b.WriteString("\n")
+	b.WriteString(styles.SubtitleStyle.Render(fmt.Sprintf("%s, scanned with %s at %s",
+		v.scan.Summary(), v.scan.Scanner, v.scan.ScannedAt.Format("15:04"))))
+	b.WriteString("\n\n")
+
+	if len(findings) == 0 {
+		b.WriteString(styles.SuccessStyle.Render("No known vulnerabilities found."))
+		return styles.BorderStyle.Width(max(v.width-4, 0)).Render(b.String())
+	}
+
+	idWidth, pkgWidth := 0, 0
+	for _, f := range findings {
+		idWidth = max(idWidth, len(f.ID))
+		pkgWidth = max(pkgWidth, len(f.Package)+len(f.Installed)+1)
+	}
+	idWidth = min(idWidth, 20)
+	pkgWidth = min(pkgWidth, 35)
+	fixWidth := 18
+	titleWidth := max(v.width-idWidth-pkgWidth-fixWidth-16, 10)
+
+	counts := v.scan.Counts()
+	end := min(v.offset+v.visibleRows(), len(findings))
+	for i := v.offset; i < end; i++ {
+		f := findings[i]
+		if i == v.offset || findings[i-1].Severity != f.Severity {
+			b.WriteString(severityStyle(f.Severity).Bold(true).Render(fmt.Sprintf("%s (%d)", f.Severity, counts[f.Severity])))
+			b.WriteString("\n")
+		}
+
+		prefix := "  "
+		idStyle := severityStyle(f.Severity)
+		if i == v.cursor {
+			prefix = "> "
+			idStyle = styles.SelectedItemStyle
+		}
+		fix := f.Fix
+		if fix == "" {
+			fix = "no fix"
+		}
+		b.WriteString(prefix + idStyle.Render(padCell(f.ID, idWidth)) + "  " +
+			padCell(f.Package+" "+f.Installed, pkgWidth) + "  " +
+			styles.SuccessStyle.Render(padCell("→ "+fix, fixWidth)) + "  " +
+			styles.DescStyle.Render(padCell(f.Title, titleWidth)))
+		b.WriteString("\n")
+	}
+	if end < len(findings) || v.offset > 0 {
+		b.WriteString(styles.DescStyle.Render(fmt.Sprintf("  %d-%d of %d", v.offset+1, end, len(findings))))
+	}
+
+	return styles.BorderStyle.Width(max(v.width-4, 0)).Render(b.String())
+}
+
+// GetHelpText returns help text for the vulnerabilities view
+func (v *VulnerabilitiesView) GetHelpText() string {
+	helps := []string{
+		styles.KeyStyle.Render("↑/↓") + " navigate",
+		styles.KeyStyle.Render(keys.Label(keys.Map.CopyID)) + " copy ID",
+		styles.KeyStyle.Render(keys.Label(keys.Map.ScanImage)) + " rescan",
+		styles.KeyStyle.Render(keys.Labels(keys.Map.Top, keys.Map.Bottom)) + " top/bottom",
+		styles.KeyStyle.Render(keys.Label(keys.Map.Back)) + " back",
+	}
+
+	return strings.Join(helps, styles.SeparatorStyle.String())
+}
@@ -1,22 +1,160 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/rizface/doui/internal/app"
+	"github.com/rizface/doui/internal/cli"
+	"github.com/rizface/doui/internal/config"
+	"github.com/rizface/doui/internal/docker"
+	"github.com/rizface/doui/internal/models"
+	"github.com/rizface/doui/internal/ui/keys"
+	"github.com/rizface/doui/internal/ui/styles"
 )
 
 func main() {
+	host := flag.String("host", "",
+		"docker daemon to connect to (e.g. unix:///var/run/docker.sock, tcp://host:2376, ssh://user@host), overrides DOCKER_HOST")
+	refresh := flag.String("refresh", os.Getenv("DOUI_REFRESH_INTERVAL"),
+		`auto-refresh interval (e.g. 2s, 10s, 1m), or "off" to refresh manually with ctrl+r`)
+	view := flag.String("view", "",
+		"view to start on: containers, images, groups, volumes, compose, networks, plugins or about")
+	containerRef := flag.String("container", "", "select this container (name or ID) on start")
+	logsRef := flag.String("logs", "",
+		"start streaming the logs of this container (name or ID), or of the --container when given without a name")
+	group := flag.String("group", "", "open this group (name or ID) in the groups view on start")
+	fresh := flag.Bool("fresh", false, "don't restore the view, selection and filters of the last session")
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), cli.Usage, "\nFlags:\n")
+		flag.PrintDefaults()
+	}
+	args, bareLogs := bareLogsFlag(os.Args[1:])
+	flag.CommandLine.Parse(args)
+
+	// The flag behaves like DOCKER_HOST, so everything reading it (client,
+	// hints, copied commands, subcommands) sees the same address
+	if *host != "" {
+		os.Setenv("DOCKER_HOST", *host)
+	}
+
+	// Every change made through doui, from the TUI or a subcommand, is
+	// recorded in the audit log
+	docker.SetAuditLog(config.AppendAuditEntry)
+
+	// Subcommands (doui ps, doui group start web...) run without the TUI
+	if flag.NArg() > 0 {
+		os.Exit(cli.Run(flag.Args()))
+	}
+
+	refreshInterval, err := config.ParseRefreshInterval(*refresh)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(2)
+	}
+
+	if bareLogs {
+		if *containerRef == "" {
+			fmt.Fprintln(os.Stderr, "Error: --logs needs a container: --logs <name> or --container <name> --logs")
+			os.Exit(2)
+		}
+		*logsRef = *containerRef
+	} else if *containerRef != "" && *logsRef != "" && *containerRef != *logsRef {
+		fmt.Fprintln(os.Stderr, "Error: --container and --logs <name> name different containers")
+		os.Exit(2)
+	}
+	if *group != "" && (*containerRef != "" || *logsRef != "") {
+		fmt.Fprintln(os.Stderr, "Error: --group opens the groups view, it can't be used with --container or --logs")
+		os.Exit(2)
+	}
+	startView := models.ViewContainers
+	if *view != "" {
+		if startView, err = models.ParseView(*view); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(2)
+		}
+		if startView != models.ViewContainers && (*containerRef != "" || *logsRef != "") {
+			fmt.Fprintln(os.Stderr, "Error: --container and --logs open the containers view, they can't be used with --view")
+			os.Exit(2)
+		}
+		if startView != models.ViewGroups && *group != "" {
+			fmt.Fprintln(os.Stderr, "Error: --group opens the groups view, it can't be used with --view")
+			os.Exit(2)
+		}
+	}
+	// A config file that can't be read is reported by the app itself
+	if *group != "" {
+		if gm, err := config.NewGroupManager(); err == nil && gm.GetGroupByName(*group) == nil {
+			fmt.Fprintf(os.Stderr, "Error: group %q not found\n", *group)
+			os.Exit(2)
+		}
+	}
+
+	// Errors reading the config file itself are reported by the group manager
+	if bindings, err := config.LoadKeybindings(); err == nil {
+		if err := keys.Map.Apply(bindings); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid keybindings in config file: %v\n", err)
+			os.Exit(2)
+		}
+	}
+
+	// Styles are copied into views when they are created, so the theme must
+	// be set before the application is. The background is only queried from
+	// the terminal when the theme is picked automatically.
+	styles.Plain = os.Getenv("NO_COLOR") != ""
+	themeName, customThemes, _ := config.LoadTheme()
+	darkBackground := true
+	if !styles.Plain && (themeName == "" || themeName == "auto") {
+		darkBackground = lipgloss.HasDarkBackground()
+	}
+	theme, err := styles.ResolveTheme(themeName, customThemes, darkBackground)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid theme in config file: %v\n", err)
+		os.Exit(2)
+	}
+	styles.SetTheme(theme)
+
+	notifyMethod, err := config.LoadNotifyMethod()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid notify setting: %v\n", err)
+		os.Exit(2)
+	}
+
 	// Create the application
 	appModel := app.New()
+	appModel.SetRefreshInterval(refreshInterval)
+	appModel.SetNotifyMethod(notifyMethod)
+	if quickActions, err := config.LoadQuickActions(); err == nil {
+		if err := appModel.SetQuickActions(quickActions); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid quick_actions in config file: %v\n", err)
+			os.Exit(2)
+		}
+	}
+	switch {
+	case *logsRef != "":
+		appModel.StartAtContainer(*logsRef, true)
+	case *containerRef != "":
+		appModel.StartAtContainer(*containerRef, false)
+	case *group != "":
+		appModel.StartAtGroup(*group)
+	case *view != "":
+		appModel.StartAt(startView)
+	case !*fresh:
+		if session, err := config.LoadSession(); err == nil && session != nil {
+			appModel.RestoreSession(*session)
+		}
+	}
 
 	// Start the Bubble Tea program
 	p := tea.NewProgram(
 		appModel,
 		tea.WithAltScreen(),
 		tea.WithMouseCellMotion(),
+		tea.WithReportFocus(),
 	)
 
 	// Run the program
@@ -24,4 +162,31 @@ func main() {
 		fmt.Fprintf(os.Stderr, "Error running application: %v\n", err)
 		os.Exit(1)
 	}
+
+	// Remember where we were for the next launch
+	if session, ok := appModel.Session(); ok {
+		if err := config.SaveSession(session); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to save session: %v\n", err)
+		}
+	}
+}
+
+// bareLogsFlag removes a --logs given without a container name (last, or
+// followed by another flag) from args, as in "--container api --logs", and
+// returns whether there was one. --logs <name> and --logs=<name> are kept.
+func bareLogsFlag(args []string) ([]string, bool) {
+	rest := make([]string, 0, len(args))
+	bare := false
+	for i, arg := range args {
+		if arg == "--" {
+			rest = append(rest, args[i:]...)
+			break
+		}
+		if (arg == "--logs" || arg == "-logs") && (i == len(args)-1 || strings.HasPrefix(args[i+1], "-")) {
+			bare = true
+			continue
+		}
+		rest = append(rest, arg)
+	}
+	return rest, bare
 }
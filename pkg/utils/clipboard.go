@@ -0,0 +1,27 @@
+package utils
+
+import (
+	"os"
+
+	"github.com/atotto/clipboard"
+	"github.com/aymanbagabas/go-osc52/v2"
+)
+
+// CopyToClipboard copies text to the system clipboard
+// Falls back to an OSC52 escape sequence when no native clipboard is available
+// (e.g. over SSH), which most modern terminals understand
+func CopyToClipboard(text string) error {
+	if err := clipboard.WriteAll(text); err == nil {
+		return nil
+	}
+
+	seq := osc52.New(text)
+	if os.Getenv("TMUX") != "" {
+		seq = seq.Tmux()
+	} else if os.Getenv("STY") != "" {
+		seq = seq.Screen()
+	}
+
+	_, err := seq.WriteTo(os.Stderr)
+	return err
+}
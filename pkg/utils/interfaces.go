@@ -0,0 +1,99 @@
+package utils
+
+import (
+	"bufio"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// HostInterface is a network interface of the local host
+type HostInterface struct {
+	Name     string
+	Addrs    []string // Addresses in CIDR notation (e.g. 192.168.1.10/24)
+	Gateway  string   // Default IPv4 gateway routed through this interface, if any
+	Wireless bool
+}
+
+// Subnet returns the IPv4 network of the interface's first IPv4 address
+// (e.g. 192.168.1.0/24), or "" if it has none
+func (i *HostInterface) Subnet() string {
+	for _, addr := range i.Addrs {
+		ip, ipNet, err := net.ParseCIDR(addr)
+		if err == nil && ip.To4() != nil {
+			return ipNet.String()
+		}
+	}
+	return ""
+}
+
+// ListHostInterfaces returns the interfaces of the local host that are up and
+// have an address, skipping loopback and the ones Docker creates (bridges,
+// veth pairs). Gateways and wireless detection only work on Linux.
+func ListHostInterfaces() ([]HostInterface, error) {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return nil, err
+	}
+	gateways := readDefaultGateways("/proc/net/route")
+
+	var result []HostInterface
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagUp == 0 || iface.Flags&net.FlagLoopback != 0 || isDockerInterface(iface.Name) {
+			continue
+		}
+		addrs, err := iface.Addrs()
+		if err != nil || len(addrs) == 0 {
+			continue
+		}
+
+		hostIface := HostInterface{
+			Name:    iface.Name,
+			Gateway: gateways[iface.Name],
+		}
+		for _, addr := range addrs {
+			// Link-local IPv6 addresses are never a useful subnet
+			if ipNet, ok := addr.(*net.IPNet); ok && !ipNet.IP.IsLinkLocalUnicast() {
+				hostIface.Addrs = append(hostIface.Addrs, ipNet.String())
+			}
+		}
+		if len(hostIface.Addrs) == 0 {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join("/sys/class/net", iface.Name, "wireless")); err == nil {
+			hostIface.Wireless = true
+		}
+		result = append(result, hostIface)
+	}
+	return result, nil
+}
+
+// isDockerInterface returns true for interfaces created by Docker itself
+func isDockerInterface(name string) bool {
+	return name == "docker0" || strings.HasPrefix(name, "br-") || strings.HasPrefix(name, "veth")
+}
+
+// readDefaultGateways reads the default IPv4 route of each interface from
+// /proc/net/route
+func readDefaultGateways(path string) map[string]string {
+	gateways := make(map[string]string)
+	f, err := os.Open(path)
+	if err != nil {
+		return gateways
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Scan() // Skip header
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 || fields[1] != "00000000" {
+			continue
+		}
+		if gw := parseProcNetIP(fields[2]); gw != "" && gw != "0.0.0.0" {
+			gateways[fields[0]] = gw
+		}
+	}
+	return gateways
+}
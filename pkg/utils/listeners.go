@@ -0,0 +1,93 @@
+package utils
+
+import (
+	"bufio"
+	"encoding/hex"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// HostListener is a socket listening on the local host
+type HostListener struct {
+	Proto string // "tcp" or "udp"
+	IP    string
+	Port  int
+}
+
+// ListHostListeners returns the listening TCP/UDP sockets of the local host
+// It reads /proc/net and therefore only works on Linux; elsewhere it returns nothing
+func ListHostListeners() ([]HostListener, error) {
+	sources := []struct {
+		path        string
+		proto       string
+		listenState string
+	}{
+		{"/proc/net/tcp", "tcp", "0A"},  // TCP_LISTEN
+		{"/proc/net/tcp6", "tcp", "0A"}, // TCP_LISTEN
+		{"/proc/net/udp", "udp", "07"},  // Unconnected (bound) UDP socket
+		{"/proc/net/udp6", "udp", "07"},
+	}
+
+	var listeners []HostListener
+	for _, src := range sources {
+		found, err := readProcNet(src.path, src.proto, src.listenState)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		listeners = append(listeners, found...)
+	}
+	return listeners, nil
+}
+
+// readProcNet parses a /proc/net/{tcp,udp}[6] table
+func readProcNet(path, proto, listenState string) ([]HostListener, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var listeners []HostListener
+	scanner := bufio.NewScanner(f)
+	scanner.Scan() // Skip header
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 4 || fields[3] != listenState {
+			continue
+		}
+
+		addr, portHex, ok := strings.Cut(fields[1], ":")
+		if !ok {
+			continue
+		}
+		port, err := strconv.ParseInt(portHex, 16, 32)
+		if err != nil {
+			continue
+		}
+
+		listeners = append(listeners, HostListener{
+			Proto: proto,
+			IP:    parseProcNetIP(addr),
+			Port:  int(port),
+		})
+	}
+	return listeners, scanner.Err()
+}
+
+// parseProcNetIP decodes the hex address format used in /proc/net
+// (IPv4 as one little-endian word, IPv6 as four little-endian words)
+func parseProcNetIP(s string) string {
+	b, err := hex.DecodeString(s)
+	if err != nil || (len(b) != net.IPv4len && len(b) != net.IPv6len) {
+		return ""
+	}
+	for i := 0; i < len(b); i += 4 {
+		b[i], b[i+1], b[i+2], b[i+3] = b[i+3], b[i+2], b[i+1], b[i]
+	}
+	return net.IP(b).String()
+}
@@ -0,0 +1,71 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Notification methods
+const (
+	NotifyAuto   = "auto"        // notify-send on a desktop session, OSC 777 and a bell otherwise
+	NotifySend   = "notify-send" // Desktop notification through libnotify
+	NotifyOSC777 = "osc777"      // Terminal notification (foot, WezTerm, rxvt, Windows Terminal...)
+	NotifyBell   = "bell"        // Terminal bell, tmux flags the pane's window
+	NotifyOff    = "off"
+)
+
+// NotifyMethods lists the accepted notification methods
+var NotifyMethods = []string{NotifyAuto, NotifySend, NotifyOSC777, NotifyBell, NotifyOff}
+
+// Notify shows a notification with the given method. Terminal sequences are
+// written to stderr like the OSC52 clipboard fallback, wrapped for tmux so
+// they reach the terminal from a background pane.
+func Notify(method, title, body string) error {
+	switch method {
+	case NotifyOff, "":
+		return nil
+	case NotifyAuto:
+		if hasDesktopSession() {
+			if err := Notify(NotifySend, title, body); err == nil {
+				return nil
+			}
+		}
+		if err := Notify(NotifyOSC777, title, body); err != nil {
+			return err
+		}
+		return Notify(NotifyBell, title, body)
+	case NotifySend:
+		return exec.Command("notify-send", "--app-name=doui", title, body).Run()
+	case NotifyOSC777:
+		// Semicolons separate the fields, keep them out of the text
+		clean := strings.NewReplacer(";", ",", "\x07", "", "\x1b", "").Replace
+		return writeTerminalSequence(fmt.Sprintf("\x1b]777;notify;%s;%s\x07", clean(title), clean(body)))
+	case NotifyBell:
+		// tmux handles the bell itself (bell-action, monitor-bell)
+		_, err := os.Stderr.WriteString("\a")
+		return err
+	}
+	return fmt.Errorf("unknown notification method %q (expected one of %s)", method, strings.Join(NotifyMethods, ", "))
+}
+
+// writeTerminalSequence writes an escape sequence to the terminal, passing it
+// through tmux if needed
+func writeTerminalSequence(seq string) error {
+	if os.Getenv("TMUX") != "" {
+		seq = "\x1bPtmux;" + strings.ReplaceAll(seq, "\x1b", "\x1b\x1b") + "\x1b\\"
+	}
+	_, err := os.Stderr.WriteString(seq)
+	return err
+}
+
+// hasDesktopSession returns true if notify-send can reach a notification
+// daemon: it is installed and a graphical session is available (not SSH)
+func hasDesktopSession() bool {
+	if os.Getenv("DISPLAY") == "" && os.Getenv("WAYLAND_DISPLAY") == "" {
+		return false
+	}
+	_, err := exec.LookPath("notify-send")
+	return err == nil
+}
@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"fmt"
+	"math/rand"
+	"net"
+	"strconv"
+)
+
+// FindFreePort returns a random TCP port in [min, max] that nothing on the
+// host is listening on, skipping any port in exclude
+func FindFreePort(min, max int, exclude map[int]bool) (int, error) {
+	if min < 1 || max > 65535 || min > max {
+		return 0, fmt.Errorf("invalid port range %d-%d", min, max)
+	}
+
+	// Probe a random sample first, then fall back to a full scan
+	size := max - min + 1
+	attempts := 50
+	if attempts > size {
+		attempts = size
+	}
+	for i := 0; i < attempts; i++ {
+		port := min + rand.Intn(size)
+		if !exclude[port] && isPortFree(port) {
+			return port, nil
+		}
+	}
+	for port := min; port <= max; port++ {
+		if !exclude[port] && isPortFree(port) {
+			return port, nil
+		}
+	}
+
+	return 0, fmt.Errorf("no free port in range %d-%d", min, max)
+}
+
+// isPortFree checks whether a TCP port can be bound on all interfaces
+func isPortFree(port int) bool {
+	ln, err := net.Listen("tcp", ":"+strconv.Itoa(port))
+	if err != nil {
+		return false
+	}
+	ln.Close()
+	return true
+}